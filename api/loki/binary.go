@@ -0,0 +1,343 @@
+package loki
+
+import (
+	"errors"
+	"fmt"
+	"math"
+
+	"github.com/commentlens/loghouse/api/loki/logql/parser/bsr"
+	"github.com/commentlens/loghouse/storage"
+)
+
+var (
+	errManyToMany = errors.New("logql: many-to-many matching not allowed")
+)
+
+func logqlIsComparison(op string) bool {
+	switch op {
+	case "==", "!=", ">", "<", ">=", "<=":
+		return true
+	}
+	return false
+}
+
+func logqlIsSetOp(op string) bool {
+	switch op {
+	case "and", "or", "unless":
+		return true
+	}
+	return false
+}
+
+func logqlNewBinary(node bsr.BSR) (*logqlMetric, error) {
+	m := &logqlMetric{op: node.GetNTChildI(1).GetTChildI(0).LiteralString()}
+	mods := node.GetNTChildI(2)
+	m.returnBool = mods.GetNTChildI(0).Alternate() == 1
+	if matching := mods.GetNTChildI(1); matching.Alternate() == 1 {
+		on := matching.GetNTChildI(0)
+		m.on = on.GetNTChildI(0).GetTChildI(0).LiteralString() == "on"
+		m.matching = logqlParseLabelKeys(on.GetNTChildI(2))
+		if group := matching.GetNTChildI(1); group.Alternate() != 0 {
+			m.group = group.GetNTChildI(0).GetTChildI(0).LiteralString()
+			m.include = []string{}
+			if group.Alternate() == 2 {
+				m.include = logqlParseLabelKeys(group.GetNTChildI(2))
+			}
+		}
+	}
+	lhs, err := logqlNewMetric(node.GetNTChildI(0))
+	if err != nil {
+		return nil, err
+	}
+	rhs, err := logqlNewMetric(node.GetNTChildI(3))
+	if err != nil {
+		return nil, err
+	}
+	m.lhs, m.rhs = lhs, rhs
+	switch {
+	case m.returnBool && !logqlIsComparison(m.op):
+		return nil, fmt.Errorf("logql: bool modifier on %s", m.op)
+	case logqlIsSetOp(m.op) && (lhs.isScalar || rhs.isScalar):
+		return nil, fmt.Errorf("logql: %s requires vectors", m.op)
+	case logqlIsSetOp(m.op) && m.group != "":
+		return nil, fmt.Errorf("logql: %s with %s", m.op, m.group)
+	case lhs.isScalar && rhs.isScalar && logqlIsComparison(m.op) && !m.returnBool:
+		return nil, fmt.Errorf("logql: comparison between scalars requires bool")
+	}
+	return m, nil
+}
+
+// logqlApply returns lhs op rhs, and false if a comparison drops the sample.
+func logqlApply(m *logqlMetric, lhs, rhs float64) (float64, bool) {
+	switch m.op {
+	case "+":
+		return lhs + rhs, true
+	case "-":
+		return lhs - rhs, true
+	case "*":
+		return lhs * rhs, true
+	case "/":
+		return lhs / rhs, true
+	case "%":
+		return math.Mod(lhs, rhs), true
+	case "^":
+		return math.Pow(lhs, rhs), true
+	}
+	var ok bool
+	switch m.op {
+	case "==":
+		ok = lhs == rhs
+	case "!=":
+		ok = lhs != rhs
+	case ">":
+		ok = lhs > rhs
+	case "<":
+		ok = lhs < rhs
+	case ">=":
+		ok = lhs >= rhs
+	case "<=":
+		ok = lhs <= rhs
+	}
+	if m.returnBool {
+		if ok {
+			return 1, true
+		}
+		return 0, true
+	}
+	return lhs, ok
+}
+
+func logqlBinary(m *logqlMetric, lhs, rhs *logqlValue, steps int) (*logqlValue, error) {
+	switch {
+	case lhs.isScalar && rhs.isScalar:
+		v, _ := logqlApply(m, lhs.scalar, rhs.scalar)
+		return &logqlValue{isScalar: true, scalar: v}, nil
+	case lhs.isScalar || rhs.isScalar:
+		var out []*logqlSeries
+		series := lhs.series
+		if lhs.isScalar {
+			series = rhs.series
+		}
+		for _, s := range series {
+			sr := &logqlSeries{
+				labels: s.labels,
+				values: make(map[int]float64),
+			}
+			for i, v := range s.values {
+				var keep bool
+				if lhs.isScalar {
+					var res float64
+					res, keep = logqlApply(m, lhs.scalar, v)
+					if m.returnBool || !logqlIsComparison(m.op) {
+						v = res
+					}
+				} else {
+					v, keep = logqlApply(m, v, rhs.scalar)
+				}
+				if keep {
+					sr.values[i] = v
+				}
+			}
+			if len(sr.values) > 0 {
+				out = append(out, sr)
+			}
+		}
+		return &logqlValue{series: out}, nil
+	case logqlIsSetOp(m.op):
+		return logqlSetOp(m, lhs.series, rhs.series)
+	default:
+		return logqlVectorMatch(m, lhs.series, rhs.series)
+	}
+}
+
+func logqlSignature(m *logqlMetric, labels map[string]string) (string, error) {
+	sig := make(map[string]string)
+	if m.on {
+		for _, k := range m.matching {
+			if v, ok := labels[k]; ok {
+				sig[k] = v
+			}
+		}
+	} else {
+		for k, v := range labels {
+			sig[k] = v
+		}
+		for _, k := range m.matching {
+			delete(sig, k)
+		}
+	}
+	return storage.HashLabels(sig)
+}
+
+func logqlSetOp(m *logqlMetric, lhs, rhs []*logqlSeries) (*logqlValue, error) {
+	// steps at which each signature has a sample
+	present := func(series []*logqlSeries) (map[string]map[int]bool, error) {
+		sigs := make(map[string]map[int]bool)
+		for _, s := range series {
+			sig, err := logqlSignature(m, s.labels)
+			if err != nil {
+				return nil, err
+			}
+			if sigs[sig] == nil {
+				sigs[sig] = make(map[int]bool)
+			}
+			for i := range s.values {
+				sigs[sig][i] = true
+			}
+		}
+		return sigs, nil
+	}
+	filter := func(series []*logqlSeries, sigs map[string]map[int]bool, want bool) ([]*logqlSeries, error) {
+		var out []*logqlSeries
+		for _, s := range series {
+			sig, err := logqlSignature(m, s.labels)
+			if err != nil {
+				return nil, err
+			}
+			sr := &logqlSeries{
+				labels: s.labels,
+				values: make(map[int]float64),
+			}
+			for i, v := range s.values {
+				if sigs[sig][i] == want {
+					sr.values[i] = v
+				}
+			}
+			if len(sr.values) > 0 {
+				out = append(out, sr)
+			}
+		}
+		return out, nil
+	}
+	switch m.op {
+	case "and", "unless":
+		sigs, err := present(rhs)
+		if err != nil {
+			return nil, err
+		}
+		out, err := filter(lhs, sigs, m.op == "and")
+		if err != nil {
+			return nil, err
+		}
+		return &logqlValue{series: out}, nil
+	default:
+		sigs, err := present(lhs)
+		if err != nil {
+			return nil, err
+		}
+		out, err := filter(rhs, sigs, false)
+		if err != nil {
+			return nil, err
+		}
+		return &logqlValue{series: append(append([]*logqlSeries{}, lhs...), out...)}, nil
+	}
+}
+
+func logqlVectorMatch(m *logqlMetric, lhs, rhs []*logqlSeries) (*logqlValue, error) {
+	// the "one" side is indexed by signature, the "many" side iterated.
+	many, one := lhs, rhs
+	if m.group == "group_right" {
+		many, one = rhs, lhs
+	}
+	ones := make(map[string]*logqlSeries)
+	for _, s := range one {
+		sig, err := logqlSignature(m, s.labels)
+		if err != nil {
+			return nil, err
+		}
+		if _, ok := ones[sig]; ok {
+			return nil, fmt.Errorf("%w: duplicate series %v", errManyToMany, s.labels)
+		}
+		ones[sig] = s
+	}
+	seen := make(map[string]bool)
+	results := make(map[string]*logqlSeries)
+	var out []*logqlSeries
+	for _, s := range many {
+		sig, err := logqlSignature(m, s.labels)
+		if err != nil {
+			return nil, err
+		}
+		o, ok := ones[sig]
+		if !ok {
+			continue
+		}
+		if m.group == "" {
+			if seen[sig] {
+				return nil, fmt.Errorf("%w: duplicate series %v", errManyToMany, s.labels)
+			}
+			seen[sig] = true
+		}
+		labels := logqlResultLabels(m, s.labels, o.labels)
+		h, err := storage.HashLabels(labels)
+		if err != nil {
+			return nil, err
+		}
+		sr, ok := results[h]
+		if !ok {
+			sr = &logqlSeries{
+				labels: labels,
+				values: make(map[int]float64),
+			}
+			results[h] = sr
+			out = append(out, sr)
+		}
+		for i, v := range s.values {
+			ov, ok := o.values[i]
+			if !ok {
+				continue
+			}
+			l, r := v, ov
+			if m.group == "group_right" {
+				l, r = ov, v
+			}
+			res, keep := logqlApply(m, l, r)
+			if !keep {
+				continue
+			}
+			if _, ok := sr.values[i]; ok {
+				return nil, fmt.Errorf("logql: multiple matches for labels %v", labels)
+			}
+			sr.values[i] = res
+		}
+	}
+	var nonEmpty []*logqlSeries
+	for _, s := range out {
+		if len(s.values) > 0 {
+			nonEmpty = append(nonEmpty, s)
+		}
+	}
+	return &logqlValue{series: nonEmpty}, nil
+}
+
+func logqlResultLabels(m *logqlMetric, many, one map[string]string) map[string]string {
+	labels := make(map[string]string)
+	if m.group == "" {
+		if m.on {
+			for _, k := range m.matching {
+				if v, ok := many[k]; ok {
+					labels[k] = v
+				}
+			}
+			return labels
+		}
+		for k, v := range many {
+			labels[k] = v
+		}
+		for _, k := range m.matching {
+			delete(labels, k)
+		}
+		return labels
+	}
+	for k, v := range many {
+		labels[k] = v
+	}
+	for _, k := range m.include {
+		if v, ok := one[k]; ok {
+			labels[k] = v
+		} else {
+			delete(labels, k)
+		}
+	}
+	return labels
+}
@@ -0,0 +1,135 @@
+package loki
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/commentlens/loghouse/storage"
+	"github.com/stretchr/testify/require"
+)
+
+func TestScalarBinary(t *testing.T) {
+	start := time.Unix(1000, 0)
+	for _, test := range []struct {
+		query string
+		want  string
+	}{
+		{query: `1 + 2 * 3`, want: "7"},
+		{query: `(1 + 2) * 3`, want: "9"},
+		{query: `2 - 1 - 1`, want: "0"},
+		{query: `2 ^ 3 ^ 2`, want: "512"},
+		{query: `-2 ^ 2`, want: "-4"},
+		{query: `7 % 4 / 2`, want: "1.5"},
+		{query: `2 > bool 1`, want: "1"},
+		{query: `2 == bool 1`, want: "0"},
+	} {
+		m, err := logqlParseMetric(test.query)
+		require.NoError(t, err, test.query)
+		got, err := logqlReadMetric(context.Background(), testReader{}, m, start, start, time.Second)
+		require.NoError(t, err, test.query)
+		require.Equal(t, []*Matrix{{
+			Metric: map[string]string{},
+			Values: [][]interface{}{{float64(1000), test.want}},
+		}}, got, test.query)
+	}
+
+	for _, query := range []string{
+		`2 > 1`,
+		`1 and 2`,
+		`1 + bool 2`,
+		`count_over_time({app="x"}[1m]) and on(app) group_left 1`,
+		`sum(2)`,
+	} {
+		_, err := logqlParseMetric(query)
+		require.Error(t, err, query)
+	}
+}
+
+func TestVectorBinary(t *testing.T) {
+	start := time.Unix(1000, 0)
+	var r testReader
+	for i, line := range []string{"error", "ok", "ok", "error"} {
+		for _, host := range []string{"a", "b"} {
+			if host == "b" && line == "error" {
+				continue
+			}
+			r = append(r, storage.LogEntry{
+				Labels: map[string]string{"app": "x", "host": host},
+				Time:   start.Add(time.Duration(i) * time.Second),
+				Data:   []byte(line),
+			})
+		}
+	}
+	r = append(r, storage.LogEntry{
+		Labels: map[string]string{"app": "y"},
+		Time:   start,
+		Data:   []byte("ok"),
+	})
+	end := start.Add(4 * time.Second)
+
+	for _, test := range []struct {
+		query string
+		want  []*Matrix
+	}{
+		{
+			query: `sum by (host) (count_over_time({app="x"} |= "error" [10s])) / sum by (host) (count_over_time({app="x"}[10s]))`,
+			want: []*Matrix{
+				{Metric: map[string]string{"host": "a"}, Values: [][]interface{}{{float64(1004), "0.5"}}},
+			},
+		},
+		{
+			query: `count_over_time({app="x"}[10s]) * 2 > 5`,
+			want: []*Matrix{
+				{Metric: map[string]string{"app": "x", "host": "a"}, Values: [][]interface{}{{float64(1004), "8"}}},
+			},
+		},
+		{
+			query: `count_over_time({app="x"}[10s]) > bool 3`,
+			want: []*Matrix{
+				{Metric: map[string]string{"app": "x", "host": "a"}, Values: [][]interface{}{{float64(1004), "1"}}},
+				{Metric: map[string]string{"app": "x", "host": "b"}, Values: [][]interface{}{{float64(1004), "0"}}},
+			},
+		},
+		{
+			query: `count_over_time({app="x"}[10s]) / on (app) group_left sum by (app) (count_over_time({app="x"}[10s]))`,
+			want: []*Matrix{
+				{Metric: map[string]string{"app": "x", "host": "a"}, Values: [][]interface{}{{float64(1004), "0.6666666666666666"}}},
+				{Metric: map[string]string{"app": "x", "host": "b"}, Values: [][]interface{}{{float64(1004), "0.3333333333333333"}}},
+			},
+		},
+		{
+			query: `count_over_time({app="x"}[10s]) / ignoring (host) group_left count_over_time({app="x"}[10s])`,
+			want:  nil,
+		},
+		{
+			query: `count_over_time({app="x"}[10s]) and count_over_time({app="x"} |= "error" [10s])`,
+			want: []*Matrix{
+				{Metric: map[string]string{"app": "x", "host": "a"}, Values: [][]interface{}{{float64(1004), "4"}}},
+			},
+		},
+		{
+			query: `count_over_time({app="x"}[10s]) unless count_over_time({app="x"} |= "error" [10s])`,
+			want: []*Matrix{
+				{Metric: map[string]string{"app": "x", "host": "b"}, Values: [][]interface{}{{float64(1004), "2"}}},
+			},
+		},
+		{
+			query: `count_over_time({app="x", host="a"}[10s]) or count_over_time({app="y"}[10s])`,
+			want: []*Matrix{
+				{Metric: map[string]string{"app": "x", "host": "a"}, Values: [][]interface{}{{float64(1004), "4"}}},
+				{Metric: map[string]string{"app": "y"}, Values: [][]interface{}{{float64(1004), "1"}}},
+			},
+		},
+	} {
+		m, err := logqlParseMetric(test.query)
+		require.NoError(t, err, test.query)
+		got, err := logqlReadMetric(context.Background(), r, m, end, end, time.Second)
+		if test.want == nil {
+			require.ErrorIs(t, err, errManyToMany, test.query)
+			continue
+		}
+		require.NoError(t, err, test.query)
+		require.Equal(t, test.want, got, test.query)
+	}
+}
@@ -7,13 +7,14 @@ import (
 	"fmt"
 	"net/http"
 	"net/url"
+	"sort"
 	"strconv"
 	"sync"
 	"time"
 
+	"github.com/commentlens/loghouse/api/loki/logql/parser/bsr"
 	"github.com/commentlens/loghouse/storage"
-	"github.com/commentlens/loghouse/storage/filesystem"
-	"github.com/commentlens/loghouse/storage/label"
+	"github.com/commentlens/loghouse/storage/tenant"
 	"github.com/julienschmidt/httprouter"
 	"nhooyr.io/websocket"
 	"nhooyr.io/websocket/wsjson"
@@ -25,13 +26,34 @@ const (
 	ReadStep        = 15 * time.Minute
 	ReadConcurrency = 100
 
-	TailInterval = 15 * time.Second
-	TailDelay    = 5 * time.Second
+	// TailBufferSize is the number of entries buffered for a tail client
+	// before entries are dropped.
+	TailBufferSize = 1000
+	TailMaxDelay   = 5 * time.Second
+)
+
+var (
+	errInvalidTail = errors.New("tail: invalid request")
 )
 
 type ServerOptions struct {
-	StorageWriter storage.Writer
-	LabelStore    *label.Store
+	Tenants         *tenant.Registry
+	ReadLimit       uint64
+	ReadConcurrency int
+}
+
+// https://grafana.com/docs/loki/latest/operations/multi-tenancy/
+func tenantID(r *http.Request) string {
+	id := r.Header.Get("X-Scope-OrgID")
+	if id == "" {
+		id = tenant.DefaultID
+	}
+	return id
+}
+
+// tenant returns the tenant to read from. Only pushes create tenants.
+func (opts *ServerOptions) tenant(r *http.Request) (*tenant.Tenant, error) {
+	return opts.Tenants.Lookup(tenantID(r))
 }
 
 func NewServer(opts *ServerOptions) http.Handler {
@@ -65,47 +87,188 @@ type Matrix struct {
 	Values [][]interface{}   `json:"values"`
 }
 
+type Vector struct {
+	Metric map[string]string `json:"metric"`
+	Value  []interface{}     `json:"value"`
+}
+
+type Scalar []interface{}
+
 type Stream struct {
 	Stream map[string]string `json:"stream"`
 	Values [][]string        `json:"values"`
 }
 
+// parseTime accepts unix seconds, unix nanoseconds, unix seconds with a
+// fraction, or RFC3339. As in Loki, integers of up to 10 digits are seconds.
+func parseTime(s string) (time.Time, error) {
+	if n, err := strconv.ParseInt(s, 10, 64); err == nil {
+		if len(s) <= 10 {
+			return time.Unix(n, 0), nil
+		}
+		return time.Unix(0, n), nil
+	}
+	if sec, err := strconv.ParseFloat(s, 64); err == nil {
+		return time.Unix(0, int64(sec*1e9)), nil
+	}
+	return time.Parse(time.RFC3339Nano, s)
+}
+
 func parseRange(query url.Values) (time.Time, time.Time, error) {
 	end := time.Now()
 	start := end.Add(-ReadRange)
-	if startNsec := query.Get("start"); startNsec != "" {
-		nsec, err := strconv.ParseUint(startNsec, 10, 64)
+	if s := query.Get("start"); s != "" {
+		t, err := parseTime(s)
 		if err != nil {
 			return time.Time{}, time.Time{}, err
 		}
-		start = time.Unix(0, int64(nsec))
+		start = t
 	}
-	if endNsec := query.Get("end"); endNsec != "" {
-		nsec, err := strconv.ParseUint(endNsec, 10, 64)
+	if s := query.Get("end"); s != "" {
+		t, err := parseTime(s)
 		if err != nil {
 			return time.Time{}, time.Time{}, err
 		}
-		end = time.Unix(0, int64(nsec))
+		end = t
 	}
 	return start, end, nil
 }
 
+func parseLimit(query url.Values, readLimit uint64) (uint64, error) {
+	if limit := query.Get("limit"); limit != "" {
+		return strconv.ParseUint(limit, 10, 64)
+	}
+	return readLimit, nil
+}
+
+func (opts *ServerOptions) readStreams(ctx context.Context, t *tenant.Tenant, query string, start, end time.Time, limit uint64, reverse bool) ([]*Stream, error) {
+	es, err := opts.readEntries(ctx, t, query, start, end, limit, reverse)
+	if err != nil {
+		return nil, err
+	}
+	return createStreams(es, reverse)
+}
+
+func (opts *ServerOptions) readEntries(ctx context.Context, t *tenant.Tenant, query string, start, end time.Time, limit uint64, reverse bool) ([]storage.LogEntry, error) {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	var es []storage.LogEntry
+	var mu sync.Mutex
+	err := logqlRead(ctx, t.NewReader(opts.ReadConcurrency, true, reverse), &storage.ReadOptions{
+		Start: start,
+		End:   end,
+		ResultFunc: func(e storage.LogEntry) {
+			mu.Lock()
+			defer mu.Unlock()
+
+			if uint64(len(es)) < limit {
+				es = append(es, e)
+			} else {
+				cancel()
+			}
+		},
+	}, query)
+	if err != nil && !errors.Is(err, context.Canceled) {
+		return nil, err
+	}
+	return es, nil
+}
+
+func writeQueryResult(rw http.ResponseWriter, result interface{}, err error) {
+	var data QueryResponseData
+	switch result := result.(type) {
+	case []*Stream:
+		data.ResultType = "streams"
+		data.Result = result
+	case []*Matrix:
+		data.ResultType = "matrix"
+		data.Result = result
+	case []*Vector:
+		data.ResultType = "vector"
+		data.Result = result
+	case Scalar:
+		data.ResultType = "scalar"
+		data.Result = result
+	default:
+		rw.WriteHeader(http.StatusBadRequest)
+		if err != nil {
+			json.NewEncoder(rw).Encode(ErrorResponse{
+				Message: err.Error(),
+			})
+		}
+		return
+	}
+	json.NewEncoder(rw).Encode(QueryResponse{
+		Status: "success",
+		Data:   data,
+	})
+}
+
 // https://grafana.com/docs/loki/latest/api/#query-loki
-func (opts *ServerOptions) query(rw http.ResponseWriter, r *http.Request, ps httprouter.Params) {
-	opts.queryRange(rw, r, ps)
+//
+// Metric queries are evaluated at time. Log queries return the newest entries
+// up to time, unless direction is forward.
+func (opts *ServerOptions) query(rw http.ResponseWriter, r *http.Request, _ httprouter.Params) {
+	result, err := func() (interface{}, error) {
+		t, err := opts.tenant(r)
+		if err != nil {
+			return nil, err
+		}
+		query := r.URL.Query()
+		ts := time.Now()
+		if s := query.Get("time"); s != "" {
+			ts, err = parseTime(s)
+			if err != nil {
+				return nil, err
+			}
+		}
+		readLimit, err := parseLimit(query, opts.ReadLimit)
+		if err != nil {
+			return nil, err
+		}
+		metric, err := logqlParseMetric(query.Get("query"))
+		if err != nil {
+			return nil, err
+		}
+		if metric != nil {
+			err = t.CheckQuery(ts, ts, readLimit)
+			if err != nil {
+				return nil, err
+			}
+			return logqlReadInstant(r.Context(), t.NewReader(opts.ReadConcurrency, false, false), metric, ts)
+		}
+		start := ts.Add(-ReadRange)
+		err = t.CheckQuery(start, ts, readLimit)
+		if err != nil {
+			return nil, err
+		}
+		reverse := query.Get("direction") != "forward"
+		return opts.readStreams(r.Context(), t, query.Get("query"), start, ts, readLimit, reverse)
+	}()
+	writeQueryResult(rw, result, err)
 }
 
 // https://grafana.com/docs/loki/latest/api/#query-loki-over-a-range-of-time
 func (opts *ServerOptions) queryRange(rw http.ResponseWriter, r *http.Request, _ httprouter.Params) {
-	ctx, cancel := context.WithCancel(r.Context())
-	defer cancel()
-
 	result, err := func() (interface{}, error) {
+		t, err := opts.tenant(r)
+		if err != nil {
+			return nil, err
+		}
 		query := r.URL.Query()
 		start, end, err := parseRange(query)
 		if err != nil {
 			return nil, err
 		}
+		readLimit, err := parseLimit(query, opts.ReadLimit)
+		if err != nil {
+			return nil, err
+		}
+		err = t.CheckQuery(start, end, readLimit)
+		if err != nil {
+			return nil, err
+		}
 		readStep := ReadStep
 		if step := query.Get("step"); step != "" {
 			d, err := time.ParseDuration(step)
@@ -117,145 +280,22 @@ func (opts *ServerOptions) queryRange(rw http.ResponseWriter, r *http.Request, _
 		if !(start.Before(end) && readStep > 0) {
 			return nil, nil
 		}
-		isHistogram, err := logqlIsHistogram(query.Get("query"))
+		metric, err := logqlParseMetric(query.Get("query"))
 		if err != nil {
 			return nil, err
 		}
-		if isHistogram {
-			histogramSize := end.Sub(start)/readStep + 1
-			histogram := make([]uint64, histogramSize)
-			mu := make([]sync.Mutex, histogramSize)
-			err := logqlRead(ctx, filesystem.NewCompactReader(&filesystem.CompactReaderOptions{
-				ReaderCount: ReadConcurrency,
-				Reverse:     false,
-			}), &storage.ReadOptions{
-				Start: start,
-				End:   end,
-				SummaryFunc: func(s storage.LogSummary) bool {
-					if s.Start.IsZero() && s.End.IsZero() {
-						return true
-					}
-					diff := s.End.Sub(s.Start)
-					if diff <= 0 {
-						return false
-					}
-					addCount := func(t time.Time, i time.Duration) {
-						if !(0 <= i && i < histogramSize) {
-							return
-						}
-						hs := start.Add(i * readStep)
-						he := start.Add((i + 1) * readStep)
-						if t.After(hs) {
-							hs = t
-						}
-						if t.Add(readStep).Before(he) {
-							he = t.Add(readStep)
-						}
-						if s.End.Before(he) {
-							he = s.End
-						}
-						hdiff := he.Sub(hs)
-						if hdiff <= 0 {
-							return
-						}
-						count := float64(s.Count)
-						scale := hdiff.Seconds() / diff.Seconds()
-						if scale < 1 {
-							count *= scale
-						}
-						mu[i].Lock()
-						histogram[i] += uint64(count)
-						mu[i].Unlock()
-					}
-					for t := s.Start; t.Before(s.End); t = t.Add(readStep) {
-						i := t.Sub(start) / readStep
-						addCount(t, i)
-						addCount(t, i+1)
-					}
-					return false
-				},
-				FilterFunc: func(e storage.LogEntry) bool {
-					i := e.Time.Sub(start) / readStep
-					mu[i].Lock()
-					histogram[i] += 1
-					mu[i].Unlock()
-					return false
-				},
-				ResultFunc: func(e storage.LogEntry) {},
-			}, query.Get("query"))
-			if err != nil {
-				return nil, err
-			}
-			var values [][]interface{}
-			for i, count := range histogram {
-				if count > 0 {
-					values = append(values, []interface{}{
-						start.Add(time.Duration(i) * readStep).Unix(),
-						fmt.Sprint(count),
-					})
-				}
-			}
-			return []*Matrix{{
-				Values: values,
-			}}, nil
-		}
-		var readLimit uint64 = ReadLimit
-		if limit := query.Get("limit"); limit != "" {
-			n, err := strconv.ParseUint(limit, 10, 64)
-			if err != nil {
-				return nil, err
-			}
-			readLimit = n
+		if metric != nil {
+			return logqlReadMetric(r.Context(), t.NewReader(opts.ReadConcurrency, false, false), metric, start, end, readStep)
 		}
 		reverse := query.Get("direction") == "backward"
-		var es []storage.LogEntry
-		var mu sync.Mutex
-		err = logqlRead(ctx, filesystem.NewCompactReader(&filesystem.CompactReaderOptions{
-			ReaderCount: ReadConcurrency,
-			Reverse:     reverse,
-		}), &storage.ReadOptions{
-			Start: start,
-			End:   end,
-			ResultFunc: func(e storage.LogEntry) {
-				mu.Lock()
-				defer mu.Unlock()
-
-				if uint64(len(es)) < readLimit {
-					es = append(es, e)
-				} else {
-					cancel()
-				}
-			},
-		}, query.Get("query"))
-		if err != nil && !errors.Is(err, context.Canceled) {
-			return nil, err
-		}
-		return createStreams(es)
+		return opts.readStreams(r.Context(), t, query.Get("query"), start, end, readLimit, reverse)
 	}()
-	var data QueryResponseData
-	switch result := result.(type) {
-	case []*Stream:
-		data.ResultType = "streams"
-		data.Result = result
-	case []*Matrix:
-		data.ResultType = "matrix"
-		data.Result = result
-	default:
-		rw.WriteHeader(http.StatusBadRequest)
-		if err != nil {
-			json.NewEncoder(rw).Encode(ErrorResponse{
-				Message: err.Error(),
-			})
-		}
-		return
-	}
-	json.NewEncoder(rw).Encode(QueryResponse{
-		Status: "success",
-		Data:   data,
-	})
+	writeQueryResult(rw, result, err)
 }
 
-func createStreams(es []storage.LogEntry) ([]*Stream, error) {
+// createStreams groups entries by labels, each stream sorted by time, or
+// newest first if reverse is set.
+func createStreams(es []storage.LogEntry, reverse bool) ([]*Stream, error) {
 	m := make(map[string][]storage.LogEntry)
 	for _, e := range es {
 		h, err := storage.HashLabels(e.Labels)
@@ -266,6 +306,12 @@ func createStreams(es []storage.LogEntry) ([]*Stream, error) {
 	}
 	var streams []*Stream
 	for _, es := range m {
+		sort.SliceStable(es, func(i, j int) bool {
+			if reverse {
+				return es[i].Time.After(es[j].Time)
+			}
+			return es[i].Time.Before(es[j].Time)
+		})
 		var values [][]string
 		for _, e := range es {
 			values = append(values, []string{
@@ -282,11 +328,101 @@ func createStreams(es []storage.LogEntry) ([]*Stream, error) {
 }
 
 type TailResponse struct {
-	Streams []*Stream `json:"streams"`
+	Streams        []*Stream       `json:"streams"`
+	DroppedEntries []*DroppedEntry `json:"dropped_entries,omitempty"`
+}
+
+type DroppedEntry struct {
+	Labels    map[string]string `json:"labels"`
+	Timestamp string            `json:"timestamp"`
+}
+
+// entryReader reads entries held in memory.
+type entryReader []storage.LogEntry
+
+func (r entryReader) Read(ctx context.Context, opts *storage.ReadOptions) error {
+	for _, e := range r {
+		if storage.MatchLogEntry(e, opts) {
+			opts.ResultFunc(e)
+		}
+	}
+	return nil
+}
+
+func tailKey(e storage.LogEntry) (string, error) {
+	h, err := storage.HashLabels(e.Labels)
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("%s/%d/%s", h, e.Time.UnixNano(), e.Data), nil
+}
+
+type tailRequest struct {
+	root  bsr.BSR
+	delay time.Duration
+	start time.Time
+	limit uint64
+}
+
+func (opts *ServerOptions) parseTail(t *tenant.Tenant, query url.Values) (*tailRequest, error) {
+	metric, err := logqlParseMetric(query.Get("query"))
+	if err != nil {
+		return nil, err
+	}
+	if metric != nil {
+		return nil, fmt.Errorf("%w: metric query", errInvalidTail)
+	}
+	tr := &tailRequest{start: time.Now().Add(-ReadRange)}
+	tr.root, err = logqlParse(query.Get("query"))
+	if err != nil {
+		return nil, err
+	}
+	if s := query.Get("delay_for"); s != "" {
+		sec, err := strconv.ParseUint(s, 10, 64)
+		if err != nil {
+			return nil, err
+		}
+		tr.delay = time.Duration(sec) * time.Second
+		if tr.delay > TailMaxDelay {
+			return nil, fmt.Errorf("%w: delay_for %s > %s", errInvalidTail, tr.delay, TailMaxDelay)
+		}
+	}
+	if s := query.Get("start"); s != "" {
+		tr.start, err = parseTime(s)
+		if err != nil {
+			return nil, err
+		}
+	}
+	tr.limit, err = parseLimit(query, opts.ReadLimit)
+	if err != nil {
+		return nil, err
+	}
+	err = t.CheckQuery(tr.start, time.Now(), tr.limit)
+	if err != nil {
+		return nil, err
+	}
+	return tr, nil
 }
 
 // https://grafana.com/docs/loki/latest/api/#stream-log-messages
+//
+// Entries written after the tail starts are sent as they arrive, held back for
+// delay_for seconds. Entries since start are sent first, up to limit.
 func (opts *ServerOptions) tail(rw http.ResponseWriter, r *http.Request, _ httprouter.Params) {
+	t, err := opts.tenant(r)
+	if err != nil {
+		rw.WriteHeader(http.StatusBadRequest)
+		return
+	}
+	query := r.URL.Query()
+	tr, err := opts.parseTail(t, query)
+	if err != nil {
+		rw.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(rw).Encode(ErrorResponse{
+			Message: err.Error(),
+		})
+		return
+	}
 	conn, err := websocket.Accept(rw, r, nil)
 	if err != nil {
 		rw.WriteHeader(http.StatusBadRequest)
@@ -307,49 +443,118 @@ func (opts *ServerOptions) tail(rw http.ResponseWriter, r *http.Request, _ httpr
 		}
 	}()
 
-	func() error {
-		query := r.URL.Query()
+	filter := func(es []storage.LogEntry) ([]storage.LogEntry, error) {
+		var out []storage.LogEntry
+		err := logqlReadQuery(ctx, entryReader(es), &storage.ReadOptions{
+			ResultFunc: func(e storage.LogEntry) {
+				out = append(out, e)
+			},
+		}, tr.root)
+		return out, err
+	}
 
-		end := time.Now().Add(-TailDelay)
-		start := end.Add(-TailInterval)
+	// subscribe before backfill so that no entry is missed in between, and
+	// only buffer entries the query keeps.
+	sub := t.Hub.Subscribe(TailBufferSize, func(e storage.LogEntry) bool {
+		es, err := filter([]storage.LogEntry{e})
+		return err == nil && len(es) > 0
+	})
+	defer sub.Close()
 
-		ticker := time.NewTicker(TailInterval)
-		defer ticker.Stop()
+	func() error {
+		es, err := opts.readEntries(ctx, t, query.Get("query"), tr.start, time.Now(), tr.limit, true)
+		if err != nil {
+			return err
+		}
+		seen := make(map[string]bool)
+		for _, e := range es {
+			key, err := tailKey(e)
+			if err != nil {
+				return err
+			}
+			seen[key] = true
+		}
+		streams, err := createStreams(es, false)
+		if err != nil {
+			return err
+		}
+		err = wsjson.Write(ctx, conn, &TailResponse{
+			Streams: streams,
+		})
+		if err != nil {
+			return err
+		}
 
+		type pendingEntry struct {
+			storage.LogEntry
+			deadline time.Time
+		}
+		var pending []pendingEntry
 		for {
-			var es []storage.LogEntry
-			var mu sync.Mutex
-			err := logqlRead(ctx, filesystem.NewCompactReader(&filesystem.CompactReaderOptions{
-				ReaderCount: ReadConcurrency,
-				Reverse:     false,
-			}), &storage.ReadOptions{
-				Start: start,
-				End:   end,
-				ResultFunc: func(e storage.LogEntry) {
-					mu.Lock()
-					defer mu.Unlock()
-					es = append(es, e)
-				},
-			}, query.Get("query"))
+			var wait <-chan time.Time
+			if len(pending) > 0 {
+				wait = time.After(time.Until(pending[0].deadline))
+			}
+			select {
+			case <-ctx.Done():
+				return ctx.Err()
+			case e := <-sub.Entries():
+				deadline := time.Now().Add(tr.delay)
+				pending = append(pending, pendingEntry{LogEntry: e, deadline: deadline})
+			DRAIN:
+				for {
+					select {
+					case e := <-sub.Entries():
+						pending = append(pending, pendingEntry{LogEntry: e, deadline: deadline})
+					default:
+						break DRAIN
+					}
+				}
+			case <-wait:
+			}
+			now := time.Now()
+			var ready []storage.LogEntry
+			for len(pending) > 0 && !pending[0].deadline.After(now) {
+				e := pending[0].LogEntry
+				pending = pending[1:]
+				if len(seen) > 0 {
+					key, err := tailKey(e)
+					if err != nil {
+						return err
+					}
+					if seen[key] {
+						continue
+					}
+				}
+				ready = append(ready, e)
+			}
+			es, err := filter(ready)
 			if err != nil {
 				return err
 			}
-			streams, err := createStreams(es)
+			dropped, err := filter(sub.Dropped())
 			if err != nil {
 				return err
 			}
-			err = wsjson.Write(ctx, conn, &TailResponse{
-				Streams: streams,
-			})
+			if len(es) == 0 && len(dropped) == 0 {
+				continue
+			}
+			streams, err := createStreams(es, false)
 			if err != nil {
 				return err
 			}
-			select {
-			case <-ctx.Done():
-				return ctx.Err()
-			case t := <-ticker.C:
-				start = end
-				end = t.Add(-TailDelay)
+			resp := &TailResponse{
+				Streams: streams,
+			}
+			for _, e := range dropped {
+				resp.DroppedEntries = append(resp.DroppedEntries, &DroppedEntry{
+					Labels:    e.Labels,
+					Timestamp: fmt.Sprint(e.Time.UnixNano()),
+				})
+			}
+			err = wsjson.Write(ctx, conn, resp)
+			if err != nil {
+				return err
 			}
 		}
 	}()
@@ -360,11 +565,68 @@ type LabelResponse struct {
 	Data   []string `json:"data"`
 }
 
+// readSeries returns the label sets of streams matching any of the selectors
+// with entries in [start, end], from the series catalog.
+func (opts *ServerOptions) readSeries(t *tenant.Tenant, selectors []string, start, end time.Time) ([]map[string]string, error) {
+	var matchers []func(map[string]string) bool
+	for _, selector := range selectors {
+		match, err := logqlSelector(selector)
+		if err != nil {
+			return nil, err
+		}
+		matchers = append(matchers, match)
+	}
+	series := make([]map[string]string, 0)
+	for _, s := range t.Storage.Catalog.Series() {
+		if !s.Start.IsZero() && s.Start.After(end) || !s.End.IsZero() && s.End.Before(start) {
+			continue
+		}
+		ok := len(matchers) == 0
+		for _, match := range matchers {
+			if match(s.Labels) {
+				ok = true
+				break
+			}
+		}
+		if ok {
+			series = append(series, s.Labels)
+		}
+	}
+	return series, nil
+}
+
 // https://grafana.com/docs/loki/latest/api/#list-labels-within-a-range-of-time
 func (opts *ServerOptions) labels(rw http.ResponseWriter, r *http.Request, _ httprouter.Params) {
-	labels, _ := func() ([]string, error) {
-		return opts.LabelStore.Labels(), nil
+	labels, err := func() ([]string, error) {
+		t, err := opts.tenant(r)
+		if err != nil {
+			return nil, err
+		}
+		start, end, err := parseRange(r.URL.Query())
+		if err != nil {
+			return nil, err
+		}
+		series, err := opts.readSeries(t, nil, start, end)
+		if err != nil {
+			return nil, err
+		}
+		m := make(map[string]struct{})
+		for _, labels := range series {
+			for k := range labels {
+				m[k] = struct{}{}
+			}
+		}
+		labels := make([]string, 0, len(m))
+		for k := range m {
+			labels = append(labels, k)
+		}
+		sort.Strings(labels)
+		return labels, nil
 	}()
+	if err != nil {
+		rw.WriteHeader(http.StatusBadRequest)
+		return
+	}
 	json.NewEncoder(rw).Encode(LabelResponse{
 		Status: "success",
 		Data:   labels,
@@ -373,10 +635,45 @@ func (opts *ServerOptions) labels(rw http.ResponseWriter, r *http.Request, _ htt
 
 // https://grafana.com/docs/loki/latest/api/#list-label-values-within-a-range-of-time
 func (opts *ServerOptions) labelValues(rw http.ResponseWriter, r *http.Request, ps httprouter.Params) {
-	labelValues, _ := func() ([]string, error) {
+	labelValues, err := func() ([]string, error) {
+		t, err := opts.tenant(r)
+		if err != nil {
+			return nil, err
+		}
+		query := r.URL.Query()
+		start, end, err := parseRange(query)
+		if err != nil {
+			return nil, err
+		}
+		var selectors []string
+		if selector := query.Get("query"); selector != "" {
+			selectors = append(selectors, selector)
+		}
+		series, err := opts.readSeries(t, selectors, start, end)
+		if err != nil {
+			return nil, err
+		}
 		label := ps.ByName("name")
-		return opts.LabelStore.LabelValues(label), nil
+		m := make(map[string]struct{})
+		for _, labels := range series {
+			if v, ok := labels[label]; ok {
+				m[v] = struct{}{}
+			}
+		}
+		values := make([]string, 0, len(m))
+		for v := range m {
+			values = append(values, v)
+		}
+		sort.Strings(values)
+		if t.LabelLimit > 0 && uint64(len(values)) > t.LabelLimit {
+			values = values[:t.LabelLimit]
+		}
+		return values, nil
 	}()
+	if err != nil {
+		rw.WriteHeader(http.StatusBadRequest)
+		return
+	}
 	json.NewEncoder(rw).Encode(LabelResponse{
 		Status: "success",
 		Data:   labelValues,
@@ -390,18 +687,25 @@ type SeriesResponse struct {
 
 // https://grafana.com/docs/loki/latest/api/#list-series
 func (opts *ServerOptions) series(rw http.ResponseWriter, r *http.Request, _ httprouter.Params) {
-	kvs, _ := func() ([]map[string]string, error) {
-		var kvs []map[string]string
-		for _, k := range opts.LabelStore.Labels() {
-			for _, v := range opts.LabelStore.LabelValues(k) {
-				kvs = append(kvs, map[string]string{k: v})
-			}
+	series, err := func() ([]map[string]string, error) {
+		t, err := opts.tenant(r)
+		if err != nil {
+			return nil, err
+		}
+		query := r.URL.Query()
+		start, end, err := parseRange(query)
+		if err != nil {
+			return nil, err
 		}
-		return kvs, nil
+		return opts.readSeries(t, query["match[]"], start, end)
 	}()
+	if err != nil {
+		rw.WriteHeader(http.StatusBadRequest)
+		return
+	}
 	json.NewEncoder(rw).Encode(SeriesResponse{
 		Status: "success",
-		Data:   kvs,
+		Data:   series,
 	})
 }
 
@@ -412,16 +716,12 @@ type PushRequest struct {
 // https://grafana.com/docs/loki/latest/api/#push-log-entries-to-loki
 func (opts *ServerOptions) push(rw http.ResponseWriter, r *http.Request, _ httprouter.Params) {
 	err := func() error {
-		var pr PushRequest
-		err := json.NewDecoder(r.Body).Decode(&pr)
+		pr, err := decodePushRequest(r)
 		if err != nil {
 			return err
 		}
+		var es []storage.LogEntry
 		for _, stream := range pr.Streams {
-			for k, v := range stream.Stream {
-				opts.LabelStore.Add(k, v)
-			}
-			var es []storage.LogEntry
 			for _, v := range stream.Values {
 				if len(v) != 2 {
 					continue
@@ -436,15 +736,29 @@ func (opts *ServerOptions) push(rw http.ResponseWriter, r *http.Request, _ httpr
 					Data:   storage.LogEntryData(v[1]),
 				})
 			}
-			err := opts.StorageWriter.Write(es)
-			if err != nil {
-				return err
-			}
 		}
-		return nil
+		if len(es) == 0 {
+			return nil
+		}
+		// limits apply to the whole push, so that a rejected push writes
+		// nothing and can be retried as is.
+		t, err := opts.Tenants.Get(tenantID(r))
+		if err != nil {
+			return err
+		}
+		return t.Write(es)
 	}()
 	if err != nil {
-		rw.WriteHeader(http.StatusBadRequest)
+		if errors.Is(err, tenant.ErrRateLimited) || errors.Is(err, tenant.ErrStreamLimited) || errors.Is(err, tenant.ErrTenantLimited) {
+			rw.WriteHeader(http.StatusTooManyRequests)
+		} else if errors.Is(err, tenant.ErrTooLarge) {
+			rw.WriteHeader(http.StatusRequestEntityTooLarge)
+		} else {
+			rw.WriteHeader(http.StatusBadRequest)
+		}
+		json.NewEncoder(rw).Encode(ErrorResponse{
+			Message: err.Error(),
+		})
 		return
 	}
 }
@@ -0,0 +1,194 @@
+package loki
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/commentlens/loghouse/storage"
+	"github.com/commentlens/loghouse/storage/filesystem"
+	"github.com/commentlens/loghouse/storage/tenant"
+	"github.com/stretchr/testify/require"
+	"nhooyr.io/websocket"
+	"nhooyr.io/websocket/wsjson"
+)
+
+func newTestServer(t *testing.T) (*tenant.Tenant, *httptest.Server) {
+	r := tenant.NewRegistry(&tenant.Options{
+		DataDir:    t.TempDir(),
+		Storage:    filesystem.NewOptions,
+		LabelLimit: 10,
+	})
+	tn, err := r.Get(tenant.DefaultID)
+	require.NoError(t, err)
+	s := httptest.NewServer(NewServer(&ServerOptions{
+		Tenants:         r,
+		ReadLimit:       ReadLimit,
+		ReadConcurrency: ReadConcurrency,
+	}))
+	t.Cleanup(s.Close)
+	return tn, s
+}
+
+func TestSeries(t *testing.T) {
+	tn, s := newTestServer(t)
+	now := time.Now()
+	var es []storage.LogEntry
+	for _, labels := range []map[string]string{
+		{"app": "api", "env": "prod"},
+		{"app": "api", "env": "dev"},
+		{"app": "web", "env": "prod", "host": "a"},
+	} {
+		es = append(es, storage.LogEntry{Labels: labels, Time: now, Data: []byte("x")})
+	}
+	err := tn.Write(es)
+	require.NoError(t, err)
+
+	get := func(path string, query url.Values, v interface{}) {
+		resp, err := http.Get(s.URL + path + "?" + query.Encode())
+		require.NoError(t, err)
+		defer resp.Body.Close()
+		require.Equal(t, http.StatusOK, resp.StatusCode, path)
+		require.NoError(t, json.NewDecoder(resp.Body).Decode(v))
+	}
+
+	var series SeriesResponse
+	get("/loki/api/v1/series", url.Values{"match[]": {`{env="prod"}`, `{app="api", env=~"d.*"}`}}, &series)
+	require.ElementsMatch(t, []map[string]string{
+		{"app": "api", "env": "prod"},
+		{"app": "api", "env": "dev"},
+		{"app": "web", "env": "prod", "host": "a"},
+	}, series.Data)
+
+	get("/loki/api/v1/series", url.Values{"match[]": {`{app="web"}`}}, &series)
+	require.Equal(t, []map[string]string{{"app": "web", "env": "prod", "host": "a"}}, series.Data)
+
+	var labels LabelResponse
+	get("/loki/api/v1/labels", nil, &labels)
+	require.Equal(t, []string{"app", "env", "host"}, labels.Data)
+
+	get("/loki/api/v1/label/env/values", url.Values{"query": {`{app="api"}`}}, &labels)
+	require.Equal(t, []string{"dev", "prod"}, labels.Data)
+
+	tn.LabelLimit = 1
+	get("/loki/api/v1/label/env/values", nil, &labels)
+	require.Equal(t, []string{"dev"}, labels.Data)
+	tn.LabelLimit = 10
+
+	get("/loki/api/v1/labels", url.Values{
+		"start": {fmt.Sprint(now.Add(-3 * time.Hour).UnixNano())},
+		"end":   {fmt.Sprint(now.Add(-2 * time.Hour).UnixNano())},
+	}, &labels)
+	require.Equal(t, []string{}, labels.Data)
+}
+
+func TestTail(t *testing.T) {
+	tn, s := newTestServer(t)
+
+	entry := func(app, data string) storage.LogEntry {
+		return storage.LogEntry{
+			Labels: map[string]string{"app": app},
+			Time:   time.Now().UTC().Truncate(time.Millisecond),
+			Data:   []byte(data),
+		}
+	}
+	err := tn.Write([]storage.LogEntry{entry("x", "old 1"), entry("x", "old 2"), entry("x", "old 3")})
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	query := url.Values{
+		"query": {`{app="x"} |= "1" or "2" or "new"`},
+		"limit": {"1"},
+	}
+	conn, _, err := websocket.Dial(ctx, "ws"+strings.TrimPrefix(s.URL, "http")+"/loki/api/v1/tail?"+query.Encode(), nil)
+	require.NoError(t, err)
+	defer conn.Close(websocket.StatusNormalClosure, "")
+
+	var resp TailResponse
+	err = wsjson.Read(ctx, conn, &resp)
+	require.NoError(t, err)
+	require.Len(t, resp.Streams, 1)
+	require.Len(t, resp.Streams[0].Values, 1)
+	require.Equal(t, "old 2", resp.Streams[0].Values[0][1])
+
+	err = tn.Write([]storage.LogEntry{entry("y", "new 1"), entry("x", "skip"), entry("x", "new 2")})
+	require.NoError(t, err)
+	resp = TailResponse{}
+	err = wsjson.Read(ctx, conn, &resp)
+	require.NoError(t, err)
+	require.Len(t, resp.Streams, 1)
+	require.Equal(t, map[string]string{"app": "x"}, resp.Streams[0].Stream)
+	require.Len(t, resp.Streams[0].Values, 1)
+	require.Equal(t, "new 2", resp.Streams[0].Values[0][1])
+
+	// other streams do not fill the buffer.
+	es := make([]storage.LogEntry, 2*TailBufferSize)
+	for i := range es {
+		es[i] = entry("y", "new")
+	}
+	es = append(es, entry("x", "new 3"))
+	err = tn.Write(es)
+	require.NoError(t, err)
+	resp = TailResponse{}
+	err = wsjson.Read(ctx, conn, &resp)
+	require.NoError(t, err)
+	require.Len(t, resp.Streams, 1)
+	require.Equal(t, "new 3", resp.Streams[0].Values[0][1])
+	require.Empty(t, resp.DroppedEntries)
+}
+
+func TestPushLimits(t *testing.T) {
+	dataDir := t.TempDir()
+	r := tenant.NewRegistry(&tenant.Options{
+		DataDir: dataDir,
+		Storage: filesystem.NewOptions,
+		Limits:  tenant.Limits{IngestRate: 1, IngestBurst: 10},
+	})
+	s := httptest.NewServer(NewServer(&ServerOptions{
+		Tenants:         r,
+		ReadLimit:       ReadLimit,
+		ReadConcurrency: ReadConcurrency,
+	}))
+	defer s.Close()
+
+	push := func(orgID, body string) int {
+		req, err := http.NewRequest("POST", s.URL+"/loki/api/v1/push", strings.NewReader(body))
+		require.NoError(t, err)
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("X-Scope-OrgID", orgID)
+		resp, err := http.DefaultClient.Do(req)
+		require.NoError(t, err)
+		resp.Body.Close()
+		return resp.StatusCode
+	}
+	ts := fmt.Sprint(time.Now().UnixNano())
+
+	// each stream is under the burst, the push is not.
+	body := `{"streams":[` +
+		`{"stream":{"app":"a"},"values":[["` + ts + `","aaaaaa"]]},` +
+		`{"stream":{"app":"b"},"values":[["` + ts + `","bbbbbb"]]}]}`
+	require.Equal(t, http.StatusRequestEntityTooLarge, push("org", body))
+	tn, err := r.Get("org")
+	require.NoError(t, err)
+	var got []storage.LogEntry
+	err = tn.NewReader(1, true, false).Read(context.Background(), &storage.ReadOptions{
+		ResultFunc: func(e storage.LogEntry) {
+			got = append(got, e)
+		},
+	})
+	require.NoError(t, err)
+	require.Empty(t, got)
+
+	// malformed pushes create no tenant.
+	require.Equal(t, http.StatusBadRequest, push("new", `{"streams":`))
+	ids, err := r.IDs()
+	require.NoError(t, err)
+	require.NotContains(t, ids, "new")
+}
@@ -3,6 +3,7 @@ package loki
 import (
 	"bytes"
 	"context"
+	"errors"
 	"fmt"
 	"regexp"
 	"regexp/syntax"
@@ -18,296 +19,561 @@ import (
 	"github.com/tidwall/gjson"
 )
 
+var (
+	errInvalidSelector = errors.New("logql: invalid stream selector")
+)
+
 func logqlRead(ctx context.Context, r storage.Reader, ropts *storage.ReadOptions, query string) error {
 	root, err := logqlParse(query)
 	if err != nil {
 		return err
 	}
-	var filters []func(e storage.LogEntry) bool
+	return logqlReadQuery(ctx, r, ropts, root)
+}
+
+func logqlReadQuery(ctx context.Context, r storage.Reader, ropts *storage.ReadOptions, root bsr.BSR) error {
+	var filters []func(e *storage.LogEntry) bool
+	var matchers []func(labels map[string]string) bool
 	var contains []string
-	err = logqlWalk(root, func(node bsr.BSR) error {
+	// filters from the first parser stage on see extracted labels.
+	parsed := -1
+	// contains hints past the first line_format do not apply to stored data.
+	formatted := -1
+	err := logqlWalk(root, func(node bsr.BSR) error {
 		switch node.Label.Slot().NT {
 		case symbols.NT_LogSelectorMember:
-			key := node.GetNTChildI(0).GetTChildI(0).LiteralString()
-			op := node.GetNTChildI(1).GetTChildI(0).LiteralString()
-			val := node.GetTChildI(2).LiteralString()
-			val, err := logqlUnquote(val)
+			key, op, val, match, err := logqlMatcher(node)
 			if err != nil {
 				return err
 			}
-			switch op {
-			case "=":
+			if op == "=" {
 				if ropts.Labels == nil {
 					ropts.Labels = make(map[string]string)
 				}
 				ropts.Labels[key] = val
-			case "!=":
-				filters = append(filters, func(e storage.LogEntry) bool {
-					v, ok := e.Labels[key]
-					if !ok {
-						return false
-					}
-					return v != val
-				})
-			case "=~":
-				re, err := regexp.Compile(val)
-				if err != nil {
-					return err
-				}
-				filters = append(filters, func(e storage.LogEntry) bool {
-					v, ok := e.Labels[key]
-					if !ok {
-						return false
-					}
-					return re.MatchString(v)
-				})
-			case "!~":
-				re, err := regexp.Compile(val)
-				if err != nil {
-					return err
-				}
-				filters = append(filters, func(e storage.LogEntry) bool {
-					v, ok := e.Labels[key]
-					if !ok {
-						return false
-					}
-					return !re.MatchString(v)
-				})
+				return nil
 			}
+			matchers = append(matchers, match)
+			filters = append(filters, func(e *storage.LogEntry) bool {
+				return match(e.Labels)
+			})
 		case symbols.NT_LineFilter:
-			op := node.GetNTChildI(0).GetTChildI(0).LiteralString()
-			val := node.GetTChildI(1).LiteralString()
-			val, err := logqlUnquote(val)
+			filter, err := logqlLineFilters(node)
 			if err != nil {
 				return err
 			}
-			if val == "" {
-				return nil
-			}
-			parseRequired := strings.ContainsAny(val, `:,{}[]"`) || val != logqlQuote(val)
-			switch op {
-			case "|=":
-				bVal := []byte(val)
-				filters = append(filters, func(e storage.LogEntry) bool {
-					if !parseRequired {
-						return bytes.Contains(e.Data, bVal)
-					}
-					ts, err := e.Data.Values()
-					if err != nil {
-						return false
-					}
-					for _, t := range ts {
-						if strings.Contains(t, val) {
-							return true
-						}
-					}
-					return false
-				})
-				contains = append(contains, val)
-			case "!=":
-				// negate
-				bVal := []byte(val)
-				filters = append(filters, func(e storage.LogEntry) bool {
-					if !parseRequired {
-						return !bytes.Contains(e.Data, bVal)
-					}
-					ts, err := e.Data.Values()
-					if err != nil {
-						return false
-					}
-					for _, t := range ts {
-						if strings.Contains(t, val) {
-							return false
-						}
-					}
-					return true
-				})
-			case "|~":
-				re, err := regexp.Compile(val)
-				if err != nil {
-					return err
-				}
-				filters = append(filters, func(e storage.LogEntry) bool {
-					if !parseRequired {
-						return re.Match(e.Data)
-					}
-					ts, err := e.Data.Values()
-					if err != nil {
-						return false
-					}
-					for _, t := range ts {
-						if re.MatchString(t) {
-							return true
-						}
-					}
-					return false
-				})
-				litVals, err := regexpExtractLiterals(val)
-				if err != nil {
-					return err
-				}
-				contains = append(contains, litVals...)
-			case "!~":
-				// negate
-				re, err := regexp.Compile(val)
-				if err != nil {
-					return err
-				}
-				filters = append(filters, func(e storage.LogEntry) bool {
-					if !parseRequired {
-						return !re.Match(e.Data)
-					}
-					ts, err := e.Data.Values()
-					if err != nil {
-						return false
-					}
-					for _, t := range ts {
-						if re.MatchString(t) {
-							return false
-						}
-					}
-					return true
-				})
+			if filter != nil {
+				filters = append(filters, filter.filter)
+				contains = append(contains, filter.contains...)
 			}
-		case symbols.NT_DataFilter:
-			key := node.GetTChildI(1).LiteralString()
-			key, err := logqlUnquote(key)
+		case symbols.NT_FilterStage:
+			filter, err := logqlFilterExpr(node.GetNTChildI(1))
 			if err != nil {
 				return err
 			}
-			op := node.GetNTChildI(2).GetTChildI(0).LiteralString()
-			val := node.GetTChildI(3).LiteralString()
-			val, err = logqlUnquote(val)
+			filters = append(filters, filter.filter)
+			contains = append(contains, filter.contains...)
+		case symbols.NT_ParserStage:
+			filter, err := logqlParserStage(node)
 			if err != nil {
 				return err
 			}
-			litKeys, err := gjsonExtractLiterals(key)
+			if parsed < 0 {
+				parsed = len(filters)
+			}
+			filters = append(filters, filter)
+		case symbols.NT_FormatStage:
+			filter, err := logqlFormatStage(node)
 			if err != nil {
 				return err
 			}
-			contains = append(contains, litKeys...)
-			switch op {
-			case "=":
-				filters = append(filters, func(e storage.LogEntry) bool {
-					v := gjson.GetBytes(e.Data, key)
-					if !v.Exists() {
-						return false
-					}
-					return v.String() == val
-				})
-				contains = append(contains, val)
-			case "!=":
-				// negate
-				filters = append(filters, func(e storage.LogEntry) bool {
-					v := gjson.GetBytes(e.Data, key)
-					if !v.Exists() {
-						return false
-					}
-					return v.String() != val
-				})
-			case "=~":
-				re, err := regexp.Compile(val)
-				if err != nil {
-					return err
-				}
-				filters = append(filters, func(e storage.LogEntry) bool {
-					v := gjson.GetBytes(e.Data, key)
-					if !v.Exists() {
-						return false
-					}
-					return re.MatchString(v.String())
-				})
-				litVals, err := regexpExtractLiterals(val)
-				if err != nil {
-					return err
-				}
-				contains = append(contains, litVals...)
-			case "!~":
-				// negate
-				re, err := regexp.Compile(val)
-				if err != nil {
-					return err
-				}
-				filters = append(filters, func(e storage.LogEntry) bool {
-					v := gjson.GetBytes(e.Data, key)
-					if !v.Exists() {
-						return false
-					}
-					return !re.MatchString(v.String())
-				})
-			case ">=":
-				fVal, err := strconv.ParseFloat(val, 64)
-				if err != nil {
-					return err
-				}
-				filters = append(filters, func(e storage.LogEntry) bool {
-					v := gjson.GetBytes(e.Data, key)
-					if !v.Exists() {
-						return false
-					}
-					return v.Float() >= fVal
-				})
-			case ">":
-				fVal, err := strconv.ParseFloat(val, 64)
-				if err != nil {
-					return err
-				}
-				filters = append(filters, func(e storage.LogEntry) bool {
-					v := gjson.GetBytes(e.Data, key)
-					if !v.Exists() {
-						return false
-					}
-					return v.Float() > fVal
-				})
-			case "<=":
-				fVal, err := strconv.ParseFloat(val, 64)
-				if err != nil {
-					return err
-				}
-				filters = append(filters, func(e storage.LogEntry) bool {
-					v := gjson.GetBytes(e.Data, key)
-					if !v.Exists() {
-						return false
-					}
-					return v.Float() <= fVal
-				})
-			case "<":
-				fVal, err := strconv.ParseFloat(val, 64)
-				if err != nil {
-					return err
-				}
-				filters = append(filters, func(e storage.LogEntry) bool {
-					v := gjson.GetBytes(e.Data, key)
-					if !v.Exists() {
-						return false
-					}
-					return v.Float() < fVal
-				})
+			if parsed < 0 {
+				parsed = len(filters)
 			}
+			if formatted < 0 && node.Alternate() == 0 {
+				formatted = len(contains)
+			}
+			filters = append(filters, filter)
 		}
 		return nil
 	})
 	if err != nil {
 		return err
 	}
+	if formatted >= 0 {
+		contains = contains[:formatted]
+	}
 	if len(filters) > 0 {
 		ropts.SummaryFunc = nil
 		ropts.Contains = contains
 	}
-	if ropts.FilterFunc != nil {
-		filters = append(filters, ropts.FilterFunc)
+	if len(matchers) > 0 {
+		labelFunc := ropts.LabelFunc
+		ropts.LabelFunc = func(labels map[string]string) bool {
+			for _, match := range matchers {
+				if !match(labels) {
+					return false
+				}
+			}
+			return labelFunc == nil || labelFunc(labels)
+		}
+	}
+	var post []func(e *storage.LogEntry) bool
+	if parsed >= 0 {
+		filters, post = filters[:parsed], filters[parsed:]
 	}
+	filterFunc := ropts.FilterFunc
 	ropts.FilterFunc = func(e storage.LogEntry) bool {
 		for _, filter := range filters {
-			if !filter(e) {
+			if !filter(&e) {
 				return false
 			}
 		}
-		return true
+		return filterFunc == nil || filterFunc(e)
+	}
+	if len(post) > 0 {
+		resultFunc := ropts.ResultFunc
+		ropts.ResultFunc = func(e storage.LogEntry) {
+			for _, filter := range post {
+				if !filter(&e) {
+					return
+				}
+			}
+			resultFunc(e)
+		}
 	}
 	return r.Read(ctx, ropts)
 }
 
+func logqlMatcher(node bsr.BSR) (string, string, string, func(labels map[string]string) bool, error) {
+	key := node.GetNTChildI(0).GetTChildI(0).LiteralString()
+	op := node.GetNTChildI(1).GetTChildI(0).LiteralString()
+	val, err := logqlUnquote(node.GetTChildI(2).LiteralString())
+	if err != nil {
+		return "", "", "", nil, err
+	}
+	switch op {
+	case "=":
+		return key, op, val, func(labels map[string]string) bool {
+			return labels[key] == val
+		}, nil
+	case "!=":
+		return key, op, val, func(labels map[string]string) bool {
+			v, ok := labels[key]
+			if !ok {
+				return false
+			}
+			return v != val
+		}, nil
+	default:
+		re, err := regexp.Compile(val)
+		if err != nil {
+			return "", "", "", nil, err
+		}
+		return key, op, val, func(labels map[string]string) bool {
+			v, ok := labels[key]
+			if !ok {
+				return false
+			}
+			return re.MatchString(v) == (op == "=~")
+		}, nil
+	}
+}
+
+// ParseSelector returns a func matching labels against a stream selector
+// such as {app=~"web|api"}.
+func ParseSelector(query string) (func(labels map[string]string) bool, error) {
+	return logqlSelector(query)
+}
+
+// logqlSelector returns a func matching labels against a stream selector.
+func logqlSelector(query string) (func(labels map[string]string) bool, error) {
+	root, err := logqlParse(query)
+	if err != nil {
+		return nil, err
+	}
+	var matchers []func(labels map[string]string) bool
+	err = logqlWalk(root, func(node bsr.BSR) error {
+		switch node.Label.Slot().NT {
+		case symbols.NT_LogSelectorMember:
+			_, _, _, match, err := logqlMatcher(node)
+			if err != nil {
+				return err
+			}
+			matchers = append(matchers, match)
+		case symbols.NT_Pipeline, symbols.NT_MetricQuery:
+			return fmt.Errorf("%w: %s", errInvalidSelector, query)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return func(labels map[string]string) bool {
+		for _, match := range matchers {
+			if !match(labels) {
+				return false
+			}
+		}
+		return true
+	}, nil
+}
+
+type logqlFilter struct {
+	filter func(e *storage.LogEntry) bool
+	// contains are literals every matching entry has.
+	contains []string
+}
+
+func logqlIntersect(a, b []string) []string {
+	m := make(map[string]bool)
+	for _, s := range b {
+		m[s] = true
+	}
+	var out []string
+	for _, s := range a {
+		if m[s] {
+			out = append(out, s)
+		}
+	}
+	return out
+}
+
+// logqlLineFilters returns nil if the line filter matches every entry.
+// `|= "a" or "b"` keeps entries with either, `!= "a" or "b"` drops both.
+func logqlLineFilters(node bsr.BSR) (*logqlFilter, error) {
+	op := node.GetNTChildI(0).GetTChildI(0).LiteralString()
+	negate := op == "!=" || op == "!~"
+	var lineFilters []func(e *storage.LogEntry) bool
+	var contains []string
+	for vals := node.GetNTChildI(1); ; vals = vals.GetNTChildI(2) {
+		val, err := logqlUnquote(vals.GetTChildI(0).LiteralString())
+		if err != nil {
+			return nil, err
+		}
+		if val == "" {
+			if !negate {
+				return nil, nil
+			}
+		} else {
+			filter, c, err := logqlLineFilter(op, val)
+			if err != nil {
+				return nil, err
+			}
+			if len(lineFilters) == 0 {
+				contains = c
+			} else {
+				contains = logqlIntersect(contains, c)
+			}
+			lineFilters = append(lineFilters, filter)
+		}
+		if vals.Alternate() == 0 {
+			break
+		}
+	}
+	if len(lineFilters) == 0 {
+		return nil, nil
+	}
+	return &logqlFilter{
+		filter: func(e *storage.LogEntry) bool {
+			for _, filter := range lineFilters {
+				if filter(e) != negate {
+					return !negate
+				}
+			}
+			return negate
+		},
+		contains: contains,
+	}, nil
+}
+
+func logqlLineFilter(op, val string) (func(e *storage.LogEntry) bool, []string, error) {
+	var filter func(e *storage.LogEntry) bool
+	var contains []string
+	parseRequired := strings.ContainsAny(val, `:,{}[]"`) || val != logqlQuote(val)
+	switch op {
+	case "|=":
+		bVal := []byte(val)
+		filter = func(e *storage.LogEntry) bool {
+			if !parseRequired {
+				return bytes.Contains(e.Data, bVal)
+			}
+			ts, err := e.Data.Values()
+			if err != nil {
+				return false
+			}
+			for _, t := range ts {
+				if strings.Contains(t, val) {
+					return true
+				}
+			}
+			return false
+		}
+		contains = append(contains, val)
+	case "!=":
+		// negate
+		bVal := []byte(val)
+		filter = func(e *storage.LogEntry) bool {
+			if !parseRequired {
+				return !bytes.Contains(e.Data, bVal)
+			}
+			ts, err := e.Data.Values()
+			if err != nil {
+				return false
+			}
+			for _, t := range ts {
+				if strings.Contains(t, val) {
+					return false
+				}
+			}
+			return true
+		}
+	case "|~":
+		re, err := regexp.Compile(val)
+		if err != nil {
+			return nil, nil, err
+		}
+		filter = func(e *storage.LogEntry) bool {
+			if !parseRequired {
+				return re.Match(e.Data)
+			}
+			ts, err := e.Data.Values()
+			if err != nil {
+				return false
+			}
+			for _, t := range ts {
+				if re.MatchString(t) {
+					return true
+				}
+			}
+			return false
+		}
+		litVals, err := regexpExtractLiterals(val)
+		if err != nil {
+			return nil, nil, err
+		}
+		contains = append(contains, litVals...)
+	case "!~":
+		// negate
+		re, err := regexp.Compile(val)
+		if err != nil {
+			return nil, nil, err
+		}
+		filter = func(e *storage.LogEntry) bool {
+			if !parseRequired {
+				return !re.Match(e.Data)
+			}
+			ts, err := e.Data.Values()
+			if err != nil {
+				return false
+			}
+			for _, t := range ts {
+				if re.MatchString(t) {
+					return false
+				}
+			}
+			return true
+		}
+	}
+	return filter, contains, nil
+}
+
+// logqlFilterExpr compiles and, or, not and parentheses over data and label
+// filters. Only literals required by every branch of an or are kept.
+func logqlFilterExpr(node bsr.BSR) (*logqlFilter, error) {
+	switch node.Label.Slot().NT {
+	case symbols.NT_FilterExpr, symbols.NT_FilterAnd:
+		left, err := logqlFilterExpr(node.GetNTChildI(0))
+		if err != nil {
+			return nil, err
+		}
+		if node.Alternate() == 0 {
+			return left, nil
+		}
+		right, err := logqlFilterExpr(node.GetNTChildI(2))
+		if err != nil {
+			return nil, err
+		}
+		if node.Label.Slot().NT == symbols.NT_FilterExpr {
+			return &logqlFilter{
+				filter: func(e *storage.LogEntry) bool {
+					return left.filter(e) || right.filter(e)
+				},
+				contains: logqlIntersect(left.contains, right.contains),
+			}, nil
+		}
+		return &logqlFilter{
+			filter: func(e *storage.LogEntry) bool {
+				return left.filter(e) && right.filter(e)
+			},
+			contains: append(append([]string{}, left.contains...), right.contains...),
+		}, nil
+	case symbols.NT_FilterNot:
+		if node.Alternate() == 0 {
+			return logqlFilterExpr(node.GetNTChildI(0))
+		}
+		f, err := logqlFilterExpr(node.GetNTChildI(1))
+		if err != nil {
+			return nil, err
+		}
+		return &logqlFilter{
+			filter: func(e *storage.LogEntry) bool {
+				return !f.filter(e)
+			},
+		}, nil
+	default:
+		switch node.Alternate() {
+		case 0:
+			node = node.GetNTChildI(0)
+			key, err := logqlUnquote(node.GetTChildI(0).LiteralString())
+			if err != nil {
+				return nil, err
+			}
+			op := node.GetNTChildI(1).GetTChildI(0).LiteralString()
+			val := node.GetNTChildI(2).GetTChildI(0).LiteralString()
+			if node.GetNTChildI(2).Alternate() == 0 {
+				val, err = logqlUnquote(val)
+				if err != nil {
+					return nil, err
+				}
+			}
+			filter, contains, err := logqlDataFilter(key, op, val)
+			if err != nil {
+				return nil, err
+			}
+			return &logqlFilter{filter: filter, contains: contains}, nil
+		case 1:
+			node = node.GetNTChildI(0)
+			key := node.GetNTChildI(0).GetTChildI(0).LiteralString()
+			op := node.GetNTChildI(1).GetTChildI(0).LiteralString()
+			val := node.GetNTChildI(2).GetTChildI(0).LiteralString()
+			isNum := node.GetNTChildI(2).Alternate() == 1
+			if !isNum {
+				var err error
+				val, err = logqlUnquote(val)
+				if err != nil {
+					return nil, err
+				}
+			}
+			filter, err := logqlLabelFilter(key, op, val, isNum)
+			if err != nil {
+				return nil, err
+			}
+			return &logqlFilter{filter: filter}, nil
+		default:
+			return logqlFilterExpr(node.GetNTChildI(1))
+		}
+	}
+}
+
+func logqlDataFilter(key, op, val string) (func(e *storage.LogEntry) bool, []string, error) {
+	var filter func(e *storage.LogEntry) bool
+	litKeys, err := gjsonExtractLiterals(key)
+	if err != nil {
+		return nil, nil, err
+	}
+	contains := litKeys
+	switch op {
+	case "=":
+		filter = func(e *storage.LogEntry) bool {
+			v := gjson.GetBytes(e.Data, key)
+			if !v.Exists() {
+				return false
+			}
+			return v.String() == val
+		}
+		contains = append(contains, val)
+	case "!=":
+		// negate
+		filter = func(e *storage.LogEntry) bool {
+			v := gjson.GetBytes(e.Data, key)
+			if !v.Exists() {
+				return false
+			}
+			return v.String() != val
+		}
+	case "=~":
+		re, err := regexp.Compile(val)
+		if err != nil {
+			return nil, nil, err
+		}
+		filter = func(e *storage.LogEntry) bool {
+			v := gjson.GetBytes(e.Data, key)
+			if !v.Exists() {
+				return false
+			}
+			return re.MatchString(v.String())
+		}
+		litVals, err := regexpExtractLiterals(val)
+		if err != nil {
+			return nil, nil, err
+		}
+		contains = append(contains, litVals...)
+	case "!~":
+		// negate
+		re, err := regexp.Compile(val)
+		if err != nil {
+			return nil, nil, err
+		}
+		filter = func(e *storage.LogEntry) bool {
+			v := gjson.GetBytes(e.Data, key)
+			if !v.Exists() {
+				return false
+			}
+			return !re.MatchString(v.String())
+		}
+	case ">=":
+		fVal, err := strconv.ParseFloat(val, 64)
+		if err != nil {
+			return nil, nil, err
+		}
+		filter = func(e *storage.LogEntry) bool {
+			v := gjson.GetBytes(e.Data, key)
+			if !v.Exists() {
+				return false
+			}
+			return v.Float() >= fVal
+		}
+	case ">":
+		fVal, err := strconv.ParseFloat(val, 64)
+		if err != nil {
+			return nil, nil, err
+		}
+		filter = func(e *storage.LogEntry) bool {
+			v := gjson.GetBytes(e.Data, key)
+			if !v.Exists() {
+				return false
+			}
+			return v.Float() > fVal
+		}
+	case "<=":
+		fVal, err := strconv.ParseFloat(val, 64)
+		if err != nil {
+			return nil, nil, err
+		}
+		filter = func(e *storage.LogEntry) bool {
+			v := gjson.GetBytes(e.Data, key)
+			if !v.Exists() {
+				return false
+			}
+			return v.Float() <= fVal
+		}
+	case "<":
+		fVal, err := strconv.ParseFloat(val, 64)
+		if err != nil {
+			return nil, nil, err
+		}
+		filter = func(e *storage.LogEntry) bool {
+			v := gjson.GetBytes(e.Data, key)
+			if !v.Exists() {
+				return false
+			}
+			return v.Float() < fVal
+		}
+	}
+	return filter, contains, nil
+}
+
 func logqlParse(query string) (bsr.BSR, error) {
 	lex := lexer.New([]rune(query))
 	q, errs := parser.Parse(lex)
@@ -349,22 +615,6 @@ func logqlWalk(node bsr.BSR, f func(bsr.BSR) error) error {
 	return nil
 }
 
-func logqlIsHistogram(query string) (bool, error) {
-	root, err := logqlParse(query)
-	if err != nil {
-		return false, err
-	}
-	var isHistogram bool
-	logqlWalk(root, func(node bsr.BSR) error {
-		switch node.Label.Slot().NT {
-		case symbols.NT_MetricQuery:
-			isHistogram = true
-		}
-		return nil
-	})
-	return isHistogram, nil
-}
-
 func gjsonExtractLiterals(s string) ([]string, error) {
 	root, err := syntax.Parse(s, syntax.POSIX)
 	if err != nil {
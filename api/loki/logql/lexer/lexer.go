@@ -289,48 +289,131 @@ var accept = []token.Type{
 	token.T_3, 
 	token.T_4, 
 	token.T_5, 
+	token.T_6, 
 	token.T_7, 
+	token.T_8, 
 	token.T_9, 
+	token.T_10, 
+	token.T_12, 
+	token.T_15, 
 	token.Error, 
 	token.T_17, 
+	token.T_38, 
 	token.Error, 
-	token.T_17, 
-	token.T_17, 
-	token.T_17, 
-	token.T_17, 
-	token.T_18, 
-	token.T_19, 
-	token.T_22, 
+	token.T_38, 
+	token.T_38, 
+	token.T_38, 
+	token.T_38, 
+	token.T_38, 
+	token.T_38, 
+	token.T_38, 
+	token.T_38, 
+	token.T_38, 
+	token.T_38, 
+	token.T_38, 
+	token.T_38, 
+	token.T_40, 
+	token.T_41, 
+	token.T_44, 
+	token.T_30, 
 	token.T_0, 
 	token.T_1, 
-	token.T_15, 
-	token.T_6, 
-	token.T_8, 
-	token.T_10, 
-	token.T_13, 
+	token.T_35, 
 	token.T_11, 
-	token.T_17, 
-	token.T_17, 
-	token.T_17, 
-	token.T_20, 
-	token.T_21, 
-	token.T_17, 
-	token.T_17, 
-	token.T_16, 
-	token.T_17, 
-	token.T_17, 
-	token.T_17, 
+	token.T_13, 
 	token.T_14, 
-	token.T_17, 
-	token.T_17, 
-	token.T_17, 
-	token.T_17, 
-	token.T_17, 
-	token.T_17, 
-	token.T_17, 
-	token.T_17, 
-	token.T_17, 
-	token.T_12, 
+	token.T_16, 
+	token.T_21, 
+	token.T_38, 
+	token.T_38, 
+	token.T_20, 
+	token.T_38, 
+	token.T_38, 
+	token.T_38, 
+	token.T_38, 
+	token.T_38, 
+	token.T_38, 
+	token.T_38, 
+	token.T_31, 
+	token.T_32, 
+	token.T_38, 
+	token.T_38, 
+	token.T_38, 
+	token.T_38, 
+	token.T_42, 
+	token.T_43, 
+	token.Error, 
+	token.T_18, 
+	token.T_38, 
+	token.T_38, 
+	token.T_38, 
+	token.T_38, 
+	token.T_38, 
+	token.T_38, 
+	token.T_38, 
+	token.T_29, 
+	token.T_38, 
+	token.T_38, 
+	token.T_38, 
+	token.T_38, 
+	token.T_38, 
+	token.T_30, 
+	token.T_19, 
+	token.T_38, 
+	token.T_38, 
+	token.T_25, 
+	token.T_38, 
+	token.T_38, 
+	token.T_38, 
+	token.T_38, 
+	token.T_38, 
+	token.T_38, 
+	token.T_38, 
+	token.T_38, 
+	token.T_38, 
+	token.T_38, 
+	token.T_38, 
+	token.T_38, 
+	token.T_38, 
+	token.T_38, 
+	token.T_38, 
+	token.T_38, 
+	token.T_38, 
+	token.T_38, 
+	token.T_38, 
+	token.T_38, 
+	token.T_38, 
+	token.T_38, 
+	token.T_28, 
+	token.T_38, 
+	token.T_34, 
+	token.T_36, 
+	token.T_37, 
+	token.T_38, 
+	token.T_38, 
+	token.T_38, 
+	token.T_38, 
+	token.T_38, 
+	token.T_38, 
+	token.T_33, 
+	token.T_39, 
+	token.T_38, 
+	token.T_38, 
+	token.T_24, 
+	token.T_38, 
+	token.T_38, 
+	token.T_38, 
+	token.T_38, 
+	token.T_38, 
+	token.T_38, 
+	token.T_22, 
+	token.T_38, 
+	token.T_38, 
+	token.T_38, 
+	token.T_23, 
+	token.T_38, 
+	token.T_27, 
+	token.T_26, 
 }
 
 var nextState = []func(r rune) state{ 
@@ -341,42 +424,72 @@ var nextState = []func(r rune) state{
 			return 1 
 		case r == '"':
 			return 2 
-		case r == '(':
+		case r == '%':
 			return 3 
-		case r == ')':
+		case r == '(':
 			return 4 
-		case r == ',':
+		case r == ')':
 			return 5 
-		case r == '<':
+		case r == '*':
 			return 6 
-		case r == '=':
+		case r == '+':
 			return 7 
-		case r == '>':
+		case r == ',':
 			return 8 
-		case r == '[':
+		case r == '-':
 			return 9 
-		case r == '_':
+		case r == '/':
 			return 10 
-		case r == '`':
+		case r == '<':
 			return 11 
-		case r == 'b':
+		case r == '=':
 			return 12 
-		case r == 'c':
+		case r == '>':
 			return 13 
-		case r == 'l':
+		case r == '[':
 			return 14 
-		case r == 's':
+		case r == '^':
 			return 15 
-		case r == '{':
+		case r == '_':
 			return 16 
-		case r == '|':
+		case r == '`':
 			return 17 
-		case r == '}':
+		case r == 'a':
 			return 18 
+		case r == 'b':
+			return 19 
+		case r == 'g':
+			return 20 
+		case r == 'i':
+			return 21 
+		case r == 'j':
+			return 22 
+		case r == 'l':
+			return 23 
+		case r == 'n':
+			return 24 
+		case r == 'o':
+			return 25 
+		case r == 'p':
+			return 26 
+		case r == 'r':
+			return 27 
+		case r == 'u':
+			return 28 
+		case r == 'w':
+			return 29 
+		case r == '{':
+			return 30 
+		case r == '|':
+			return 31 
+		case r == '}':
+			return 32 
+		case unicode.IsNumber(r):
+			return 33 
 		case unicode.IsUpper(r):
-			return 10 
+			return 16 
 		case unicode.IsLower(r):
-			return 10 
+			return 16 
 		}
 		return nullState
 	}, 
@@ -384,9 +497,9 @@ var nextState = []func(r rune) state{
 	func(r rune) state {
 		switch { 
 		case r == '=':
-			return 19 
+			return 34 
 		case r == '~':
-			return 20 
+			return 35 
 		}
 		return nullState
 	}, 
@@ -394,7 +507,7 @@ var nextState = []func(r rune) state{
 	func(r rune) state {
 		switch { 
 		case r == '"':
-			return 21 
+			return 36 
 		case not(r, []rune{'"'}):
 			return 2 
 		}
@@ -421,522 +534,1742 @@ var nextState = []func(r rune) state{
 	// Set6
 	func(r rune) state {
 		switch { 
-		case r == '=':
-			return 22 
 		}
 		return nullState
 	}, 
 	// Set7
 	func(r rune) state {
 		switch { 
-		case r == '~':
-			return 23 
 		}
 		return nullState
 	}, 
 	// Set8
 	func(r rune) state {
 		switch { 
-		case r == '=':
-			return 24 
 		}
 		return nullState
 	}, 
 	// Set9
 	func(r rune) state {
 		switch { 
-		case r == ']':
-			return 25 
-		case not(r, []rune{']'}):
-			return 9 
 		}
 		return nullState
 	}, 
 	// Set10
 	func(r rune) state {
 		switch { 
-		case r == '_':
-			return 10 
-		case unicode.IsUpper(r):
-			return 10 
-		case unicode.IsLower(r):
-			return 10 
-		case unicode.IsNumber(r):
-			return 10 
 		}
 		return nullState
 	}, 
 	// Set11
 	func(r rune) state {
 		switch { 
-		case r == '`':
-			return 21 
-		case not(r, []rune{'`'}):
-			return 11 
+		case r == '=':
+			return 37 
 		}
 		return nullState
 	}, 
 	// Set12
 	func(r rune) state {
 		switch { 
-		case r == '_':
-			return 10 
-		case r == 'y':
-			return 26 
-		case unicode.IsUpper(r):
-			return 10 
-		case unicode.IsLower(r):
-			return 10 
-		case unicode.IsNumber(r):
-			return 10 
+		case r == '=':
+			return 38 
+		case r == '~':
+			return 39 
 		}
 		return nullState
 	}, 
 	// Set13
 	func(r rune) state {
 		switch { 
-		case r == '_':
-			return 10 
-		case r == 'o':
-			return 27 
-		case unicode.IsUpper(r):
-			return 10 
-		case unicode.IsLower(r):
-			return 10 
-		case unicode.IsNumber(r):
-			return 10 
+		case r == '=':
+			return 40 
 		}
 		return nullState
 	}, 
 	// Set14
 	func(r rune) state {
 		switch { 
-		case r == '_':
-			return 10 
-		case r == 'e':
-			return 28 
-		case unicode.IsUpper(r):
-			return 10 
-		case unicode.IsLower(r):
-			return 10 
-		case unicode.IsNumber(r):
-			return 10 
+		case r == ']':
+			return 41 
+		case not(r, []rune{']'}):
+			return 14 
 		}
 		return nullState
 	}, 
 	// Set15
 	func(r rune) state {
 		switch { 
-		case r == '_':
-			return 10 
-		case r == 'u':
-			return 29 
-		case unicode.IsUpper(r):
-			return 10 
-		case unicode.IsLower(r):
-			return 10 
-		case unicode.IsNumber(r):
-			return 10 
 		}
 		return nullState
 	}, 
 	// Set16
 	func(r rune) state {
 		switch { 
+		case r == '_':
+			return 16 
+		case unicode.IsUpper(r):
+			return 16 
+		case unicode.IsLower(r):
+			return 16 
+		case unicode.IsNumber(r):
+			return 16 
 		}
 		return nullState
 	}, 
 	// Set17
 	func(r rune) state {
 		switch { 
-		case r == '=':
-			return 30 
-		case r == '~':
-			return 31 
+		case r == '`':
+			return 36 
+		case not(r, []rune{'`'}):
+			return 17 
 		}
 		return nullState
 	}, 
 	// Set18
 	func(r rune) state {
 		switch { 
+		case r == '_':
+			return 16 
+		case r == 'n':
+			return 42 
+		case unicode.IsUpper(r):
+			return 16 
+		case unicode.IsLower(r):
+			return 16 
+		case unicode.IsNumber(r):
+			return 16 
 		}
 		return nullState
 	}, 
 	// Set19
 	func(r rune) state {
 		switch { 
+		case r == '_':
+			return 16 
+		case r == 'o':
+			return 43 
+		case r == 'y':
+			return 44 
+		case unicode.IsUpper(r):
+			return 16 
+		case unicode.IsLower(r):
+			return 16 
+		case unicode.IsNumber(r):
+			return 16 
 		}
 		return nullState
 	}, 
 	// Set20
 	func(r rune) state {
 		switch { 
+		case r == '_':
+			return 16 
+		case r == 'r':
+			return 45 
+		case unicode.IsUpper(r):
+			return 16 
+		case unicode.IsLower(r):
+			return 16 
+		case unicode.IsNumber(r):
+			return 16 
 		}
 		return nullState
 	}, 
 	// Set21
 	func(r rune) state {
 		switch { 
+		case r == '_':
+			return 16 
+		case r == 'g':
+			return 46 
+		case unicode.IsUpper(r):
+			return 16 
+		case unicode.IsLower(r):
+			return 16 
+		case unicode.IsNumber(r):
+			return 16 
 		}
 		return nullState
 	}, 
 	// Set22
 	func(r rune) state {
 		switch { 
+		case r == '_':
+			return 16 
+		case r == 's':
+			return 47 
+		case unicode.IsUpper(r):
+			return 16 
+		case unicode.IsLower(r):
+			return 16 
+		case unicode.IsNumber(r):
+			return 16 
 		}
 		return nullState
 	}, 
 	// Set23
 	func(r rune) state {
 		switch { 
+		case r == '_':
+			return 16 
+		case r == 'a':
+			return 48 
+		case r == 'i':
+			return 49 
+		case r == 'o':
+			return 50 
+		case unicode.IsUpper(r):
+			return 16 
+		case unicode.IsLower(r):
+			return 16 
+		case unicode.IsNumber(r):
+			return 16 
+		}
+		return nullState
+	}, 
+	// Set24
+	func(r rune) state {
+		switch { 
+		case r == '_':
+			return 16 
+		case r == 'o':
+			return 51 
+		case unicode.IsUpper(r):
+			return 16 
+		case unicode.IsLower(r):
+			return 16 
+		case unicode.IsNumber(r):
+			return 16 
+		}
+		return nullState
+	}, 
+	// Set25
+	func(r rune) state {
+		switch { 
+		case r == '_':
+			return 16 
+		case r == 'n':
+			return 52 
+		case r == 'r':
+			return 53 
+		case unicode.IsUpper(r):
+			return 16 
+		case unicode.IsLower(r):
+			return 16 
+		case unicode.IsNumber(r):
+			return 16 
+		}
+		return nullState
+	}, 
+	// Set26
+	func(r rune) state {
+		switch { 
+		case r == '_':
+			return 16 
+		case r == 'a':
+			return 54 
+		case unicode.IsUpper(r):
+			return 16 
+		case unicode.IsLower(r):
+			return 16 
+		case unicode.IsNumber(r):
+			return 16 
+		}
+		return nullState
+	}, 
+	// Set27
+	func(r rune) state {
+		switch { 
+		case r == '_':
+			return 16 
+		case r == 'e':
+			return 55 
+		case unicode.IsUpper(r):
+			return 16 
+		case unicode.IsLower(r):
+			return 16 
+		case unicode.IsNumber(r):
+			return 16 
+		}
+		return nullState
+	}, 
+	// Set28
+	func(r rune) state {
+		switch { 
+		case r == '_':
+			return 16 
+		case r == 'n':
+			return 56 
+		case unicode.IsUpper(r):
+			return 16 
+		case unicode.IsLower(r):
+			return 16 
+		case unicode.IsNumber(r):
+			return 16 
+		}
+		return nullState
+	}, 
+	// Set29
+	func(r rune) state {
+		switch { 
+		case r == '_':
+			return 16 
+		case r == 'i':
+			return 57 
+		case unicode.IsUpper(r):
+			return 16 
+		case unicode.IsLower(r):
+			return 16 
+		case unicode.IsNumber(r):
+			return 16 
+		}
+		return nullState
+	}, 
+	// Set30
+	func(r rune) state {
+		switch { 
+		}
+		return nullState
+	}, 
+	// Set31
+	func(r rune) state {
+		switch { 
+		case r == '=':
+			return 58 
+		case r == '~':
+			return 59 
+		}
+		return nullState
+	}, 
+	// Set32
+	func(r rune) state {
+		switch { 
+		}
+		return nullState
+	}, 
+	// Set33
+	func(r rune) state {
+		switch { 
+		case r == '.':
+			return 60 
+		case unicode.IsNumber(r):
+			return 33 
+		}
+		return nullState
+	}, 
+	// Set34
+	func(r rune) state {
+		switch { 
+		}
+		return nullState
+	}, 
+	// Set35
+	func(r rune) state {
+		switch { 
+		}
+		return nullState
+	}, 
+	// Set36
+	func(r rune) state {
+		switch { 
+		}
+		return nullState
+	}, 
+	// Set37
+	func(r rune) state {
+		switch { 
+		}
+		return nullState
+	}, 
+	// Set38
+	func(r rune) state {
+		switch { 
+		}
+		return nullState
+	}, 
+	// Set39
+	func(r rune) state {
+		switch { 
+		}
+		return nullState
+	}, 
+	// Set40
+	func(r rune) state {
+		switch { 
+		}
+		return nullState
+	}, 
+	// Set41
+	func(r rune) state {
+		switch { 
+		}
+		return nullState
+	}, 
+	// Set42
+	func(r rune) state {
+		switch { 
+		case r == '_':
+			return 16 
+		case r == 'd':
+			return 61 
+		case unicode.IsUpper(r):
+			return 16 
+		case unicode.IsLower(r):
+			return 16 
+		case unicode.IsNumber(r):
+			return 16 
+		}
+		return nullState
+	}, 
+	// Set43
+	func(r rune) state {
+		switch { 
+		case r == '_':
+			return 16 
+		case r == 'o':
+			return 62 
+		case unicode.IsUpper(r):
+			return 16 
+		case unicode.IsLower(r):
+			return 16 
+		case unicode.IsNumber(r):
+			return 16 
+		}
+		return nullState
+	}, 
+	// Set44
+	func(r rune) state {
+		switch { 
+		case r == '_':
+			return 16 
+		case unicode.IsUpper(r):
+			return 16 
+		case unicode.IsLower(r):
+			return 16 
+		case unicode.IsNumber(r):
+			return 16 
+		}
+		return nullState
+	}, 
+	// Set45
+	func(r rune) state {
+		switch { 
+		case r == '_':
+			return 16 
+		case r == 'o':
+			return 63 
+		case unicode.IsUpper(r):
+			return 16 
+		case unicode.IsLower(r):
+			return 16 
+		case unicode.IsNumber(r):
+			return 16 
+		}
+		return nullState
+	}, 
+	// Set46
+	func(r rune) state {
+		switch { 
+		case r == '_':
+			return 16 
+		case r == 'n':
+			return 64 
+		case unicode.IsUpper(r):
+			return 16 
+		case unicode.IsLower(r):
+			return 16 
+		case unicode.IsNumber(r):
+			return 16 
+		}
+		return nullState
+	}, 
+	// Set47
+	func(r rune) state {
+		switch { 
+		case r == '_':
+			return 16 
+		case r == 'o':
+			return 65 
+		case unicode.IsUpper(r):
+			return 16 
+		case unicode.IsLower(r):
+			return 16 
+		case unicode.IsNumber(r):
+			return 16 
+		}
+		return nullState
+	}, 
+	// Set48
+	func(r rune) state {
+		switch { 
+		case r == '_':
+			return 16 
+		case r == 'b':
+			return 66 
+		case unicode.IsUpper(r):
+			return 16 
+		case unicode.IsLower(r):
+			return 16 
+		case unicode.IsNumber(r):
+			return 16 
+		}
+		return nullState
+	}, 
+	// Set49
+	func(r rune) state {
+		switch { 
+		case r == '_':
+			return 16 
+		case r == 'n':
+			return 67 
+		case unicode.IsUpper(r):
+			return 16 
+		case unicode.IsLower(r):
+			return 16 
+		case unicode.IsNumber(r):
+			return 16 
+		}
+		return nullState
+	}, 
+	// Set50
+	func(r rune) state {
+		switch { 
+		case r == '_':
+			return 16 
+		case r == 'g':
+			return 68 
+		case unicode.IsUpper(r):
+			return 16 
+		case unicode.IsLower(r):
+			return 16 
+		case unicode.IsNumber(r):
+			return 16 
+		}
+		return nullState
+	}, 
+	// Set51
+	func(r rune) state {
+		switch { 
+		case r == '_':
+			return 16 
+		case r == 't':
+			return 69 
+		case unicode.IsUpper(r):
+			return 16 
+		case unicode.IsLower(r):
+			return 16 
+		case unicode.IsNumber(r):
+			return 16 
+		}
+		return nullState
+	}, 
+	// Set52
+	func(r rune) state {
+		switch { 
+		case r == '_':
+			return 16 
+		case unicode.IsUpper(r):
+			return 16 
+		case unicode.IsLower(r):
+			return 16 
+		case unicode.IsNumber(r):
+			return 16 
+		}
+		return nullState
+	}, 
+	// Set53
+	func(r rune) state {
+		switch { 
+		case r == '_':
+			return 16 
+		case unicode.IsUpper(r):
+			return 16 
+		case unicode.IsLower(r):
+			return 16 
+		case unicode.IsNumber(r):
+			return 16 
+		}
+		return nullState
+	}, 
+	// Set54
+	func(r rune) state {
+		switch { 
+		case r == '_':
+			return 16 
+		case r == 't':
+			return 70 
+		case unicode.IsUpper(r):
+			return 16 
+		case unicode.IsLower(r):
+			return 16 
+		case unicode.IsNumber(r):
+			return 16 
+		}
+		return nullState
+	}, 
+	// Set55
+	func(r rune) state {
+		switch { 
+		case r == '_':
+			return 16 
+		case r == 'g':
+			return 71 
+		case unicode.IsUpper(r):
+			return 16 
+		case unicode.IsLower(r):
+			return 16 
+		case unicode.IsNumber(r):
+			return 16 
+		}
+		return nullState
+	}, 
+	// Set56
+	func(r rune) state {
+		switch { 
+		case r == '_':
+			return 16 
+		case r == 'l':
+			return 72 
+		case r == 'w':
+			return 73 
+		case unicode.IsUpper(r):
+			return 16 
+		case unicode.IsLower(r):
+			return 16 
+		case unicode.IsNumber(r):
+			return 16 
+		}
+		return nullState
+	}, 
+	// Set57
+	func(r rune) state {
+		switch { 
+		case r == '_':
+			return 16 
+		case r == 't':
+			return 74 
+		case unicode.IsUpper(r):
+			return 16 
+		case unicode.IsLower(r):
+			return 16 
+		case unicode.IsNumber(r):
+			return 16 
+		}
+		return nullState
+	}, 
+	// Set58
+	func(r rune) state {
+		switch { 
+		}
+		return nullState
+	}, 
+	// Set59
+	func(r rune) state {
+		switch { 
+		}
+		return nullState
+	}, 
+	// Set60
+	func(r rune) state {
+		switch { 
+		case unicode.IsNumber(r):
+			return 75 
+		}
+		return nullState
+	}, 
+	// Set61
+	func(r rune) state {
+		switch { 
+		case r == '_':
+			return 16 
+		case unicode.IsUpper(r):
+			return 16 
+		case unicode.IsLower(r):
+			return 16 
+		case unicode.IsNumber(r):
+			return 16 
+		}
+		return nullState
+	}, 
+	// Set62
+	func(r rune) state {
+		switch { 
+		case r == '_':
+			return 16 
+		case r == 'l':
+			return 76 
+		case unicode.IsUpper(r):
+			return 16 
+		case unicode.IsLower(r):
+			return 16 
+		case unicode.IsNumber(r):
+			return 16 
+		}
+		return nullState
+	}, 
+	// Set63
+	func(r rune) state {
+		switch { 
+		case r == '_':
+			return 16 
+		case r == 'u':
+			return 77 
+		case unicode.IsUpper(r):
+			return 16 
+		case unicode.IsLower(r):
+			return 16 
+		case unicode.IsNumber(r):
+			return 16 
+		}
+		return nullState
+	}, 
+	// Set64
+	func(r rune) state {
+		switch { 
+		case r == '_':
+			return 16 
+		case r == 'o':
+			return 78 
+		case unicode.IsUpper(r):
+			return 16 
+		case unicode.IsLower(r):
+			return 16 
+		case unicode.IsNumber(r):
+			return 16 
+		}
+		return nullState
+	}, 
+	// Set65
+	func(r rune) state {
+		switch { 
+		case r == '_':
+			return 16 
+		case r == 'n':
+			return 79 
+		case unicode.IsUpper(r):
+			return 16 
+		case unicode.IsLower(r):
+			return 16 
+		case unicode.IsNumber(r):
+			return 16 
+		}
+		return nullState
+	}, 
+	// Set66
+	func(r rune) state {
+		switch { 
+		case r == '_':
+			return 16 
+		case r == 'e':
+			return 80 
+		case unicode.IsUpper(r):
+			return 16 
+		case unicode.IsLower(r):
+			return 16 
+		case unicode.IsNumber(r):
+			return 16 
+		}
+		return nullState
+	}, 
+	// Set67
+	func(r rune) state {
+		switch { 
+		case r == '_':
+			return 16 
+		case r == 'e':
+			return 81 
+		case unicode.IsUpper(r):
+			return 16 
+		case unicode.IsLower(r):
+			return 16 
+		case unicode.IsNumber(r):
+			return 16 
+		}
+		return nullState
+	}, 
+	// Set68
+	func(r rune) state {
+		switch { 
+		case r == '_':
+			return 16 
+		case r == 'f':
+			return 82 
+		case unicode.IsUpper(r):
+			return 16 
+		case unicode.IsLower(r):
+			return 16 
+		case unicode.IsNumber(r):
+			return 16 
+		}
+		return nullState
+	}, 
+	// Set69
+	func(r rune) state {
+		switch { 
+		case r == '_':
+			return 16 
+		case unicode.IsUpper(r):
+			return 16 
+		case unicode.IsLower(r):
+			return 16 
+		case unicode.IsNumber(r):
+			return 16 
+		}
+		return nullState
+	}, 
+	// Set70
+	func(r rune) state {
+		switch { 
+		case r == '_':
+			return 16 
+		case r == 't':
+			return 83 
+		case unicode.IsUpper(r):
+			return 16 
+		case unicode.IsLower(r):
+			return 16 
+		case unicode.IsNumber(r):
+			return 16 
+		}
+		return nullState
+	}, 
+	// Set71
+	func(r rune) state {
+		switch { 
+		case r == '_':
+			return 16 
+		case r == 'e':
+			return 84 
+		case unicode.IsUpper(r):
+			return 16 
+		case unicode.IsLower(r):
+			return 16 
+		case unicode.IsNumber(r):
+			return 16 
+		}
+		return nullState
+	}, 
+	// Set72
+	func(r rune) state {
+		switch { 
+		case r == '_':
+			return 16 
+		case r == 'e':
+			return 85 
+		case unicode.IsUpper(r):
+			return 16 
+		case unicode.IsLower(r):
+			return 16 
+		case unicode.IsNumber(r):
+			return 16 
+		}
+		return nullState
+	}, 
+	// Set73
+	func(r rune) state {
+		switch { 
+		case r == '_':
+			return 16 
+		case r == 'r':
+			return 86 
+		case unicode.IsUpper(r):
+			return 16 
+		case unicode.IsLower(r):
+			return 16 
+		case unicode.IsNumber(r):
+			return 16 
+		}
+		return nullState
+	}, 
+	// Set74
+	func(r rune) state {
+		switch { 
+		case r == '_':
+			return 16 
+		case r == 'h':
+			return 87 
+		case unicode.IsUpper(r):
+			return 16 
+		case unicode.IsLower(r):
+			return 16 
+		case unicode.IsNumber(r):
+			return 16 
+		}
+		return nullState
+	}, 
+	// Set75
+	func(r rune) state {
+		switch { 
+		case unicode.IsNumber(r):
+			return 75 
+		}
+		return nullState
+	}, 
+	// Set76
+	func(r rune) state {
+		switch { 
+		case r == '_':
+			return 16 
+		case unicode.IsUpper(r):
+			return 16 
+		case unicode.IsLower(r):
+			return 16 
+		case unicode.IsNumber(r):
+			return 16 
+		}
+		return nullState
+	}, 
+	// Set77
+	func(r rune) state {
+		switch { 
+		case r == '_':
+			return 16 
+		case r == 'p':
+			return 88 
+		case unicode.IsUpper(r):
+			return 16 
+		case unicode.IsLower(r):
+			return 16 
+		case unicode.IsNumber(r):
+			return 16 
+		}
+		return nullState
+	}, 
+	// Set78
+	func(r rune) state {
+		switch { 
+		case r == '_':
+			return 16 
+		case r == 'r':
+			return 89 
+		case unicode.IsUpper(r):
+			return 16 
+		case unicode.IsLower(r):
+			return 16 
+		case unicode.IsNumber(r):
+			return 16 
+		}
+		return nullState
+	}, 
+	// Set79
+	func(r rune) state {
+		switch { 
+		case r == '_':
+			return 16 
+		case unicode.IsUpper(r):
+			return 16 
+		case unicode.IsLower(r):
+			return 16 
+		case unicode.IsNumber(r):
+			return 16 
+		}
+		return nullState
+	}, 
+	// Set80
+	func(r rune) state {
+		switch { 
+		case r == '_':
+			return 16 
+		case r == 'l':
+			return 90 
+		case unicode.IsUpper(r):
+			return 16 
+		case unicode.IsLower(r):
+			return 16 
+		case unicode.IsNumber(r):
+			return 16 
+		}
+		return nullState
+	}, 
+	// Set81
+	func(r rune) state {
+		switch { 
+		case r == '_':
+			return 91 
+		case unicode.IsUpper(r):
+			return 16 
+		case unicode.IsLower(r):
+			return 16 
+		case unicode.IsNumber(r):
+			return 16 
+		}
+		return nullState
+	}, 
+	// Set82
+	func(r rune) state {
+		switch { 
+		case r == '_':
+			return 16 
+		case r == 'm':
+			return 92 
+		case unicode.IsUpper(r):
+			return 16 
+		case unicode.IsLower(r):
+			return 16 
+		case unicode.IsNumber(r):
+			return 16 
+		}
+		return nullState
+	}, 
+	// Set83
+	func(r rune) state {
+		switch { 
+		case r == '_':
+			return 16 
+		case r == 'e':
+			return 93 
+		case unicode.IsUpper(r):
+			return 16 
+		case unicode.IsLower(r):
+			return 16 
+		case unicode.IsNumber(r):
+			return 16 
+		}
+		return nullState
+	}, 
+	// Set84
+	func(r rune) state {
+		switch { 
+		case r == '_':
+			return 16 
+		case r == 'x':
+			return 94 
+		case unicode.IsUpper(r):
+			return 16 
+		case unicode.IsLower(r):
+			return 16 
+		case unicode.IsNumber(r):
+			return 16 
+		}
+		return nullState
+	}, 
+	// Set85
+	func(r rune) state {
+		switch { 
+		case r == '_':
+			return 16 
+		case r == 's':
+			return 95 
+		case unicode.IsUpper(r):
+			return 16 
+		case unicode.IsLower(r):
+			return 16 
+		case unicode.IsNumber(r):
+			return 16 
+		}
+		return nullState
+	}, 
+	// Set86
+	func(r rune) state {
+		switch { 
+		case r == '_':
+			return 16 
+		case r == 'a':
+			return 96 
+		case unicode.IsUpper(r):
+			return 16 
+		case unicode.IsLower(r):
+			return 16 
+		case unicode.IsNumber(r):
+			return 16 
+		}
+		return nullState
+	}, 
+	// Set87
+	func(r rune) state {
+		switch { 
+		case r == '_':
+			return 16 
+		case r == 'o':
+			return 97 
+		case unicode.IsUpper(r):
+			return 16 
+		case unicode.IsLower(r):
+			return 16 
+		case unicode.IsNumber(r):
+			return 16 
+		}
+		return nullState
+	}, 
+	// Set88
+	func(r rune) state {
+		switch { 
+		case r == '_':
+			return 98 
+		case unicode.IsUpper(r):
+			return 16 
+		case unicode.IsLower(r):
+			return 16 
+		case unicode.IsNumber(r):
+			return 16 
+		}
+		return nullState
+	}, 
+	// Set89
+	func(r rune) state {
+		switch { 
+		case r == '_':
+			return 16 
+		case r == 'i':
+			return 99 
+		case unicode.IsUpper(r):
+			return 16 
+		case unicode.IsLower(r):
+			return 16 
+		case unicode.IsNumber(r):
+			return 16 
+		}
+		return nullState
+	}, 
+	// Set90
+	func(r rune) state {
+		switch { 
+		case r == '_':
+			return 100 
+		case unicode.IsUpper(r):
+			return 16 
+		case unicode.IsLower(r):
+			return 16 
+		case unicode.IsNumber(r):
+			return 16 
+		}
+		return nullState
+	}, 
+	// Set91
+	func(r rune) state {
+		switch { 
+		case r == '_':
+			return 16 
+		case r == 'f':
+			return 101 
+		case unicode.IsUpper(r):
+			return 16 
+		case unicode.IsLower(r):
+			return 16 
+		case unicode.IsNumber(r):
+			return 16 
+		}
+		return nullState
+	}, 
+	// Set92
+	func(r rune) state {
+		switch { 
+		case r == '_':
+			return 16 
+		case r == 't':
+			return 102 
+		case unicode.IsUpper(r):
+			return 16 
+		case unicode.IsLower(r):
+			return 16 
+		case unicode.IsNumber(r):
+			return 16 
+		}
+		return nullState
+	}, 
+	// Set93
+	func(r rune) state {
+		switch { 
+		case r == '_':
+			return 16 
+		case r == 'r':
+			return 103 
+		case unicode.IsUpper(r):
+			return 16 
+		case unicode.IsLower(r):
+			return 16 
+		case unicode.IsNumber(r):
+			return 16 
+		}
+		return nullState
+	}, 
+	// Set94
+	func(r rune) state {
+		switch { 
+		case r == '_':
+			return 16 
+		case r == 'p':
+			return 104 
+		case unicode.IsUpper(r):
+			return 16 
+		case unicode.IsLower(r):
+			return 16 
+		case unicode.IsNumber(r):
+			return 16 
+		}
+		return nullState
+	}, 
+	// Set95
+	func(r rune) state {
+		switch { 
+		case r == '_':
+			return 16 
+		case r == 's':
+			return 105 
+		case unicode.IsUpper(r):
+			return 16 
+		case unicode.IsLower(r):
+			return 16 
+		case unicode.IsNumber(r):
+			return 16 
+		}
+		return nullState
+	}, 
+	// Set96
+	func(r rune) state {
+		switch { 
+		case r == '_':
+			return 16 
+		case r == 'p':
+			return 106 
+		case unicode.IsUpper(r):
+			return 16 
+		case unicode.IsLower(r):
+			return 16 
+		case unicode.IsNumber(r):
+			return 16 
+		}
+		return nullState
+	}, 
+	// Set97
+	func(r rune) state {
+		switch { 
+		case r == '_':
+			return 16 
+		case r == 'u':
+			return 107 
+		case unicode.IsUpper(r):
+			return 16 
+		case unicode.IsLower(r):
+			return 16 
+		case unicode.IsNumber(r):
+			return 16 
+		}
+		return nullState
+	}, 
+	// Set98
+	func(r rune) state {
+		switch { 
+		case r == '_':
+			return 16 
+		case r == 'l':
+			return 108 
+		case r == 'r':
+			return 109 
+		case unicode.IsUpper(r):
+			return 16 
+		case unicode.IsLower(r):
+			return 16 
+		case unicode.IsNumber(r):
+			return 16 
+		}
+		return nullState
+	}, 
+	// Set99
+	func(r rune) state {
+		switch { 
+		case r == '_':
+			return 16 
+		case r == 'n':
+			return 110 
+		case unicode.IsUpper(r):
+			return 16 
+		case unicode.IsLower(r):
+			return 16 
+		case unicode.IsNumber(r):
+			return 16 
+		}
+		return nullState
+	}, 
+	// Set100
+	func(r rune) state {
+		switch { 
+		case r == '_':
+			return 16 
+		case r == 'f':
+			return 111 
+		case unicode.IsUpper(r):
+			return 16 
+		case unicode.IsLower(r):
+			return 16 
+		case unicode.IsNumber(r):
+			return 16 
+		}
+		return nullState
+	}, 
+	// Set101
+	func(r rune) state {
+		switch { 
+		case r == '_':
+			return 16 
+		case r == 'o':
+			return 112 
+		case unicode.IsUpper(r):
+			return 16 
+		case unicode.IsLower(r):
+			return 16 
+		case unicode.IsNumber(r):
+			return 16 
+		}
+		return nullState
+	}, 
+	// Set102
+	func(r rune) state {
+		switch { 
+		case r == '_':
+			return 16 
+		case unicode.IsUpper(r):
+			return 16 
+		case unicode.IsLower(r):
+			return 16 
+		case unicode.IsNumber(r):
+			return 16 
+		}
+		return nullState
+	}, 
+	// Set103
+	func(r rune) state {
+		switch { 
+		case r == '_':
+			return 16 
+		case r == 'n':
+			return 113 
+		case unicode.IsUpper(r):
+			return 16 
+		case unicode.IsLower(r):
+			return 16 
+		case unicode.IsNumber(r):
+			return 16 
+		}
+		return nullState
+	}, 
+	// Set104
+	func(r rune) state {
+		switch { 
+		case r == '_':
+			return 16 
+		case unicode.IsUpper(r):
+			return 16 
+		case unicode.IsLower(r):
+			return 16 
+		case unicode.IsNumber(r):
+			return 16 
+		}
+		return nullState
+	}, 
+	// Set105
+	func(r rune) state {
+		switch { 
+		case r == '_':
+			return 16 
+		case unicode.IsUpper(r):
+			return 16 
+		case unicode.IsLower(r):
+			return 16 
+		case unicode.IsNumber(r):
+			return 16 
+		}
+		return nullState
+	}, 
+	// Set106
+	func(r rune) state {
+		switch { 
+		case r == '_':
+			return 16 
+		case unicode.IsUpper(r):
+			return 16 
+		case unicode.IsLower(r):
+			return 16 
+		case unicode.IsNumber(r):
+			return 16 
+		}
+		return nullState
+	}, 
+	// Set107
+	func(r rune) state {
+		switch { 
+		case r == '_':
+			return 16 
+		case r == 't':
+			return 114 
+		case unicode.IsUpper(r):
+			return 16 
+		case unicode.IsLower(r):
+			return 16 
+		case unicode.IsNumber(r):
+			return 16 
 		}
 		return nullState
 	}, 
-	// Set24
-	func(r rune) state {
-		switch { 
-		}
-		return nullState
-	}, 
-	// Set25
+	// Set108
 	func(r rune) state {
 		switch { 
+		case r == '_':
+			return 16 
+		case r == 'e':
+			return 115 
+		case unicode.IsUpper(r):
+			return 16 
+		case unicode.IsLower(r):
+			return 16 
+		case unicode.IsNumber(r):
+			return 16 
 		}
 		return nullState
 	}, 
-	// Set26
+	// Set109
 	func(r rune) state {
 		switch { 
 		case r == '_':
-			return 10 
+			return 16 
+		case r == 'i':
+			return 116 
 		case unicode.IsUpper(r):
-			return 10 
+			return 16 
 		case unicode.IsLower(r):
-			return 10 
+			return 16 
 		case unicode.IsNumber(r):
-			return 10 
+			return 16 
 		}
 		return nullState
 	}, 
-	// Set27
+	// Set110
 	func(r rune) state {
 		switch { 
 		case r == '_':
-			return 10 
-		case r == 'u':
-			return 32 
+			return 16 
+		case r == 'g':
+			return 117 
 		case unicode.IsUpper(r):
-			return 10 
+			return 16 
 		case unicode.IsLower(r):
-			return 10 
+			return 16 
 		case unicode.IsNumber(r):
-			return 10 
+			return 16 
 		}
 		return nullState
 	}, 
-	// Set28
+	// Set111
 	func(r rune) state {
 		switch { 
 		case r == '_':
-			return 10 
-		case r == 'v':
-			return 33 
+			return 16 
+		case r == 'o':
+			return 118 
 		case unicode.IsUpper(r):
-			return 10 
+			return 16 
 		case unicode.IsLower(r):
-			return 10 
+			return 16 
 		case unicode.IsNumber(r):
-			return 10 
+			return 16 
 		}
 		return nullState
 	}, 
-	// Set29
+	// Set112
 	func(r rune) state {
 		switch { 
 		case r == '_':
-			return 10 
-		case r == 'm':
-			return 34 
+			return 16 
+		case r == 'r':
+			return 119 
 		case unicode.IsUpper(r):
-			return 10 
+			return 16 
 		case unicode.IsLower(r):
-			return 10 
+			return 16 
 		case unicode.IsNumber(r):
-			return 10 
+			return 16 
 		}
 		return nullState
 	}, 
-	// Set30
+	// Set113
 	func(r rune) state {
 		switch { 
+		case r == '_':
+			return 16 
+		case unicode.IsUpper(r):
+			return 16 
+		case unicode.IsLower(r):
+			return 16 
+		case unicode.IsNumber(r):
+			return 16 
 		}
 		return nullState
 	}, 
-	// Set31
+	// Set114
 	func(r rune) state {
 		switch { 
+		case r == '_':
+			return 16 
+		case unicode.IsUpper(r):
+			return 16 
+		case unicode.IsLower(r):
+			return 16 
+		case unicode.IsNumber(r):
+			return 16 
 		}
 		return nullState
 	}, 
-	// Set32
+	// Set115
 	func(r rune) state {
 		switch { 
 		case r == '_':
-			return 10 
-		case r == 'n':
-			return 35 
+			return 16 
+		case r == 'f':
+			return 120 
 		case unicode.IsUpper(r):
-			return 10 
+			return 16 
 		case unicode.IsLower(r):
-			return 10 
+			return 16 
 		case unicode.IsNumber(r):
-			return 10 
+			return 16 
 		}
 		return nullState
 	}, 
-	// Set33
+	// Set116
 	func(r rune) state {
 		switch { 
 		case r == '_':
-			return 10 
-		case r == 'e':
-			return 36 
+			return 16 
+		case r == 'g':
+			return 121 
 		case unicode.IsUpper(r):
-			return 10 
+			return 16 
 		case unicode.IsLower(r):
-			return 10 
+			return 16 
 		case unicode.IsNumber(r):
-			return 10 
+			return 16 
 		}
 		return nullState
 	}, 
-	// Set34
+	// Set117
 	func(r rune) state {
 		switch { 
 		case r == '_':
-			return 10 
+			return 16 
 		case unicode.IsUpper(r):
-			return 10 
+			return 16 
 		case unicode.IsLower(r):
-			return 10 
+			return 16 
 		case unicode.IsNumber(r):
-			return 10 
+			return 16 
 		}
 		return nullState
 	}, 
-	// Set35
+	// Set118
 	func(r rune) state {
 		switch { 
 		case r == '_':
-			return 10 
-		case r == 't':
-			return 37 
+			return 16 
+		case r == 'r':
+			return 122 
 		case unicode.IsUpper(r):
-			return 10 
+			return 16 
 		case unicode.IsLower(r):
-			return 10 
+			return 16 
 		case unicode.IsNumber(r):
-			return 10 
+			return 16 
 		}
 		return nullState
 	}, 
-	// Set36
+	// Set119
 	func(r rune) state {
 		switch { 
 		case r == '_':
-			return 10 
-		case r == 'l':
-			return 38 
+			return 16 
+		case r == 'm':
+			return 123 
 		case unicode.IsUpper(r):
-			return 10 
+			return 16 
 		case unicode.IsLower(r):
-			return 10 
+			return 16 
 		case unicode.IsNumber(r):
-			return 10 
+			return 16 
 		}
 		return nullState
 	}, 
-	// Set37
+	// Set120
 	func(r rune) state {
 		switch { 
 		case r == '_':
-			return 39 
+			return 16 
+		case r == 't':
+			return 124 
 		case unicode.IsUpper(r):
-			return 10 
+			return 16 
 		case unicode.IsLower(r):
-			return 10 
+			return 16 
 		case unicode.IsNumber(r):
-			return 10 
+			return 16 
 		}
 		return nullState
 	}, 
-	// Set38
+	// Set121
 	func(r rune) state {
 		switch { 
 		case r == '_':
-			return 10 
+			return 16 
+		case r == 'h':
+			return 125 
 		case unicode.IsUpper(r):
-			return 10 
+			return 16 
 		case unicode.IsLower(r):
-			return 10 
+			return 16 
 		case unicode.IsNumber(r):
-			return 10 
+			return 16 
 		}
 		return nullState
 	}, 
-	// Set39
+	// Set122
 	func(r rune) state {
 		switch { 
 		case r == '_':
-			return 10 
-		case r == 'o':
-			return 40 
+			return 16 
+		case r == 'm':
+			return 126 
 		case unicode.IsUpper(r):
-			return 10 
+			return 16 
 		case unicode.IsLower(r):
-			return 10 
+			return 16 
 		case unicode.IsNumber(r):
-			return 10 
+			return 16 
 		}
 		return nullState
 	}, 
-	// Set40
+	// Set123
 	func(r rune) state {
 		switch { 
 		case r == '_':
-			return 10 
-		case r == 'v':
-			return 41 
+			return 16 
+		case r == 'a':
+			return 127 
 		case unicode.IsUpper(r):
-			return 10 
+			return 16 
 		case unicode.IsLower(r):
-			return 10 
+			return 16 
 		case unicode.IsNumber(r):
-			return 10 
+			return 16 
 		}
 		return nullState
 	}, 
-	// Set41
+	// Set124
 	func(r rune) state {
 		switch { 
 		case r == '_':
-			return 10 
-		case r == 'e':
-			return 42 
+			return 16 
 		case unicode.IsUpper(r):
-			return 10 
+			return 16 
 		case unicode.IsLower(r):
-			return 10 
+			return 16 
 		case unicode.IsNumber(r):
-			return 10 
+			return 16 
 		}
 		return nullState
 	}, 
-	// Set42
+	// Set125
 	func(r rune) state {
 		switch { 
 		case r == '_':
-			return 10 
-		case r == 'r':
-			return 43 
+			return 16 
+		case r == 't':
+			return 128 
 		case unicode.IsUpper(r):
-			return 10 
+			return 16 
 		case unicode.IsLower(r):
-			return 10 
+			return 16 
 		case unicode.IsNumber(r):
-			return 10 
+			return 16 
 		}
 		return nullState
 	}, 
-	// Set43
+	// Set126
 	func(r rune) state {
 		switch { 
 		case r == '_':
-			return 44 
+			return 16 
+		case r == 'a':
+			return 129 
 		case unicode.IsUpper(r):
-			return 10 
+			return 16 
 		case unicode.IsLower(r):
-			return 10 
+			return 16 
 		case unicode.IsNumber(r):
-			return 10 
+			return 16 
 		}
 		return nullState
 	}, 
-	// Set44
+	// Set127
 	func(r rune) state {
 		switch { 
 		case r == '_':
-			return 10 
+			return 16 
 		case r == 't':
-			return 45 
+			return 130 
 		case unicode.IsUpper(r):
-			return 10 
+			return 16 
 		case unicode.IsLower(r):
-			return 10 
+			return 16 
 		case unicode.IsNumber(r):
-			return 10 
+			return 16 
 		}
 		return nullState
 	}, 
-	// Set45
+	// Set128
 	func(r rune) state {
 		switch { 
 		case r == '_':
-			return 10 
-		case r == 'i':
-			return 46 
+			return 16 
 		case unicode.IsUpper(r):
-			return 10 
+			return 16 
 		case unicode.IsLower(r):
-			return 10 
+			return 16 
 		case unicode.IsNumber(r):
-			return 10 
+			return 16 
 		}
 		return nullState
 	}, 
-	// Set46
+	// Set129
 	func(r rune) state {
 		switch { 
 		case r == '_':
-			return 10 
-		case r == 'm':
-			return 47 
+			return 16 
+		case r == 't':
+			return 131 
 		case unicode.IsUpper(r):
-			return 10 
+			return 16 
 		case unicode.IsLower(r):
-			return 10 
+			return 16 
 		case unicode.IsNumber(r):
-			return 10 
+			return 16 
 		}
 		return nullState
 	}, 
-	// Set47
+	// Set130
 	func(r rune) state {
 		switch { 
 		case r == '_':
-			return 10 
-		case r == 'e':
-			return 48 
+			return 16 
 		case unicode.IsUpper(r):
-			return 10 
+			return 16 
 		case unicode.IsLower(r):
-			return 10 
+			return 16 
 		case unicode.IsNumber(r):
-			return 10 
+			return 16 
 		}
 		return nullState
 	}, 
-	// Set48
+	// Set131
 	func(r rune) state {
 		switch { 
 		case r == '_':
-			return 10 
+			return 16 
 		case unicode.IsUpper(r):
-			return 10 
+			return 16 
 		case unicode.IsLower(r):
-			return 10 
+			return 16 
 		case unicode.IsNumber(r):
-			return 10 
+			return 16 
 		}
 		return nullState
 	}, 
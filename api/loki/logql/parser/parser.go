@@ -65,7 +65,132 @@ func (p *parser) parse() (*bsr.Set, []*Error) {
 		// p.DumpDescriptors()
 
 		switch L {
-		case slot.DataFilter0R0: // DataFilter : ∙| string DataFilterOp string
+		case slot.AddOp0R0: // AddOp : ∙+
+
+			p.bsrSet.Add(slot.AddOp0R1, cU, p.cI, p.cI+1)
+			p.cI++
+			if p.follow(symbols.NT_AddOp) {
+				p.rtn(symbols.NT_AddOp, cU, p.cI)
+			} else {
+				p.parseError(slot.AddOp0R0, p.cI, followSets[symbols.NT_AddOp])
+			}
+		case slot.AddOp1R0: // AddOp : ∙-
+
+			p.bsrSet.Add(slot.AddOp1R1, cU, p.cI, p.cI+1)
+			p.cI++
+			if p.follow(symbols.NT_AddOp) {
+				p.rtn(symbols.NT_AddOp, cU, p.cI)
+			} else {
+				p.parseError(slot.AddOp1R0, p.cI, followSets[symbols.NT_AddOp])
+			}
+		case slot.AndOp0R0: // AndOp : ∙and
+
+			p.bsrSet.Add(slot.AndOp0R1, cU, p.cI, p.cI+1)
+			p.cI++
+			if p.follow(symbols.NT_AndOp) {
+				p.rtn(symbols.NT_AndOp, cU, p.cI)
+			} else {
+				p.parseError(slot.AndOp0R0, p.cI, followSets[symbols.NT_AndOp])
+			}
+		case slot.AndOp1R0: // AndOp : ∙unless
+
+			p.bsrSet.Add(slot.AndOp1R1, cU, p.cI, p.cI+1)
+			p.cI++
+			if p.follow(symbols.NT_AndOp) {
+				p.rtn(symbols.NT_AndOp, cU, p.cI)
+			} else {
+				p.parseError(slot.AndOp1R0, p.cI, followSets[symbols.NT_AndOp])
+			}
+		case slot.BinModifiers0R0: // BinModifiers : ∙BoolMaybe MatchingMaybe
+
+			p.call(slot.BinModifiers0R1, cU, p.cI)
+		case slot.BinModifiers0R1: // BinModifiers : BoolMaybe ∙MatchingMaybe
+
+			if !p.testSelect(slot.BinModifiers0R1) {
+				p.parseError(slot.BinModifiers0R1, p.cI, first[slot.BinModifiers0R1])
+				break
+			}
+
+			p.call(slot.BinModifiers0R2, cU, p.cI)
+		case slot.BinModifiers0R2: // BinModifiers : BoolMaybe MatchingMaybe ∙
+
+			if p.follow(symbols.NT_BinModifiers) {
+				p.rtn(symbols.NT_BinModifiers, cU, p.cI)
+			} else {
+				p.parseError(slot.BinModifiers0R0, p.cI, followSets[symbols.NT_BinModifiers])
+			}
+		case slot.BoolMaybe0R0: // BoolMaybe : ∙
+			p.bsrSet.AddEmpty(slot.BoolMaybe0R0, p.cI)
+
+			if p.follow(symbols.NT_BoolMaybe) {
+				p.rtn(symbols.NT_BoolMaybe, cU, p.cI)
+			} else {
+				p.parseError(slot.BoolMaybe0R0, p.cI, followSets[symbols.NT_BoolMaybe])
+			}
+		case slot.BoolMaybe1R0: // BoolMaybe : ∙bool
+
+			p.bsrSet.Add(slot.BoolMaybe1R1, cU, p.cI, p.cI+1)
+			p.cI++
+			if p.follow(symbols.NT_BoolMaybe) {
+				p.rtn(symbols.NT_BoolMaybe, cU, p.cI)
+			} else {
+				p.parseError(slot.BoolMaybe1R0, p.cI, followSets[symbols.NT_BoolMaybe])
+			}
+		case slot.CmpOp0R0: // CmpOp : ∙==
+
+			p.bsrSet.Add(slot.CmpOp0R1, cU, p.cI, p.cI+1)
+			p.cI++
+			if p.follow(symbols.NT_CmpOp) {
+				p.rtn(symbols.NT_CmpOp, cU, p.cI)
+			} else {
+				p.parseError(slot.CmpOp0R0, p.cI, followSets[symbols.NT_CmpOp])
+			}
+		case slot.CmpOp1R0: // CmpOp : ∙!=
+
+			p.bsrSet.Add(slot.CmpOp1R1, cU, p.cI, p.cI+1)
+			p.cI++
+			if p.follow(symbols.NT_CmpOp) {
+				p.rtn(symbols.NT_CmpOp, cU, p.cI)
+			} else {
+				p.parseError(slot.CmpOp1R0, p.cI, followSets[symbols.NT_CmpOp])
+			}
+		case slot.CmpOp2R0: // CmpOp : ∙>
+
+			p.bsrSet.Add(slot.CmpOp2R1, cU, p.cI, p.cI+1)
+			p.cI++
+			if p.follow(symbols.NT_CmpOp) {
+				p.rtn(symbols.NT_CmpOp, cU, p.cI)
+			} else {
+				p.parseError(slot.CmpOp2R0, p.cI, followSets[symbols.NT_CmpOp])
+			}
+		case slot.CmpOp3R0: // CmpOp : ∙<
+
+			p.bsrSet.Add(slot.CmpOp3R1, cU, p.cI, p.cI+1)
+			p.cI++
+			if p.follow(symbols.NT_CmpOp) {
+				p.rtn(symbols.NT_CmpOp, cU, p.cI)
+			} else {
+				p.parseError(slot.CmpOp3R0, p.cI, followSets[symbols.NT_CmpOp])
+			}
+		case slot.CmpOp4R0: // CmpOp : ∙>=
+
+			p.bsrSet.Add(slot.CmpOp4R1, cU, p.cI, p.cI+1)
+			p.cI++
+			if p.follow(symbols.NT_CmpOp) {
+				p.rtn(symbols.NT_CmpOp, cU, p.cI)
+			} else {
+				p.parseError(slot.CmpOp4R0, p.cI, followSets[symbols.NT_CmpOp])
+			}
+		case slot.CmpOp5R0: // CmpOp : ∙<=
+
+			p.bsrSet.Add(slot.CmpOp5R1, cU, p.cI, p.cI+1)
+			p.cI++
+			if p.follow(symbols.NT_CmpOp) {
+				p.rtn(symbols.NT_CmpOp, cU, p.cI)
+			} else {
+				p.parseError(slot.CmpOp5R0, p.cI, followSets[symbols.NT_CmpOp])
+			}
+		case slot.DataFilter0R0: // DataFilter : ∙string DataFilterOp FilterValue
 
 			p.bsrSet.Add(slot.DataFilter0R1, cU, p.cI, p.cI+1)
 			p.cI++
@@ -74,23 +199,17 @@ func (p *parser) parse() (*bsr.Set, []*Error) {
 				break
 			}
 
-			p.bsrSet.Add(slot.DataFilter0R2, cU, p.cI, p.cI+1)
-			p.cI++
+			p.call(slot.DataFilter0R2, cU, p.cI)
+		case slot.DataFilter0R2: // DataFilter : string DataFilterOp ∙FilterValue
+
 			if !p.testSelect(slot.DataFilter0R2) {
 				p.parseError(slot.DataFilter0R2, p.cI, first[slot.DataFilter0R2])
 				break
 			}
 
 			p.call(slot.DataFilter0R3, cU, p.cI)
-		case slot.DataFilter0R3: // DataFilter : | string DataFilterOp ∙string
-
-			if !p.testSelect(slot.DataFilter0R3) {
-				p.parseError(slot.DataFilter0R3, p.cI, first[slot.DataFilter0R3])
-				break
-			}
+		case slot.DataFilter0R3: // DataFilter : string DataFilterOp FilterValue ∙
 
-			p.bsrSet.Add(slot.DataFilter0R4, cU, p.cI, p.cI+1)
-			p.cI++
 			if p.follow(symbols.NT_DataFilter) {
 				p.rtn(symbols.NT_DataFilter, cU, p.cI)
 			} else {
@@ -168,1183 +287,5599 @@ func (p *parser) parse() (*bsr.Set, []*Error) {
 			} else {
 				p.parseError(slot.DataFilterOp7R0, p.cI, followSets[symbols.NT_DataFilterOp])
 			}
-		case slot.LabelKey0R0: // LabelKey : ∙var_name
+		case slot.FilterAnd0R0: // FilterAnd : ∙FilterNot
 
-			p.bsrSet.Add(slot.LabelKey0R1, cU, p.cI, p.cI+1)
-			p.cI++
-			if p.follow(symbols.NT_LabelKey) {
-				p.rtn(symbols.NT_LabelKey, cU, p.cI)
+			p.call(slot.FilterAnd0R1, cU, p.cI)
+		case slot.FilterAnd0R1: // FilterAnd : FilterNot ∙
+
+			if p.follow(symbols.NT_FilterAnd) {
+				p.rtn(symbols.NT_FilterAnd, cU, p.cI)
 			} else {
-				p.parseError(slot.LabelKey0R0, p.cI, followSets[symbols.NT_LabelKey])
+				p.parseError(slot.FilterAnd0R0, p.cI, followSets[symbols.NT_FilterAnd])
 			}
-		case slot.LineFilter0R0: // LineFilter : ∙LineFilterOp string
+		case slot.FilterAnd1R0: // FilterAnd : ∙FilterNot and FilterAnd
 
-			p.call(slot.LineFilter0R1, cU, p.cI)
-		case slot.LineFilter0R1: // LineFilter : LineFilterOp ∙string
+			p.call(slot.FilterAnd1R1, cU, p.cI)
+		case slot.FilterAnd1R1: // FilterAnd : FilterNot ∙and FilterAnd
 
-			if !p.testSelect(slot.LineFilter0R1) {
-				p.parseError(slot.LineFilter0R1, p.cI, first[slot.LineFilter0R1])
+			if !p.testSelect(slot.FilterAnd1R1) {
+				p.parseError(slot.FilterAnd1R1, p.cI, first[slot.FilterAnd1R1])
 				break
 			}
 
-			p.bsrSet.Add(slot.LineFilter0R2, cU, p.cI, p.cI+1)
+			p.bsrSet.Add(slot.FilterAnd1R2, cU, p.cI, p.cI+1)
 			p.cI++
-			if p.follow(symbols.NT_LineFilter) {
-				p.rtn(symbols.NT_LineFilter, cU, p.cI)
-			} else {
-				p.parseError(slot.LineFilter0R0, p.cI, followSets[symbols.NT_LineFilter])
+			if !p.testSelect(slot.FilterAnd1R2) {
+				p.parseError(slot.FilterAnd1R2, p.cI, first[slot.FilterAnd1R2])
+				break
 			}
-		case slot.LineFilterOp0R0: // LineFilterOp : ∙|=
 
-			p.bsrSet.Add(slot.LineFilterOp0R1, cU, p.cI, p.cI+1)
-			p.cI++
-			if p.follow(symbols.NT_LineFilterOp) {
-				p.rtn(symbols.NT_LineFilterOp, cU, p.cI)
+			p.call(slot.FilterAnd1R3, cU, p.cI)
+		case slot.FilterAnd1R3: // FilterAnd : FilterNot and FilterAnd ∙
+
+			if p.follow(symbols.NT_FilterAnd) {
+				p.rtn(symbols.NT_FilterAnd, cU, p.cI)
 			} else {
-				p.parseError(slot.LineFilterOp0R0, p.cI, followSets[symbols.NT_LineFilterOp])
+				p.parseError(slot.FilterAnd1R0, p.cI, followSets[symbols.NT_FilterAnd])
 			}
-		case slot.LineFilterOp1R0: // LineFilterOp : ∙!=
+		case slot.FilterAnd2R0: // FilterAnd : ∙FilterNot , FilterAnd
 
-			p.bsrSet.Add(slot.LineFilterOp1R1, cU, p.cI, p.cI+1)
-			p.cI++
-			if p.follow(symbols.NT_LineFilterOp) {
-				p.rtn(symbols.NT_LineFilterOp, cU, p.cI)
-			} else {
-				p.parseError(slot.LineFilterOp1R0, p.cI, followSets[symbols.NT_LineFilterOp])
+			p.call(slot.FilterAnd2R1, cU, p.cI)
+		case slot.FilterAnd2R1: // FilterAnd : FilterNot ∙, FilterAnd
+
+			if !p.testSelect(slot.FilterAnd2R1) {
+				p.parseError(slot.FilterAnd2R1, p.cI, first[slot.FilterAnd2R1])
+				break
 			}
-		case slot.LineFilterOp2R0: // LineFilterOp : ∙|~
 
-			p.bsrSet.Add(slot.LineFilterOp2R1, cU, p.cI, p.cI+1)
+			p.bsrSet.Add(slot.FilterAnd2R2, cU, p.cI, p.cI+1)
 			p.cI++
-			if p.follow(symbols.NT_LineFilterOp) {
-				p.rtn(symbols.NT_LineFilterOp, cU, p.cI)
-			} else {
-				p.parseError(slot.LineFilterOp2R0, p.cI, followSets[symbols.NT_LineFilterOp])
+			if !p.testSelect(slot.FilterAnd2R2) {
+				p.parseError(slot.FilterAnd2R2, p.cI, first[slot.FilterAnd2R2])
+				break
 			}
-		case slot.LineFilterOp3R0: // LineFilterOp : ∙!~
 
-			p.bsrSet.Add(slot.LineFilterOp3R1, cU, p.cI, p.cI+1)
-			p.cI++
-			if p.follow(symbols.NT_LineFilterOp) {
-				p.rtn(symbols.NT_LineFilterOp, cU, p.cI)
+			p.call(slot.FilterAnd2R3, cU, p.cI)
+		case slot.FilterAnd2R3: // FilterAnd : FilterNot , FilterAnd ∙
+
+			if p.follow(symbols.NT_FilterAnd) {
+				p.rtn(symbols.NT_FilterAnd, cU, p.cI)
 			} else {
-				p.parseError(slot.LineFilterOp3R0, p.cI, followSets[symbols.NT_LineFilterOp])
+				p.parseError(slot.FilterAnd2R0, p.cI, followSets[symbols.NT_FilterAnd])
 			}
-		case slot.LogQuery0R0: // LogQuery : ∙LogSelector PipelinesMaybe
+		case slot.FilterExpr0R0: // FilterExpr : ∙FilterAnd
 
-			p.call(slot.LogQuery0R1, cU, p.cI)
-		case slot.LogQuery0R1: // LogQuery : LogSelector ∙PipelinesMaybe
+			p.call(slot.FilterExpr0R1, cU, p.cI)
+		case slot.FilterExpr0R1: // FilterExpr : FilterAnd ∙
 
-			if !p.testSelect(slot.LogQuery0R1) {
-				p.parseError(slot.LogQuery0R1, p.cI, first[slot.LogQuery0R1])
-				break
+			if p.follow(symbols.NT_FilterExpr) {
+				p.rtn(symbols.NT_FilterExpr, cU, p.cI)
+			} else {
+				p.parseError(slot.FilterExpr0R0, p.cI, followSets[symbols.NT_FilterExpr])
 			}
+		case slot.FilterExpr1R0: // FilterExpr : ∙FilterAnd or FilterExpr
 
-			p.call(slot.LogQuery0R2, cU, p.cI)
-		case slot.LogQuery0R2: // LogQuery : LogSelector PipelinesMaybe ∙
+			p.call(slot.FilterExpr1R1, cU, p.cI)
+		case slot.FilterExpr1R1: // FilterExpr : FilterAnd ∙or FilterExpr
 
-			if p.follow(symbols.NT_LogQuery) {
-				p.rtn(symbols.NT_LogQuery, cU, p.cI)
-			} else {
-				p.parseError(slot.LogQuery0R0, p.cI, followSets[symbols.NT_LogQuery])
+			if !p.testSelect(slot.FilterExpr1R1) {
+				p.parseError(slot.FilterExpr1R1, p.cI, first[slot.FilterExpr1R1])
+				break
 			}
-		case slot.LogSelector0R0: // LogSelector : ∙{ LogSelectorMembersMaybe }
 
-			p.bsrSet.Add(slot.LogSelector0R1, cU, p.cI, p.cI+1)
+			p.bsrSet.Add(slot.FilterExpr1R2, cU, p.cI, p.cI+1)
 			p.cI++
-			if !p.testSelect(slot.LogSelector0R1) {
-				p.parseError(slot.LogSelector0R1, p.cI, first[slot.LogSelector0R1])
+			if !p.testSelect(slot.FilterExpr1R2) {
+				p.parseError(slot.FilterExpr1R2, p.cI, first[slot.FilterExpr1R2])
 				break
 			}
 
-			p.call(slot.LogSelector0R2, cU, p.cI)
-		case slot.LogSelector0R2: // LogSelector : { LogSelectorMembersMaybe ∙}
+			p.call(slot.FilterExpr1R3, cU, p.cI)
+		case slot.FilterExpr1R3: // FilterExpr : FilterAnd or FilterExpr ∙
 
-			if !p.testSelect(slot.LogSelector0R2) {
-				p.parseError(slot.LogSelector0R2, p.cI, first[slot.LogSelector0R2])
-				break
+			if p.follow(symbols.NT_FilterExpr) {
+				p.rtn(symbols.NT_FilterExpr, cU, p.cI)
+			} else {
+				p.parseError(slot.FilterExpr1R0, p.cI, followSets[symbols.NT_FilterExpr])
 			}
+		case slot.FilterNot0R0: // FilterNot : ∙FilterTerm
 
-			p.bsrSet.Add(slot.LogSelector0R3, cU, p.cI, p.cI+1)
-			p.cI++
-			if p.follow(symbols.NT_LogSelector) {
-				p.rtn(symbols.NT_LogSelector, cU, p.cI)
+			p.call(slot.FilterNot0R1, cU, p.cI)
+		case slot.FilterNot0R1: // FilterNot : FilterTerm ∙
+
+			if p.follow(symbols.NT_FilterNot) {
+				p.rtn(symbols.NT_FilterNot, cU, p.cI)
 			} else {
-				p.parseError(slot.LogSelector0R0, p.cI, followSets[symbols.NT_LogSelector])
+				p.parseError(slot.FilterNot0R0, p.cI, followSets[symbols.NT_FilterNot])
 			}
-		case slot.LogSelectorMember0R0: // LogSelectorMember : ∙LabelKey LogSelectorOp string
-
-			p.call(slot.LogSelectorMember0R1, cU, p.cI)
-		case slot.LogSelectorMember0R1: // LogSelectorMember : LabelKey ∙LogSelectorOp string
+		case slot.FilterNot1R0: // FilterNot : ∙not FilterNot
 
-			if !p.testSelect(slot.LogSelectorMember0R1) {
-				p.parseError(slot.LogSelectorMember0R1, p.cI, first[slot.LogSelectorMember0R1])
+			p.bsrSet.Add(slot.FilterNot1R1, cU, p.cI, p.cI+1)
+			p.cI++
+			if !p.testSelect(slot.FilterNot1R1) {
+				p.parseError(slot.FilterNot1R1, p.cI, first[slot.FilterNot1R1])
 				break
 			}
 
-			p.call(slot.LogSelectorMember0R2, cU, p.cI)
-		case slot.LogSelectorMember0R2: // LogSelectorMember : LabelKey LogSelectorOp ∙string
+			p.call(slot.FilterNot1R2, cU, p.cI)
+		case slot.FilterNot1R2: // FilterNot : not FilterNot ∙
 
-			if !p.testSelect(slot.LogSelectorMember0R2) {
-				p.parseError(slot.LogSelectorMember0R2, p.cI, first[slot.LogSelectorMember0R2])
-				break
+			if p.follow(symbols.NT_FilterNot) {
+				p.rtn(symbols.NT_FilterNot, cU, p.cI)
+			} else {
+				p.parseError(slot.FilterNot1R0, p.cI, followSets[symbols.NT_FilterNot])
 			}
+		case slot.FilterStage0R0: // FilterStage : ∙| FilterExpr
 
-			p.bsrSet.Add(slot.LogSelectorMember0R3, cU, p.cI, p.cI+1)
+			p.bsrSet.Add(slot.FilterStage0R1, cU, p.cI, p.cI+1)
 			p.cI++
-			if p.follow(symbols.NT_LogSelectorMember) {
-				p.rtn(symbols.NT_LogSelectorMember, cU, p.cI)
+			if !p.testSelect(slot.FilterStage0R1) {
+				p.parseError(slot.FilterStage0R1, p.cI, first[slot.FilterStage0R1])
+				break
+			}
+
+			p.call(slot.FilterStage0R2, cU, p.cI)
+		case slot.FilterStage0R2: // FilterStage : | FilterExpr ∙
+
+			if p.follow(symbols.NT_FilterStage) {
+				p.rtn(symbols.NT_FilterStage, cU, p.cI)
 			} else {
-				p.parseError(slot.LogSelectorMember0R0, p.cI, followSets[symbols.NT_LogSelectorMember])
+				p.parseError(slot.FilterStage0R0, p.cI, followSets[symbols.NT_FilterStage])
 			}
-		case slot.LogSelectorMembers0R0: // LogSelectorMembers : ∙LogSelectorMember
+		case slot.FilterTerm0R0: // FilterTerm : ∙DataFilter
 
-			p.call(slot.LogSelectorMembers0R1, cU, p.cI)
-		case slot.LogSelectorMembers0R1: // LogSelectorMembers : LogSelectorMember ∙
+			p.call(slot.FilterTerm0R1, cU, p.cI)
+		case slot.FilterTerm0R1: // FilterTerm : DataFilter ∙
 
-			if p.follow(symbols.NT_LogSelectorMembers) {
-				p.rtn(symbols.NT_LogSelectorMembers, cU, p.cI)
+			if p.follow(symbols.NT_FilterTerm) {
+				p.rtn(symbols.NT_FilterTerm, cU, p.cI)
 			} else {
-				p.parseError(slot.LogSelectorMembers0R0, p.cI, followSets[symbols.NT_LogSelectorMembers])
+				p.parseError(slot.FilterTerm0R0, p.cI, followSets[symbols.NT_FilterTerm])
 			}
-		case slot.LogSelectorMembers1R0: // LogSelectorMembers : ∙LogSelectorMember , LogSelectorMembers
+		case slot.FilterTerm1R0: // FilterTerm : ∙LabelFilter
 
-			p.call(slot.LogSelectorMembers1R1, cU, p.cI)
-		case slot.LogSelectorMembers1R1: // LogSelectorMembers : LogSelectorMember ∙, LogSelectorMembers
+			p.call(slot.FilterTerm1R1, cU, p.cI)
+		case slot.FilterTerm1R1: // FilterTerm : LabelFilter ∙
 
-			if !p.testSelect(slot.LogSelectorMembers1R1) {
-				p.parseError(slot.LogSelectorMembers1R1, p.cI, first[slot.LogSelectorMembers1R1])
-				break
+			if p.follow(symbols.NT_FilterTerm) {
+				p.rtn(symbols.NT_FilterTerm, cU, p.cI)
+			} else {
+				p.parseError(slot.FilterTerm1R0, p.cI, followSets[symbols.NT_FilterTerm])
 			}
+		case slot.FilterTerm2R0: // FilterTerm : ∙( FilterExpr )
 
-			p.bsrSet.Add(slot.LogSelectorMembers1R2, cU, p.cI, p.cI+1)
+			p.bsrSet.Add(slot.FilterTerm2R1, cU, p.cI, p.cI+1)
 			p.cI++
-			if !p.testSelect(slot.LogSelectorMembers1R2) {
-				p.parseError(slot.LogSelectorMembers1R2, p.cI, first[slot.LogSelectorMembers1R2])
+			if !p.testSelect(slot.FilterTerm2R1) {
+				p.parseError(slot.FilterTerm2R1, p.cI, first[slot.FilterTerm2R1])
 				break
 			}
 
-			p.call(slot.LogSelectorMembers1R3, cU, p.cI)
-		case slot.LogSelectorMembers1R3: // LogSelectorMembers : LogSelectorMember , LogSelectorMembers ∙
+			p.call(slot.FilterTerm2R2, cU, p.cI)
+		case slot.FilterTerm2R2: // FilterTerm : ( FilterExpr ∙)
 
-			if p.follow(symbols.NT_LogSelectorMembers) {
-				p.rtn(symbols.NT_LogSelectorMembers, cU, p.cI)
-			} else {
-				p.parseError(slot.LogSelectorMembers1R0, p.cI, followSets[symbols.NT_LogSelectorMembers])
+			if !p.testSelect(slot.FilterTerm2R2) {
+				p.parseError(slot.FilterTerm2R2, p.cI, first[slot.FilterTerm2R2])
+				break
 			}
-		case slot.LogSelectorMembersMaybe0R0: // LogSelectorMembersMaybe : ∙
-			p.bsrSet.AddEmpty(slot.LogSelectorMembersMaybe0R0, p.cI)
 
-			if p.follow(symbols.NT_LogSelectorMembersMaybe) {
-				p.rtn(symbols.NT_LogSelectorMembersMaybe, cU, p.cI)
+			p.bsrSet.Add(slot.FilterTerm2R3, cU, p.cI, p.cI+1)
+			p.cI++
+			if p.follow(symbols.NT_FilterTerm) {
+				p.rtn(symbols.NT_FilterTerm, cU, p.cI)
 			} else {
-				p.parseError(slot.LogSelectorMembersMaybe0R0, p.cI, followSets[symbols.NT_LogSelectorMembersMaybe])
+				p.parseError(slot.FilterTerm2R0, p.cI, followSets[symbols.NT_FilterTerm])
 			}
-		case slot.LogSelectorMembersMaybe1R0: // LogSelectorMembersMaybe : ∙LogSelectorMembers
-
-			p.call(slot.LogSelectorMembersMaybe1R1, cU, p.cI)
-		case slot.LogSelectorMembersMaybe1R1: // LogSelectorMembersMaybe : LogSelectorMembers ∙
+		case slot.FilterValue0R0: // FilterValue : ∙string
 
-			if p.follow(symbols.NT_LogSelectorMembersMaybe) {
-				p.rtn(symbols.NT_LogSelectorMembersMaybe, cU, p.cI)
+			p.bsrSet.Add(slot.FilterValue0R1, cU, p.cI, p.cI+1)
+			p.cI++
+			if p.follow(symbols.NT_FilterValue) {
+				p.rtn(symbols.NT_FilterValue, cU, p.cI)
 			} else {
-				p.parseError(slot.LogSelectorMembersMaybe1R0, p.cI, followSets[symbols.NT_LogSelectorMembersMaybe])
+				p.parseError(slot.FilterValue0R0, p.cI, followSets[symbols.NT_FilterValue])
 			}
-		case slot.LogSelectorOp0R0: // LogSelectorOp : ∙=
+		case slot.FilterValue1R0: // FilterValue : ∙num
 
-			p.bsrSet.Add(slot.LogSelectorOp0R1, cU, p.cI, p.cI+1)
+			p.bsrSet.Add(slot.FilterValue1R1, cU, p.cI, p.cI+1)
 			p.cI++
-			if p.follow(symbols.NT_LogSelectorOp) {
-				p.rtn(symbols.NT_LogSelectorOp, cU, p.cI)
+			if p.follow(symbols.NT_FilterValue) {
+				p.rtn(symbols.NT_FilterValue, cU, p.cI)
 			} else {
-				p.parseError(slot.LogSelectorOp0R0, p.cI, followSets[symbols.NT_LogSelectorOp])
+				p.parseError(slot.FilterValue1R0, p.cI, followSets[symbols.NT_FilterValue])
 			}
-		case slot.LogSelectorOp1R0: // LogSelectorOp : ∙!=
+		case slot.FormatStage0R0: // FormatStage : ∙| line_format string
 
-			p.bsrSet.Add(slot.LogSelectorOp1R1, cU, p.cI, p.cI+1)
+			p.bsrSet.Add(slot.FormatStage0R1, cU, p.cI, p.cI+1)
 			p.cI++
-			if p.follow(symbols.NT_LogSelectorOp) {
-				p.rtn(symbols.NT_LogSelectorOp, cU, p.cI)
-			} else {
-				p.parseError(slot.LogSelectorOp1R0, p.cI, followSets[symbols.NT_LogSelectorOp])
+			if !p.testSelect(slot.FormatStage0R1) {
+				p.parseError(slot.FormatStage0R1, p.cI, first[slot.FormatStage0R1])
+				break
 			}
-		case slot.LogSelectorOp2R0: // LogSelectorOp : ∙=~
 
-			p.bsrSet.Add(slot.LogSelectorOp2R1, cU, p.cI, p.cI+1)
+			p.bsrSet.Add(slot.FormatStage0R2, cU, p.cI, p.cI+1)
 			p.cI++
-			if p.follow(symbols.NT_LogSelectorOp) {
-				p.rtn(symbols.NT_LogSelectorOp, cU, p.cI)
-			} else {
-				p.parseError(slot.LogSelectorOp2R0, p.cI, followSets[symbols.NT_LogSelectorOp])
+			if !p.testSelect(slot.FormatStage0R2) {
+				p.parseError(slot.FormatStage0R2, p.cI, first[slot.FormatStage0R2])
+				break
 			}
-		case slot.LogSelectorOp3R0: // LogSelectorOp : ∙!~
 
-			p.bsrSet.Add(slot.LogSelectorOp3R1, cU, p.cI, p.cI+1)
+			p.bsrSet.Add(slot.FormatStage0R3, cU, p.cI, p.cI+1)
 			p.cI++
-			if p.follow(symbols.NT_LogSelectorOp) {
-				p.rtn(symbols.NT_LogSelectorOp, cU, p.cI)
+			if p.follow(symbols.NT_FormatStage) {
+				p.rtn(symbols.NT_FormatStage, cU, p.cI)
 			} else {
-				p.parseError(slot.LogSelectorOp3R0, p.cI, followSets[symbols.NT_LogSelectorOp])
+				p.parseError(slot.FormatStage0R0, p.cI, followSets[symbols.NT_FormatStage])
 			}
-		case slot.MetricQuery0R0: // MetricQuery : ∙sum by ( level ) ( count_over_time ( LogQuery duration ) )
+		case slot.FormatStage1R0: // FormatStage : ∙| label_format LabelFormats
 
-			p.bsrSet.Add(slot.MetricQuery0R1, cU, p.cI, p.cI+1)
+			p.bsrSet.Add(slot.FormatStage1R1, cU, p.cI, p.cI+1)
 			p.cI++
-			if !p.testSelect(slot.MetricQuery0R1) {
-				p.parseError(slot.MetricQuery0R1, p.cI, first[slot.MetricQuery0R1])
+			if !p.testSelect(slot.FormatStage1R1) {
+				p.parseError(slot.FormatStage1R1, p.cI, first[slot.FormatStage1R1])
 				break
 			}
 
-			p.bsrSet.Add(slot.MetricQuery0R2, cU, p.cI, p.cI+1)
+			p.bsrSet.Add(slot.FormatStage1R2, cU, p.cI, p.cI+1)
 			p.cI++
-			if !p.testSelect(slot.MetricQuery0R2) {
-				p.parseError(slot.MetricQuery0R2, p.cI, first[slot.MetricQuery0R2])
+			if !p.testSelect(slot.FormatStage1R2) {
+				p.parseError(slot.FormatStage1R2, p.cI, first[slot.FormatStage1R2])
 				break
 			}
 
-			p.bsrSet.Add(slot.MetricQuery0R3, cU, p.cI, p.cI+1)
-			p.cI++
-			if !p.testSelect(slot.MetricQuery0R3) {
-				p.parseError(slot.MetricQuery0R3, p.cI, first[slot.MetricQuery0R3])
+			p.call(slot.FormatStage1R3, cU, p.cI)
+		case slot.FormatStage1R3: // FormatStage : | label_format LabelFormats ∙
+
+			if p.follow(symbols.NT_FormatStage) {
+				p.rtn(symbols.NT_FormatStage, cU, p.cI)
+			} else {
+				p.parseError(slot.FormatStage1R0, p.cI, followSets[symbols.NT_FormatStage])
+			}
+		case slot.GroupModifierMaybe0R0: // GroupModifierMaybe : ∙
+			p.bsrSet.AddEmpty(slot.GroupModifierMaybe0R0, p.cI)
+
+			if p.follow(symbols.NT_GroupModifierMaybe) {
+				p.rtn(symbols.NT_GroupModifierMaybe, cU, p.cI)
+			} else {
+				p.parseError(slot.GroupModifierMaybe0R0, p.cI, followSets[symbols.NT_GroupModifierMaybe])
+			}
+		case slot.GroupModifierMaybe1R0: // GroupModifierMaybe : ∙GroupSide
+
+			p.call(slot.GroupModifierMaybe1R1, cU, p.cI)
+		case slot.GroupModifierMaybe1R1: // GroupModifierMaybe : GroupSide ∙
+
+			if p.follow(symbols.NT_GroupModifierMaybe) {
+				p.rtn(symbols.NT_GroupModifierMaybe, cU, p.cI)
+			} else {
+				p.parseError(slot.GroupModifierMaybe1R0, p.cI, followSets[symbols.NT_GroupModifierMaybe])
+			}
+		case slot.GroupModifierMaybe2R0: // GroupModifierMaybe : ∙GroupSide ( LabelKeysMaybe )
+
+			p.call(slot.GroupModifierMaybe2R1, cU, p.cI)
+		case slot.GroupModifierMaybe2R1: // GroupModifierMaybe : GroupSide ∙( LabelKeysMaybe )
+
+			if !p.testSelect(slot.GroupModifierMaybe2R1) {
+				p.parseError(slot.GroupModifierMaybe2R1, p.cI, first[slot.GroupModifierMaybe2R1])
 				break
 			}
 
-			p.bsrSet.Add(slot.MetricQuery0R4, cU, p.cI, p.cI+1)
+			p.bsrSet.Add(slot.GroupModifierMaybe2R2, cU, p.cI, p.cI+1)
 			p.cI++
-			if !p.testSelect(slot.MetricQuery0R4) {
-				p.parseError(slot.MetricQuery0R4, p.cI, first[slot.MetricQuery0R4])
+			if !p.testSelect(slot.GroupModifierMaybe2R2) {
+				p.parseError(slot.GroupModifierMaybe2R2, p.cI, first[slot.GroupModifierMaybe2R2])
 				break
 			}
 
-			p.bsrSet.Add(slot.MetricQuery0R5, cU, p.cI, p.cI+1)
-			p.cI++
-			if !p.testSelect(slot.MetricQuery0R5) {
-				p.parseError(slot.MetricQuery0R5, p.cI, first[slot.MetricQuery0R5])
+			p.call(slot.GroupModifierMaybe2R3, cU, p.cI)
+		case slot.GroupModifierMaybe2R3: // GroupModifierMaybe : GroupSide ( LabelKeysMaybe ∙)
+
+			if !p.testSelect(slot.GroupModifierMaybe2R3) {
+				p.parseError(slot.GroupModifierMaybe2R3, p.cI, first[slot.GroupModifierMaybe2R3])
 				break
 			}
 
-			p.bsrSet.Add(slot.MetricQuery0R6, cU, p.cI, p.cI+1)
+			p.bsrSet.Add(slot.GroupModifierMaybe2R4, cU, p.cI, p.cI+1)
 			p.cI++
-			if !p.testSelect(slot.MetricQuery0R6) {
-				p.parseError(slot.MetricQuery0R6, p.cI, first[slot.MetricQuery0R6])
-				break
+			if p.follow(symbols.NT_GroupModifierMaybe) {
+				p.rtn(symbols.NT_GroupModifierMaybe, cU, p.cI)
+			} else {
+				p.parseError(slot.GroupModifierMaybe2R0, p.cI, followSets[symbols.NT_GroupModifierMaybe])
 			}
+		case slot.GroupSide0R0: // GroupSide : ∙group_left
 
-			p.bsrSet.Add(slot.MetricQuery0R7, cU, p.cI, p.cI+1)
+			p.bsrSet.Add(slot.GroupSide0R1, cU, p.cI, p.cI+1)
 			p.cI++
-			if !p.testSelect(slot.MetricQuery0R7) {
-				p.parseError(slot.MetricQuery0R7, p.cI, first[slot.MetricQuery0R7])
-				break
+			if p.follow(symbols.NT_GroupSide) {
+				p.rtn(symbols.NT_GroupSide, cU, p.cI)
+			} else {
+				p.parseError(slot.GroupSide0R0, p.cI, followSets[symbols.NT_GroupSide])
 			}
+		case slot.GroupSide1R0: // GroupSide : ∙group_right
 
-			p.bsrSet.Add(slot.MetricQuery0R8, cU, p.cI, p.cI+1)
+			p.bsrSet.Add(slot.GroupSide1R1, cU, p.cI, p.cI+1)
 			p.cI++
-			if !p.testSelect(slot.MetricQuery0R8) {
-				p.parseError(slot.MetricQuery0R8, p.cI, first[slot.MetricQuery0R8])
-				break
+			if p.follow(symbols.NT_GroupSide) {
+				p.rtn(symbols.NT_GroupSide, cU, p.cI)
+			} else {
+				p.parseError(slot.GroupSide1R0, p.cI, followSets[symbols.NT_GroupSide])
 			}
+		case slot.Grouping0R0: // Grouping : ∙GroupingOp ( LabelKeysMaybe )
 
-			p.call(slot.MetricQuery0R9, cU, p.cI)
-		case slot.MetricQuery0R9: // MetricQuery : sum by ( level ) ( count_over_time ( LogQuery ∙duration ) )
+			p.call(slot.Grouping0R1, cU, p.cI)
+		case slot.Grouping0R1: // Grouping : GroupingOp ∙( LabelKeysMaybe )
 
-			if !p.testSelect(slot.MetricQuery0R9) {
-				p.parseError(slot.MetricQuery0R9, p.cI, first[slot.MetricQuery0R9])
+			if !p.testSelect(slot.Grouping0R1) {
+				p.parseError(slot.Grouping0R1, p.cI, first[slot.Grouping0R1])
 				break
 			}
 
-			p.bsrSet.Add(slot.MetricQuery0R10, cU, p.cI, p.cI+1)
+			p.bsrSet.Add(slot.Grouping0R2, cU, p.cI, p.cI+1)
 			p.cI++
-			if !p.testSelect(slot.MetricQuery0R10) {
-				p.parseError(slot.MetricQuery0R10, p.cI, first[slot.MetricQuery0R10])
+			if !p.testSelect(slot.Grouping0R2) {
+				p.parseError(slot.Grouping0R2, p.cI, first[slot.Grouping0R2])
 				break
 			}
 
-			p.bsrSet.Add(slot.MetricQuery0R11, cU, p.cI, p.cI+1)
-			p.cI++
-			if !p.testSelect(slot.MetricQuery0R11) {
-				p.parseError(slot.MetricQuery0R11, p.cI, first[slot.MetricQuery0R11])
+			p.call(slot.Grouping0R3, cU, p.cI)
+		case slot.Grouping0R3: // Grouping : GroupingOp ( LabelKeysMaybe ∙)
+
+			if !p.testSelect(slot.Grouping0R3) {
+				p.parseError(slot.Grouping0R3, p.cI, first[slot.Grouping0R3])
 				break
 			}
 
-			p.bsrSet.Add(slot.MetricQuery0R12, cU, p.cI, p.cI+1)
+			p.bsrSet.Add(slot.Grouping0R4, cU, p.cI, p.cI+1)
 			p.cI++
-			if p.follow(symbols.NT_MetricQuery) {
-				p.rtn(symbols.NT_MetricQuery, cU, p.cI)
+			if p.follow(symbols.NT_Grouping) {
+				p.rtn(symbols.NT_Grouping, cU, p.cI)
 			} else {
-				p.parseError(slot.MetricQuery0R0, p.cI, followSets[symbols.NT_MetricQuery])
+				p.parseError(slot.Grouping0R0, p.cI, followSets[symbols.NT_Grouping])
 			}
-		case slot.Pipeline0R0: // Pipeline : ∙LineFilter
-
-			p.call(slot.Pipeline0R1, cU, p.cI)
-		case slot.Pipeline0R1: // Pipeline : LineFilter ∙
+		case slot.GroupingMaybe0R0: // GroupingMaybe : ∙
+			p.bsrSet.AddEmpty(slot.GroupingMaybe0R0, p.cI)
 
-			if p.follow(symbols.NT_Pipeline) {
-				p.rtn(symbols.NT_Pipeline, cU, p.cI)
+			if p.follow(symbols.NT_GroupingMaybe) {
+				p.rtn(symbols.NT_GroupingMaybe, cU, p.cI)
 			} else {
-				p.parseError(slot.Pipeline0R0, p.cI, followSets[symbols.NT_Pipeline])
+				p.parseError(slot.GroupingMaybe0R0, p.cI, followSets[symbols.NT_GroupingMaybe])
 			}
-		case slot.Pipeline1R0: // Pipeline : ∙DataFilter
+		case slot.GroupingMaybe1R0: // GroupingMaybe : ∙Grouping
 
-			p.call(slot.Pipeline1R1, cU, p.cI)
-		case slot.Pipeline1R1: // Pipeline : DataFilter ∙
+			p.call(slot.GroupingMaybe1R1, cU, p.cI)
+		case slot.GroupingMaybe1R1: // GroupingMaybe : Grouping ∙
 
-			if p.follow(symbols.NT_Pipeline) {
-				p.rtn(symbols.NT_Pipeline, cU, p.cI)
+			if p.follow(symbols.NT_GroupingMaybe) {
+				p.rtn(symbols.NT_GroupingMaybe, cU, p.cI)
 			} else {
-				p.parseError(slot.Pipeline1R0, p.cI, followSets[symbols.NT_Pipeline])
+				p.parseError(slot.GroupingMaybe1R0, p.cI, followSets[symbols.NT_GroupingMaybe])
 			}
-		case slot.Pipelines0R0: // Pipelines : ∙Pipeline
+		case slot.GroupingOp0R0: // GroupingOp : ∙by
 
-			p.call(slot.Pipelines0R1, cU, p.cI)
-		case slot.Pipelines0R1: // Pipelines : Pipeline ∙
+			p.bsrSet.Add(slot.GroupingOp0R1, cU, p.cI, p.cI+1)
+			p.cI++
+			if p.follow(symbols.NT_GroupingOp) {
+				p.rtn(symbols.NT_GroupingOp, cU, p.cI)
+			} else {
+				p.parseError(slot.GroupingOp0R0, p.cI, followSets[symbols.NT_GroupingOp])
+			}
+		case slot.GroupingOp1R0: // GroupingOp : ∙without
 
-			if p.follow(symbols.NT_Pipelines) {
-				p.rtn(symbols.NT_Pipelines, cU, p.cI)
+			p.bsrSet.Add(slot.GroupingOp1R1, cU, p.cI, p.cI+1)
+			p.cI++
+			if p.follow(symbols.NT_GroupingOp) {
+				p.rtn(symbols.NT_GroupingOp, cU, p.cI)
 			} else {
-				p.parseError(slot.Pipelines0R0, p.cI, followSets[symbols.NT_Pipelines])
+				p.parseError(slot.GroupingOp1R0, p.cI, followSets[symbols.NT_GroupingOp])
 			}
-		case slot.Pipelines1R0: // Pipelines : ∙Pipeline Pipelines
+		case slot.LabelFilter0R0: // LabelFilter : ∙LabelKey LabelFilterOp FilterValue
 
-			p.call(slot.Pipelines1R1, cU, p.cI)
-		case slot.Pipelines1R1: // Pipelines : Pipeline ∙Pipelines
+			p.call(slot.LabelFilter0R1, cU, p.cI)
+		case slot.LabelFilter0R1: // LabelFilter : LabelKey ∙LabelFilterOp FilterValue
 
-			if !p.testSelect(slot.Pipelines1R1) {
-				p.parseError(slot.Pipelines1R1, p.cI, first[slot.Pipelines1R1])
+			if !p.testSelect(slot.LabelFilter0R1) {
+				p.parseError(slot.LabelFilter0R1, p.cI, first[slot.LabelFilter0R1])
 				break
 			}
 
-			p.call(slot.Pipelines1R2, cU, p.cI)
-		case slot.Pipelines1R2: // Pipelines : Pipeline Pipelines ∙
+			p.call(slot.LabelFilter0R2, cU, p.cI)
+		case slot.LabelFilter0R2: // LabelFilter : LabelKey LabelFilterOp ∙FilterValue
 
-			if p.follow(symbols.NT_Pipelines) {
-				p.rtn(symbols.NT_Pipelines, cU, p.cI)
-			} else {
-				p.parseError(slot.Pipelines1R0, p.cI, followSets[symbols.NT_Pipelines])
+			if !p.testSelect(slot.LabelFilter0R2) {
+				p.parseError(slot.LabelFilter0R2, p.cI, first[slot.LabelFilter0R2])
+				break
 			}
-		case slot.PipelinesMaybe0R0: // PipelinesMaybe : ∙
-			p.bsrSet.AddEmpty(slot.PipelinesMaybe0R0, p.cI)
 
-			if p.follow(symbols.NT_PipelinesMaybe) {
-				p.rtn(symbols.NT_PipelinesMaybe, cU, p.cI)
+			p.call(slot.LabelFilter0R3, cU, p.cI)
+		case slot.LabelFilter0R3: // LabelFilter : LabelKey LabelFilterOp FilterValue ∙
+
+			if p.follow(symbols.NT_LabelFilter) {
+				p.rtn(symbols.NT_LabelFilter, cU, p.cI)
 			} else {
-				p.parseError(slot.PipelinesMaybe0R0, p.cI, followSets[symbols.NT_PipelinesMaybe])
+				p.parseError(slot.LabelFilter0R0, p.cI, followSets[symbols.NT_LabelFilter])
 			}
-		case slot.PipelinesMaybe1R0: // PipelinesMaybe : ∙Pipelines
+		case slot.LabelFilterOp0R0: // LabelFilterOp : ∙=
 
-			p.call(slot.PipelinesMaybe1R1, cU, p.cI)
-		case slot.PipelinesMaybe1R1: // PipelinesMaybe : Pipelines ∙
-
-			if p.follow(symbols.NT_PipelinesMaybe) {
-				p.rtn(symbols.NT_PipelinesMaybe, cU, p.cI)
+			p.bsrSet.Add(slot.LabelFilterOp0R1, cU, p.cI, p.cI+1)
+			p.cI++
+			if p.follow(symbols.NT_LabelFilterOp) {
+				p.rtn(symbols.NT_LabelFilterOp, cU, p.cI)
 			} else {
-				p.parseError(slot.PipelinesMaybe1R0, p.cI, followSets[symbols.NT_PipelinesMaybe])
+				p.parseError(slot.LabelFilterOp0R0, p.cI, followSets[symbols.NT_LabelFilterOp])
 			}
-		case slot.Query0R0: // Query : ∙LogQuery
+		case slot.LabelFilterOp1R0: // LabelFilterOp : ∙==
 
-			p.call(slot.Query0R1, cU, p.cI)
-		case slot.Query0R1: // Query : LogQuery ∙
+			p.bsrSet.Add(slot.LabelFilterOp1R1, cU, p.cI, p.cI+1)
+			p.cI++
+			if p.follow(symbols.NT_LabelFilterOp) {
+				p.rtn(symbols.NT_LabelFilterOp, cU, p.cI)
+			} else {
+				p.parseError(slot.LabelFilterOp1R0, p.cI, followSets[symbols.NT_LabelFilterOp])
+			}
+		case slot.LabelFilterOp2R0: // LabelFilterOp : ∙!=
 
-			if p.follow(symbols.NT_Query) {
-				p.rtn(symbols.NT_Query, cU, p.cI)
+			p.bsrSet.Add(slot.LabelFilterOp2R1, cU, p.cI, p.cI+1)
+			p.cI++
+			if p.follow(symbols.NT_LabelFilterOp) {
+				p.rtn(symbols.NT_LabelFilterOp, cU, p.cI)
 			} else {
-				p.parseError(slot.Query0R0, p.cI, followSets[symbols.NT_Query])
+				p.parseError(slot.LabelFilterOp2R0, p.cI, followSets[symbols.NT_LabelFilterOp])
 			}
-		case slot.Query1R0: // Query : ∙MetricQuery
+		case slot.LabelFilterOp3R0: // LabelFilterOp : ∙=~
 
-			p.call(slot.Query1R1, cU, p.cI)
-		case slot.Query1R1: // Query : MetricQuery ∙
+			p.bsrSet.Add(slot.LabelFilterOp3R1, cU, p.cI, p.cI+1)
+			p.cI++
+			if p.follow(symbols.NT_LabelFilterOp) {
+				p.rtn(symbols.NT_LabelFilterOp, cU, p.cI)
+			} else {
+				p.parseError(slot.LabelFilterOp3R0, p.cI, followSets[symbols.NT_LabelFilterOp])
+			}
+		case slot.LabelFilterOp4R0: // LabelFilterOp : ∙!~
 
-			if p.follow(symbols.NT_Query) {
-				p.rtn(symbols.NT_Query, cU, p.cI)
+			p.bsrSet.Add(slot.LabelFilterOp4R1, cU, p.cI, p.cI+1)
+			p.cI++
+			if p.follow(symbols.NT_LabelFilterOp) {
+				p.rtn(symbols.NT_LabelFilterOp, cU, p.cI)
 			} else {
-				p.parseError(slot.Query1R0, p.cI, followSets[symbols.NT_Query])
+				p.parseError(slot.LabelFilterOp4R0, p.cI, followSets[symbols.NT_LabelFilterOp])
 			}
+		case slot.LabelFilterOp5R0: // LabelFilterOp : ∙>=
 
-		default:
-			panic("This must not happen")
-		}
-	}
-	if !p.bsrSet.Contain(symbols.NT_Query, 0, m) {
-		p.sortParseErrors()
-		return nil, p.parseErrors
-	}
-	return p.bsrSet, nil
-}
+			p.bsrSet.Add(slot.LabelFilterOp5R1, cU, p.cI, p.cI+1)
+			p.cI++
+			if p.follow(symbols.NT_LabelFilterOp) {
+				p.rtn(symbols.NT_LabelFilterOp, cU, p.cI)
+			} else {
+				p.parseError(slot.LabelFilterOp5R0, p.cI, followSets[symbols.NT_LabelFilterOp])
+			}
+		case slot.LabelFilterOp6R0: // LabelFilterOp : ∙>
 
-func (p *parser) ntAdd(nt symbols.NT, j int) {
-	// fmt.Printf("p.ntAdd(%s, %d)\n", nt, j)
-	failed := true
-	expected := map[token.Type]string{}
-	for _, l := range slot.GetAlternates(nt) {
-		if p.testSelect(l) {
-			p.dscAdd(l, j, j)
-			failed = false
-		} else {
-			for k, v := range first[l] {
-				expected[k] = v
+			p.bsrSet.Add(slot.LabelFilterOp6R1, cU, p.cI, p.cI+1)
+			p.cI++
+			if p.follow(symbols.NT_LabelFilterOp) {
+				p.rtn(symbols.NT_LabelFilterOp, cU, p.cI)
+			} else {
+				p.parseError(slot.LabelFilterOp6R0, p.cI, followSets[symbols.NT_LabelFilterOp])
 			}
-		}
-	}
-	if failed {
-		for _, l := range slot.GetAlternates(nt) {
-			p.parseError(l, j, expected)
-		}
-	}
-}
+		case slot.LabelFilterOp7R0: // LabelFilterOp : ∙<=
 
-/*** Call Return Forest ***/
+			p.bsrSet.Add(slot.LabelFilterOp7R1, cU, p.cI, p.cI+1)
+			p.cI++
+			if p.follow(symbols.NT_LabelFilterOp) {
+				p.rtn(symbols.NT_LabelFilterOp, cU, p.cI)
+			} else {
+				p.parseError(slot.LabelFilterOp7R0, p.cI, followSets[symbols.NT_LabelFilterOp])
+			}
+		case slot.LabelFilterOp8R0: // LabelFilterOp : ∙<
 
-type poppedNode struct {
-	X    symbols.NT
-	k, j int
-}
+			p.bsrSet.Add(slot.LabelFilterOp8R1, cU, p.cI, p.cI+1)
+			p.cI++
+			if p.follow(symbols.NT_LabelFilterOp) {
+				p.rtn(symbols.NT_LabelFilterOp, cU, p.cI)
+			} else {
+				p.parseError(slot.LabelFilterOp8R0, p.cI, followSets[symbols.NT_LabelFilterOp])
+			}
+		case slot.LabelFormat0R0: // LabelFormat : ∙LabelKey = LabelFormatValue
 
-type clusterNode struct {
-	X symbols.NT
-	k int
-}
+			p.call(slot.LabelFormat0R1, cU, p.cI)
+		case slot.LabelFormat0R1: // LabelFormat : LabelKey ∙= LabelFormatValue
 
-type crfNode struct {
-	L slot.Label
-	i int
-}
+			if !p.testSelect(slot.LabelFormat0R1) {
+				p.parseError(slot.LabelFormat0R1, p.cI, first[slot.LabelFormat0R1])
+				break
+			}
 
-/*
-suppose that L is Y ::=αX ·β
-if there is no CRF node labelled (L,i)
+			p.bsrSet.Add(slot.LabelFormat0R2, cU, p.cI, p.cI+1)
+			p.cI++
+			if !p.testSelect(slot.LabelFormat0R2) {
+				p.parseError(slot.LabelFormat0R2, p.cI, first[slot.LabelFormat0R2])
+				break
+			}
 
-	create one let u be the CRF node labelled (L,i)
+			p.call(slot.LabelFormat0R3, cU, p.cI)
+		case slot.LabelFormat0R3: // LabelFormat : LabelKey = LabelFormatValue ∙
 
-if there is no CRF node labelled (X, j) {
+			if p.follow(symbols.NT_LabelFormat) {
+				p.rtn(symbols.NT_LabelFormat, cU, p.cI)
+			} else {
+				p.parseError(slot.LabelFormat0R0, p.cI, followSets[symbols.NT_LabelFormat])
+			}
+		case slot.LabelFormatValue0R0: // LabelFormatValue : ∙LabelKey
 
-		create a CRF node v labelled (X, j)
-		create an edge from v to u
-		ntAdd(X, j)
-	} else {
+			p.call(slot.LabelFormatValue0R1, cU, p.cI)
+		case slot.LabelFormatValue0R1: // LabelFormatValue : LabelKey ∙
 
-		let v be the CRF node labelled (X, j)
-		if there is not an edge from v to u {
-			create an edge from v to u
-			for all ((X, j,h)∈P) {
-				dscAdd(L, i, h);
-				bsrAdd(L, i, j, h)
+			if p.follow(symbols.NT_LabelFormatValue) {
+				p.rtn(symbols.NT_LabelFormatValue, cU, p.cI)
+			} else {
+				p.parseError(slot.LabelFormatValue0R0, p.cI, followSets[symbols.NT_LabelFormatValue])
 			}
-		}
-	}
-*/
-func (p *parser) call(L slot.Label, i, j int) {
-	// fmt.Printf("p.call(%s,%d,%d)\n", L,i,j)
-	u, exist := p.crfNodes[crfNode{L, i}]
-	// fmt.Printf("  u exist=%t\n", exist)
-	if !exist {
-		u = &crfNode{L, i}
-		p.crfNodes[*u] = u
-	}
-	X := L.Symbols()[L.Pos()-1].(symbols.NT)
-	ndV := clusterNode{X, j}
-	v, exist := p.crf[ndV]
-	if !exist {
-		// fmt.Println("  v !exist")
-		p.crf[ndV] = []*crfNode{u}
-		p.ntAdd(X, j)
-	} else {
-		// fmt.Println("  v exist")
-		if !existEdge(v, u) {
-			// fmt.Printf("  !existEdge(%v)\n", u)
-			p.crf[ndV] = append(v, u)
-			// fmt.Printf("|popped|=%d\n", len(popped))
-			for pnd := range p.popped {
-				if pnd.X == X && pnd.k == j {
-					p.dscAdd(L, i, pnd.j)
-					p.bsrSet.Add(L, i, j, pnd.j)
-				}
+		case slot.LabelFormatValue1R0: // LabelFormatValue : ∙string
+
+			p.bsrSet.Add(slot.LabelFormatValue1R1, cU, p.cI, p.cI+1)
+			p.cI++
+			if p.follow(symbols.NT_LabelFormatValue) {
+				p.rtn(symbols.NT_LabelFormatValue, cU, p.cI)
+			} else {
+				p.parseError(slot.LabelFormatValue1R0, p.cI, followSets[symbols.NT_LabelFormatValue])
 			}
-		}
-	}
-}
+		case slot.LabelFormats0R0: // LabelFormats : ∙LabelFormat
 
-func existEdge(nds []*crfNode, nd *crfNode) bool {
-	for _, nd1 := range nds {
-		if nd1 == nd {
-			return true
-		}
-	}
-	return false
-}
+			p.call(slot.LabelFormats0R1, cU, p.cI)
+		case slot.LabelFormats0R1: // LabelFormats : LabelFormat ∙
 
-func (p *parser) rtn(X symbols.NT, k, j int) {
-	// fmt.Printf("p.rtn(%s,%d,%d)\n", X,k,j)
-	pn := poppedNode{X, k, j}
-	if _, exist := p.popped[pn]; !exist {
-		p.popped[pn] = true
-		for _, nd := range p.crf[clusterNode{X, k}] {
-			p.dscAdd(nd.L, nd.i, j)
-			p.bsrSet.Add(nd.L, nd.i, k, j)
-		}
-	}
-}
+			if p.follow(symbols.NT_LabelFormats) {
+				p.rtn(symbols.NT_LabelFormats, cU, p.cI)
+			} else {
+				p.parseError(slot.LabelFormats0R0, p.cI, followSets[symbols.NT_LabelFormats])
+			}
+		case slot.LabelFormats1R0: // LabelFormats : ∙LabelFormat , LabelFormats
 
-// func CRFString() string {
-// 	buf := new(bytes.Buffer)
-// 	buf.WriteString("CRF: {")
-// 	for cn, nds := range crf{
-// 		for _, nd := range nds {
-// 			fmt.Fprintf(buf, "%s->%s, ", cn, nd)
-// 		}
-// 	}
-// 	buf.WriteString("}")
-// 	return buf.String()
-// }
+			p.call(slot.LabelFormats1R1, cU, p.cI)
+		case slot.LabelFormats1R1: // LabelFormats : LabelFormat ∙, LabelFormats
 
-func (cn clusterNode) String() string {
-	return fmt.Sprintf("(%s,%d)", cn.X, cn.k)
-}
+			if !p.testSelect(slot.LabelFormats1R1) {
+				p.parseError(slot.LabelFormats1R1, p.cI, first[slot.LabelFormats1R1])
+				break
+			}
 
-func (n crfNode) String() string {
-	return fmt.Sprintf("(%s,%d)", n.L.String(), n.i)
-}
+			p.bsrSet.Add(slot.LabelFormats1R2, cU, p.cI, p.cI+1)
+			p.cI++
+			if !p.testSelect(slot.LabelFormats1R2) {
+				p.parseError(slot.LabelFormats1R2, p.cI, first[slot.LabelFormats1R2])
+				break
+			}
 
-// func PoppedString() string {
-// 	buf := new(bytes.Buffer)
-// 	buf.WriteString("Popped: {")
-// 	for p, _ := range popped {
-// 		fmt.Fprintf(buf, "(%s,%d,%d) ", p.X, p.k, p.j)
-// 	}
-// 	buf.WriteString("}")
-// 	return buf.String()
-// }
+			p.call(slot.LabelFormats1R3, cU, p.cI)
+		case slot.LabelFormats1R3: // LabelFormats : LabelFormat , LabelFormats ∙
 
-/*** descriptors ***/
+			if p.follow(symbols.NT_LabelFormats) {
+				p.rtn(symbols.NT_LabelFormats, cU, p.cI)
+			} else {
+				p.parseError(slot.LabelFormats1R0, p.cI, followSets[symbols.NT_LabelFormats])
+			}
+		case slot.LabelKey0R0: // LabelKey : ∙var_name
 
-type descriptors struct {
-	set []*descriptor
-}
+			p.bsrSet.Add(slot.LabelKey0R1, cU, p.cI, p.cI+1)
+			p.cI++
+			if p.follow(symbols.NT_LabelKey) {
+				p.rtn(symbols.NT_LabelKey, cU, p.cI)
+			} else {
+				p.parseError(slot.LabelKey0R0, p.cI, followSets[symbols.NT_LabelKey])
+			}
+		case slot.LabelKey1R0: // LabelKey : ∙by
 
-func (ds *descriptors) contain(d *descriptor) bool {
-	for _, d1 := range ds.set {
-		if d1 == d {
-			return true
-		}
-	}
-	return false
-}
+			p.bsrSet.Add(slot.LabelKey1R1, cU, p.cI, p.cI+1)
+			p.cI++
+			if p.follow(symbols.NT_LabelKey) {
+				p.rtn(symbols.NT_LabelKey, cU, p.cI)
+			} else {
+				p.parseError(slot.LabelKey1R0, p.cI, followSets[symbols.NT_LabelKey])
+			}
+		case slot.LabelKey2R0: // LabelKey : ∙without
 
-func (ds *descriptors) empty() bool {
-	return len(ds.set) == 0
-}
+			p.bsrSet.Add(slot.LabelKey2R1, cU, p.cI, p.cI+1)
+			p.cI++
+			if p.follow(symbols.NT_LabelKey) {
+				p.rtn(symbols.NT_LabelKey, cU, p.cI)
+			} else {
+				p.parseError(slot.LabelKey2R0, p.cI, followSets[symbols.NT_LabelKey])
+			}
+		case slot.LabelKey3R0: // LabelKey : ∙unwrap
 
-func (ds *descriptors) String() string {
-	buf := new(bytes.Buffer)
-	buf.WriteString("{")
-	for i, d := range ds.set {
-		if i > 0 {
-			buf.WriteString("; ")
-		}
-		fmt.Fprintf(buf, "%s", d)
-	}
-	buf.WriteString("}")
-	return buf.String()
-}
+			p.bsrSet.Add(slot.LabelKey3R1, cU, p.cI, p.cI+1)
+			p.cI++
+			if p.follow(symbols.NT_LabelKey) {
+				p.rtn(symbols.NT_LabelKey, cU, p.cI)
+			} else {
+				p.parseError(slot.LabelKey3R0, p.cI, followSets[symbols.NT_LabelKey])
+			}
+		case slot.LabelKey4R0: // LabelKey : ∙json
 
-type descriptor struct {
-	L slot.Label
-	k int
-	i int
-}
+			p.bsrSet.Add(slot.LabelKey4R1, cU, p.cI, p.cI+1)
+			p.cI++
+			if p.follow(symbols.NT_LabelKey) {
+				p.rtn(symbols.NT_LabelKey, cU, p.cI)
+			} else {
+				p.parseError(slot.LabelKey4R0, p.cI, followSets[symbols.NT_LabelKey])
+			}
+		case slot.LabelKey5R0: // LabelKey : ∙logfmt
 
-func (d *descriptor) String() string {
-	return fmt.Sprintf("%s,%d,%d", d.L, d.k, d.i)
-}
+			p.bsrSet.Add(slot.LabelKey5R1, cU, p.cI, p.cI+1)
+			p.cI++
+			if p.follow(symbols.NT_LabelKey) {
+				p.rtn(symbols.NT_LabelKey, cU, p.cI)
+			} else {
+				p.parseError(slot.LabelKey5R0, p.cI, followSets[symbols.NT_LabelKey])
+			}
+		case slot.LabelKey6R0: // LabelKey : ∙regexp
 
-func (p *parser) dscAdd(L slot.Label, k, i int) {
-	// fmt.Printf("p.dscAdd(%s,%d,%d)\n", L, k, i)
-	d := &descriptor{L, k, i}
-	if !p.U.contain(d) {
-		p.R.set = append(p.R.set, d)
-		p.U.set = append(p.U.set, d)
-	}
-}
+			p.bsrSet.Add(slot.LabelKey6R1, cU, p.cI, p.cI+1)
+			p.cI++
+			if p.follow(symbols.NT_LabelKey) {
+				p.rtn(symbols.NT_LabelKey, cU, p.cI)
+			} else {
+				p.parseError(slot.LabelKey6R0, p.cI, followSets[symbols.NT_LabelKey])
+			}
+		case slot.LabelKey7R0: // LabelKey : ∙pattern
 
-func (ds *descriptors) remove() (L slot.Label, k, i int) {
-	d := ds.set[len(ds.set)-1]
-	ds.set = ds.set[:len(ds.set)-1]
-	// fmt.Printf("remove: %s,%d,%d\n", d.L, d.k, d.i)
-	return d.L, d.k, d.i
-}
+			p.bsrSet.Add(slot.LabelKey7R1, cU, p.cI, p.cI+1)
+			p.cI++
+			if p.follow(symbols.NT_LabelKey) {
+				p.rtn(symbols.NT_LabelKey, cU, p.cI)
+			} else {
+				p.parseError(slot.LabelKey7R0, p.cI, followSets[symbols.NT_LabelKey])
+			}
+		case slot.LabelKey8R0: // LabelKey : ∙line_format
 
-func (p *parser) DumpDescriptors() {
-	p.DumpR()
-	p.DumpU()
-}
+			p.bsrSet.Add(slot.LabelKey8R1, cU, p.cI, p.cI+1)
+			p.cI++
+			if p.follow(symbols.NT_LabelKey) {
+				p.rtn(symbols.NT_LabelKey, cU, p.cI)
+			} else {
+				p.parseError(slot.LabelKey8R0, p.cI, followSets[symbols.NT_LabelKey])
+			}
+		case slot.LabelKey9R0: // LabelKey : ∙label_format
 
-func (p *parser) DumpR() {
-	fmt.Println("R:")
-	for _, d := range p.R.set {
-		fmt.Printf(" %s\n", d)
-	}
-}
+			p.bsrSet.Add(slot.LabelKey9R1, cU, p.cI, p.cI+1)
+			p.cI++
+			if p.follow(symbols.NT_LabelKey) {
+				p.rtn(symbols.NT_LabelKey, cU, p.cI)
+			} else {
+				p.parseError(slot.LabelKey9R0, p.cI, followSets[symbols.NT_LabelKey])
+			}
+		case slot.LabelKeys0R0: // LabelKeys : ∙LabelKey
 
-func (p *parser) DumpU() {
-	fmt.Println("U:")
-	for _, d := range p.U.set {
-		fmt.Printf(" %s\n", d)
-	}
-}
+			p.call(slot.LabelKeys0R1, cU, p.cI)
+		case slot.LabelKeys0R1: // LabelKeys : LabelKey ∙
 
-/*** TestSelect ***/
+			if p.follow(symbols.NT_LabelKeys) {
+				p.rtn(symbols.NT_LabelKeys, cU, p.cI)
+			} else {
+				p.parseError(slot.LabelKeys0R0, p.cI, followSets[symbols.NT_LabelKeys])
+			}
+		case slot.LabelKeys1R0: // LabelKeys : ∙LabelKey , LabelKeys
 
-func (p *parser) follow(nt symbols.NT) bool {
-	_, exist := followSets[nt][p.lex.Tokens[p.cI].Type()]
-	return exist
-}
+			p.call(slot.LabelKeys1R1, cU, p.cI)
+		case slot.LabelKeys1R1: // LabelKeys : LabelKey ∙, LabelKeys
 
-func (p *parser) testSelect(l slot.Label) bool {
-	_, exist := first[l][p.lex.Tokens[p.cI].Type()]
-	// fmt.Printf("testSelect(%s) = %t\n", l, exist)
-	return exist
-}
+			if !p.testSelect(slot.LabelKeys1R1) {
+				p.parseError(slot.LabelKeys1R1, p.cI, first[slot.LabelKeys1R1])
+				break
+			}
 
-var first = []map[token.Type]string{
-	// DataFilter : ∙| string DataFilterOp string
+			p.bsrSet.Add(slot.LabelKeys1R2, cU, p.cI, p.cI+1)
+			p.cI++
+			if !p.testSelect(slot.LabelKeys1R2) {
+				p.parseError(slot.LabelKeys1R2, p.cI, first[slot.LabelKeys1R2])
+				break
+			}
+
+			p.call(slot.LabelKeys1R3, cU, p.cI)
+		case slot.LabelKeys1R3: // LabelKeys : LabelKey , LabelKeys ∙
+
+			if p.follow(symbols.NT_LabelKeys) {
+				p.rtn(symbols.NT_LabelKeys, cU, p.cI)
+			} else {
+				p.parseError(slot.LabelKeys1R0, p.cI, followSets[symbols.NT_LabelKeys])
+			}
+		case slot.LabelKeysMaybe0R0: // LabelKeysMaybe : ∙
+			p.bsrSet.AddEmpty(slot.LabelKeysMaybe0R0, p.cI)
+
+			if p.follow(symbols.NT_LabelKeysMaybe) {
+				p.rtn(symbols.NT_LabelKeysMaybe, cU, p.cI)
+			} else {
+				p.parseError(slot.LabelKeysMaybe0R0, p.cI, followSets[symbols.NT_LabelKeysMaybe])
+			}
+		case slot.LabelKeysMaybe1R0: // LabelKeysMaybe : ∙LabelKeys
+
+			p.call(slot.LabelKeysMaybe1R1, cU, p.cI)
+		case slot.LabelKeysMaybe1R1: // LabelKeysMaybe : LabelKeys ∙
+
+			if p.follow(symbols.NT_LabelKeysMaybe) {
+				p.rtn(symbols.NT_LabelKeysMaybe, cU, p.cI)
+			} else {
+				p.parseError(slot.LabelKeysMaybe1R0, p.cI, followSets[symbols.NT_LabelKeysMaybe])
+			}
+		case slot.LineFilter0R0: // LineFilter : ∙LineFilterOp LineFilterValues
+
+			p.call(slot.LineFilter0R1, cU, p.cI)
+		case slot.LineFilter0R1: // LineFilter : LineFilterOp ∙LineFilterValues
+
+			if !p.testSelect(slot.LineFilter0R1) {
+				p.parseError(slot.LineFilter0R1, p.cI, first[slot.LineFilter0R1])
+				break
+			}
+
+			p.call(slot.LineFilter0R2, cU, p.cI)
+		case slot.LineFilter0R2: // LineFilter : LineFilterOp LineFilterValues ∙
+
+			if p.follow(symbols.NT_LineFilter) {
+				p.rtn(symbols.NT_LineFilter, cU, p.cI)
+			} else {
+				p.parseError(slot.LineFilter0R0, p.cI, followSets[symbols.NT_LineFilter])
+			}
+		case slot.LineFilterOp0R0: // LineFilterOp : ∙|=
+
+			p.bsrSet.Add(slot.LineFilterOp0R1, cU, p.cI, p.cI+1)
+			p.cI++
+			if p.follow(symbols.NT_LineFilterOp) {
+				p.rtn(symbols.NT_LineFilterOp, cU, p.cI)
+			} else {
+				p.parseError(slot.LineFilterOp0R0, p.cI, followSets[symbols.NT_LineFilterOp])
+			}
+		case slot.LineFilterOp1R0: // LineFilterOp : ∙!=
+
+			p.bsrSet.Add(slot.LineFilterOp1R1, cU, p.cI, p.cI+1)
+			p.cI++
+			if p.follow(symbols.NT_LineFilterOp) {
+				p.rtn(symbols.NT_LineFilterOp, cU, p.cI)
+			} else {
+				p.parseError(slot.LineFilterOp1R0, p.cI, followSets[symbols.NT_LineFilterOp])
+			}
+		case slot.LineFilterOp2R0: // LineFilterOp : ∙|~
+
+			p.bsrSet.Add(slot.LineFilterOp2R1, cU, p.cI, p.cI+1)
+			p.cI++
+			if p.follow(symbols.NT_LineFilterOp) {
+				p.rtn(symbols.NT_LineFilterOp, cU, p.cI)
+			} else {
+				p.parseError(slot.LineFilterOp2R0, p.cI, followSets[symbols.NT_LineFilterOp])
+			}
+		case slot.LineFilterOp3R0: // LineFilterOp : ∙!~
+
+			p.bsrSet.Add(slot.LineFilterOp3R1, cU, p.cI, p.cI+1)
+			p.cI++
+			if p.follow(symbols.NT_LineFilterOp) {
+				p.rtn(symbols.NT_LineFilterOp, cU, p.cI)
+			} else {
+				p.parseError(slot.LineFilterOp3R0, p.cI, followSets[symbols.NT_LineFilterOp])
+			}
+		case slot.LineFilterValues0R0: // LineFilterValues : ∙string
+
+			p.bsrSet.Add(slot.LineFilterValues0R1, cU, p.cI, p.cI+1)
+			p.cI++
+			if p.follow(symbols.NT_LineFilterValues) {
+				p.rtn(symbols.NT_LineFilterValues, cU, p.cI)
+			} else {
+				p.parseError(slot.LineFilterValues0R0, p.cI, followSets[symbols.NT_LineFilterValues])
+			}
+		case slot.LineFilterValues1R0: // LineFilterValues : ∙string or LineFilterValues
+
+			p.bsrSet.Add(slot.LineFilterValues1R1, cU, p.cI, p.cI+1)
+			p.cI++
+			if !p.testSelect(slot.LineFilterValues1R1) {
+				p.parseError(slot.LineFilterValues1R1, p.cI, first[slot.LineFilterValues1R1])
+				break
+			}
+
+			p.bsrSet.Add(slot.LineFilterValues1R2, cU, p.cI, p.cI+1)
+			p.cI++
+			if !p.testSelect(slot.LineFilterValues1R2) {
+				p.parseError(slot.LineFilterValues1R2, p.cI, first[slot.LineFilterValues1R2])
+				break
+			}
+
+			p.call(slot.LineFilterValues1R3, cU, p.cI)
+		case slot.LineFilterValues1R3: // LineFilterValues : string or LineFilterValues ∙
+
+			if p.follow(symbols.NT_LineFilterValues) {
+				p.rtn(symbols.NT_LineFilterValues, cU, p.cI)
+			} else {
+				p.parseError(slot.LineFilterValues1R0, p.cI, followSets[symbols.NT_LineFilterValues])
+			}
+		case slot.LogQuery0R0: // LogQuery : ∙LogSelector PipelinesMaybe
+
+			p.call(slot.LogQuery0R1, cU, p.cI)
+		case slot.LogQuery0R1: // LogQuery : LogSelector ∙PipelinesMaybe
+
+			if !p.testSelect(slot.LogQuery0R1) {
+				p.parseError(slot.LogQuery0R1, p.cI, first[slot.LogQuery0R1])
+				break
+			}
+
+			p.call(slot.LogQuery0R2, cU, p.cI)
+		case slot.LogQuery0R2: // LogQuery : LogSelector PipelinesMaybe ∙
+
+			if p.follow(symbols.NT_LogQuery) {
+				p.rtn(symbols.NT_LogQuery, cU, p.cI)
+			} else {
+				p.parseError(slot.LogQuery0R0, p.cI, followSets[symbols.NT_LogQuery])
+			}
+		case slot.LogSelector0R0: // LogSelector : ∙{ LogSelectorMembersMaybe }
+
+			p.bsrSet.Add(slot.LogSelector0R1, cU, p.cI, p.cI+1)
+			p.cI++
+			if !p.testSelect(slot.LogSelector0R1) {
+				p.parseError(slot.LogSelector0R1, p.cI, first[slot.LogSelector0R1])
+				break
+			}
+
+			p.call(slot.LogSelector0R2, cU, p.cI)
+		case slot.LogSelector0R2: // LogSelector : { LogSelectorMembersMaybe ∙}
+
+			if !p.testSelect(slot.LogSelector0R2) {
+				p.parseError(slot.LogSelector0R2, p.cI, first[slot.LogSelector0R2])
+				break
+			}
+
+			p.bsrSet.Add(slot.LogSelector0R3, cU, p.cI, p.cI+1)
+			p.cI++
+			if p.follow(symbols.NT_LogSelector) {
+				p.rtn(symbols.NT_LogSelector, cU, p.cI)
+			} else {
+				p.parseError(slot.LogSelector0R0, p.cI, followSets[symbols.NT_LogSelector])
+			}
+		case slot.LogSelectorMember0R0: // LogSelectorMember : ∙LabelKey LogSelectorOp string
+
+			p.call(slot.LogSelectorMember0R1, cU, p.cI)
+		case slot.LogSelectorMember0R1: // LogSelectorMember : LabelKey ∙LogSelectorOp string
+
+			if !p.testSelect(slot.LogSelectorMember0R1) {
+				p.parseError(slot.LogSelectorMember0R1, p.cI, first[slot.LogSelectorMember0R1])
+				break
+			}
+
+			p.call(slot.LogSelectorMember0R2, cU, p.cI)
+		case slot.LogSelectorMember0R2: // LogSelectorMember : LabelKey LogSelectorOp ∙string
+
+			if !p.testSelect(slot.LogSelectorMember0R2) {
+				p.parseError(slot.LogSelectorMember0R2, p.cI, first[slot.LogSelectorMember0R2])
+				break
+			}
+
+			p.bsrSet.Add(slot.LogSelectorMember0R3, cU, p.cI, p.cI+1)
+			p.cI++
+			if p.follow(symbols.NT_LogSelectorMember) {
+				p.rtn(symbols.NT_LogSelectorMember, cU, p.cI)
+			} else {
+				p.parseError(slot.LogSelectorMember0R0, p.cI, followSets[symbols.NT_LogSelectorMember])
+			}
+		case slot.LogSelectorMembers0R0: // LogSelectorMembers : ∙LogSelectorMember
+
+			p.call(slot.LogSelectorMembers0R1, cU, p.cI)
+		case slot.LogSelectorMembers0R1: // LogSelectorMembers : LogSelectorMember ∙
+
+			if p.follow(symbols.NT_LogSelectorMembers) {
+				p.rtn(symbols.NT_LogSelectorMembers, cU, p.cI)
+			} else {
+				p.parseError(slot.LogSelectorMembers0R0, p.cI, followSets[symbols.NT_LogSelectorMembers])
+			}
+		case slot.LogSelectorMembers1R0: // LogSelectorMembers : ∙LogSelectorMember , LogSelectorMembers
+
+			p.call(slot.LogSelectorMembers1R1, cU, p.cI)
+		case slot.LogSelectorMembers1R1: // LogSelectorMembers : LogSelectorMember ∙, LogSelectorMembers
+
+			if !p.testSelect(slot.LogSelectorMembers1R1) {
+				p.parseError(slot.LogSelectorMembers1R1, p.cI, first[slot.LogSelectorMembers1R1])
+				break
+			}
+
+			p.bsrSet.Add(slot.LogSelectorMembers1R2, cU, p.cI, p.cI+1)
+			p.cI++
+			if !p.testSelect(slot.LogSelectorMembers1R2) {
+				p.parseError(slot.LogSelectorMembers1R2, p.cI, first[slot.LogSelectorMembers1R2])
+				break
+			}
+
+			p.call(slot.LogSelectorMembers1R3, cU, p.cI)
+		case slot.LogSelectorMembers1R3: // LogSelectorMembers : LogSelectorMember , LogSelectorMembers ∙
+
+			if p.follow(symbols.NT_LogSelectorMembers) {
+				p.rtn(symbols.NT_LogSelectorMembers, cU, p.cI)
+			} else {
+				p.parseError(slot.LogSelectorMembers1R0, p.cI, followSets[symbols.NT_LogSelectorMembers])
+			}
+		case slot.LogSelectorMembersMaybe0R0: // LogSelectorMembersMaybe : ∙
+			p.bsrSet.AddEmpty(slot.LogSelectorMembersMaybe0R0, p.cI)
+
+			if p.follow(symbols.NT_LogSelectorMembersMaybe) {
+				p.rtn(symbols.NT_LogSelectorMembersMaybe, cU, p.cI)
+			} else {
+				p.parseError(slot.LogSelectorMembersMaybe0R0, p.cI, followSets[symbols.NT_LogSelectorMembersMaybe])
+			}
+		case slot.LogSelectorMembersMaybe1R0: // LogSelectorMembersMaybe : ∙LogSelectorMembers
+
+			p.call(slot.LogSelectorMembersMaybe1R1, cU, p.cI)
+		case slot.LogSelectorMembersMaybe1R1: // LogSelectorMembersMaybe : LogSelectorMembers ∙
+
+			if p.follow(symbols.NT_LogSelectorMembersMaybe) {
+				p.rtn(symbols.NT_LogSelectorMembersMaybe, cU, p.cI)
+			} else {
+				p.parseError(slot.LogSelectorMembersMaybe1R0, p.cI, followSets[symbols.NT_LogSelectorMembersMaybe])
+			}
+		case slot.LogSelectorOp0R0: // LogSelectorOp : ∙=
+
+			p.bsrSet.Add(slot.LogSelectorOp0R1, cU, p.cI, p.cI+1)
+			p.cI++
+			if p.follow(symbols.NT_LogSelectorOp) {
+				p.rtn(symbols.NT_LogSelectorOp, cU, p.cI)
+			} else {
+				p.parseError(slot.LogSelectorOp0R0, p.cI, followSets[symbols.NT_LogSelectorOp])
+			}
+		case slot.LogSelectorOp1R0: // LogSelectorOp : ∙!=
+
+			p.bsrSet.Add(slot.LogSelectorOp1R1, cU, p.cI, p.cI+1)
+			p.cI++
+			if p.follow(symbols.NT_LogSelectorOp) {
+				p.rtn(symbols.NT_LogSelectorOp, cU, p.cI)
+			} else {
+				p.parseError(slot.LogSelectorOp1R0, p.cI, followSets[symbols.NT_LogSelectorOp])
+			}
+		case slot.LogSelectorOp2R0: // LogSelectorOp : ∙=~
+
+			p.bsrSet.Add(slot.LogSelectorOp2R1, cU, p.cI, p.cI+1)
+			p.cI++
+			if p.follow(symbols.NT_LogSelectorOp) {
+				p.rtn(symbols.NT_LogSelectorOp, cU, p.cI)
+			} else {
+				p.parseError(slot.LogSelectorOp2R0, p.cI, followSets[symbols.NT_LogSelectorOp])
+			}
+		case slot.LogSelectorOp3R0: // LogSelectorOp : ∙!~
+
+			p.bsrSet.Add(slot.LogSelectorOp3R1, cU, p.cI, p.cI+1)
+			p.cI++
+			if p.follow(symbols.NT_LogSelectorOp) {
+				p.rtn(symbols.NT_LogSelectorOp, cU, p.cI)
+			} else {
+				p.parseError(slot.LogSelectorOp3R0, p.cI, followSets[symbols.NT_LogSelectorOp])
+			}
+		case slot.Matching0R0: // Matching : ∙MatchingOp ( LabelKeysMaybe )
+
+			p.call(slot.Matching0R1, cU, p.cI)
+		case slot.Matching0R1: // Matching : MatchingOp ∙( LabelKeysMaybe )
+
+			if !p.testSelect(slot.Matching0R1) {
+				p.parseError(slot.Matching0R1, p.cI, first[slot.Matching0R1])
+				break
+			}
+
+			p.bsrSet.Add(slot.Matching0R2, cU, p.cI, p.cI+1)
+			p.cI++
+			if !p.testSelect(slot.Matching0R2) {
+				p.parseError(slot.Matching0R2, p.cI, first[slot.Matching0R2])
+				break
+			}
+
+			p.call(slot.Matching0R3, cU, p.cI)
+		case slot.Matching0R3: // Matching : MatchingOp ( LabelKeysMaybe ∙)
+
+			if !p.testSelect(slot.Matching0R3) {
+				p.parseError(slot.Matching0R3, p.cI, first[slot.Matching0R3])
+				break
+			}
+
+			p.bsrSet.Add(slot.Matching0R4, cU, p.cI, p.cI+1)
+			p.cI++
+			if p.follow(symbols.NT_Matching) {
+				p.rtn(symbols.NT_Matching, cU, p.cI)
+			} else {
+				p.parseError(slot.Matching0R0, p.cI, followSets[symbols.NT_Matching])
+			}
+		case slot.MatchingMaybe0R0: // MatchingMaybe : ∙
+			p.bsrSet.AddEmpty(slot.MatchingMaybe0R0, p.cI)
+
+			if p.follow(symbols.NT_MatchingMaybe) {
+				p.rtn(symbols.NT_MatchingMaybe, cU, p.cI)
+			} else {
+				p.parseError(slot.MatchingMaybe0R0, p.cI, followSets[symbols.NT_MatchingMaybe])
+			}
+		case slot.MatchingMaybe1R0: // MatchingMaybe : ∙Matching GroupModifierMaybe
+
+			p.call(slot.MatchingMaybe1R1, cU, p.cI)
+		case slot.MatchingMaybe1R1: // MatchingMaybe : Matching ∙GroupModifierMaybe
+
+			if !p.testSelect(slot.MatchingMaybe1R1) {
+				p.parseError(slot.MatchingMaybe1R1, p.cI, first[slot.MatchingMaybe1R1])
+				break
+			}
+
+			p.call(slot.MatchingMaybe1R2, cU, p.cI)
+		case slot.MatchingMaybe1R2: // MatchingMaybe : Matching GroupModifierMaybe ∙
+
+			if p.follow(symbols.NT_MatchingMaybe) {
+				p.rtn(symbols.NT_MatchingMaybe, cU, p.cI)
+			} else {
+				p.parseError(slot.MatchingMaybe1R0, p.cI, followSets[symbols.NT_MatchingMaybe])
+			}
+		case slot.MatchingOp0R0: // MatchingOp : ∙on
+
+			p.bsrSet.Add(slot.MatchingOp0R1, cU, p.cI, p.cI+1)
+			p.cI++
+			if p.follow(symbols.NT_MatchingOp) {
+				p.rtn(symbols.NT_MatchingOp, cU, p.cI)
+			} else {
+				p.parseError(slot.MatchingOp0R0, p.cI, followSets[symbols.NT_MatchingOp])
+			}
+		case slot.MatchingOp1R0: // MatchingOp : ∙ignoring
+
+			p.bsrSet.Add(slot.MatchingOp1R1, cU, p.cI, p.cI+1)
+			p.cI++
+			if p.follow(symbols.NT_MatchingOp) {
+				p.rtn(symbols.NT_MatchingOp, cU, p.cI)
+			} else {
+				p.parseError(slot.MatchingOp1R0, p.cI, followSets[symbols.NT_MatchingOp])
+			}
+		case slot.MetricAdd0R0: // MetricAdd : ∙MetricMul
+
+			p.call(slot.MetricAdd0R1, cU, p.cI)
+		case slot.MetricAdd0R1: // MetricAdd : MetricMul ∙
+
+			if p.follow(symbols.NT_MetricAdd) {
+				p.rtn(symbols.NT_MetricAdd, cU, p.cI)
+			} else {
+				p.parseError(slot.MetricAdd0R0, p.cI, followSets[symbols.NT_MetricAdd])
+			}
+		case slot.MetricAdd1R0: // MetricAdd : ∙MetricAdd AddOp BinModifiers MetricMul
+
+			p.call(slot.MetricAdd1R1, cU, p.cI)
+		case slot.MetricAdd1R1: // MetricAdd : MetricAdd ∙AddOp BinModifiers MetricMul
+
+			if !p.testSelect(slot.MetricAdd1R1) {
+				p.parseError(slot.MetricAdd1R1, p.cI, first[slot.MetricAdd1R1])
+				break
+			}
+
+			p.call(slot.MetricAdd1R2, cU, p.cI)
+		case slot.MetricAdd1R2: // MetricAdd : MetricAdd AddOp ∙BinModifiers MetricMul
+
+			if !p.testSelect(slot.MetricAdd1R2) {
+				p.parseError(slot.MetricAdd1R2, p.cI, first[slot.MetricAdd1R2])
+				break
+			}
+
+			p.call(slot.MetricAdd1R3, cU, p.cI)
+		case slot.MetricAdd1R3: // MetricAdd : MetricAdd AddOp BinModifiers ∙MetricMul
+
+			if !p.testSelect(slot.MetricAdd1R3) {
+				p.parseError(slot.MetricAdd1R3, p.cI, first[slot.MetricAdd1R3])
+				break
+			}
+
+			p.call(slot.MetricAdd1R4, cU, p.cI)
+		case slot.MetricAdd1R4: // MetricAdd : MetricAdd AddOp BinModifiers MetricMul ∙
+
+			if p.follow(symbols.NT_MetricAdd) {
+				p.rtn(symbols.NT_MetricAdd, cU, p.cI)
+			} else {
+				p.parseError(slot.MetricAdd1R0, p.cI, followSets[symbols.NT_MetricAdd])
+			}
+		case slot.MetricAnd0R0: // MetricAnd : ∙MetricCmp
+
+			p.call(slot.MetricAnd0R1, cU, p.cI)
+		case slot.MetricAnd0R1: // MetricAnd : MetricCmp ∙
+
+			if p.follow(symbols.NT_MetricAnd) {
+				p.rtn(symbols.NT_MetricAnd, cU, p.cI)
+			} else {
+				p.parseError(slot.MetricAnd0R0, p.cI, followSets[symbols.NT_MetricAnd])
+			}
+		case slot.MetricAnd1R0: // MetricAnd : ∙MetricAnd AndOp BinModifiers MetricCmp
+
+			p.call(slot.MetricAnd1R1, cU, p.cI)
+		case slot.MetricAnd1R1: // MetricAnd : MetricAnd ∙AndOp BinModifiers MetricCmp
+
+			if !p.testSelect(slot.MetricAnd1R1) {
+				p.parseError(slot.MetricAnd1R1, p.cI, first[slot.MetricAnd1R1])
+				break
+			}
+
+			p.call(slot.MetricAnd1R2, cU, p.cI)
+		case slot.MetricAnd1R2: // MetricAnd : MetricAnd AndOp ∙BinModifiers MetricCmp
+
+			if !p.testSelect(slot.MetricAnd1R2) {
+				p.parseError(slot.MetricAnd1R2, p.cI, first[slot.MetricAnd1R2])
+				break
+			}
+
+			p.call(slot.MetricAnd1R3, cU, p.cI)
+		case slot.MetricAnd1R3: // MetricAnd : MetricAnd AndOp BinModifiers ∙MetricCmp
+
+			if !p.testSelect(slot.MetricAnd1R3) {
+				p.parseError(slot.MetricAnd1R3, p.cI, first[slot.MetricAnd1R3])
+				break
+			}
+
+			p.call(slot.MetricAnd1R4, cU, p.cI)
+		case slot.MetricAnd1R4: // MetricAnd : MetricAnd AndOp BinModifiers MetricCmp ∙
+
+			if p.follow(symbols.NT_MetricAnd) {
+				p.rtn(symbols.NT_MetricAnd, cU, p.cI)
+			} else {
+				p.parseError(slot.MetricAnd1R0, p.cI, followSets[symbols.NT_MetricAnd])
+			}
+		case slot.MetricCmp0R0: // MetricCmp : ∙MetricAdd
+
+			p.call(slot.MetricCmp0R1, cU, p.cI)
+		case slot.MetricCmp0R1: // MetricCmp : MetricAdd ∙
+
+			if p.follow(symbols.NT_MetricCmp) {
+				p.rtn(symbols.NT_MetricCmp, cU, p.cI)
+			} else {
+				p.parseError(slot.MetricCmp0R0, p.cI, followSets[symbols.NT_MetricCmp])
+			}
+		case slot.MetricCmp1R0: // MetricCmp : ∙MetricCmp CmpOp BinModifiers MetricAdd
+
+			p.call(slot.MetricCmp1R1, cU, p.cI)
+		case slot.MetricCmp1R1: // MetricCmp : MetricCmp ∙CmpOp BinModifiers MetricAdd
+
+			if !p.testSelect(slot.MetricCmp1R1) {
+				p.parseError(slot.MetricCmp1R1, p.cI, first[slot.MetricCmp1R1])
+				break
+			}
+
+			p.call(slot.MetricCmp1R2, cU, p.cI)
+		case slot.MetricCmp1R2: // MetricCmp : MetricCmp CmpOp ∙BinModifiers MetricAdd
+
+			if !p.testSelect(slot.MetricCmp1R2) {
+				p.parseError(slot.MetricCmp1R2, p.cI, first[slot.MetricCmp1R2])
+				break
+			}
+
+			p.call(slot.MetricCmp1R3, cU, p.cI)
+		case slot.MetricCmp1R3: // MetricCmp : MetricCmp CmpOp BinModifiers ∙MetricAdd
+
+			if !p.testSelect(slot.MetricCmp1R3) {
+				p.parseError(slot.MetricCmp1R3, p.cI, first[slot.MetricCmp1R3])
+				break
+			}
+
+			p.call(slot.MetricCmp1R4, cU, p.cI)
+		case slot.MetricCmp1R4: // MetricCmp : MetricCmp CmpOp BinModifiers MetricAdd ∙
+
+			if p.follow(symbols.NT_MetricCmp) {
+				p.rtn(symbols.NT_MetricCmp, cU, p.cI)
+			} else {
+				p.parseError(slot.MetricCmp1R0, p.cI, followSets[symbols.NT_MetricCmp])
+			}
+		case slot.MetricMul0R0: // MetricMul : ∙MetricUnary
+
+			p.call(slot.MetricMul0R1, cU, p.cI)
+		case slot.MetricMul0R1: // MetricMul : MetricUnary ∙
+
+			if p.follow(symbols.NT_MetricMul) {
+				p.rtn(symbols.NT_MetricMul, cU, p.cI)
+			} else {
+				p.parseError(slot.MetricMul0R0, p.cI, followSets[symbols.NT_MetricMul])
+			}
+		case slot.MetricMul1R0: // MetricMul : ∙MetricMul MulOp BinModifiers MetricUnary
+
+			p.call(slot.MetricMul1R1, cU, p.cI)
+		case slot.MetricMul1R1: // MetricMul : MetricMul ∙MulOp BinModifiers MetricUnary
+
+			if !p.testSelect(slot.MetricMul1R1) {
+				p.parseError(slot.MetricMul1R1, p.cI, first[slot.MetricMul1R1])
+				break
+			}
+
+			p.call(slot.MetricMul1R2, cU, p.cI)
+		case slot.MetricMul1R2: // MetricMul : MetricMul MulOp ∙BinModifiers MetricUnary
+
+			if !p.testSelect(slot.MetricMul1R2) {
+				p.parseError(slot.MetricMul1R2, p.cI, first[slot.MetricMul1R2])
+				break
+			}
+
+			p.call(slot.MetricMul1R3, cU, p.cI)
+		case slot.MetricMul1R3: // MetricMul : MetricMul MulOp BinModifiers ∙MetricUnary
+
+			if !p.testSelect(slot.MetricMul1R3) {
+				p.parseError(slot.MetricMul1R3, p.cI, first[slot.MetricMul1R3])
+				break
+			}
+
+			p.call(slot.MetricMul1R4, cU, p.cI)
+		case slot.MetricMul1R4: // MetricMul : MetricMul MulOp BinModifiers MetricUnary ∙
+
+			if p.follow(symbols.NT_MetricMul) {
+				p.rtn(symbols.NT_MetricMul, cU, p.cI)
+			} else {
+				p.parseError(slot.MetricMul1R0, p.cI, followSets[symbols.NT_MetricMul])
+			}
+		case slot.MetricOr0R0: // MetricOr : ∙MetricAnd
+
+			p.call(slot.MetricOr0R1, cU, p.cI)
+		case slot.MetricOr0R1: // MetricOr : MetricAnd ∙
+
+			if p.follow(symbols.NT_MetricOr) {
+				p.rtn(symbols.NT_MetricOr, cU, p.cI)
+			} else {
+				p.parseError(slot.MetricOr0R0, p.cI, followSets[symbols.NT_MetricOr])
+			}
+		case slot.MetricOr1R0: // MetricOr : ∙MetricOr OrOp BinModifiers MetricAnd
+
+			p.call(slot.MetricOr1R1, cU, p.cI)
+		case slot.MetricOr1R1: // MetricOr : MetricOr ∙OrOp BinModifiers MetricAnd
+
+			if !p.testSelect(slot.MetricOr1R1) {
+				p.parseError(slot.MetricOr1R1, p.cI, first[slot.MetricOr1R1])
+				break
+			}
+
+			p.call(slot.MetricOr1R2, cU, p.cI)
+		case slot.MetricOr1R2: // MetricOr : MetricOr OrOp ∙BinModifiers MetricAnd
+
+			if !p.testSelect(slot.MetricOr1R2) {
+				p.parseError(slot.MetricOr1R2, p.cI, first[slot.MetricOr1R2])
+				break
+			}
+
+			p.call(slot.MetricOr1R3, cU, p.cI)
+		case slot.MetricOr1R3: // MetricOr : MetricOr OrOp BinModifiers ∙MetricAnd
+
+			if !p.testSelect(slot.MetricOr1R3) {
+				p.parseError(slot.MetricOr1R3, p.cI, first[slot.MetricOr1R3])
+				break
+			}
+
+			p.call(slot.MetricOr1R4, cU, p.cI)
+		case slot.MetricOr1R4: // MetricOr : MetricOr OrOp BinModifiers MetricAnd ∙
+
+			if p.follow(symbols.NT_MetricOr) {
+				p.rtn(symbols.NT_MetricOr, cU, p.cI)
+			} else {
+				p.parseError(slot.MetricOr1R0, p.cI, followSets[symbols.NT_MetricOr])
+			}
+		case slot.MetricPow0R0: // MetricPow : ∙MetricTerm
+
+			p.call(slot.MetricPow0R1, cU, p.cI)
+		case slot.MetricPow0R1: // MetricPow : MetricTerm ∙
+
+			if p.follow(symbols.NT_MetricPow) {
+				p.rtn(symbols.NT_MetricPow, cU, p.cI)
+			} else {
+				p.parseError(slot.MetricPow0R0, p.cI, followSets[symbols.NT_MetricPow])
+			}
+		case slot.MetricPow1R0: // MetricPow : ∙MetricTerm PowOp BinModifiers MetricUnary
+
+			p.call(slot.MetricPow1R1, cU, p.cI)
+		case slot.MetricPow1R1: // MetricPow : MetricTerm ∙PowOp BinModifiers MetricUnary
+
+			if !p.testSelect(slot.MetricPow1R1) {
+				p.parseError(slot.MetricPow1R1, p.cI, first[slot.MetricPow1R1])
+				break
+			}
+
+			p.call(slot.MetricPow1R2, cU, p.cI)
+		case slot.MetricPow1R2: // MetricPow : MetricTerm PowOp ∙BinModifiers MetricUnary
+
+			if !p.testSelect(slot.MetricPow1R2) {
+				p.parseError(slot.MetricPow1R2, p.cI, first[slot.MetricPow1R2])
+				break
+			}
+
+			p.call(slot.MetricPow1R3, cU, p.cI)
+		case slot.MetricPow1R3: // MetricPow : MetricTerm PowOp BinModifiers ∙MetricUnary
+
+			if !p.testSelect(slot.MetricPow1R3) {
+				p.parseError(slot.MetricPow1R3, p.cI, first[slot.MetricPow1R3])
+				break
+			}
+
+			p.call(slot.MetricPow1R4, cU, p.cI)
+		case slot.MetricPow1R4: // MetricPow : MetricTerm PowOp BinModifiers MetricUnary ∙
+
+			if p.follow(symbols.NT_MetricPow) {
+				p.rtn(symbols.NT_MetricPow, cU, p.cI)
+			} else {
+				p.parseError(slot.MetricPow1R0, p.cI, followSets[symbols.NT_MetricPow])
+			}
+		case slot.MetricQuery0R0: // MetricQuery : ∙MetricOr
+
+			p.call(slot.MetricQuery0R1, cU, p.cI)
+		case slot.MetricQuery0R1: // MetricQuery : MetricOr ∙
+
+			if p.follow(symbols.NT_MetricQuery) {
+				p.rtn(symbols.NT_MetricQuery, cU, p.cI)
+			} else {
+				p.parseError(slot.MetricQuery0R0, p.cI, followSets[symbols.NT_MetricQuery])
+			}
+		case slot.MetricTerm0R0: // MetricTerm : ∙RangeAggregation
+
+			p.call(slot.MetricTerm0R1, cU, p.cI)
+		case slot.MetricTerm0R1: // MetricTerm : RangeAggregation ∙
+
+			if p.follow(symbols.NT_MetricTerm) {
+				p.rtn(symbols.NT_MetricTerm, cU, p.cI)
+			} else {
+				p.parseError(slot.MetricTerm0R0, p.cI, followSets[symbols.NT_MetricTerm])
+			}
+		case slot.MetricTerm1R0: // MetricTerm : ∙VectorAggregation
+
+			p.call(slot.MetricTerm1R1, cU, p.cI)
+		case slot.MetricTerm1R1: // MetricTerm : VectorAggregation ∙
+
+			if p.follow(symbols.NT_MetricTerm) {
+				p.rtn(symbols.NT_MetricTerm, cU, p.cI)
+			} else {
+				p.parseError(slot.MetricTerm1R0, p.cI, followSets[symbols.NT_MetricTerm])
+			}
+		case slot.MetricTerm2R0: // MetricTerm : ∙num
+
+			p.bsrSet.Add(slot.MetricTerm2R1, cU, p.cI, p.cI+1)
+			p.cI++
+			if p.follow(symbols.NT_MetricTerm) {
+				p.rtn(symbols.NT_MetricTerm, cU, p.cI)
+			} else {
+				p.parseError(slot.MetricTerm2R0, p.cI, followSets[symbols.NT_MetricTerm])
+			}
+		case slot.MetricTerm3R0: // MetricTerm : ∙( MetricQuery )
+
+			p.bsrSet.Add(slot.MetricTerm3R1, cU, p.cI, p.cI+1)
+			p.cI++
+			if !p.testSelect(slot.MetricTerm3R1) {
+				p.parseError(slot.MetricTerm3R1, p.cI, first[slot.MetricTerm3R1])
+				break
+			}
+
+			p.call(slot.MetricTerm3R2, cU, p.cI)
+		case slot.MetricTerm3R2: // MetricTerm : ( MetricQuery ∙)
+
+			if !p.testSelect(slot.MetricTerm3R2) {
+				p.parseError(slot.MetricTerm3R2, p.cI, first[slot.MetricTerm3R2])
+				break
+			}
+
+			p.bsrSet.Add(slot.MetricTerm3R3, cU, p.cI, p.cI+1)
+			p.cI++
+			if p.follow(symbols.NT_MetricTerm) {
+				p.rtn(symbols.NT_MetricTerm, cU, p.cI)
+			} else {
+				p.parseError(slot.MetricTerm3R0, p.cI, followSets[symbols.NT_MetricTerm])
+			}
+		case slot.MetricUnary0R0: // MetricUnary : ∙MetricPow
+
+			p.call(slot.MetricUnary0R1, cU, p.cI)
+		case slot.MetricUnary0R1: // MetricUnary : MetricPow ∙
+
+			if p.follow(symbols.NT_MetricUnary) {
+				p.rtn(symbols.NT_MetricUnary, cU, p.cI)
+			} else {
+				p.parseError(slot.MetricUnary0R0, p.cI, followSets[symbols.NT_MetricUnary])
+			}
+		case slot.MetricUnary1R0: // MetricUnary : ∙- MetricUnary
+
+			p.bsrSet.Add(slot.MetricUnary1R1, cU, p.cI, p.cI+1)
+			p.cI++
+			if !p.testSelect(slot.MetricUnary1R1) {
+				p.parseError(slot.MetricUnary1R1, p.cI, first[slot.MetricUnary1R1])
+				break
+			}
+
+			p.call(slot.MetricUnary1R2, cU, p.cI)
+		case slot.MetricUnary1R2: // MetricUnary : - MetricUnary ∙
+
+			if p.follow(symbols.NT_MetricUnary) {
+				p.rtn(symbols.NT_MetricUnary, cU, p.cI)
+			} else {
+				p.parseError(slot.MetricUnary1R0, p.cI, followSets[symbols.NT_MetricUnary])
+			}
+		case slot.MulOp0R0: // MulOp : ∙*
+
+			p.bsrSet.Add(slot.MulOp0R1, cU, p.cI, p.cI+1)
+			p.cI++
+			if p.follow(symbols.NT_MulOp) {
+				p.rtn(symbols.NT_MulOp, cU, p.cI)
+			} else {
+				p.parseError(slot.MulOp0R0, p.cI, followSets[symbols.NT_MulOp])
+			}
+		case slot.MulOp1R0: // MulOp : ∙/
+
+			p.bsrSet.Add(slot.MulOp1R1, cU, p.cI, p.cI+1)
+			p.cI++
+			if p.follow(symbols.NT_MulOp) {
+				p.rtn(symbols.NT_MulOp, cU, p.cI)
+			} else {
+				p.parseError(slot.MulOp1R0, p.cI, followSets[symbols.NT_MulOp])
+			}
+		case slot.MulOp2R0: // MulOp : ∙%
+
+			p.bsrSet.Add(slot.MulOp2R1, cU, p.cI, p.cI+1)
+			p.cI++
+			if p.follow(symbols.NT_MulOp) {
+				p.rtn(symbols.NT_MulOp, cU, p.cI)
+			} else {
+				p.parseError(slot.MulOp2R0, p.cI, followSets[symbols.NT_MulOp])
+			}
+		case slot.OrOp0R0: // OrOp : ∙or
+
+			p.bsrSet.Add(slot.OrOp0R1, cU, p.cI, p.cI+1)
+			p.cI++
+			if p.follow(symbols.NT_OrOp) {
+				p.rtn(symbols.NT_OrOp, cU, p.cI)
+			} else {
+				p.parseError(slot.OrOp0R0, p.cI, followSets[symbols.NT_OrOp])
+			}
+		case slot.ParserStage0R0: // ParserStage : ∙| json
+
+			p.bsrSet.Add(slot.ParserStage0R1, cU, p.cI, p.cI+1)
+			p.cI++
+			if !p.testSelect(slot.ParserStage0R1) {
+				p.parseError(slot.ParserStage0R1, p.cI, first[slot.ParserStage0R1])
+				break
+			}
+
+			p.bsrSet.Add(slot.ParserStage0R2, cU, p.cI, p.cI+1)
+			p.cI++
+			if p.follow(symbols.NT_ParserStage) {
+				p.rtn(symbols.NT_ParserStage, cU, p.cI)
+			} else {
+				p.parseError(slot.ParserStage0R0, p.cI, followSets[symbols.NT_ParserStage])
+			}
+		case slot.ParserStage1R0: // ParserStage : ∙| logfmt
+
+			p.bsrSet.Add(slot.ParserStage1R1, cU, p.cI, p.cI+1)
+			p.cI++
+			if !p.testSelect(slot.ParserStage1R1) {
+				p.parseError(slot.ParserStage1R1, p.cI, first[slot.ParserStage1R1])
+				break
+			}
+
+			p.bsrSet.Add(slot.ParserStage1R2, cU, p.cI, p.cI+1)
+			p.cI++
+			if p.follow(symbols.NT_ParserStage) {
+				p.rtn(symbols.NT_ParserStage, cU, p.cI)
+			} else {
+				p.parseError(slot.ParserStage1R0, p.cI, followSets[symbols.NT_ParserStage])
+			}
+		case slot.ParserStage2R0: // ParserStage : ∙| regexp string
+
+			p.bsrSet.Add(slot.ParserStage2R1, cU, p.cI, p.cI+1)
+			p.cI++
+			if !p.testSelect(slot.ParserStage2R1) {
+				p.parseError(slot.ParserStage2R1, p.cI, first[slot.ParserStage2R1])
+				break
+			}
+
+			p.bsrSet.Add(slot.ParserStage2R2, cU, p.cI, p.cI+1)
+			p.cI++
+			if !p.testSelect(slot.ParserStage2R2) {
+				p.parseError(slot.ParserStage2R2, p.cI, first[slot.ParserStage2R2])
+				break
+			}
+
+			p.bsrSet.Add(slot.ParserStage2R3, cU, p.cI, p.cI+1)
+			p.cI++
+			if p.follow(symbols.NT_ParserStage) {
+				p.rtn(symbols.NT_ParserStage, cU, p.cI)
+			} else {
+				p.parseError(slot.ParserStage2R0, p.cI, followSets[symbols.NT_ParserStage])
+			}
+		case slot.ParserStage3R0: // ParserStage : ∙| pattern string
+
+			p.bsrSet.Add(slot.ParserStage3R1, cU, p.cI, p.cI+1)
+			p.cI++
+			if !p.testSelect(slot.ParserStage3R1) {
+				p.parseError(slot.ParserStage3R1, p.cI, first[slot.ParserStage3R1])
+				break
+			}
+
+			p.bsrSet.Add(slot.ParserStage3R2, cU, p.cI, p.cI+1)
+			p.cI++
+			if !p.testSelect(slot.ParserStage3R2) {
+				p.parseError(slot.ParserStage3R2, p.cI, first[slot.ParserStage3R2])
+				break
+			}
+
+			p.bsrSet.Add(slot.ParserStage3R3, cU, p.cI, p.cI+1)
+			p.cI++
+			if p.follow(symbols.NT_ParserStage) {
+				p.rtn(symbols.NT_ParserStage, cU, p.cI)
+			} else {
+				p.parseError(slot.ParserStage3R0, p.cI, followSets[symbols.NT_ParserStage])
+			}
+		case slot.Pipeline0R0: // Pipeline : ∙LineFilter
+
+			p.call(slot.Pipeline0R1, cU, p.cI)
+		case slot.Pipeline0R1: // Pipeline : LineFilter ∙
+
+			if p.follow(symbols.NT_Pipeline) {
+				p.rtn(symbols.NT_Pipeline, cU, p.cI)
+			} else {
+				p.parseError(slot.Pipeline0R0, p.cI, followSets[symbols.NT_Pipeline])
+			}
+		case slot.Pipeline1R0: // Pipeline : ∙FilterStage
+
+			p.call(slot.Pipeline1R1, cU, p.cI)
+		case slot.Pipeline1R1: // Pipeline : FilterStage ∙
+
+			if p.follow(symbols.NT_Pipeline) {
+				p.rtn(symbols.NT_Pipeline, cU, p.cI)
+			} else {
+				p.parseError(slot.Pipeline1R0, p.cI, followSets[symbols.NT_Pipeline])
+			}
+		case slot.Pipeline2R0: // Pipeline : ∙ParserStage
+
+			p.call(slot.Pipeline2R1, cU, p.cI)
+		case slot.Pipeline2R1: // Pipeline : ParserStage ∙
+
+			if p.follow(symbols.NT_Pipeline) {
+				p.rtn(symbols.NT_Pipeline, cU, p.cI)
+			} else {
+				p.parseError(slot.Pipeline2R0, p.cI, followSets[symbols.NT_Pipeline])
+			}
+		case slot.Pipeline3R0: // Pipeline : ∙FormatStage
+
+			p.call(slot.Pipeline3R1, cU, p.cI)
+		case slot.Pipeline3R1: // Pipeline : FormatStage ∙
+
+			if p.follow(symbols.NT_Pipeline) {
+				p.rtn(symbols.NT_Pipeline, cU, p.cI)
+			} else {
+				p.parseError(slot.Pipeline3R0, p.cI, followSets[symbols.NT_Pipeline])
+			}
+		case slot.Pipelines0R0: // Pipelines : ∙Pipeline
+
+			p.call(slot.Pipelines0R1, cU, p.cI)
+		case slot.Pipelines0R1: // Pipelines : Pipeline ∙
+
+			if p.follow(symbols.NT_Pipelines) {
+				p.rtn(symbols.NT_Pipelines, cU, p.cI)
+			} else {
+				p.parseError(slot.Pipelines0R0, p.cI, followSets[symbols.NT_Pipelines])
+			}
+		case slot.Pipelines1R0: // Pipelines : ∙Pipeline Pipelines
+
+			p.call(slot.Pipelines1R1, cU, p.cI)
+		case slot.Pipelines1R1: // Pipelines : Pipeline ∙Pipelines
+
+			if !p.testSelect(slot.Pipelines1R1) {
+				p.parseError(slot.Pipelines1R1, p.cI, first[slot.Pipelines1R1])
+				break
+			}
+
+			p.call(slot.Pipelines1R2, cU, p.cI)
+		case slot.Pipelines1R2: // Pipelines : Pipeline Pipelines ∙
+
+			if p.follow(symbols.NT_Pipelines) {
+				p.rtn(symbols.NT_Pipelines, cU, p.cI)
+			} else {
+				p.parseError(slot.Pipelines1R0, p.cI, followSets[symbols.NT_Pipelines])
+			}
+		case slot.PipelinesMaybe0R0: // PipelinesMaybe : ∙
+			p.bsrSet.AddEmpty(slot.PipelinesMaybe0R0, p.cI)
+
+			if p.follow(symbols.NT_PipelinesMaybe) {
+				p.rtn(symbols.NT_PipelinesMaybe, cU, p.cI)
+			} else {
+				p.parseError(slot.PipelinesMaybe0R0, p.cI, followSets[symbols.NT_PipelinesMaybe])
+			}
+		case slot.PipelinesMaybe1R0: // PipelinesMaybe : ∙Pipelines
+
+			p.call(slot.PipelinesMaybe1R1, cU, p.cI)
+		case slot.PipelinesMaybe1R1: // PipelinesMaybe : Pipelines ∙
+
+			if p.follow(symbols.NT_PipelinesMaybe) {
+				p.rtn(symbols.NT_PipelinesMaybe, cU, p.cI)
+			} else {
+				p.parseError(slot.PipelinesMaybe1R0, p.cI, followSets[symbols.NT_PipelinesMaybe])
+			}
+		case slot.PowOp0R0: // PowOp : ∙^
+
+			p.bsrSet.Add(slot.PowOp0R1, cU, p.cI, p.cI+1)
+			p.cI++
+			if p.follow(symbols.NT_PowOp) {
+				p.rtn(symbols.NT_PowOp, cU, p.cI)
+			} else {
+				p.parseError(slot.PowOp0R0, p.cI, followSets[symbols.NT_PowOp])
+			}
+		case slot.Query0R0: // Query : ∙LogQuery
+
+			p.call(slot.Query0R1, cU, p.cI)
+		case slot.Query0R1: // Query : LogQuery ∙
+
+			if p.follow(symbols.NT_Query) {
+				p.rtn(symbols.NT_Query, cU, p.cI)
+			} else {
+				p.parseError(slot.Query0R0, p.cI, followSets[symbols.NT_Query])
+			}
+		case slot.Query1R0: // Query : ∙MetricQuery
+
+			p.call(slot.Query1R1, cU, p.cI)
+		case slot.Query1R1: // Query : MetricQuery ∙
+
+			if p.follow(symbols.NT_Query) {
+				p.rtn(symbols.NT_Query, cU, p.cI)
+			} else {
+				p.parseError(slot.Query1R0, p.cI, followSets[symbols.NT_Query])
+			}
+		case slot.RangeAggregation0R0: // RangeAggregation : ∙var_name ( RangeParamMaybe LogQuery UnwrapMaybe duration )
+
+			p.bsrSet.Add(slot.RangeAggregation0R1, cU, p.cI, p.cI+1)
+			p.cI++
+			if !p.testSelect(slot.RangeAggregation0R1) {
+				p.parseError(slot.RangeAggregation0R1, p.cI, first[slot.RangeAggregation0R1])
+				break
+			}
+
+			p.bsrSet.Add(slot.RangeAggregation0R2, cU, p.cI, p.cI+1)
+			p.cI++
+			if !p.testSelect(slot.RangeAggregation0R2) {
+				p.parseError(slot.RangeAggregation0R2, p.cI, first[slot.RangeAggregation0R2])
+				break
+			}
+
+			p.call(slot.RangeAggregation0R3, cU, p.cI)
+		case slot.RangeAggregation0R3: // RangeAggregation : var_name ( RangeParamMaybe ∙LogQuery UnwrapMaybe duration )
+
+			if !p.testSelect(slot.RangeAggregation0R3) {
+				p.parseError(slot.RangeAggregation0R3, p.cI, first[slot.RangeAggregation0R3])
+				break
+			}
+
+			p.call(slot.RangeAggregation0R4, cU, p.cI)
+		case slot.RangeAggregation0R4: // RangeAggregation : var_name ( RangeParamMaybe LogQuery ∙UnwrapMaybe duration )
+
+			if !p.testSelect(slot.RangeAggregation0R4) {
+				p.parseError(slot.RangeAggregation0R4, p.cI, first[slot.RangeAggregation0R4])
+				break
+			}
+
+			p.call(slot.RangeAggregation0R5, cU, p.cI)
+		case slot.RangeAggregation0R5: // RangeAggregation : var_name ( RangeParamMaybe LogQuery UnwrapMaybe ∙duration )
+
+			if !p.testSelect(slot.RangeAggregation0R5) {
+				p.parseError(slot.RangeAggregation0R5, p.cI, first[slot.RangeAggregation0R5])
+				break
+			}
+
+			p.bsrSet.Add(slot.RangeAggregation0R6, cU, p.cI, p.cI+1)
+			p.cI++
+			if !p.testSelect(slot.RangeAggregation0R6) {
+				p.parseError(slot.RangeAggregation0R6, p.cI, first[slot.RangeAggregation0R6])
+				break
+			}
+
+			p.bsrSet.Add(slot.RangeAggregation0R7, cU, p.cI, p.cI+1)
+			p.cI++
+			if p.follow(symbols.NT_RangeAggregation) {
+				p.rtn(symbols.NT_RangeAggregation, cU, p.cI)
+			} else {
+				p.parseError(slot.RangeAggregation0R0, p.cI, followSets[symbols.NT_RangeAggregation])
+			}
+		case slot.RangeParamMaybe0R0: // RangeParamMaybe : ∙
+			p.bsrSet.AddEmpty(slot.RangeParamMaybe0R0, p.cI)
+
+			if p.follow(symbols.NT_RangeParamMaybe) {
+				p.rtn(symbols.NT_RangeParamMaybe, cU, p.cI)
+			} else {
+				p.parseError(slot.RangeParamMaybe0R0, p.cI, followSets[symbols.NT_RangeParamMaybe])
+			}
+		case slot.RangeParamMaybe1R0: // RangeParamMaybe : ∙num ,
+
+			p.bsrSet.Add(slot.RangeParamMaybe1R1, cU, p.cI, p.cI+1)
+			p.cI++
+			if !p.testSelect(slot.RangeParamMaybe1R1) {
+				p.parseError(slot.RangeParamMaybe1R1, p.cI, first[slot.RangeParamMaybe1R1])
+				break
+			}
+
+			p.bsrSet.Add(slot.RangeParamMaybe1R2, cU, p.cI, p.cI+1)
+			p.cI++
+			if p.follow(symbols.NT_RangeParamMaybe) {
+				p.rtn(symbols.NT_RangeParamMaybe, cU, p.cI)
+			} else {
+				p.parseError(slot.RangeParamMaybe1R0, p.cI, followSets[symbols.NT_RangeParamMaybe])
+			}
+		case slot.UnwrapField0R0: // UnwrapField : ∙var_name
+
+			p.bsrSet.Add(slot.UnwrapField0R1, cU, p.cI, p.cI+1)
+			p.cI++
+			if p.follow(symbols.NT_UnwrapField) {
+				p.rtn(symbols.NT_UnwrapField, cU, p.cI)
+			} else {
+				p.parseError(slot.UnwrapField0R0, p.cI, followSets[symbols.NT_UnwrapField])
+			}
+		case slot.UnwrapField1R0: // UnwrapField : ∙string
+
+			p.bsrSet.Add(slot.UnwrapField1R1, cU, p.cI, p.cI+1)
+			p.cI++
+			if p.follow(symbols.NT_UnwrapField) {
+				p.rtn(symbols.NT_UnwrapField, cU, p.cI)
+			} else {
+				p.parseError(slot.UnwrapField1R0, p.cI, followSets[symbols.NT_UnwrapField])
+			}
+		case slot.UnwrapMaybe0R0: // UnwrapMaybe : ∙
+			p.bsrSet.AddEmpty(slot.UnwrapMaybe0R0, p.cI)
+
+			if p.follow(symbols.NT_UnwrapMaybe) {
+				p.rtn(symbols.NT_UnwrapMaybe, cU, p.cI)
+			} else {
+				p.parseError(slot.UnwrapMaybe0R0, p.cI, followSets[symbols.NT_UnwrapMaybe])
+			}
+		case slot.UnwrapMaybe1R0: // UnwrapMaybe : ∙| unwrap UnwrapField
+
+			p.bsrSet.Add(slot.UnwrapMaybe1R1, cU, p.cI, p.cI+1)
+			p.cI++
+			if !p.testSelect(slot.UnwrapMaybe1R1) {
+				p.parseError(slot.UnwrapMaybe1R1, p.cI, first[slot.UnwrapMaybe1R1])
+				break
+			}
+
+			p.bsrSet.Add(slot.UnwrapMaybe1R2, cU, p.cI, p.cI+1)
+			p.cI++
+			if !p.testSelect(slot.UnwrapMaybe1R2) {
+				p.parseError(slot.UnwrapMaybe1R2, p.cI, first[slot.UnwrapMaybe1R2])
+				break
+			}
+
+			p.call(slot.UnwrapMaybe1R3, cU, p.cI)
+		case slot.UnwrapMaybe1R3: // UnwrapMaybe : | unwrap UnwrapField ∙
+
+			if p.follow(symbols.NT_UnwrapMaybe) {
+				p.rtn(symbols.NT_UnwrapMaybe, cU, p.cI)
+			} else {
+				p.parseError(slot.UnwrapMaybe1R0, p.cI, followSets[symbols.NT_UnwrapMaybe])
+			}
+		case slot.VectorAggregation0R0: // VectorAggregation : ∙var_name GroupingMaybe ( VectorParamMaybe MetricQuery ) GroupingMaybe
+
+			p.bsrSet.Add(slot.VectorAggregation0R1, cU, p.cI, p.cI+1)
+			p.cI++
+			if !p.testSelect(slot.VectorAggregation0R1) {
+				p.parseError(slot.VectorAggregation0R1, p.cI, first[slot.VectorAggregation0R1])
+				break
+			}
+
+			p.call(slot.VectorAggregation0R2, cU, p.cI)
+		case slot.VectorAggregation0R2: // VectorAggregation : var_name GroupingMaybe ∙( VectorParamMaybe MetricQuery ) GroupingMaybe
+
+			if !p.testSelect(slot.VectorAggregation0R2) {
+				p.parseError(slot.VectorAggregation0R2, p.cI, first[slot.VectorAggregation0R2])
+				break
+			}
+
+			p.bsrSet.Add(slot.VectorAggregation0R3, cU, p.cI, p.cI+1)
+			p.cI++
+			if !p.testSelect(slot.VectorAggregation0R3) {
+				p.parseError(slot.VectorAggregation0R3, p.cI, first[slot.VectorAggregation0R3])
+				break
+			}
+
+			p.call(slot.VectorAggregation0R4, cU, p.cI)
+		case slot.VectorAggregation0R4: // VectorAggregation : var_name GroupingMaybe ( VectorParamMaybe ∙MetricQuery ) GroupingMaybe
+
+			if !p.testSelect(slot.VectorAggregation0R4) {
+				p.parseError(slot.VectorAggregation0R4, p.cI, first[slot.VectorAggregation0R4])
+				break
+			}
+
+			p.call(slot.VectorAggregation0R5, cU, p.cI)
+		case slot.VectorAggregation0R5: // VectorAggregation : var_name GroupingMaybe ( VectorParamMaybe MetricQuery ∙) GroupingMaybe
+
+			if !p.testSelect(slot.VectorAggregation0R5) {
+				p.parseError(slot.VectorAggregation0R5, p.cI, first[slot.VectorAggregation0R5])
+				break
+			}
+
+			p.bsrSet.Add(slot.VectorAggregation0R6, cU, p.cI, p.cI+1)
+			p.cI++
+			if !p.testSelect(slot.VectorAggregation0R6) {
+				p.parseError(slot.VectorAggregation0R6, p.cI, first[slot.VectorAggregation0R6])
+				break
+			}
+
+			p.call(slot.VectorAggregation0R7, cU, p.cI)
+		case slot.VectorAggregation0R7: // VectorAggregation : var_name GroupingMaybe ( VectorParamMaybe MetricQuery ) GroupingMaybe ∙
+
+			if p.follow(symbols.NT_VectorAggregation) {
+				p.rtn(symbols.NT_VectorAggregation, cU, p.cI)
+			} else {
+				p.parseError(slot.VectorAggregation0R0, p.cI, followSets[symbols.NT_VectorAggregation])
+			}
+		case slot.VectorParamMaybe0R0: // VectorParamMaybe : ∙
+			p.bsrSet.AddEmpty(slot.VectorParamMaybe0R0, p.cI)
+
+			if p.follow(symbols.NT_VectorParamMaybe) {
+				p.rtn(symbols.NT_VectorParamMaybe, cU, p.cI)
+			} else {
+				p.parseError(slot.VectorParamMaybe0R0, p.cI, followSets[symbols.NT_VectorParamMaybe])
+			}
+		case slot.VectorParamMaybe1R0: // VectorParamMaybe : ∙num ,
+
+			p.bsrSet.Add(slot.VectorParamMaybe1R1, cU, p.cI, p.cI+1)
+			p.cI++
+			if !p.testSelect(slot.VectorParamMaybe1R1) {
+				p.parseError(slot.VectorParamMaybe1R1, p.cI, first[slot.VectorParamMaybe1R1])
+				break
+			}
+
+			p.bsrSet.Add(slot.VectorParamMaybe1R2, cU, p.cI, p.cI+1)
+			p.cI++
+			if p.follow(symbols.NT_VectorParamMaybe) {
+				p.rtn(symbols.NT_VectorParamMaybe, cU, p.cI)
+			} else {
+				p.parseError(slot.VectorParamMaybe1R0, p.cI, followSets[symbols.NT_VectorParamMaybe])
+			}
+
+		default:
+			panic("This must not happen")
+		}
+	}
+	if !p.bsrSet.Contain(symbols.NT_Query, 0, m) {
+		p.sortParseErrors()
+		return nil, p.parseErrors
+	}
+	return p.bsrSet, nil
+}
+
+func (p *parser) ntAdd(nt symbols.NT, j int) {
+	// fmt.Printf("p.ntAdd(%s, %d)\n", nt, j)
+	failed := true
+	expected := map[token.Type]string{}
+	for _, l := range slot.GetAlternates(nt) {
+		if p.testSelect(l) {
+			p.dscAdd(l, j, j)
+			failed = false
+		} else {
+			for k, v := range first[l] {
+				expected[k] = v
+			}
+		}
+	}
+	if failed {
+		for _, l := range slot.GetAlternates(nt) {
+			p.parseError(l, j, expected)
+		}
+	}
+}
+
+/*** Call Return Forest ***/
+
+type poppedNode struct {
+	X    symbols.NT
+	k, j int
+}
+
+type clusterNode struct {
+	X symbols.NT
+	k int
+}
+
+type crfNode struct {
+	L slot.Label
+	i int
+}
+
+/*
+suppose that L is Y ::=αX ·β
+if there is no CRF node labelled (L,i)
+
+	create one let u be the CRF node labelled (L,i)
+
+if there is no CRF node labelled (X, j) {
+
+		create a CRF node v labelled (X, j)
+		create an edge from v to u
+		ntAdd(X, j)
+	} else {
+
+		let v be the CRF node labelled (X, j)
+		if there is not an edge from v to u {
+			create an edge from v to u
+			for all ((X, j,h)∈P) {
+				dscAdd(L, i, h);
+				bsrAdd(L, i, j, h)
+			}
+		}
+	}
+*/
+func (p *parser) call(L slot.Label, i, j int) {
+	// fmt.Printf("p.call(%s,%d,%d)\n", L,i,j)
+	u, exist := p.crfNodes[crfNode{L, i}]
+	// fmt.Printf("  u exist=%t\n", exist)
+	if !exist {
+		u = &crfNode{L, i}
+		p.crfNodes[*u] = u
+	}
+	X := L.Symbols()[L.Pos()-1].(symbols.NT)
+	ndV := clusterNode{X, j}
+	v, exist := p.crf[ndV]
+	if !exist {
+		// fmt.Println("  v !exist")
+		p.crf[ndV] = []*crfNode{u}
+		p.ntAdd(X, j)
+	} else {
+		// fmt.Println("  v exist")
+		if !existEdge(v, u) {
+			// fmt.Printf("  !existEdge(%v)\n", u)
+			p.crf[ndV] = append(v, u)
+			// fmt.Printf("|popped|=%d\n", len(popped))
+			for pnd := range p.popped {
+				if pnd.X == X && pnd.k == j {
+					p.dscAdd(L, i, pnd.j)
+					p.bsrSet.Add(L, i, j, pnd.j)
+				}
+			}
+		}
+	}
+}
+
+func existEdge(nds []*crfNode, nd *crfNode) bool {
+	for _, nd1 := range nds {
+		if nd1 == nd {
+			return true
+		}
+	}
+	return false
+}
+
+func (p *parser) rtn(X symbols.NT, k, j int) {
+	// fmt.Printf("p.rtn(%s,%d,%d)\n", X,k,j)
+	pn := poppedNode{X, k, j}
+	if _, exist := p.popped[pn]; !exist {
+		p.popped[pn] = true
+		for _, nd := range p.crf[clusterNode{X, k}] {
+			p.dscAdd(nd.L, nd.i, j)
+			p.bsrSet.Add(nd.L, nd.i, k, j)
+		}
+	}
+}
+
+// func CRFString() string {
+// 	buf := new(bytes.Buffer)
+// 	buf.WriteString("CRF: {")
+// 	for cn, nds := range crf{
+// 		for _, nd := range nds {
+// 			fmt.Fprintf(buf, "%s->%s, ", cn, nd)
+// 		}
+// 	}
+// 	buf.WriteString("}")
+// 	return buf.String()
+// }
+
+func (cn clusterNode) String() string {
+	return fmt.Sprintf("(%s,%d)", cn.X, cn.k)
+}
+
+func (n crfNode) String() string {
+	return fmt.Sprintf("(%s,%d)", n.L.String(), n.i)
+}
+
+// func PoppedString() string {
+// 	buf := new(bytes.Buffer)
+// 	buf.WriteString("Popped: {")
+// 	for p, _ := range popped {
+// 		fmt.Fprintf(buf, "(%s,%d,%d) ", p.X, p.k, p.j)
+// 	}
+// 	buf.WriteString("}")
+// 	return buf.String()
+// }
+
+/*** descriptors ***/
+
+type descriptors struct {
+	set []*descriptor
+}
+
+func (ds *descriptors) contain(d *descriptor) bool {
+	for _, d1 := range ds.set {
+		if d1 == d {
+			return true
+		}
+	}
+	return false
+}
+
+func (ds *descriptors) empty() bool {
+	return len(ds.set) == 0
+}
+
+func (ds *descriptors) String() string {
+	buf := new(bytes.Buffer)
+	buf.WriteString("{")
+	for i, d := range ds.set {
+		if i > 0 {
+			buf.WriteString("; ")
+		}
+		fmt.Fprintf(buf, "%s", d)
+	}
+	buf.WriteString("}")
+	return buf.String()
+}
+
+type descriptor struct {
+	L slot.Label
+	k int
+	i int
+}
+
+func (d *descriptor) String() string {
+	return fmt.Sprintf("%s,%d,%d", d.L, d.k, d.i)
+}
+
+func (p *parser) dscAdd(L slot.Label, k, i int) {
+	// fmt.Printf("p.dscAdd(%s,%d,%d)\n", L, k, i)
+	d := &descriptor{L, k, i}
+	if !p.U.contain(d) {
+		p.R.set = append(p.R.set, d)
+		p.U.set = append(p.U.set, d)
+	}
+}
+
+func (ds *descriptors) remove() (L slot.Label, k, i int) {
+	d := ds.set[len(ds.set)-1]
+	ds.set = ds.set[:len(ds.set)-1]
+	// fmt.Printf("remove: %s,%d,%d\n", d.L, d.k, d.i)
+	return d.L, d.k, d.i
+}
+
+func (p *parser) DumpDescriptors() {
+	p.DumpR()
+	p.DumpU()
+}
+
+func (p *parser) DumpR() {
+	fmt.Println("R:")
+	for _, d := range p.R.set {
+		fmt.Printf(" %s\n", d)
+	}
+}
+
+func (p *parser) DumpU() {
+	fmt.Println("U:")
+	for _, d := range p.U.set {
+		fmt.Printf(" %s\n", d)
+	}
+}
+
+/*** TestSelect ***/
+
+func (p *parser) follow(nt symbols.NT) bool {
+	_, exist := followSets[nt][p.lex.Tokens[p.cI].Type()]
+	return exist
+}
+
+func (p *parser) testSelect(l slot.Label) bool {
+	_, exist := first[l][p.lex.Tokens[p.cI].Type()]
+	// fmt.Printf("testSelect(%s) = %t\n", l, exist)
+	return exist
+}
+
+var first = []map[token.Type]string{
+	// AddOp : ∙+
+	{
+		token.T_6: "+",
+	},
+	// AddOp : + ∙
+	{
+		token.T_3:  "(",
+		token.T_8:  "-",
+		token.T_19: "bool",
+		token.T_24: "ignoring",
+		token.T_30: "num",
+		token.T_31: "on",
+		token.T_38: "var_name",
+	},
+	// AddOp : ∙-
+	{
+		token.T_8: "-",
+	},
+	// AddOp : - ∙
+	{
+		token.T_3:  "(",
+		token.T_8:  "-",
+		token.T_19: "bool",
+		token.T_24: "ignoring",
+		token.T_30: "num",
+		token.T_31: "on",
+		token.T_38: "var_name",
+	},
+	// AndOp : ∙and
+	{
+		token.T_18: "and",
+	},
+	// AndOp : and ∙
+	{
+		token.T_3:  "(",
+		token.T_8:  "-",
+		token.T_19: "bool",
+		token.T_24: "ignoring",
+		token.T_30: "num",
+		token.T_31: "on",
+		token.T_38: "var_name",
+	},
+	// AndOp : ∙unless
+	{
+		token.T_36: "unless",
+	},
+	// AndOp : unless ∙
+	{
+		token.T_3:  "(",
+		token.T_8:  "-",
+		token.T_19: "bool",
+		token.T_24: "ignoring",
+		token.T_30: "num",
+		token.T_31: "on",
+		token.T_38: "var_name",
+	},
+	// BinModifiers : ∙BoolMaybe MatchingMaybe
+	{
+		token.T_19: "bool",
+		token.T_24: "ignoring",
+		token.T_31: "on",
+		token.T_3:  "(",
+		token.T_8:  "-",
+		token.T_30: "num",
+		token.T_38: "var_name",
+	},
+	// BinModifiers : BoolMaybe ∙MatchingMaybe
+	{
+		token.T_24: "ignoring",
+		token.T_31: "on",
+		token.T_3:  "(",
+		token.T_8:  "-",
+		token.T_30: "num",
+		token.T_38: "var_name",
+	},
+	// BinModifiers : BoolMaybe MatchingMaybe ∙
+	{
+		token.T_3:  "(",
+		token.T_8:  "-",
+		token.T_30: "num",
+		token.T_38: "var_name",
+	},
+	// BoolMaybe : ∙
+	{
+		token.T_3:  "(",
+		token.T_8:  "-",
+		token.T_24: "ignoring",
+		token.T_30: "num",
+		token.T_31: "on",
+		token.T_38: "var_name",
+	},
+	// BoolMaybe : ∙bool
+	{
+		token.T_19: "bool",
+	},
+	// BoolMaybe : bool ∙
+	{
+		token.T_3:  "(",
+		token.T_8:  "-",
+		token.T_24: "ignoring",
+		token.T_30: "num",
+		token.T_31: "on",
+		token.T_38: "var_name",
+	},
+	// CmpOp : ∙==
+	{
+		token.T_13: "==",
+	},
+	// CmpOp : == ∙
+	{
+		token.T_3:  "(",
+		token.T_8:  "-",
+		token.T_19: "bool",
+		token.T_24: "ignoring",
+		token.T_30: "num",
+		token.T_31: "on",
+		token.T_38: "var_name",
+	},
+	// CmpOp : ∙!=
+	{
+		token.T_0: "!=",
+	},
+	// CmpOp : != ∙
+	{
+		token.T_3:  "(",
+		token.T_8:  "-",
+		token.T_19: "bool",
+		token.T_24: "ignoring",
+		token.T_30: "num",
+		token.T_31: "on",
+		token.T_38: "var_name",
+	},
+	// CmpOp : ∙>
+	{
+		token.T_15: ">",
+	},
+	// CmpOp : > ∙
+	{
+		token.T_3:  "(",
+		token.T_8:  "-",
+		token.T_19: "bool",
+		token.T_24: "ignoring",
+		token.T_30: "num",
+		token.T_31: "on",
+		token.T_38: "var_name",
+	},
+	// CmpOp : ∙<
+	{
+		token.T_10: "<",
+	},
+	// CmpOp : < ∙
+	{
+		token.T_3:  "(",
+		token.T_8:  "-",
+		token.T_19: "bool",
+		token.T_24: "ignoring",
+		token.T_30: "num",
+		token.T_31: "on",
+		token.T_38: "var_name",
+	},
+	// CmpOp : ∙>=
+	{
+		token.T_16: ">=",
+	},
+	// CmpOp : >= ∙
+	{
+		token.T_3:  "(",
+		token.T_8:  "-",
+		token.T_19: "bool",
+		token.T_24: "ignoring",
+		token.T_30: "num",
+		token.T_31: "on",
+		token.T_38: "var_name",
+	},
+	// CmpOp : ∙<=
+	{
+		token.T_11: "<=",
+	},
+	// CmpOp : <= ∙
+	{
+		token.T_3:  "(",
+		token.T_8:  "-",
+		token.T_19: "bool",
+		token.T_24: "ignoring",
+		token.T_30: "num",
+		token.T_31: "on",
+		token.T_38: "var_name",
+	},
+	// DataFilter : ∙string DataFilterOp FilterValue
+	{
+		token.T_35: "string",
+	},
+	// DataFilter : string ∙DataFilterOp FilterValue
+	{
+		token.T_0:  "!=",
+		token.T_1:  "!~",
+		token.T_10: "<",
+		token.T_11: "<=",
+		token.T_12: "=",
+		token.T_14: "=~",
+		token.T_15: ">",
+		token.T_16: ">=",
+	},
+	// DataFilter : string DataFilterOp ∙FilterValue
+	{
+		token.T_30: "num",
+		token.T_35: "string",
+	},
+	// DataFilter : string DataFilterOp FilterValue ∙
+	{
+		token.T_0:  "!=",
+		token.T_1:  "!~",
+		token.EOF:  "$",
+		token.T_4:  ")",
+		token.T_7:  ",",
+		token.T_18: "and",
+		token.T_21: "duration",
+		token.T_32: "or",
+		token.T_41: "|",
+		token.T_42: "|=",
+		token.T_43: "|~",
+	},
+	// DataFilterOp : ∙=
+	{
+		token.T_12: "=",
+	},
+	// DataFilterOp : = ∙
+	{
+		token.T_30: "num",
+		token.T_35: "string",
+	},
+	// DataFilterOp : ∙!=
+	{
+		token.T_0: "!=",
+	},
+	// DataFilterOp : != ∙
+	{
+		token.T_30: "num",
+		token.T_35: "string",
+	},
+	// DataFilterOp : ∙=~
+	{
+		token.T_14: "=~",
+	},
+	// DataFilterOp : =~ ∙
+	{
+		token.T_30: "num",
+		token.T_35: "string",
+	},
+	// DataFilterOp : ∙!~
+	{
+		token.T_1: "!~",
+	},
+	// DataFilterOp : !~ ∙
+	{
+		token.T_30: "num",
+		token.T_35: "string",
+	},
+	// DataFilterOp : ∙>=
+	{
+		token.T_16: ">=",
+	},
+	// DataFilterOp : >= ∙
+	{
+		token.T_30: "num",
+		token.T_35: "string",
+	},
+	// DataFilterOp : ∙>
+	{
+		token.T_15: ">",
+	},
+	// DataFilterOp : > ∙
+	{
+		token.T_30: "num",
+		token.T_35: "string",
+	},
+	// DataFilterOp : ∙<=
+	{
+		token.T_11: "<=",
+	},
+	// DataFilterOp : <= ∙
+	{
+		token.T_30: "num",
+		token.T_35: "string",
+	},
+	// DataFilterOp : ∙<
+	{
+		token.T_10: "<",
+	},
+	// DataFilterOp : < ∙
+	{
+		token.T_30: "num",
+		token.T_35: "string",
+	},
+	// FilterAnd : ∙FilterNot
+	{
+		token.T_3:  "(",
+		token.T_20: "by",
+		token.T_25: "json",
+		token.T_26: "label_format",
+		token.T_27: "line_format",
+		token.T_28: "logfmt",
+		token.T_29: "not",
+		token.T_33: "pattern",
+		token.T_34: "regexp",
+		token.T_35: "string",
+		token.T_37: "unwrap",
+		token.T_38: "var_name",
+		token.T_39: "without",
+	},
+	// FilterAnd : FilterNot ∙
+	{
+		token.T_0:  "!=",
+		token.T_1:  "!~",
+		token.EOF:  "$",
+		token.T_4:  ")",
+		token.T_21: "duration",
+		token.T_32: "or",
+		token.T_41: "|",
+		token.T_42: "|=",
+		token.T_43: "|~",
+	},
+	// FilterAnd : ∙FilterNot and FilterAnd
+	{
+		token.T_3:  "(",
+		token.T_20: "by",
+		token.T_25: "json",
+		token.T_26: "label_format",
+		token.T_27: "line_format",
+		token.T_28: "logfmt",
+		token.T_29: "not",
+		token.T_33: "pattern",
+		token.T_34: "regexp",
+		token.T_35: "string",
+		token.T_37: "unwrap",
+		token.T_38: "var_name",
+		token.T_39: "without",
+	},
+	// FilterAnd : FilterNot ∙and FilterAnd
+	{
+		token.T_18: "and",
+	},
+	// FilterAnd : FilterNot and ∙FilterAnd
+	{
+		token.T_3:  "(",
+		token.T_20: "by",
+		token.T_25: "json",
+		token.T_26: "label_format",
+		token.T_27: "line_format",
+		token.T_28: "logfmt",
+		token.T_29: "not",
+		token.T_33: "pattern",
+		token.T_34: "regexp",
+		token.T_35: "string",
+		token.T_37: "unwrap",
+		token.T_38: "var_name",
+		token.T_39: "without",
+	},
+	// FilterAnd : FilterNot and FilterAnd ∙
+	{
+		token.T_0:  "!=",
+		token.T_1:  "!~",
+		token.EOF:  "$",
+		token.T_4:  ")",
+		token.T_21: "duration",
+		token.T_32: "or",
+		token.T_41: "|",
+		token.T_42: "|=",
+		token.T_43: "|~",
+	},
+	// FilterAnd : ∙FilterNot , FilterAnd
+	{
+		token.T_3:  "(",
+		token.T_20: "by",
+		token.T_25: "json",
+		token.T_26: "label_format",
+		token.T_27: "line_format",
+		token.T_28: "logfmt",
+		token.T_29: "not",
+		token.T_33: "pattern",
+		token.T_34: "regexp",
+		token.T_35: "string",
+		token.T_37: "unwrap",
+		token.T_38: "var_name",
+		token.T_39: "without",
+	},
+	// FilterAnd : FilterNot ∙, FilterAnd
+	{
+		token.T_7: ",",
+	},
+	// FilterAnd : FilterNot , ∙FilterAnd
+	{
+		token.T_3:  "(",
+		token.T_20: "by",
+		token.T_25: "json",
+		token.T_26: "label_format",
+		token.T_27: "line_format",
+		token.T_28: "logfmt",
+		token.T_29: "not",
+		token.T_33: "pattern",
+		token.T_34: "regexp",
+		token.T_35: "string",
+		token.T_37: "unwrap",
+		token.T_38: "var_name",
+		token.T_39: "without",
+	},
+	// FilterAnd : FilterNot , FilterAnd ∙
+	{
+		token.T_0:  "!=",
+		token.T_1:  "!~",
+		token.EOF:  "$",
+		token.T_4:  ")",
+		token.T_21: "duration",
+		token.T_32: "or",
+		token.T_41: "|",
+		token.T_42: "|=",
+		token.T_43: "|~",
+	},
+	// FilterExpr : ∙FilterAnd
+	{
+		token.T_3:  "(",
+		token.T_20: "by",
+		token.T_25: "json",
+		token.T_26: "label_format",
+		token.T_27: "line_format",
+		token.T_28: "logfmt",
+		token.T_29: "not",
+		token.T_33: "pattern",
+		token.T_34: "regexp",
+		token.T_35: "string",
+		token.T_37: "unwrap",
+		token.T_38: "var_name",
+		token.T_39: "without",
+	},
+	// FilterExpr : FilterAnd ∙
+	{
+		token.T_0:  "!=",
+		token.T_1:  "!~",
+		token.EOF:  "$",
+		token.T_4:  ")",
+		token.T_21: "duration",
+		token.T_41: "|",
+		token.T_42: "|=",
+		token.T_43: "|~",
+	},
+	// FilterExpr : ∙FilterAnd or FilterExpr
+	{
+		token.T_3:  "(",
+		token.T_20: "by",
+		token.T_25: "json",
+		token.T_26: "label_format",
+		token.T_27: "line_format",
+		token.T_28: "logfmt",
+		token.T_29: "not",
+		token.T_33: "pattern",
+		token.T_34: "regexp",
+		token.T_35: "string",
+		token.T_37: "unwrap",
+		token.T_38: "var_name",
+		token.T_39: "without",
+	},
+	// FilterExpr : FilterAnd ∙or FilterExpr
+	{
+		token.T_32: "or",
+	},
+	// FilterExpr : FilterAnd or ∙FilterExpr
+	{
+		token.T_3:  "(",
+		token.T_20: "by",
+		token.T_25: "json",
+		token.T_26: "label_format",
+		token.T_27: "line_format",
+		token.T_28: "logfmt",
+		token.T_29: "not",
+		token.T_33: "pattern",
+		token.T_34: "regexp",
+		token.T_35: "string",
+		token.T_37: "unwrap",
+		token.T_38: "var_name",
+		token.T_39: "without",
+	},
+	// FilterExpr : FilterAnd or FilterExpr ∙
+	{
+		token.T_0:  "!=",
+		token.T_1:  "!~",
+		token.EOF:  "$",
+		token.T_4:  ")",
+		token.T_21: "duration",
+		token.T_41: "|",
+		token.T_42: "|=",
+		token.T_43: "|~",
+	},
+	// FilterNot : ∙FilterTerm
+	{
+		token.T_3:  "(",
+		token.T_20: "by",
+		token.T_25: "json",
+		token.T_26: "label_format",
+		token.T_27: "line_format",
+		token.T_28: "logfmt",
+		token.T_33: "pattern",
+		token.T_34: "regexp",
+		token.T_35: "string",
+		token.T_37: "unwrap",
+		token.T_38: "var_name",
+		token.T_39: "without",
+	},
+	// FilterNot : FilterTerm ∙
+	{
+		token.T_0:  "!=",
+		token.T_1:  "!~",
+		token.EOF:  "$",
+		token.T_4:  ")",
+		token.T_7:  ",",
+		token.T_18: "and",
+		token.T_21: "duration",
+		token.T_32: "or",
+		token.T_41: "|",
+		token.T_42: "|=",
+		token.T_43: "|~",
+	},
+	// FilterNot : ∙not FilterNot
+	{
+		token.T_29: "not",
+	},
+	// FilterNot : not ∙FilterNot
+	{
+		token.T_3:  "(",
+		token.T_20: "by",
+		token.T_25: "json",
+		token.T_26: "label_format",
+		token.T_27: "line_format",
+		token.T_28: "logfmt",
+		token.T_29: "not",
+		token.T_33: "pattern",
+		token.T_34: "regexp",
+		token.T_35: "string",
+		token.T_37: "unwrap",
+		token.T_38: "var_name",
+		token.T_39: "without",
+	},
+	// FilterNot : not FilterNot ∙
+	{
+		token.T_0:  "!=",
+		token.T_1:  "!~",
+		token.EOF:  "$",
+		token.T_4:  ")",
+		token.T_7:  ",",
+		token.T_18: "and",
+		token.T_21: "duration",
+		token.T_32: "or",
+		token.T_41: "|",
+		token.T_42: "|=",
+		token.T_43: "|~",
+	},
+	// FilterStage : ∙| FilterExpr
+	{
+		token.T_41: "|",
+	},
+	// FilterStage : | ∙FilterExpr
+	{
+		token.T_3:  "(",
+		token.T_20: "by",
+		token.T_25: "json",
+		token.T_26: "label_format",
+		token.T_27: "line_format",
+		token.T_28: "logfmt",
+		token.T_29: "not",
+		token.T_33: "pattern",
+		token.T_34: "regexp",
+		token.T_35: "string",
+		token.T_37: "unwrap",
+		token.T_38: "var_name",
+		token.T_39: "without",
+	},
+	// FilterStage : | FilterExpr ∙
+	{
+		token.T_0:  "!=",
+		token.T_1:  "!~",
+		token.EOF:  "$",
+		token.T_21: "duration",
+		token.T_41: "|",
+		token.T_42: "|=",
+		token.T_43: "|~",
+	},
+	// FilterTerm : ∙DataFilter
+	{
+		token.T_35: "string",
+	},
+	// FilterTerm : DataFilter ∙
+	{
+		token.T_0:  "!=",
+		token.T_1:  "!~",
+		token.EOF:  "$",
+		token.T_4:  ")",
+		token.T_7:  ",",
+		token.T_18: "and",
+		token.T_21: "duration",
+		token.T_32: "or",
+		token.T_41: "|",
+		token.T_42: "|=",
+		token.T_43: "|~",
+	},
+	// FilterTerm : ∙LabelFilter
+	{
+		token.T_20: "by",
+		token.T_25: "json",
+		token.T_26: "label_format",
+		token.T_27: "line_format",
+		token.T_28: "logfmt",
+		token.T_33: "pattern",
+		token.T_34: "regexp",
+		token.T_37: "unwrap",
+		token.T_38: "var_name",
+		token.T_39: "without",
+	},
+	// FilterTerm : LabelFilter ∙
+	{
+		token.T_0:  "!=",
+		token.T_1:  "!~",
+		token.EOF:  "$",
+		token.T_4:  ")",
+		token.T_7:  ",",
+		token.T_18: "and",
+		token.T_21: "duration",
+		token.T_32: "or",
+		token.T_41: "|",
+		token.T_42: "|=",
+		token.T_43: "|~",
+	},
+	// FilterTerm : ∙( FilterExpr )
+	{
+		token.T_3: "(",
+	},
+	// FilterTerm : ( ∙FilterExpr )
+	{
+		token.T_3:  "(",
+		token.T_20: "by",
+		token.T_25: "json",
+		token.T_26: "label_format",
+		token.T_27: "line_format",
+		token.T_28: "logfmt",
+		token.T_29: "not",
+		token.T_33: "pattern",
+		token.T_34: "regexp",
+		token.T_35: "string",
+		token.T_37: "unwrap",
+		token.T_38: "var_name",
+		token.T_39: "without",
+	},
+	// FilterTerm : ( FilterExpr ∙)
+	{
+		token.T_4: ")",
+	},
+	// FilterTerm : ( FilterExpr ) ∙
+	{
+		token.T_0:  "!=",
+		token.T_1:  "!~",
+		token.EOF:  "$",
+		token.T_4:  ")",
+		token.T_7:  ",",
+		token.T_18: "and",
+		token.T_21: "duration",
+		token.T_32: "or",
+		token.T_41: "|",
+		token.T_42: "|=",
+		token.T_43: "|~",
+	},
+	// FilterValue : ∙string
+	{
+		token.T_35: "string",
+	},
+	// FilterValue : string ∙
+	{
+		token.T_0:  "!=",
+		token.T_1:  "!~",
+		token.EOF:  "$",
+		token.T_4:  ")",
+		token.T_7:  ",",
+		token.T_18: "and",
+		token.T_21: "duration",
+		token.T_32: "or",
+		token.T_41: "|",
+		token.T_42: "|=",
+		token.T_43: "|~",
+	},
+	// FilterValue : ∙num
+	{
+		token.T_30: "num",
+	},
+	// FilterValue : num ∙
+	{
+		token.T_0:  "!=",
+		token.T_1:  "!~",
+		token.EOF:  "$",
+		token.T_4:  ")",
+		token.T_7:  ",",
+		token.T_18: "and",
+		token.T_21: "duration",
+		token.T_32: "or",
+		token.T_41: "|",
+		token.T_42: "|=",
+		token.T_43: "|~",
+	},
+	// FormatStage : ∙| line_format string
+	{
+		token.T_41: "|",
+	},
+	// FormatStage : | ∙line_format string
+	{
+		token.T_27: "line_format",
+	},
+	// FormatStage : | line_format ∙string
+	{
+		token.T_35: "string",
+	},
+	// FormatStage : | line_format string ∙
+	{
+		token.T_0:  "!=",
+		token.T_1:  "!~",
+		token.EOF:  "$",
+		token.T_21: "duration",
+		token.T_41: "|",
+		token.T_42: "|=",
+		token.T_43: "|~",
+	},
+	// FormatStage : ∙| label_format LabelFormats
+	{
+		token.T_41: "|",
+	},
+	// FormatStage : | ∙label_format LabelFormats
+	{
+		token.T_26: "label_format",
+	},
+	// FormatStage : | label_format ∙LabelFormats
+	{
+		token.T_20: "by",
+		token.T_25: "json",
+		token.T_26: "label_format",
+		token.T_27: "line_format",
+		token.T_28: "logfmt",
+		token.T_33: "pattern",
+		token.T_34: "regexp",
+		token.T_37: "unwrap",
+		token.T_38: "var_name",
+		token.T_39: "without",
+	},
+	// FormatStage : | label_format LabelFormats ∙
+	{
+		token.T_0:  "!=",
+		token.T_1:  "!~",
+		token.EOF:  "$",
+		token.T_21: "duration",
+		token.T_41: "|",
+		token.T_42: "|=",
+		token.T_43: "|~",
+	},
+	// GroupModifierMaybe : ∙
+	{
+		token.T_3:  "(",
+		token.T_8:  "-",
+		token.T_30: "num",
+		token.T_38: "var_name",
+	},
+	// GroupModifierMaybe : ∙GroupSide
+	{
+		token.T_22: "group_left",
+		token.T_23: "group_right",
+	},
+	// GroupModifierMaybe : GroupSide ∙
+	{
+		token.T_3:  "(",
+		token.T_8:  "-",
+		token.T_30: "num",
+		token.T_38: "var_name",
+	},
+	// GroupModifierMaybe : ∙GroupSide ( LabelKeysMaybe )
+	{
+		token.T_22: "group_left",
+		token.T_23: "group_right",
+	},
+	// GroupModifierMaybe : GroupSide ∙( LabelKeysMaybe )
+	{
+		token.T_3: "(",
+	},
+	// GroupModifierMaybe : GroupSide ( ∙LabelKeysMaybe )
+	{
+		token.T_4:  ")",
+		token.T_20: "by",
+		token.T_25: "json",
+		token.T_26: "label_format",
+		token.T_27: "line_format",
+		token.T_28: "logfmt",
+		token.T_33: "pattern",
+		token.T_34: "regexp",
+		token.T_37: "unwrap",
+		token.T_38: "var_name",
+		token.T_39: "without",
+	},
+	// GroupModifierMaybe : GroupSide ( LabelKeysMaybe ∙)
+	{
+		token.T_4: ")",
+	},
+	// GroupModifierMaybe : GroupSide ( LabelKeysMaybe ) ∙
+	{
+		token.T_3:  "(",
+		token.T_8:  "-",
+		token.T_30: "num",
+		token.T_38: "var_name",
+	},
+	// GroupSide : ∙group_left
+	{
+		token.T_22: "group_left",
+	},
+	// GroupSide : group_left ∙
+	{
+		token.T_3:  "(",
+		token.T_8:  "-",
+		token.T_30: "num",
+		token.T_38: "var_name",
+	},
+	// GroupSide : ∙group_right
+	{
+		token.T_23: "group_right",
+	},
+	// GroupSide : group_right ∙
+	{
+		token.T_3:  "(",
+		token.T_8:  "-",
+		token.T_30: "num",
+		token.T_38: "var_name",
+	},
+	// Grouping : ∙GroupingOp ( LabelKeysMaybe )
+	{
+		token.T_20: "by",
+		token.T_39: "without",
+	},
+	// Grouping : GroupingOp ∙( LabelKeysMaybe )
+	{
+		token.T_3: "(",
+	},
+	// Grouping : GroupingOp ( ∙LabelKeysMaybe )
+	{
+		token.T_4:  ")",
+		token.T_20: "by",
+		token.T_25: "json",
+		token.T_26: "label_format",
+		token.T_27: "line_format",
+		token.T_28: "logfmt",
+		token.T_33: "pattern",
+		token.T_34: "regexp",
+		token.T_37: "unwrap",
+		token.T_38: "var_name",
+		token.T_39: "without",
+	},
+	// Grouping : GroupingOp ( LabelKeysMaybe ∙)
+	{
+		token.T_4: ")",
+	},
+	// Grouping : GroupingOp ( LabelKeysMaybe ) ∙
+	{
+		token.T_0:  "!=",
+		token.EOF:  "$",
+		token.T_2:  "%",
+		token.T_3:  "(",
+		token.T_4:  ")",
+		token.T_5:  "*",
+		token.T_6:  "+",
+		token.T_8:  "-",
+		token.T_9:  "/",
+		token.T_10: "<",
+		token.T_11: "<=",
+		token.T_13: "==",
+		token.T_15: ">",
+		token.T_16: ">=",
+		token.T_17: "^",
+		token.T_18: "and",
+		token.T_32: "or",
+		token.T_36: "unless",
+	},
+	// GroupingMaybe : ∙
+	{
+		token.T_0:  "!=",
+		token.EOF:  "$",
+		token.T_2:  "%",
+		token.T_3:  "(",
+		token.T_4:  ")",
+		token.T_5:  "*",
+		token.T_6:  "+",
+		token.T_8:  "-",
+		token.T_9:  "/",
+		token.T_10: "<",
+		token.T_11: "<=",
+		token.T_13: "==",
+		token.T_15: ">",
+		token.T_16: ">=",
+		token.T_17: "^",
+		token.T_18: "and",
+		token.T_32: "or",
+		token.T_36: "unless",
+	},
+	// GroupingMaybe : ∙Grouping
+	{
+		token.T_20: "by",
+		token.T_39: "without",
+	},
+	// GroupingMaybe : Grouping ∙
+	{
+		token.T_0:  "!=",
+		token.EOF:  "$",
+		token.T_2:  "%",
+		token.T_3:  "(",
+		token.T_4:  ")",
+		token.T_5:  "*",
+		token.T_6:  "+",
+		token.T_8:  "-",
+		token.T_9:  "/",
+		token.T_10: "<",
+		token.T_11: "<=",
+		token.T_13: "==",
+		token.T_15: ">",
+		token.T_16: ">=",
+		token.T_17: "^",
+		token.T_18: "and",
+		token.T_32: "or",
+		token.T_36: "unless",
+	},
+	// GroupingOp : ∙by
+	{
+		token.T_20: "by",
+	},
+	// GroupingOp : by ∙
+	{
+		token.T_3: "(",
+	},
+	// GroupingOp : ∙without
+	{
+		token.T_39: "without",
+	},
+	// GroupingOp : without ∙
+	{
+		token.T_3: "(",
+	},
+	// LabelFilter : ∙LabelKey LabelFilterOp FilterValue
+	{
+		token.T_20: "by",
+		token.T_25: "json",
+		token.T_26: "label_format",
+		token.T_27: "line_format",
+		token.T_28: "logfmt",
+		token.T_33: "pattern",
+		token.T_34: "regexp",
+		token.T_37: "unwrap",
+		token.T_38: "var_name",
+		token.T_39: "without",
+	},
+	// LabelFilter : LabelKey ∙LabelFilterOp FilterValue
+	{
+		token.T_0:  "!=",
+		token.T_1:  "!~",
+		token.T_10: "<",
+		token.T_11: "<=",
+		token.T_12: "=",
+		token.T_13: "==",
+		token.T_14: "=~",
+		token.T_15: ">",
+		token.T_16: ">=",
+	},
+	// LabelFilter : LabelKey LabelFilterOp ∙FilterValue
+	{
+		token.T_30: "num",
+		token.T_35: "string",
+	},
+	// LabelFilter : LabelKey LabelFilterOp FilterValue ∙
+	{
+		token.T_0:  "!=",
+		token.T_1:  "!~",
+		token.EOF:  "$",
+		token.T_4:  ")",
+		token.T_7:  ",",
+		token.T_18: "and",
+		token.T_21: "duration",
+		token.T_32: "or",
+		token.T_41: "|",
+		token.T_42: "|=",
+		token.T_43: "|~",
+	},
+	// LabelFilterOp : ∙=
+	{
+		token.T_12: "=",
+	},
+	// LabelFilterOp : = ∙
+	{
+		token.T_30: "num",
+		token.T_35: "string",
+	},
+	// LabelFilterOp : ∙==
+	{
+		token.T_13: "==",
+	},
+	// LabelFilterOp : == ∙
+	{
+		token.T_30: "num",
+		token.T_35: "string",
+	},
+	// LabelFilterOp : ∙!=
+	{
+		token.T_0: "!=",
+	},
+	// LabelFilterOp : != ∙
+	{
+		token.T_30: "num",
+		token.T_35: "string",
+	},
+	// LabelFilterOp : ∙=~
+	{
+		token.T_14: "=~",
+	},
+	// LabelFilterOp : =~ ∙
+	{
+		token.T_30: "num",
+		token.T_35: "string",
+	},
+	// LabelFilterOp : ∙!~
+	{
+		token.T_1: "!~",
+	},
+	// LabelFilterOp : !~ ∙
+	{
+		token.T_30: "num",
+		token.T_35: "string",
+	},
+	// LabelFilterOp : ∙>=
+	{
+		token.T_16: ">=",
+	},
+	// LabelFilterOp : >= ∙
+	{
+		token.T_30: "num",
+		token.T_35: "string",
+	},
+	// LabelFilterOp : ∙>
+	{
+		token.T_15: ">",
+	},
+	// LabelFilterOp : > ∙
+	{
+		token.T_30: "num",
+		token.T_35: "string",
+	},
+	// LabelFilterOp : ∙<=
+	{
+		token.T_11: "<=",
+	},
+	// LabelFilterOp : <= ∙
+	{
+		token.T_30: "num",
+		token.T_35: "string",
+	},
+	// LabelFilterOp : ∙<
+	{
+		token.T_10: "<",
+	},
+	// LabelFilterOp : < ∙
+	{
+		token.T_30: "num",
+		token.T_35: "string",
+	},
+	// LabelFormat : ∙LabelKey = LabelFormatValue
+	{
+		token.T_20: "by",
+		token.T_25: "json",
+		token.T_26: "label_format",
+		token.T_27: "line_format",
+		token.T_28: "logfmt",
+		token.T_33: "pattern",
+		token.T_34: "regexp",
+		token.T_37: "unwrap",
+		token.T_38: "var_name",
+		token.T_39: "without",
+	},
+	// LabelFormat : LabelKey ∙= LabelFormatValue
+	{
+		token.T_12: "=",
+	},
+	// LabelFormat : LabelKey = ∙LabelFormatValue
+	{
+		token.T_20: "by",
+		token.T_25: "json",
+		token.T_26: "label_format",
+		token.T_27: "line_format",
+		token.T_28: "logfmt",
+		token.T_33: "pattern",
+		token.T_34: "regexp",
+		token.T_35: "string",
+		token.T_37: "unwrap",
+		token.T_38: "var_name",
+		token.T_39: "without",
+	},
+	// LabelFormat : LabelKey = LabelFormatValue ∙
+	{
+		token.T_0:  "!=",
+		token.T_1:  "!~",
+		token.EOF:  "$",
+		token.T_7:  ",",
+		token.T_21: "duration",
+		token.T_41: "|",
+		token.T_42: "|=",
+		token.T_43: "|~",
+	},
+	// LabelFormatValue : ∙LabelKey
+	{
+		token.T_20: "by",
+		token.T_25: "json",
+		token.T_26: "label_format",
+		token.T_27: "line_format",
+		token.T_28: "logfmt",
+		token.T_33: "pattern",
+		token.T_34: "regexp",
+		token.T_37: "unwrap",
+		token.T_38: "var_name",
+		token.T_39: "without",
+	},
+	// LabelFormatValue : LabelKey ∙
+	{
+		token.T_0:  "!=",
+		token.T_1:  "!~",
+		token.EOF:  "$",
+		token.T_7:  ",",
+		token.T_21: "duration",
+		token.T_41: "|",
+		token.T_42: "|=",
+		token.T_43: "|~",
+	},
+	// LabelFormatValue : ∙string
+	{
+		token.T_35: "string",
+	},
+	// LabelFormatValue : string ∙
+	{
+		token.T_0:  "!=",
+		token.T_1:  "!~",
+		token.EOF:  "$",
+		token.T_7:  ",",
+		token.T_21: "duration",
+		token.T_41: "|",
+		token.T_42: "|=",
+		token.T_43: "|~",
+	},
+	// LabelFormats : ∙LabelFormat
+	{
+		token.T_20: "by",
+		token.T_25: "json",
+		token.T_26: "label_format",
+		token.T_27: "line_format",
+		token.T_28: "logfmt",
+		token.T_33: "pattern",
+		token.T_34: "regexp",
+		token.T_37: "unwrap",
+		token.T_38: "var_name",
+		token.T_39: "without",
+	},
+	// LabelFormats : LabelFormat ∙
+	{
+		token.T_0:  "!=",
+		token.T_1:  "!~",
+		token.EOF:  "$",
+		token.T_21: "duration",
+		token.T_41: "|",
+		token.T_42: "|=",
+		token.T_43: "|~",
+	},
+	// LabelFormats : ∙LabelFormat , LabelFormats
+	{
+		token.T_20: "by",
+		token.T_25: "json",
+		token.T_26: "label_format",
+		token.T_27: "line_format",
+		token.T_28: "logfmt",
+		token.T_33: "pattern",
+		token.T_34: "regexp",
+		token.T_37: "unwrap",
+		token.T_38: "var_name",
+		token.T_39: "without",
+	},
+	// LabelFormats : LabelFormat ∙, LabelFormats
+	{
+		token.T_7: ",",
+	},
+	// LabelFormats : LabelFormat , ∙LabelFormats
+	{
+		token.T_20: "by",
+		token.T_25: "json",
+		token.T_26: "label_format",
+		token.T_27: "line_format",
+		token.T_28: "logfmt",
+		token.T_33: "pattern",
+		token.T_34: "regexp",
+		token.T_37: "unwrap",
+		token.T_38: "var_name",
+		token.T_39: "without",
+	},
+	// LabelFormats : LabelFormat , LabelFormats ∙
+	{
+		token.T_0:  "!=",
+		token.T_1:  "!~",
+		token.EOF:  "$",
+		token.T_21: "duration",
+		token.T_41: "|",
+		token.T_42: "|=",
+		token.T_43: "|~",
+	},
+	// LabelKey : ∙var_name
+	{
+		token.T_38: "var_name",
+	},
+	// LabelKey : var_name ∙
+	{
+		token.T_0:  "!=",
+		token.T_1:  "!~",
+		token.EOF:  "$",
+		token.T_4:  ")",
+		token.T_7:  ",",
+		token.T_10: "<",
+		token.T_11: "<=",
+		token.T_12: "=",
+		token.T_13: "==",
+		token.T_14: "=~",
+		token.T_15: ">",
+		token.T_16: ">=",
+		token.T_21: "duration",
+		token.T_41: "|",
+		token.T_42: "|=",
+		token.T_43: "|~",
+	},
+	// LabelKey : ∙by
+	{
+		token.T_20: "by",
+	},
+	// LabelKey : by ∙
+	{
+		token.T_0:  "!=",
+		token.T_1:  "!~",
+		token.EOF:  "$",
+		token.T_4:  ")",
+		token.T_7:  ",",
+		token.T_10: "<",
+		token.T_11: "<=",
+		token.T_12: "=",
+		token.T_13: "==",
+		token.T_14: "=~",
+		token.T_15: ">",
+		token.T_16: ">=",
+		token.T_21: "duration",
+		token.T_41: "|",
+		token.T_42: "|=",
+		token.T_43: "|~",
+	},
+	// LabelKey : ∙without
+	{
+		token.T_39: "without",
+	},
+	// LabelKey : without ∙
+	{
+		token.T_0:  "!=",
+		token.T_1:  "!~",
+		token.EOF:  "$",
+		token.T_4:  ")",
+		token.T_7:  ",",
+		token.T_10: "<",
+		token.T_11: "<=",
+		token.T_12: "=",
+		token.T_13: "==",
+		token.T_14: "=~",
+		token.T_15: ">",
+		token.T_16: ">=",
+		token.T_21: "duration",
+		token.T_41: "|",
+		token.T_42: "|=",
+		token.T_43: "|~",
+	},
+	// LabelKey : ∙unwrap
+	{
+		token.T_37: "unwrap",
+	},
+	// LabelKey : unwrap ∙
+	{
+		token.T_0:  "!=",
+		token.T_1:  "!~",
+		token.EOF:  "$",
+		token.T_4:  ")",
+		token.T_7:  ",",
+		token.T_10: "<",
+		token.T_11: "<=",
+		token.T_12: "=",
+		token.T_13: "==",
+		token.T_14: "=~",
+		token.T_15: ">",
+		token.T_16: ">=",
+		token.T_21: "duration",
+		token.T_41: "|",
+		token.T_42: "|=",
+		token.T_43: "|~",
+	},
+	// LabelKey : ∙json
+	{
+		token.T_25: "json",
+	},
+	// LabelKey : json ∙
+	{
+		token.T_0:  "!=",
+		token.T_1:  "!~",
+		token.EOF:  "$",
+		token.T_4:  ")",
+		token.T_7:  ",",
+		token.T_10: "<",
+		token.T_11: "<=",
+		token.T_12: "=",
+		token.T_13: "==",
+		token.T_14: "=~",
+		token.T_15: ">",
+		token.T_16: ">=",
+		token.T_21: "duration",
+		token.T_41: "|",
+		token.T_42: "|=",
+		token.T_43: "|~",
+	},
+	// LabelKey : ∙logfmt
+	{
+		token.T_28: "logfmt",
+	},
+	// LabelKey : logfmt ∙
+	{
+		token.T_0:  "!=",
+		token.T_1:  "!~",
+		token.EOF:  "$",
+		token.T_4:  ")",
+		token.T_7:  ",",
+		token.T_10: "<",
+		token.T_11: "<=",
+		token.T_12: "=",
+		token.T_13: "==",
+		token.T_14: "=~",
+		token.T_15: ">",
+		token.T_16: ">=",
+		token.T_21: "duration",
+		token.T_41: "|",
+		token.T_42: "|=",
+		token.T_43: "|~",
+	},
+	// LabelKey : ∙regexp
+	{
+		token.T_34: "regexp",
+	},
+	// LabelKey : regexp ∙
+	{
+		token.T_0:  "!=",
+		token.T_1:  "!~",
+		token.EOF:  "$",
+		token.T_4:  ")",
+		token.T_7:  ",",
+		token.T_10: "<",
+		token.T_11: "<=",
+		token.T_12: "=",
+		token.T_13: "==",
+		token.T_14: "=~",
+		token.T_15: ">",
+		token.T_16: ">=",
+		token.T_21: "duration",
+		token.T_41: "|",
+		token.T_42: "|=",
+		token.T_43: "|~",
+	},
+	// LabelKey : ∙pattern
+	{
+		token.T_33: "pattern",
+	},
+	// LabelKey : pattern ∙
+	{
+		token.T_0:  "!=",
+		token.T_1:  "!~",
+		token.EOF:  "$",
+		token.T_4:  ")",
+		token.T_7:  ",",
+		token.T_10: "<",
+		token.T_11: "<=",
+		token.T_12: "=",
+		token.T_13: "==",
+		token.T_14: "=~",
+		token.T_15: ">",
+		token.T_16: ">=",
+		token.T_21: "duration",
+		token.T_41: "|",
+		token.T_42: "|=",
+		token.T_43: "|~",
+	},
+	// LabelKey : ∙line_format
+	{
+		token.T_27: "line_format",
+	},
+	// LabelKey : line_format ∙
+	{
+		token.T_0:  "!=",
+		token.T_1:  "!~",
+		token.EOF:  "$",
+		token.T_4:  ")",
+		token.T_7:  ",",
+		token.T_10: "<",
+		token.T_11: "<=",
+		token.T_12: "=",
+		token.T_13: "==",
+		token.T_14: "=~",
+		token.T_15: ">",
+		token.T_16: ">=",
+		token.T_21: "duration",
+		token.T_41: "|",
+		token.T_42: "|=",
+		token.T_43: "|~",
+	},
+	// LabelKey : ∙label_format
+	{
+		token.T_26: "label_format",
+	},
+	// LabelKey : label_format ∙
+	{
+		token.T_0:  "!=",
+		token.T_1:  "!~",
+		token.EOF:  "$",
+		token.T_4:  ")",
+		token.T_7:  ",",
+		token.T_10: "<",
+		token.T_11: "<=",
+		token.T_12: "=",
+		token.T_13: "==",
+		token.T_14: "=~",
+		token.T_15: ">",
+		token.T_16: ">=",
+		token.T_21: "duration",
+		token.T_41: "|",
+		token.T_42: "|=",
+		token.T_43: "|~",
+	},
+	// LabelKeys : ∙LabelKey
+	{
+		token.T_20: "by",
+		token.T_25: "json",
+		token.T_26: "label_format",
+		token.T_27: "line_format",
+		token.T_28: "logfmt",
+		token.T_33: "pattern",
+		token.T_34: "regexp",
+		token.T_37: "unwrap",
+		token.T_38: "var_name",
+		token.T_39: "without",
+	},
+	// LabelKeys : LabelKey ∙
+	{
+		token.T_4: ")",
+	},
+	// LabelKeys : ∙LabelKey , LabelKeys
+	{
+		token.T_20: "by",
+		token.T_25: "json",
+		token.T_26: "label_format",
+		token.T_27: "line_format",
+		token.T_28: "logfmt",
+		token.T_33: "pattern",
+		token.T_34: "regexp",
+		token.T_37: "unwrap",
+		token.T_38: "var_name",
+		token.T_39: "without",
+	},
+	// LabelKeys : LabelKey ∙, LabelKeys
+	{
+		token.T_7: ",",
+	},
+	// LabelKeys : LabelKey , ∙LabelKeys
+	{
+		token.T_20: "by",
+		token.T_25: "json",
+		token.T_26: "label_format",
+		token.T_27: "line_format",
+		token.T_28: "logfmt",
+		token.T_33: "pattern",
+		token.T_34: "regexp",
+		token.T_37: "unwrap",
+		token.T_38: "var_name",
+		token.T_39: "without",
+	},
+	// LabelKeys : LabelKey , LabelKeys ∙
+	{
+		token.T_4: ")",
+	},
+	// LabelKeysMaybe : ∙
+	{
+		token.T_4: ")",
+	},
+	// LabelKeysMaybe : ∙LabelKeys
+	{
+		token.T_20: "by",
+		token.T_25: "json",
+		token.T_26: "label_format",
+		token.T_27: "line_format",
+		token.T_28: "logfmt",
+		token.T_33: "pattern",
+		token.T_34: "regexp",
+		token.T_37: "unwrap",
+		token.T_38: "var_name",
+		token.T_39: "without",
+	},
+	// LabelKeysMaybe : LabelKeys ∙
+	{
+		token.T_4: ")",
+	},
+	// LineFilter : ∙LineFilterOp LineFilterValues
+	{
+		token.T_0:  "!=",
+		token.T_1:  "!~",
+		token.T_42: "|=",
+		token.T_43: "|~",
+	},
+	// LineFilter : LineFilterOp ∙LineFilterValues
+	{
+		token.T_35: "string",
+	},
+	// LineFilter : LineFilterOp LineFilterValues ∙
+	{
+		token.T_0:  "!=",
+		token.T_1:  "!~",
+		token.EOF:  "$",
+		token.T_21: "duration",
+		token.T_41: "|",
+		token.T_42: "|=",
+		token.T_43: "|~",
+	},
+	// LineFilterOp : ∙|=
+	{
+		token.T_42: "|=",
+	},
+	// LineFilterOp : |= ∙
+	{
+		token.T_35: "string",
+	},
+	// LineFilterOp : ∙!=
+	{
+		token.T_0: "!=",
+	},
+	// LineFilterOp : != ∙
+	{
+		token.T_35: "string",
+	},
+	// LineFilterOp : ∙|~
+	{
+		token.T_43: "|~",
+	},
+	// LineFilterOp : |~ ∙
+	{
+		token.T_35: "string",
+	},
+	// LineFilterOp : ∙!~
+	{
+		token.T_1: "!~",
+	},
+	// LineFilterOp : !~ ∙
+	{
+		token.T_35: "string",
+	},
+	// LineFilterValues : ∙string
+	{
+		token.T_35: "string",
+	},
+	// LineFilterValues : string ∙
+	{
+		token.T_0:  "!=",
+		token.T_1:  "!~",
+		token.EOF:  "$",
+		token.T_21: "duration",
+		token.T_41: "|",
+		token.T_42: "|=",
+		token.T_43: "|~",
+	},
+	// LineFilterValues : ∙string or LineFilterValues
+	{
+		token.T_35: "string",
+	},
+	// LineFilterValues : string ∙or LineFilterValues
+	{
+		token.T_32: "or",
+	},
+	// LineFilterValues : string or ∙LineFilterValues
+	{
+		token.T_35: "string",
+	},
+	// LineFilterValues : string or LineFilterValues ∙
+	{
+		token.T_0:  "!=",
+		token.T_1:  "!~",
+		token.EOF:  "$",
+		token.T_21: "duration",
+		token.T_41: "|",
+		token.T_42: "|=",
+		token.T_43: "|~",
+	},
+	// LogQuery : ∙LogSelector PipelinesMaybe
+	{
+		token.T_40: "{",
+	},
+	// LogQuery : LogSelector ∙PipelinesMaybe
+	{
+		token.T_0:  "!=",
+		token.T_1:  "!~",
+		token.T_41: "|",
+		token.T_42: "|=",
+		token.T_43: "|~",
+		token.EOF:  "$",
+		token.T_21: "duration",
+	},
+	// LogQuery : LogSelector PipelinesMaybe ∙
+	{
+		token.EOF:  "$",
+		token.T_21: "duration",
+		token.T_41: "|",
+	},
+	// LogSelector : ∙{ LogSelectorMembersMaybe }
+	{
+		token.T_40: "{",
+	},
+	// LogSelector : { ∙LogSelectorMembersMaybe }
+	{
+		token.T_20: "by",
+		token.T_25: "json",
+		token.T_26: "label_format",
+		token.T_27: "line_format",
+		token.T_28: "logfmt",
+		token.T_33: "pattern",
+		token.T_34: "regexp",
+		token.T_37: "unwrap",
+		token.T_38: "var_name",
+		token.T_39: "without",
+		token.T_44: "}",
+	},
+	// LogSelector : { LogSelectorMembersMaybe ∙}
+	{
+		token.T_44: "}",
+	},
+	// LogSelector : { LogSelectorMembersMaybe } ∙
+	{
+		token.T_0:  "!=",
+		token.T_1:  "!~",
+		token.EOF:  "$",
+		token.T_21: "duration",
+		token.T_41: "|",
+		token.T_42: "|=",
+		token.T_43: "|~",
+	},
+	// LogSelectorMember : ∙LabelKey LogSelectorOp string
+	{
+		token.T_20: "by",
+		token.T_25: "json",
+		token.T_26: "label_format",
+		token.T_27: "line_format",
+		token.T_28: "logfmt",
+		token.T_33: "pattern",
+		token.T_34: "regexp",
+		token.T_37: "unwrap",
+		token.T_38: "var_name",
+		token.T_39: "without",
+	},
+	// LogSelectorMember : LabelKey ∙LogSelectorOp string
+	{
+		token.T_0:  "!=",
+		token.T_1:  "!~",
+		token.T_12: "=",
+		token.T_14: "=~",
+	},
+	// LogSelectorMember : LabelKey LogSelectorOp ∙string
+	{
+		token.T_35: "string",
+	},
+	// LogSelectorMember : LabelKey LogSelectorOp string ∙
+	{
+		token.T_7:  ",",
+		token.T_44: "}",
+	},
+	// LogSelectorMembers : ∙LogSelectorMember
+	{
+		token.T_20: "by",
+		token.T_25: "json",
+		token.T_26: "label_format",
+		token.T_27: "line_format",
+		token.T_28: "logfmt",
+		token.T_33: "pattern",
+		token.T_34: "regexp",
+		token.T_37: "unwrap",
+		token.T_38: "var_name",
+		token.T_39: "without",
+	},
+	// LogSelectorMembers : LogSelectorMember ∙
+	{
+		token.T_44: "}",
+	},
+	// LogSelectorMembers : ∙LogSelectorMember , LogSelectorMembers
+	{
+		token.T_20: "by",
+		token.T_25: "json",
+		token.T_26: "label_format",
+		token.T_27: "line_format",
+		token.T_28: "logfmt",
+		token.T_33: "pattern",
+		token.T_34: "regexp",
+		token.T_37: "unwrap",
+		token.T_38: "var_name",
+		token.T_39: "without",
+	},
+	// LogSelectorMembers : LogSelectorMember ∙, LogSelectorMembers
+	{
+		token.T_7: ",",
+	},
+	// LogSelectorMembers : LogSelectorMember , ∙LogSelectorMembers
+	{
+		token.T_20: "by",
+		token.T_25: "json",
+		token.T_26: "label_format",
+		token.T_27: "line_format",
+		token.T_28: "logfmt",
+		token.T_33: "pattern",
+		token.T_34: "regexp",
+		token.T_37: "unwrap",
+		token.T_38: "var_name",
+		token.T_39: "without",
+	},
+	// LogSelectorMembers : LogSelectorMember , LogSelectorMembers ∙
+	{
+		token.T_44: "}",
+	},
+	// LogSelectorMembersMaybe : ∙
+	{
+		token.T_44: "}",
+	},
+	// LogSelectorMembersMaybe : ∙LogSelectorMembers
+	{
+		token.T_20: "by",
+		token.T_25: "json",
+		token.T_26: "label_format",
+		token.T_27: "line_format",
+		token.T_28: "logfmt",
+		token.T_33: "pattern",
+		token.T_34: "regexp",
+		token.T_37: "unwrap",
+		token.T_38: "var_name",
+		token.T_39: "without",
+	},
+	// LogSelectorMembersMaybe : LogSelectorMembers ∙
+	{
+		token.T_44: "}",
+	},
+	// LogSelectorOp : ∙=
+	{
+		token.T_12: "=",
+	},
+	// LogSelectorOp : = ∙
+	{
+		token.T_35: "string",
+	},
+	// LogSelectorOp : ∙!=
+	{
+		token.T_0: "!=",
+	},
+	// LogSelectorOp : != ∙
+	{
+		token.T_35: "string",
+	},
+	// LogSelectorOp : ∙=~
+	{
+		token.T_14: "=~",
+	},
+	// LogSelectorOp : =~ ∙
+	{
+		token.T_35: "string",
+	},
+	// LogSelectorOp : ∙!~
+	{
+		token.T_1: "!~",
+	},
+	// LogSelectorOp : !~ ∙
+	{
+		token.T_35: "string",
+	},
+	// Matching : ∙MatchingOp ( LabelKeysMaybe )
+	{
+		token.T_24: "ignoring",
+		token.T_31: "on",
+	},
+	// Matching : MatchingOp ∙( LabelKeysMaybe )
+	{
+		token.T_3: "(",
+	},
+	// Matching : MatchingOp ( ∙LabelKeysMaybe )
+	{
+		token.T_4:  ")",
+		token.T_20: "by",
+		token.T_25: "json",
+		token.T_26: "label_format",
+		token.T_27: "line_format",
+		token.T_28: "logfmt",
+		token.T_33: "pattern",
+		token.T_34: "regexp",
+		token.T_37: "unwrap",
+		token.T_38: "var_name",
+		token.T_39: "without",
+	},
+	// Matching : MatchingOp ( LabelKeysMaybe ∙)
+	{
+		token.T_4: ")",
+	},
+	// Matching : MatchingOp ( LabelKeysMaybe ) ∙
+	{
+		token.T_3:  "(",
+		token.T_8:  "-",
+		token.T_22: "group_left",
+		token.T_23: "group_right",
+		token.T_30: "num",
+		token.T_38: "var_name",
+	},
+	// MatchingMaybe : ∙
+	{
+		token.T_3:  "(",
+		token.T_8:  "-",
+		token.T_30: "num",
+		token.T_38: "var_name",
+	},
+	// MatchingMaybe : ∙Matching GroupModifierMaybe
+	{
+		token.T_24: "ignoring",
+		token.T_31: "on",
+	},
+	// MatchingMaybe : Matching ∙GroupModifierMaybe
+	{
+		token.T_22: "group_left",
+		token.T_23: "group_right",
+		token.T_3:  "(",
+		token.T_8:  "-",
+		token.T_30: "num",
+		token.T_38: "var_name",
+	},
+	// MatchingMaybe : Matching GroupModifierMaybe ∙
+	{
+		token.T_3:  "(",
+		token.T_8:  "-",
+		token.T_30: "num",
+		token.T_38: "var_name",
+	},
+	// MatchingOp : ∙on
+	{
+		token.T_31: "on",
+	},
+	// MatchingOp : on ∙
+	{
+		token.T_3: "(",
+	},
+	// MatchingOp : ∙ignoring
+	{
+		token.T_24: "ignoring",
+	},
+	// MatchingOp : ignoring ∙
+	{
+		token.T_3: "(",
+	},
+	// MetricAdd : ∙MetricMul
+	{
+		token.T_3:  "(",
+		token.T_8:  "-",
+		token.T_30: "num",
+		token.T_38: "var_name",
+	},
+	// MetricAdd : MetricMul ∙
+	{
+		token.T_0:  "!=",
+		token.EOF:  "$",
+		token.T_4:  ")",
+		token.T_6:  "+",
+		token.T_8:  "-",
+		token.T_10: "<",
+		token.T_11: "<=",
+		token.T_13: "==",
+		token.T_15: ">",
+		token.T_16: ">=",
+		token.T_18: "and",
+		token.T_32: "or",
+		token.T_36: "unless",
+	},
+	// MetricAdd : ∙MetricAdd AddOp BinModifiers MetricMul
+	{
+		token.T_3:  "(",
+		token.T_8:  "-",
+		token.T_30: "num",
+		token.T_38: "var_name",
+	},
+	// MetricAdd : MetricAdd ∙AddOp BinModifiers MetricMul
+	{
+		token.T_6: "+",
+		token.T_8: "-",
+	},
+	// MetricAdd : MetricAdd AddOp ∙BinModifiers MetricMul
+	{
+		token.T_3:  "(",
+		token.T_8:  "-",
+		token.T_19: "bool",
+		token.T_24: "ignoring",
+		token.T_30: "num",
+		token.T_31: "on",
+		token.T_38: "var_name",
+	},
+	// MetricAdd : MetricAdd AddOp BinModifiers ∙MetricMul
+	{
+		token.T_3:  "(",
+		token.T_8:  "-",
+		token.T_30: "num",
+		token.T_38: "var_name",
+	},
+	// MetricAdd : MetricAdd AddOp BinModifiers MetricMul ∙
+	{
+		token.T_0:  "!=",
+		token.EOF:  "$",
+		token.T_4:  ")",
+		token.T_6:  "+",
+		token.T_8:  "-",
+		token.T_10: "<",
+		token.T_11: "<=",
+		token.T_13: "==",
+		token.T_15: ">",
+		token.T_16: ">=",
+		token.T_18: "and",
+		token.T_32: "or",
+		token.T_36: "unless",
+	},
+	// MetricAnd : ∙MetricCmp
+	{
+		token.T_3:  "(",
+		token.T_8:  "-",
+		token.T_30: "num",
+		token.T_38: "var_name",
+	},
+	// MetricAnd : MetricCmp ∙
+	{
+		token.EOF:  "$",
+		token.T_4:  ")",
+		token.T_18: "and",
+		token.T_32: "or",
+		token.T_36: "unless",
+	},
+	// MetricAnd : ∙MetricAnd AndOp BinModifiers MetricCmp
+	{
+		token.T_3:  "(",
+		token.T_8:  "-",
+		token.T_30: "num",
+		token.T_38: "var_name",
+	},
+	// MetricAnd : MetricAnd ∙AndOp BinModifiers MetricCmp
+	{
+		token.T_18: "and",
+		token.T_36: "unless",
+	},
+	// MetricAnd : MetricAnd AndOp ∙BinModifiers MetricCmp
+	{
+		token.T_3:  "(",
+		token.T_8:  "-",
+		token.T_19: "bool",
+		token.T_24: "ignoring",
+		token.T_30: "num",
+		token.T_31: "on",
+		token.T_38: "var_name",
+	},
+	// MetricAnd : MetricAnd AndOp BinModifiers ∙MetricCmp
+	{
+		token.T_3:  "(",
+		token.T_8:  "-",
+		token.T_30: "num",
+		token.T_38: "var_name",
+	},
+	// MetricAnd : MetricAnd AndOp BinModifiers MetricCmp ∙
+	{
+		token.EOF:  "$",
+		token.T_4:  ")",
+		token.T_18: "and",
+		token.T_32: "or",
+		token.T_36: "unless",
+	},
+	// MetricCmp : ∙MetricAdd
+	{
+		token.T_3:  "(",
+		token.T_8:  "-",
+		token.T_30: "num",
+		token.T_38: "var_name",
+	},
+	// MetricCmp : MetricAdd ∙
+	{
+		token.T_0:  "!=",
+		token.EOF:  "$",
+		token.T_4:  ")",
+		token.T_10: "<",
+		token.T_11: "<=",
+		token.T_13: "==",
+		token.T_15: ">",
+		token.T_16: ">=",
+		token.T_18: "and",
+		token.T_32: "or",
+		token.T_36: "unless",
+	},
+	// MetricCmp : ∙MetricCmp CmpOp BinModifiers MetricAdd
+	{
+		token.T_3:  "(",
+		token.T_8:  "-",
+		token.T_30: "num",
+		token.T_38: "var_name",
+	},
+	// MetricCmp : MetricCmp ∙CmpOp BinModifiers MetricAdd
+	{
+		token.T_0:  "!=",
+		token.T_10: "<",
+		token.T_11: "<=",
+		token.T_13: "==",
+		token.T_15: ">",
+		token.T_16: ">=",
+	},
+	// MetricCmp : MetricCmp CmpOp ∙BinModifiers MetricAdd
+	{
+		token.T_3:  "(",
+		token.T_8:  "-",
+		token.T_19: "bool",
+		token.T_24: "ignoring",
+		token.T_30: "num",
+		token.T_31: "on",
+		token.T_38: "var_name",
+	},
+	// MetricCmp : MetricCmp CmpOp BinModifiers ∙MetricAdd
+	{
+		token.T_3:  "(",
+		token.T_8:  "-",
+		token.T_30: "num",
+		token.T_38: "var_name",
+	},
+	// MetricCmp : MetricCmp CmpOp BinModifiers MetricAdd ∙
+	{
+		token.T_0:  "!=",
+		token.EOF:  "$",
+		token.T_4:  ")",
+		token.T_10: "<",
+		token.T_11: "<=",
+		token.T_13: "==",
+		token.T_15: ">",
+		token.T_16: ">=",
+		token.T_18: "and",
+		token.T_32: "or",
+		token.T_36: "unless",
+	},
+	// MetricMul : ∙MetricUnary
+	{
+		token.T_3:  "(",
+		token.T_8:  "-",
+		token.T_30: "num",
+		token.T_38: "var_name",
+	},
+	// MetricMul : MetricUnary ∙
+	{
+		token.T_0:  "!=",
+		token.EOF:  "$",
+		token.T_2:  "%",
+		token.T_4:  ")",
+		token.T_5:  "*",
+		token.T_6:  "+",
+		token.T_8:  "-",
+		token.T_9:  "/",
+		token.T_10: "<",
+		token.T_11: "<=",
+		token.T_13: "==",
+		token.T_15: ">",
+		token.T_16: ">=",
+		token.T_18: "and",
+		token.T_32: "or",
+		token.T_36: "unless",
+	},
+	// MetricMul : ∙MetricMul MulOp BinModifiers MetricUnary
+	{
+		token.T_3:  "(",
+		token.T_8:  "-",
+		token.T_30: "num",
+		token.T_38: "var_name",
+	},
+	// MetricMul : MetricMul ∙MulOp BinModifiers MetricUnary
+	{
+		token.T_2: "%",
+		token.T_5: "*",
+		token.T_9: "/",
+	},
+	// MetricMul : MetricMul MulOp ∙BinModifiers MetricUnary
+	{
+		token.T_3:  "(",
+		token.T_8:  "-",
+		token.T_19: "bool",
+		token.T_24: "ignoring",
+		token.T_30: "num",
+		token.T_31: "on",
+		token.T_38: "var_name",
+	},
+	// MetricMul : MetricMul MulOp BinModifiers ∙MetricUnary
+	{
+		token.T_3:  "(",
+		token.T_8:  "-",
+		token.T_30: "num",
+		token.T_38: "var_name",
+	},
+	// MetricMul : MetricMul MulOp BinModifiers MetricUnary ∙
+	{
+		token.T_0:  "!=",
+		token.EOF:  "$",
+		token.T_2:  "%",
+		token.T_4:  ")",
+		token.T_5:  "*",
+		token.T_6:  "+",
+		token.T_8:  "-",
+		token.T_9:  "/",
+		token.T_10: "<",
+		token.T_11: "<=",
+		token.T_13: "==",
+		token.T_15: ">",
+		token.T_16: ">=",
+		token.T_18: "and",
+		token.T_32: "or",
+		token.T_36: "unless",
+	},
+	// MetricOr : ∙MetricAnd
+	{
+		token.T_3:  "(",
+		token.T_8:  "-",
+		token.T_30: "num",
+		token.T_38: "var_name",
+	},
+	// MetricOr : MetricAnd ∙
+	{
+		token.EOF:  "$",
+		token.T_4:  ")",
+		token.T_32: "or",
+	},
+	// MetricOr : ∙MetricOr OrOp BinModifiers MetricAnd
+	{
+		token.T_3:  "(",
+		token.T_8:  "-",
+		token.T_30: "num",
+		token.T_38: "var_name",
+	},
+	// MetricOr : MetricOr ∙OrOp BinModifiers MetricAnd
+	{
+		token.T_32: "or",
+	},
+	// MetricOr : MetricOr OrOp ∙BinModifiers MetricAnd
+	{
+		token.T_3:  "(",
+		token.T_8:  "-",
+		token.T_19: "bool",
+		token.T_24: "ignoring",
+		token.T_30: "num",
+		token.T_31: "on",
+		token.T_38: "var_name",
+	},
+	// MetricOr : MetricOr OrOp BinModifiers ∙MetricAnd
+	{
+		token.T_3:  "(",
+		token.T_8:  "-",
+		token.T_30: "num",
+		token.T_38: "var_name",
+	},
+	// MetricOr : MetricOr OrOp BinModifiers MetricAnd ∙
+	{
+		token.EOF:  "$",
+		token.T_4:  ")",
+		token.T_32: "or",
+	},
+	// MetricPow : ∙MetricTerm
+	{
+		token.T_3:  "(",
+		token.T_30: "num",
+		token.T_38: "var_name",
+	},
+	// MetricPow : MetricTerm ∙
+	{
+		token.T_0:  "!=",
+		token.EOF:  "$",
+		token.T_2:  "%",
+		token.T_4:  ")",
+		token.T_5:  "*",
+		token.T_6:  "+",
+		token.T_8:  "-",
+		token.T_9:  "/",
+		token.T_10: "<",
+		token.T_11: "<=",
+		token.T_13: "==",
+		token.T_15: ">",
+		token.T_16: ">=",
+		token.T_18: "and",
+		token.T_32: "or",
+		token.T_36: "unless",
+	},
+	// MetricPow : ∙MetricTerm PowOp BinModifiers MetricUnary
+	{
+		token.T_3:  "(",
+		token.T_30: "num",
+		token.T_38: "var_name",
+	},
+	// MetricPow : MetricTerm ∙PowOp BinModifiers MetricUnary
+	{
+		token.T_17: "^",
+	},
+	// MetricPow : MetricTerm PowOp ∙BinModifiers MetricUnary
+	{
+		token.T_3:  "(",
+		token.T_8:  "-",
+		token.T_19: "bool",
+		token.T_24: "ignoring",
+		token.T_30: "num",
+		token.T_31: "on",
+		token.T_38: "var_name",
+	},
+	// MetricPow : MetricTerm PowOp BinModifiers ∙MetricUnary
+	{
+		token.T_3:  "(",
+		token.T_8:  "-",
+		token.T_30: "num",
+		token.T_38: "var_name",
+	},
+	// MetricPow : MetricTerm PowOp BinModifiers MetricUnary ∙
+	{
+		token.T_0:  "!=",
+		token.EOF:  "$",
+		token.T_2:  "%",
+		token.T_4:  ")",
+		token.T_5:  "*",
+		token.T_6:  "+",
+		token.T_8:  "-",
+		token.T_9:  "/",
+		token.T_10: "<",
+		token.T_11: "<=",
+		token.T_13: "==",
+		token.T_15: ">",
+		token.T_16: ">=",
+		token.T_18: "and",
+		token.T_32: "or",
+		token.T_36: "unless",
+	},
+	// MetricQuery : ∙MetricOr
+	{
+		token.T_3:  "(",
+		token.T_8:  "-",
+		token.T_30: "num",
+		token.T_38: "var_name",
+	},
+	// MetricQuery : MetricOr ∙
+	{
+		token.EOF: "$",
+		token.T_4: ")",
+	},
+	// MetricTerm : ∙RangeAggregation
+	{
+		token.T_38: "var_name",
+	},
+	// MetricTerm : RangeAggregation ∙
+	{
+		token.T_0:  "!=",
+		token.EOF:  "$",
+		token.T_2:  "%",
+		token.T_4:  ")",
+		token.T_5:  "*",
+		token.T_6:  "+",
+		token.T_8:  "-",
+		token.T_9:  "/",
+		token.T_10: "<",
+		token.T_11: "<=",
+		token.T_13: "==",
+		token.T_15: ">",
+		token.T_16: ">=",
+		token.T_17: "^",
+		token.T_18: "and",
+		token.T_32: "or",
+		token.T_36: "unless",
+	},
+	// MetricTerm : ∙VectorAggregation
+	{
+		token.T_38: "var_name",
+	},
+	// MetricTerm : VectorAggregation ∙
+	{
+		token.T_0:  "!=",
+		token.EOF:  "$",
+		token.T_2:  "%",
+		token.T_4:  ")",
+		token.T_5:  "*",
+		token.T_6:  "+",
+		token.T_8:  "-",
+		token.T_9:  "/",
+		token.T_10: "<",
+		token.T_11: "<=",
+		token.T_13: "==",
+		token.T_15: ">",
+		token.T_16: ">=",
+		token.T_17: "^",
+		token.T_18: "and",
+		token.T_32: "or",
+		token.T_36: "unless",
+	},
+	// MetricTerm : ∙num
+	{
+		token.T_30: "num",
+	},
+	// MetricTerm : num ∙
+	{
+		token.T_0:  "!=",
+		token.EOF:  "$",
+		token.T_2:  "%",
+		token.T_4:  ")",
+		token.T_5:  "*",
+		token.T_6:  "+",
+		token.T_8:  "-",
+		token.T_9:  "/",
+		token.T_10: "<",
+		token.T_11: "<=",
+		token.T_13: "==",
+		token.T_15: ">",
+		token.T_16: ">=",
+		token.T_17: "^",
+		token.T_18: "and",
+		token.T_32: "or",
+		token.T_36: "unless",
+	},
+	// MetricTerm : ∙( MetricQuery )
+	{
+		token.T_3: "(",
+	},
+	// MetricTerm : ( ∙MetricQuery )
+	{
+		token.T_3:  "(",
+		token.T_8:  "-",
+		token.T_30: "num",
+		token.T_38: "var_name",
+	},
+	// MetricTerm : ( MetricQuery ∙)
+	{
+		token.T_4: ")",
+	},
+	// MetricTerm : ( MetricQuery ) ∙
+	{
+		token.T_0:  "!=",
+		token.EOF:  "$",
+		token.T_2:  "%",
+		token.T_4:  ")",
+		token.T_5:  "*",
+		token.T_6:  "+",
+		token.T_8:  "-",
+		token.T_9:  "/",
+		token.T_10: "<",
+		token.T_11: "<=",
+		token.T_13: "==",
+		token.T_15: ">",
+		token.T_16: ">=",
+		token.T_17: "^",
+		token.T_18: "and",
+		token.T_32: "or",
+		token.T_36: "unless",
+	},
+	// MetricUnary : ∙MetricPow
+	{
+		token.T_3:  "(",
+		token.T_30: "num",
+		token.T_38: "var_name",
+	},
+	// MetricUnary : MetricPow ∙
+	{
+		token.T_0:  "!=",
+		token.EOF:  "$",
+		token.T_2:  "%",
+		token.T_4:  ")",
+		token.T_5:  "*",
+		token.T_6:  "+",
+		token.T_8:  "-",
+		token.T_9:  "/",
+		token.T_10: "<",
+		token.T_11: "<=",
+		token.T_13: "==",
+		token.T_15: ">",
+		token.T_16: ">=",
+		token.T_18: "and",
+		token.T_32: "or",
+		token.T_36: "unless",
+	},
+	// MetricUnary : ∙- MetricUnary
+	{
+		token.T_8: "-",
+	},
+	// MetricUnary : - ∙MetricUnary
+	{
+		token.T_3:  "(",
+		token.T_8:  "-",
+		token.T_30: "num",
+		token.T_38: "var_name",
+	},
+	// MetricUnary : - MetricUnary ∙
+	{
+		token.T_0:  "!=",
+		token.EOF:  "$",
+		token.T_2:  "%",
+		token.T_4:  ")",
+		token.T_5:  "*",
+		token.T_6:  "+",
+		token.T_8:  "-",
+		token.T_9:  "/",
+		token.T_10: "<",
+		token.T_11: "<=",
+		token.T_13: "==",
+		token.T_15: ">",
+		token.T_16: ">=",
+		token.T_18: "and",
+		token.T_32: "or",
+		token.T_36: "unless",
+	},
+	// MulOp : ∙*
+	{
+		token.T_5: "*",
+	},
+	// MulOp : * ∙
+	{
+		token.T_3:  "(",
+		token.T_8:  "-",
+		token.T_19: "bool",
+		token.T_24: "ignoring",
+		token.T_30: "num",
+		token.T_31: "on",
+		token.T_38: "var_name",
+	},
+	// MulOp : ∙/
+	{
+		token.T_9: "/",
+	},
+	// MulOp : / ∙
+	{
+		token.T_3:  "(",
+		token.T_8:  "-",
+		token.T_19: "bool",
+		token.T_24: "ignoring",
+		token.T_30: "num",
+		token.T_31: "on",
+		token.T_38: "var_name",
+	},
+	// MulOp : ∙%
+	{
+		token.T_2: "%",
+	},
+	// MulOp : % ∙
+	{
+		token.T_3:  "(",
+		token.T_8:  "-",
+		token.T_19: "bool",
+		token.T_24: "ignoring",
+		token.T_30: "num",
+		token.T_31: "on",
+		token.T_38: "var_name",
+	},
+	// OrOp : ∙or
+	{
+		token.T_32: "or",
+	},
+	// OrOp : or ∙
+	{
+		token.T_3:  "(",
+		token.T_8:  "-",
+		token.T_19: "bool",
+		token.T_24: "ignoring",
+		token.T_30: "num",
+		token.T_31: "on",
+		token.T_38: "var_name",
+	},
+	// ParserStage : ∙| json
 	{
-		token.T_19: "|",
+		token.T_41: "|",
 	},
-	// DataFilter : | ∙string DataFilterOp string
+	// ParserStage : | ∙json
 	{
-		token.T_15: "string",
+		token.T_25: "json",
 	},
-	// DataFilter : | string ∙DataFilterOp string
+	// ParserStage : | json ∙
 	{
 		token.T_0:  "!=",
 		token.T_1:  "!~",
-		token.T_5:  "<",
-		token.T_6:  "<=",
-		token.T_7:  "=",
-		token.T_8:  "=~",
-		token.T_9:  ">",
-		token.T_10: ">=",
+		token.EOF:  "$",
+		token.T_21: "duration",
+		token.T_41: "|",
+		token.T_42: "|=",
+		token.T_43: "|~",
+	},
+	// ParserStage : ∙| logfmt
+	{
+		token.T_41: "|",
 	},
-	// DataFilter : | string DataFilterOp ∙string
+	// ParserStage : | ∙logfmt
 	{
-		token.T_15: "string",
+		token.T_28: "logfmt",
 	},
-	// DataFilter : | string DataFilterOp string ∙
+	// ParserStage : | logfmt ∙
 	{
 		token.T_0:  "!=",
 		token.T_1:  "!~",
 		token.EOF:  "$",
-		token.T_13: "duration",
-		token.T_19: "|",
-		token.T_20: "|=",
-		token.T_21: "|~",
+		token.T_21: "duration",
+		token.T_41: "|",
+		token.T_42: "|=",
+		token.T_43: "|~",
 	},
-	// DataFilterOp : ∙=
+	// ParserStage : ∙| regexp string
 	{
-		token.T_7: "=",
+		token.T_41: "|",
 	},
-	// DataFilterOp : = ∙
+	// ParserStage : | ∙regexp string
 	{
-		token.T_15: "string",
+		token.T_34: "regexp",
 	},
-	// DataFilterOp : ∙!=
+	// ParserStage : | regexp ∙string
 	{
-		token.T_0: "!=",
+		token.T_35: "string",
 	},
-	// DataFilterOp : != ∙
+	// ParserStage : | regexp string ∙
 	{
-		token.T_15: "string",
+		token.T_0:  "!=",
+		token.T_1:  "!~",
+		token.EOF:  "$",
+		token.T_21: "duration",
+		token.T_41: "|",
+		token.T_42: "|=",
+		token.T_43: "|~",
 	},
-	// DataFilterOp : ∙=~
+	// ParserStage : ∙| pattern string
 	{
-		token.T_8: "=~",
+		token.T_41: "|",
 	},
-	// DataFilterOp : =~ ∙
+	// ParserStage : | ∙pattern string
 	{
-		token.T_15: "string",
+		token.T_33: "pattern",
 	},
-	// DataFilterOp : ∙!~
+	// ParserStage : | pattern ∙string
 	{
-		token.T_1: "!~",
+		token.T_35: "string",
 	},
-	// DataFilterOp : !~ ∙
+	// ParserStage : | pattern string ∙
 	{
-		token.T_15: "string",
+		token.T_0:  "!=",
+		token.T_1:  "!~",
+		token.EOF:  "$",
+		token.T_21: "duration",
+		token.T_41: "|",
+		token.T_42: "|=",
+		token.T_43: "|~",
 	},
-	// DataFilterOp : ∙>=
+	// Pipeline : ∙LineFilter
 	{
-		token.T_10: ">=",
+		token.T_0:  "!=",
+		token.T_1:  "!~",
+		token.T_42: "|=",
+		token.T_43: "|~",
 	},
-	// DataFilterOp : >= ∙
+	// Pipeline : LineFilter ∙
 	{
-		token.T_15: "string",
+		token.T_0:  "!=",
+		token.T_1:  "!~",
+		token.EOF:  "$",
+		token.T_21: "duration",
+		token.T_41: "|",
+		token.T_42: "|=",
+		token.T_43: "|~",
 	},
-	// DataFilterOp : ∙>
+	// Pipeline : ∙FilterStage
 	{
-		token.T_9: ">",
+		token.T_41: "|",
 	},
-	// DataFilterOp : > ∙
+	// Pipeline : FilterStage ∙
 	{
-		token.T_15: "string",
+		token.T_0:  "!=",
+		token.T_1:  "!~",
+		token.EOF:  "$",
+		token.T_21: "duration",
+		token.T_41: "|",
+		token.T_42: "|=",
+		token.T_43: "|~",
 	},
-	// DataFilterOp : ∙<=
+	// Pipeline : ∙ParserStage
 	{
-		token.T_6: "<=",
+		token.T_41: "|",
 	},
-	// DataFilterOp : <= ∙
+	// Pipeline : ParserStage ∙
 	{
-		token.T_15: "string",
+		token.T_0:  "!=",
+		token.T_1:  "!~",
+		token.EOF:  "$",
+		token.T_21: "duration",
+		token.T_41: "|",
+		token.T_42: "|=",
+		token.T_43: "|~",
 	},
-	// DataFilterOp : ∙<
+	// Pipeline : ∙FormatStage
 	{
-		token.T_5: "<",
+		token.T_41: "|",
 	},
-	// DataFilterOp : < ∙
+	// Pipeline : FormatStage ∙
 	{
-		token.T_15: "string",
+		token.T_0:  "!=",
+		token.T_1:  "!~",
+		token.EOF:  "$",
+		token.T_21: "duration",
+		token.T_41: "|",
+		token.T_42: "|=",
+		token.T_43: "|~",
 	},
-	// LabelKey : ∙var_name
+	// Pipelines : ∙Pipeline
 	{
-		token.T_17: "var_name",
+		token.T_0:  "!=",
+		token.T_1:  "!~",
+		token.T_41: "|",
+		token.T_42: "|=",
+		token.T_43: "|~",
 	},
-	// LabelKey : var_name ∙
+	// Pipelines : Pipeline ∙
 	{
-		token.T_0: "!=",
-		token.T_1: "!~",
-		token.T_7: "=",
-		token.T_8: "=~",
+		token.EOF:  "$",
+		token.T_21: "duration",
+		token.T_41: "|",
 	},
-	// LineFilter : ∙LineFilterOp string
+	// Pipelines : ∙Pipeline Pipelines
 	{
 		token.T_0:  "!=",
 		token.T_1:  "!~",
-		token.T_20: "|=",
-		token.T_21: "|~",
-	},
-	// LineFilter : LineFilterOp ∙string
-	{
-		token.T_15: "string",
+		token.T_41: "|",
+		token.T_42: "|=",
+		token.T_43: "|~",
 	},
-	// LineFilter : LineFilterOp string ∙
+	// Pipelines : Pipeline ∙Pipelines
 	{
 		token.T_0:  "!=",
 		token.T_1:  "!~",
+		token.T_41: "|",
+		token.T_42: "|=",
+		token.T_43: "|~",
+	},
+	// Pipelines : Pipeline Pipelines ∙
+	{
 		token.EOF:  "$",
-		token.T_13: "duration",
-		token.T_19: "|",
-		token.T_20: "|=",
-		token.T_21: "|~",
+		token.T_21: "duration",
+		token.T_41: "|",
 	},
-	// LineFilterOp : ∙|=
+	// PipelinesMaybe : ∙
 	{
-		token.T_20: "|=",
+		token.EOF:  "$",
+		token.T_21: "duration",
+		token.T_41: "|",
 	},
-	// LineFilterOp : |= ∙
+	// PipelinesMaybe : ∙Pipelines
 	{
-		token.T_15: "string",
+		token.T_0:  "!=",
+		token.T_1:  "!~",
+		token.T_41: "|",
+		token.T_42: "|=",
+		token.T_43: "|~",
 	},
-	// LineFilterOp : ∙!=
+	// PipelinesMaybe : Pipelines ∙
 	{
-		token.T_0: "!=",
+		token.EOF:  "$",
+		token.T_21: "duration",
+		token.T_41: "|",
 	},
-	// LineFilterOp : != ∙
+	// PowOp : ∙^
 	{
-		token.T_15: "string",
+		token.T_17: "^",
 	},
-	// LineFilterOp : ∙|~
+	// PowOp : ^ ∙
 	{
-		token.T_21: "|~",
+		token.T_3:  "(",
+		token.T_8:  "-",
+		token.T_19: "bool",
+		token.T_24: "ignoring",
+		token.T_30: "num",
+		token.T_31: "on",
+		token.T_38: "var_name",
 	},
-	// LineFilterOp : |~ ∙
+	// Query : ∙LogQuery
 	{
-		token.T_15: "string",
+		token.T_40: "{",
 	},
-	// LineFilterOp : ∙!~
+	// Query : LogQuery ∙
 	{
-		token.T_1: "!~",
+		token.EOF: "$",
 	},
-	// LineFilterOp : !~ ∙
+	// Query : ∙MetricQuery
 	{
-		token.T_15: "string",
+		token.T_3:  "(",
+		token.T_8:  "-",
+		token.T_30: "num",
+		token.T_38: "var_name",
 	},
-	// LogQuery : ∙LogSelector PipelinesMaybe
+	// Query : MetricQuery ∙
 	{
-		token.T_18: "{",
+		token.EOF: "$",
 	},
-	// LogQuery : LogSelector ∙PipelinesMaybe
+	// RangeAggregation : ∙var_name ( RangeParamMaybe LogQuery UnwrapMaybe duration )
 	{
-		token.T_0:  "!=",
-		token.T_1:  "!~",
-		token.T_19: "|",
-		token.T_20: "|=",
-		token.T_21: "|~",
-		token.EOF:  "$",
-		token.T_13: "duration",
+		token.T_38: "var_name",
 	},
-	// LogQuery : LogSelector PipelinesMaybe ∙
+	// RangeAggregation : var_name ∙( RangeParamMaybe LogQuery UnwrapMaybe duration )
 	{
-		token.EOF:  "$",
-		token.T_13: "duration",
+		token.T_3: "(",
 	},
-	// LogSelector : ∙{ LogSelectorMembersMaybe }
+	// RangeAggregation : var_name ( ∙RangeParamMaybe LogQuery UnwrapMaybe duration )
 	{
-		token.T_18: "{",
+		token.T_30: "num",
+		token.T_40: "{",
 	},
-	// LogSelector : { ∙LogSelectorMembersMaybe }
+	// RangeAggregation : var_name ( RangeParamMaybe ∙LogQuery UnwrapMaybe duration )
 	{
-		token.T_17: "var_name",
-		token.T_22: "}",
+		token.T_40: "{",
 	},
-	// LogSelector : { LogSelectorMembersMaybe ∙}
+	// RangeAggregation : var_name ( RangeParamMaybe LogQuery ∙UnwrapMaybe duration )
 	{
-		token.T_22: "}",
+		token.T_21: "duration",
+		token.T_41: "|",
 	},
-	// LogSelector : { LogSelectorMembersMaybe } ∙
+	// RangeAggregation : var_name ( RangeParamMaybe LogQuery UnwrapMaybe ∙duration )
 	{
-		token.T_0:  "!=",
-		token.T_1:  "!~",
-		token.EOF:  "$",
-		token.T_13: "duration",
-		token.T_19: "|",
-		token.T_20: "|=",
-		token.T_21: "|~",
+		token.T_21: "duration",
 	},
-	// LogSelectorMember : ∙LabelKey LogSelectorOp string
+	// RangeAggregation : var_name ( RangeParamMaybe LogQuery UnwrapMaybe duration ∙)
 	{
-		token.T_17: "var_name",
+		token.T_4: ")",
 	},
-	// LogSelectorMember : LabelKey ∙LogSelectorOp string
+	// RangeAggregation : var_name ( RangeParamMaybe LogQuery UnwrapMaybe duration ) ∙
 	{
-		token.T_0: "!=",
-		token.T_1: "!~",
-		token.T_7: "=",
-		token.T_8: "=~",
+		token.T_0:  "!=",
+		token.EOF:  "$",
+		token.T_2:  "%",
+		token.T_4:  ")",
+		token.T_5:  "*",
+		token.T_6:  "+",
+		token.T_8:  "-",
+		token.T_9:  "/",
+		token.T_10: "<",
+		token.T_11: "<=",
+		token.T_13: "==",
+		token.T_15: ">",
+		token.T_16: ">=",
+		token.T_17: "^",
+		token.T_18: "and",
+		token.T_32: "or",
+		token.T_36: "unless",
 	},
-	// LogSelectorMember : LabelKey LogSelectorOp ∙string
+	// RangeParamMaybe : ∙
 	{
-		token.T_15: "string",
+		token.T_40: "{",
 	},
-	// LogSelectorMember : LabelKey LogSelectorOp string ∙
+	// RangeParamMaybe : ∙num ,
 	{
-		token.T_4:  ",",
-		token.T_22: "}",
+		token.T_30: "num",
 	},
-	// LogSelectorMembers : ∙LogSelectorMember
+	// RangeParamMaybe : num ∙,
 	{
-		token.T_17: "var_name",
+		token.T_7: ",",
 	},
-	// LogSelectorMembers : LogSelectorMember ∙
+	// RangeParamMaybe : num , ∙
 	{
-		token.T_22: "}",
+		token.T_40: "{",
 	},
-	// LogSelectorMembers : ∙LogSelectorMember , LogSelectorMembers
+	// UnwrapField : ∙var_name
 	{
-		token.T_17: "var_name",
+		token.T_38: "var_name",
 	},
-	// LogSelectorMembers : LogSelectorMember ∙, LogSelectorMembers
+	// UnwrapField : var_name ∙
 	{
-		token.T_4: ",",
+		token.T_21: "duration",
 	},
-	// LogSelectorMembers : LogSelectorMember , ∙LogSelectorMembers
+	// UnwrapField : ∙string
 	{
-		token.T_17: "var_name",
+		token.T_35: "string",
 	},
-	// LogSelectorMembers : LogSelectorMember , LogSelectorMembers ∙
+	// UnwrapField : string ∙
 	{
-		token.T_22: "}",
+		token.T_21: "duration",
 	},
-	// LogSelectorMembersMaybe : ∙
+	// UnwrapMaybe : ∙
 	{
-		token.T_22: "}",
+		token.T_21: "duration",
 	},
-	// LogSelectorMembersMaybe : ∙LogSelectorMembers
+	// UnwrapMaybe : ∙| unwrap UnwrapField
 	{
-		token.T_17: "var_name",
+		token.T_41: "|",
 	},
-	// LogSelectorMembersMaybe : LogSelectorMembers ∙
+	// UnwrapMaybe : | ∙unwrap UnwrapField
 	{
-		token.T_22: "}",
+		token.T_37: "unwrap",
 	},
-	// LogSelectorOp : ∙=
+	// UnwrapMaybe : | unwrap ∙UnwrapField
 	{
-		token.T_7: "=",
+		token.T_35: "string",
+		token.T_38: "var_name",
 	},
-	// LogSelectorOp : = ∙
+	// UnwrapMaybe : | unwrap UnwrapField ∙
 	{
-		token.T_15: "string",
+		token.T_21: "duration",
 	},
-	// LogSelectorOp : ∙!=
+	// VectorAggregation : ∙var_name GroupingMaybe ( VectorParamMaybe MetricQuery ) GroupingMaybe
 	{
-		token.T_0: "!=",
+		token.T_38: "var_name",
 	},
-	// LogSelectorOp : != ∙
+	// VectorAggregation : var_name ∙GroupingMaybe ( VectorParamMaybe MetricQuery ) GroupingMaybe
 	{
-		token.T_15: "string",
+		token.T_3:  "(",
+		token.T_20: "by",
+		token.T_39: "without",
 	},
-	// LogSelectorOp : ∙=~
+	// VectorAggregation : var_name GroupingMaybe ∙( VectorParamMaybe MetricQuery ) GroupingMaybe
 	{
-		token.T_8: "=~",
+		token.T_3: "(",
 	},
-	// LogSelectorOp : =~ ∙
+	// VectorAggregation : var_name GroupingMaybe ( ∙VectorParamMaybe MetricQuery ) GroupingMaybe
 	{
-		token.T_15: "string",
+		token.T_3:  "(",
+		token.T_8:  "-",
+		token.T_30: "num",
+		token.T_38: "var_name",
 	},
-	// LogSelectorOp : ∙!~
+	// VectorAggregation : var_name GroupingMaybe ( VectorParamMaybe ∙MetricQuery ) GroupingMaybe
 	{
-		token.T_1: "!~",
+		token.T_3:  "(",
+		token.T_8:  "-",
+		token.T_30: "num",
+		token.T_38: "var_name",
 	},
-	// LogSelectorOp : !~ ∙
+	// VectorAggregation : var_name GroupingMaybe ( VectorParamMaybe MetricQuery ∙) GroupingMaybe
 	{
-		token.T_15: "string",
+		token.T_4: ")",
 	},
-	// MetricQuery : ∙sum by ( level ) ( count_over_time ( LogQuery duration ) )
+	// VectorAggregation : var_name GroupingMaybe ( VectorParamMaybe MetricQuery ) ∙GroupingMaybe
 	{
-		token.T_16: "sum",
+		token.T_20: "by",
+		token.T_39: "without",
+		token.T_0:  "!=",
+		token.EOF:  "$",
+		token.T_2:  "%",
+		token.T_4:  ")",
+		token.T_5:  "*",
+		token.T_6:  "+",
+		token.T_8:  "-",
+		token.T_9:  "/",
+		token.T_10: "<",
+		token.T_11: "<=",
+		token.T_13: "==",
+		token.T_15: ">",
+		token.T_16: ">=",
+		token.T_17: "^",
+		token.T_18: "and",
+		token.T_32: "or",
+		token.T_36: "unless",
 	},
-	// MetricQuery : sum ∙by ( level ) ( count_over_time ( LogQuery duration ) )
+	// VectorAggregation : var_name GroupingMaybe ( VectorParamMaybe MetricQuery ) GroupingMaybe ∙
 	{
-		token.T_11: "by",
+		token.T_0:  "!=",
+		token.EOF:  "$",
+		token.T_2:  "%",
+		token.T_4:  ")",
+		token.T_5:  "*",
+		token.T_6:  "+",
+		token.T_8:  "-",
+		token.T_9:  "/",
+		token.T_10: "<",
+		token.T_11: "<=",
+		token.T_13: "==",
+		token.T_15: ">",
+		token.T_16: ">=",
+		token.T_17: "^",
+		token.T_18: "and",
+		token.T_32: "or",
+		token.T_36: "unless",
 	},
-	// MetricQuery : sum by ∙( level ) ( count_over_time ( LogQuery duration ) )
+	// VectorParamMaybe : ∙
 	{
-		token.T_2: "(",
+		token.T_3:  "(",
+		token.T_8:  "-",
+		token.T_30: "num",
+		token.T_38: "var_name",
 	},
-	// MetricQuery : sum by ( ∙level ) ( count_over_time ( LogQuery duration ) )
+	// VectorParamMaybe : ∙num ,
 	{
-		token.T_14: "level",
+		token.T_30: "num",
 	},
-	// MetricQuery : sum by ( level ∙) ( count_over_time ( LogQuery duration ) )
+	// VectorParamMaybe : num ∙,
 	{
-		token.T_3: ")",
+		token.T_7: ",",
 	},
-	// MetricQuery : sum by ( level ) ∙( count_over_time ( LogQuery duration ) )
+	// VectorParamMaybe : num , ∙
 	{
-		token.T_2: "(",
+		token.T_3:  "(",
+		token.T_8:  "-",
+		token.T_30: "num",
+		token.T_38: "var_name",
 	},
-	// MetricQuery : sum by ( level ) ( ∙count_over_time ( LogQuery duration ) )
+}
+
+var followSets = []map[token.Type]string{
+	// AddOp
 	{
-		token.T_12: "count_over_time",
+		token.T_3:  "(",
+		token.T_8:  "-",
+		token.T_19: "bool",
+		token.T_24: "ignoring",
+		token.T_30: "num",
+		token.T_31: "on",
+		token.T_38: "var_name",
 	},
-	// MetricQuery : sum by ( level ) ( count_over_time ∙( LogQuery duration ) )
+	// AndOp
 	{
-		token.T_2: "(",
+		token.T_3:  "(",
+		token.T_8:  "-",
+		token.T_19: "bool",
+		token.T_24: "ignoring",
+		token.T_30: "num",
+		token.T_31: "on",
+		token.T_38: "var_name",
 	},
-	// MetricQuery : sum by ( level ) ( count_over_time ( ∙LogQuery duration ) )
+	// BinModifiers
 	{
-		token.T_18: "{",
+		token.T_3:  "(",
+		token.T_8:  "-",
+		token.T_30: "num",
+		token.T_38: "var_name",
 	},
-	// MetricQuery : sum by ( level ) ( count_over_time ( LogQuery ∙duration ) )
+	// BoolMaybe
 	{
-		token.T_13: "duration",
+		token.T_3:  "(",
+		token.T_8:  "-",
+		token.T_24: "ignoring",
+		token.T_30: "num",
+		token.T_31: "on",
+		token.T_38: "var_name",
 	},
-	// MetricQuery : sum by ( level ) ( count_over_time ( LogQuery duration ∙) )
+	// CmpOp
 	{
-		token.T_3: ")",
+		token.T_3:  "(",
+		token.T_8:  "-",
+		token.T_19: "bool",
+		token.T_24: "ignoring",
+		token.T_30: "num",
+		token.T_31: "on",
+		token.T_38: "var_name",
 	},
-	// MetricQuery : sum by ( level ) ( count_over_time ( LogQuery duration ) ∙)
+	// DataFilter
 	{
-		token.T_3: ")",
+		token.T_0:  "!=",
+		token.T_1:  "!~",
+		token.EOF:  "$",
+		token.T_4:  ")",
+		token.T_7:  ",",
+		token.T_18: "and",
+		token.T_21: "duration",
+		token.T_32: "or",
+		token.T_41: "|",
+		token.T_42: "|=",
+		token.T_43: "|~",
 	},
-	// MetricQuery : sum by ( level ) ( count_over_time ( LogQuery duration ) ) ∙
+	// DataFilterOp
 	{
-		token.EOF: "$",
+		token.T_30: "num",
+		token.T_35: "string",
 	},
-	// Pipeline : ∙LineFilter
+	// FilterAnd
 	{
 		token.T_0:  "!=",
 		token.T_1:  "!~",
-		token.T_20: "|=",
-		token.T_21: "|~",
+		token.EOF:  "$",
+		token.T_4:  ")",
+		token.T_21: "duration",
+		token.T_32: "or",
+		token.T_41: "|",
+		token.T_42: "|=",
+		token.T_43: "|~",
 	},
-	// Pipeline : LineFilter ∙
+	// FilterExpr
 	{
 		token.T_0:  "!=",
 		token.T_1:  "!~",
 		token.EOF:  "$",
-		token.T_13: "duration",
-		token.T_19: "|",
-		token.T_20: "|=",
-		token.T_21: "|~",
-	},
-	// Pipeline : ∙DataFilter
-	{
-		token.T_19: "|",
+		token.T_4:  ")",
+		token.T_21: "duration",
+		token.T_41: "|",
+		token.T_42: "|=",
+		token.T_43: "|~",
 	},
-	// Pipeline : DataFilter ∙
+	// FilterNot
 	{
 		token.T_0:  "!=",
 		token.T_1:  "!~",
 		token.EOF:  "$",
-		token.T_13: "duration",
-		token.T_19: "|",
-		token.T_20: "|=",
-		token.T_21: "|~",
+		token.T_4:  ")",
+		token.T_7:  ",",
+		token.T_18: "and",
+		token.T_21: "duration",
+		token.T_32: "or",
+		token.T_41: "|",
+		token.T_42: "|=",
+		token.T_43: "|~",
 	},
-	// Pipelines : ∙Pipeline
+	// FilterStage
 	{
 		token.T_0:  "!=",
 		token.T_1:  "!~",
-		token.T_19: "|",
-		token.T_20: "|=",
-		token.T_21: "|~",
+		token.EOF:  "$",
+		token.T_21: "duration",
+		token.T_41: "|",
+		token.T_42: "|=",
+		token.T_43: "|~",
 	},
-	// Pipelines : Pipeline ∙
+	// FilterTerm
 	{
+		token.T_0:  "!=",
+		token.T_1:  "!~",
 		token.EOF:  "$",
-		token.T_13: "duration",
+		token.T_4:  ")",
+		token.T_7:  ",",
+		token.T_18: "and",
+		token.T_21: "duration",
+		token.T_32: "or",
+		token.T_41: "|",
+		token.T_42: "|=",
+		token.T_43: "|~",
 	},
-	// Pipelines : ∙Pipeline Pipelines
+	// FilterValue
 	{
 		token.T_0:  "!=",
 		token.T_1:  "!~",
-		token.T_19: "|",
-		token.T_20: "|=",
-		token.T_21: "|~",
+		token.EOF:  "$",
+		token.T_4:  ")",
+		token.T_7:  ",",
+		token.T_18: "and",
+		token.T_21: "duration",
+		token.T_32: "or",
+		token.T_41: "|",
+		token.T_42: "|=",
+		token.T_43: "|~",
 	},
-	// Pipelines : Pipeline ∙Pipelines
+	// FormatStage
 	{
 		token.T_0:  "!=",
 		token.T_1:  "!~",
-		token.T_19: "|",
-		token.T_20: "|=",
-		token.T_21: "|~",
+		token.EOF:  "$",
+		token.T_21: "duration",
+		token.T_41: "|",
+		token.T_42: "|=",
+		token.T_43: "|~",
 	},
-	// Pipelines : Pipeline Pipelines ∙
+	// GroupModifierMaybe
 	{
-		token.EOF:  "$",
-		token.T_13: "duration",
+		token.T_3:  "(",
+		token.T_8:  "-",
+		token.T_30: "num",
+		token.T_38: "var_name",
 	},
-	// PipelinesMaybe : ∙
+	// GroupSide
 	{
-		token.EOF:  "$",
-		token.T_13: "duration",
+		token.T_3:  "(",
+		token.T_8:  "-",
+		token.T_30: "num",
+		token.T_38: "var_name",
 	},
-	// PipelinesMaybe : ∙Pipelines
+	// Grouping
 	{
 		token.T_0:  "!=",
-		token.T_1:  "!~",
-		token.T_19: "|",
-		token.T_20: "|=",
-		token.T_21: "|~",
+		token.EOF:  "$",
+		token.T_2:  "%",
+		token.T_3:  "(",
+		token.T_4:  ")",
+		token.T_5:  "*",
+		token.T_6:  "+",
+		token.T_8:  "-",
+		token.T_9:  "/",
+		token.T_10: "<",
+		token.T_11: "<=",
+		token.T_13: "==",
+		token.T_15: ">",
+		token.T_16: ">=",
+		token.T_17: "^",
+		token.T_18: "and",
+		token.T_32: "or",
+		token.T_36: "unless",
 	},
-	// PipelinesMaybe : Pipelines ∙
+	// GroupingMaybe
 	{
+		token.T_0:  "!=",
 		token.EOF:  "$",
-		token.T_13: "duration",
+		token.T_2:  "%",
+		token.T_3:  "(",
+		token.T_4:  ")",
+		token.T_5:  "*",
+		token.T_6:  "+",
+		token.T_8:  "-",
+		token.T_9:  "/",
+		token.T_10: "<",
+		token.T_11: "<=",
+		token.T_13: "==",
+		token.T_15: ">",
+		token.T_16: ">=",
+		token.T_17: "^",
+		token.T_18: "and",
+		token.T_32: "or",
+		token.T_36: "unless",
 	},
-	// Query : ∙LogQuery
+	// GroupingOp
 	{
-		token.T_18: "{",
+		token.T_3: "(",
 	},
-	// Query : LogQuery ∙
+	// LabelFilter
 	{
-		token.EOF: "$",
+		token.T_0:  "!=",
+		token.T_1:  "!~",
+		token.EOF:  "$",
+		token.T_4:  ")",
+		token.T_7:  ",",
+		token.T_18: "and",
+		token.T_21: "duration",
+		token.T_32: "or",
+		token.T_41: "|",
+		token.T_42: "|=",
+		token.T_43: "|~",
 	},
-	// Query : ∙MetricQuery
+	// LabelFilterOp
 	{
-		token.T_16: "sum",
+		token.T_30: "num",
+		token.T_35: "string",
 	},
-	// Query : MetricQuery ∙
+	// LabelFormat
 	{
-		token.EOF: "$",
+		token.T_0:  "!=",
+		token.T_1:  "!~",
+		token.EOF:  "$",
+		token.T_7:  ",",
+		token.T_21: "duration",
+		token.T_41: "|",
+		token.T_42: "|=",
+		token.T_43: "|~",
 	},
-}
-
-var followSets = []map[token.Type]string{
-	// DataFilter
+	// LabelFormatValue
 	{
 		token.T_0:  "!=",
 		token.T_1:  "!~",
 		token.EOF:  "$",
-		token.T_13: "duration",
-		token.T_19: "|",
-		token.T_20: "|=",
-		token.T_21: "|~",
+		token.T_7:  ",",
+		token.T_21: "duration",
+		token.T_41: "|",
+		token.T_42: "|=",
+		token.T_43: "|~",
 	},
-	// DataFilterOp
+	// LabelFormats
 	{
-		token.T_15: "string",
+		token.T_0:  "!=",
+		token.T_1:  "!~",
+		token.EOF:  "$",
+		token.T_21: "duration",
+		token.T_41: "|",
+		token.T_42: "|=",
+		token.T_43: "|~",
 	},
 	// LabelKey
 	{
-		token.T_0: "!=",
-		token.T_1: "!~",
-		token.T_7: "=",
-		token.T_8: "=~",
+		token.T_0:  "!=",
+		token.T_1:  "!~",
+		token.EOF:  "$",
+		token.T_4:  ")",
+		token.T_7:  ",",
+		token.T_10: "<",
+		token.T_11: "<=",
+		token.T_12: "=",
+		token.T_13: "==",
+		token.T_14: "=~",
+		token.T_15: ">",
+		token.T_16: ">=",
+		token.T_21: "duration",
+		token.T_41: "|",
+		token.T_42: "|=",
+		token.T_43: "|~",
+	},
+	// LabelKeys
+	{
+		token.T_4: ")",
+	},
+	// LabelKeysMaybe
+	{
+		token.T_4: ")",
 	},
 	// LineFilter
 	{
 		token.T_0:  "!=",
 		token.T_1:  "!~",
 		token.EOF:  "$",
-		token.T_13: "duration",
-		token.T_19: "|",
-		token.T_20: "|=",
-		token.T_21: "|~",
+		token.T_21: "duration",
+		token.T_41: "|",
+		token.T_42: "|=",
+		token.T_43: "|~",
 	},
 	// LineFilterOp
 	{
-		token.T_15: "string",
+		token.T_35: "string",
+	},
+	// LineFilterValues
+	{
+		token.T_0:  "!=",
+		token.T_1:  "!~",
+		token.EOF:  "$",
+		token.T_21: "duration",
+		token.T_41: "|",
+		token.T_42: "|=",
+		token.T_43: "|~",
 	},
 	// LogQuery
 	{
 		token.EOF:  "$",
-		token.T_13: "duration",
+		token.T_21: "duration",
+		token.T_41: "|",
 	},
 	// LogSelector
 	{
 		token.T_0:  "!=",
 		token.T_1:  "!~",
 		token.EOF:  "$",
-		token.T_13: "duration",
-		token.T_19: "|",
-		token.T_20: "|=",
-		token.T_21: "|~",
+		token.T_21: "duration",
+		token.T_41: "|",
+		token.T_42: "|=",
+		token.T_43: "|~",
 	},
 	// LogSelectorMember
 	{
-		token.T_4:  ",",
-		token.T_22: "}",
+		token.T_7:  ",",
+		token.T_44: "}",
 	},
 	// LogSelectorMembers
 	{
-		token.T_22: "}",
+		token.T_44: "}",
 	},
 	// LogSelectorMembersMaybe
 	{
-		token.T_22: "}",
+		token.T_44: "}",
 	},
 	// LogSelectorOp
 	{
-		token.T_15: "string",
+		token.T_35: "string",
+	},
+	// Matching
+	{
+		token.T_3:  "(",
+		token.T_8:  "-",
+		token.T_22: "group_left",
+		token.T_23: "group_right",
+		token.T_30: "num",
+		token.T_38: "var_name",
+	},
+	// MatchingMaybe
+	{
+		token.T_3:  "(",
+		token.T_8:  "-",
+		token.T_30: "num",
+		token.T_38: "var_name",
+	},
+	// MatchingOp
+	{
+		token.T_3: "(",
+	},
+	// MetricAdd
+	{
+		token.T_0:  "!=",
+		token.EOF:  "$",
+		token.T_4:  ")",
+		token.T_6:  "+",
+		token.T_8:  "-",
+		token.T_10: "<",
+		token.T_11: "<=",
+		token.T_13: "==",
+		token.T_15: ">",
+		token.T_16: ">=",
+		token.T_18: "and",
+		token.T_32: "or",
+		token.T_36: "unless",
+	},
+	// MetricAnd
+	{
+		token.EOF:  "$",
+		token.T_4:  ")",
+		token.T_18: "and",
+		token.T_32: "or",
+		token.T_36: "unless",
+	},
+	// MetricCmp
+	{
+		token.T_0:  "!=",
+		token.EOF:  "$",
+		token.T_4:  ")",
+		token.T_10: "<",
+		token.T_11: "<=",
+		token.T_13: "==",
+		token.T_15: ">",
+		token.T_16: ">=",
+		token.T_18: "and",
+		token.T_32: "or",
+		token.T_36: "unless",
+	},
+	// MetricMul
+	{
+		token.T_0:  "!=",
+		token.EOF:  "$",
+		token.T_2:  "%",
+		token.T_4:  ")",
+		token.T_5:  "*",
+		token.T_6:  "+",
+		token.T_8:  "-",
+		token.T_9:  "/",
+		token.T_10: "<",
+		token.T_11: "<=",
+		token.T_13: "==",
+		token.T_15: ">",
+		token.T_16: ">=",
+		token.T_18: "and",
+		token.T_32: "or",
+		token.T_36: "unless",
+	},
+	// MetricOr
+	{
+		token.EOF:  "$",
+		token.T_4:  ")",
+		token.T_32: "or",
+	},
+	// MetricPow
+	{
+		token.T_0:  "!=",
+		token.EOF:  "$",
+		token.T_2:  "%",
+		token.T_4:  ")",
+		token.T_5:  "*",
+		token.T_6:  "+",
+		token.T_8:  "-",
+		token.T_9:  "/",
+		token.T_10: "<",
+		token.T_11: "<=",
+		token.T_13: "==",
+		token.T_15: ">",
+		token.T_16: ">=",
+		token.T_18: "and",
+		token.T_32: "or",
+		token.T_36: "unless",
 	},
 	// MetricQuery
 	{
 		token.EOF: "$",
+		token.T_4: ")",
+	},
+	// MetricTerm
+	{
+		token.T_0:  "!=",
+		token.EOF:  "$",
+		token.T_2:  "%",
+		token.T_4:  ")",
+		token.T_5:  "*",
+		token.T_6:  "+",
+		token.T_8:  "-",
+		token.T_9:  "/",
+		token.T_10: "<",
+		token.T_11: "<=",
+		token.T_13: "==",
+		token.T_15: ">",
+		token.T_16: ">=",
+		token.T_17: "^",
+		token.T_18: "and",
+		token.T_32: "or",
+		token.T_36: "unless",
+	},
+	// MetricUnary
+	{
+		token.T_0:  "!=",
+		token.EOF:  "$",
+		token.T_2:  "%",
+		token.T_4:  ")",
+		token.T_5:  "*",
+		token.T_6:  "+",
+		token.T_8:  "-",
+		token.T_9:  "/",
+		token.T_10: "<",
+		token.T_11: "<=",
+		token.T_13: "==",
+		token.T_15: ">",
+		token.T_16: ">=",
+		token.T_18: "and",
+		token.T_32: "or",
+		token.T_36: "unless",
+	},
+	// MulOp
+	{
+		token.T_3:  "(",
+		token.T_8:  "-",
+		token.T_19: "bool",
+		token.T_24: "ignoring",
+		token.T_30: "num",
+		token.T_31: "on",
+		token.T_38: "var_name",
+	},
+	// OrOp
+	{
+		token.T_3:  "(",
+		token.T_8:  "-",
+		token.T_19: "bool",
+		token.T_24: "ignoring",
+		token.T_30: "num",
+		token.T_31: "on",
+		token.T_38: "var_name",
+	},
+	// ParserStage
+	{
+		token.T_0:  "!=",
+		token.T_1:  "!~",
+		token.EOF:  "$",
+		token.T_21: "duration",
+		token.T_41: "|",
+		token.T_42: "|=",
+		token.T_43: "|~",
 	},
 	// Pipeline
 	{
 		token.T_0:  "!=",
 		token.T_1:  "!~",
 		token.EOF:  "$",
-		token.T_13: "duration",
-		token.T_19: "|",
-		token.T_20: "|=",
-		token.T_21: "|~",
+		token.T_21: "duration",
+		token.T_41: "|",
+		token.T_42: "|=",
+		token.T_43: "|~",
 	},
 	// Pipelines
 	{
 		token.EOF:  "$",
-		token.T_13: "duration",
+		token.T_21: "duration",
+		token.T_41: "|",
 	},
 	// PipelinesMaybe
 	{
 		token.EOF:  "$",
-		token.T_13: "duration",
+		token.T_21: "duration",
+		token.T_41: "|",
+	},
+	// PowOp
+	{
+		token.T_3:  "(",
+		token.T_8:  "-",
+		token.T_19: "bool",
+		token.T_24: "ignoring",
+		token.T_30: "num",
+		token.T_31: "on",
+		token.T_38: "var_name",
 	},
 	// Query
 	{
 		token.EOF: "$",
 	},
+	// RangeAggregation
+	{
+		token.T_0:  "!=",
+		token.EOF:  "$",
+		token.T_2:  "%",
+		token.T_4:  ")",
+		token.T_5:  "*",
+		token.T_6:  "+",
+		token.T_8:  "-",
+		token.T_9:  "/",
+		token.T_10: "<",
+		token.T_11: "<=",
+		token.T_13: "==",
+		token.T_15: ">",
+		token.T_16: ">=",
+		token.T_17: "^",
+		token.T_18: "and",
+		token.T_32: "or",
+		token.T_36: "unless",
+	},
+	// RangeParamMaybe
+	{
+		token.T_40: "{",
+	},
+	// UnwrapField
+	{
+		token.T_21: "duration",
+	},
+	// UnwrapMaybe
+	{
+		token.T_21: "duration",
+	},
+	// VectorAggregation
+	{
+		token.T_0:  "!=",
+		token.EOF:  "$",
+		token.T_2:  "%",
+		token.T_4:  ")",
+		token.T_5:  "*",
+		token.T_6:  "+",
+		token.T_8:  "-",
+		token.T_9:  "/",
+		token.T_10: "<",
+		token.T_11: "<=",
+		token.T_13: "==",
+		token.T_15: ">",
+		token.T_16: ">=",
+		token.T_17: "^",
+		token.T_18: "and",
+		token.T_32: "or",
+		token.T_36: "unless",
+	},
+	// VectorParamMaybe
+	{
+		token.T_3:  "(",
+		token.T_8:  "-",
+		token.T_30: "num",
+		token.T_38: "var_name",
+	},
 }
 
 /*** Errors ***/
@@ -12,11 +12,36 @@ import(
 type Label int
 
 const(
-	DataFilter0R0 Label = iota
+	AddOp0R0 Label = iota
+	AddOp0R1
+	AddOp1R0
+	AddOp1R1
+	AndOp0R0
+	AndOp0R1
+	AndOp1R0
+	AndOp1R1
+	BinModifiers0R0
+	BinModifiers0R1
+	BinModifiers0R2
+	BoolMaybe0R0
+	BoolMaybe1R0
+	BoolMaybe1R1
+	CmpOp0R0
+	CmpOp0R1
+	CmpOp1R0
+	CmpOp1R1
+	CmpOp2R0
+	CmpOp2R1
+	CmpOp3R0
+	CmpOp3R1
+	CmpOp4R0
+	CmpOp4R1
+	CmpOp5R0
+	CmpOp5R1
+	DataFilter0R0
 	DataFilter0R1
 	DataFilter0R2
 	DataFilter0R3
-	DataFilter0R4
 	DataFilterOp0R0
 	DataFilterOp0R1
 	DataFilterOp1R0
@@ -33,8 +58,139 @@ const(
 	DataFilterOp6R1
 	DataFilterOp7R0
 	DataFilterOp7R1
+	FilterAnd0R0
+	FilterAnd0R1
+	FilterAnd1R0
+	FilterAnd1R1
+	FilterAnd1R2
+	FilterAnd1R3
+	FilterAnd2R0
+	FilterAnd2R1
+	FilterAnd2R2
+	FilterAnd2R3
+	FilterExpr0R0
+	FilterExpr0R1
+	FilterExpr1R0
+	FilterExpr1R1
+	FilterExpr1R2
+	FilterExpr1R3
+	FilterNot0R0
+	FilterNot0R1
+	FilterNot1R0
+	FilterNot1R1
+	FilterNot1R2
+	FilterStage0R0
+	FilterStage0R1
+	FilterStage0R2
+	FilterTerm0R0
+	FilterTerm0R1
+	FilterTerm1R0
+	FilterTerm1R1
+	FilterTerm2R0
+	FilterTerm2R1
+	FilterTerm2R2
+	FilterTerm2R3
+	FilterValue0R0
+	FilterValue0R1
+	FilterValue1R0
+	FilterValue1R1
+	FormatStage0R0
+	FormatStage0R1
+	FormatStage0R2
+	FormatStage0R3
+	FormatStage1R0
+	FormatStage1R1
+	FormatStage1R2
+	FormatStage1R3
+	GroupModifierMaybe0R0
+	GroupModifierMaybe1R0
+	GroupModifierMaybe1R1
+	GroupModifierMaybe2R0
+	GroupModifierMaybe2R1
+	GroupModifierMaybe2R2
+	GroupModifierMaybe2R3
+	GroupModifierMaybe2R4
+	GroupSide0R0
+	GroupSide0R1
+	GroupSide1R0
+	GroupSide1R1
+	Grouping0R0
+	Grouping0R1
+	Grouping0R2
+	Grouping0R3
+	Grouping0R4
+	GroupingMaybe0R0
+	GroupingMaybe1R0
+	GroupingMaybe1R1
+	GroupingOp0R0
+	GroupingOp0R1
+	GroupingOp1R0
+	GroupingOp1R1
+	LabelFilter0R0
+	LabelFilter0R1
+	LabelFilter0R2
+	LabelFilter0R3
+	LabelFilterOp0R0
+	LabelFilterOp0R1
+	LabelFilterOp1R0
+	LabelFilterOp1R1
+	LabelFilterOp2R0
+	LabelFilterOp2R1
+	LabelFilterOp3R0
+	LabelFilterOp3R1
+	LabelFilterOp4R0
+	LabelFilterOp4R1
+	LabelFilterOp5R0
+	LabelFilterOp5R1
+	LabelFilterOp6R0
+	LabelFilterOp6R1
+	LabelFilterOp7R0
+	LabelFilterOp7R1
+	LabelFilterOp8R0
+	LabelFilterOp8R1
+	LabelFormat0R0
+	LabelFormat0R1
+	LabelFormat0R2
+	LabelFormat0R3
+	LabelFormatValue0R0
+	LabelFormatValue0R1
+	LabelFormatValue1R0
+	LabelFormatValue1R1
+	LabelFormats0R0
+	LabelFormats0R1
+	LabelFormats1R0
+	LabelFormats1R1
+	LabelFormats1R2
+	LabelFormats1R3
 	LabelKey0R0
 	LabelKey0R1
+	LabelKey1R0
+	LabelKey1R1
+	LabelKey2R0
+	LabelKey2R1
+	LabelKey3R0
+	LabelKey3R1
+	LabelKey4R0
+	LabelKey4R1
+	LabelKey5R0
+	LabelKey5R1
+	LabelKey6R0
+	LabelKey6R1
+	LabelKey7R0
+	LabelKey7R1
+	LabelKey8R0
+	LabelKey8R1
+	LabelKey9R0
+	LabelKey9R1
+	LabelKeys0R0
+	LabelKeys0R1
+	LabelKeys1R0
+	LabelKeys1R1
+	LabelKeys1R2
+	LabelKeys1R3
+	LabelKeysMaybe0R0
+	LabelKeysMaybe1R0
+	LabelKeysMaybe1R1
 	LineFilter0R0
 	LineFilter0R1
 	LineFilter0R2
@@ -46,6 +202,12 @@ const(
 	LineFilterOp2R1
 	LineFilterOp3R0
 	LineFilterOp3R1
+	LineFilterValues0R0
+	LineFilterValues0R1
+	LineFilterValues1R0
+	LineFilterValues1R1
+	LineFilterValues1R2
+	LineFilterValues1R3
 	LogQuery0R0
 	LogQuery0R1
 	LogQuery0R2
@@ -74,23 +236,108 @@ const(
 	LogSelectorOp2R1
 	LogSelectorOp3R0
 	LogSelectorOp3R1
+	Matching0R0
+	Matching0R1
+	Matching0R2
+	Matching0R3
+	Matching0R4
+	MatchingMaybe0R0
+	MatchingMaybe1R0
+	MatchingMaybe1R1
+	MatchingMaybe1R2
+	MatchingOp0R0
+	MatchingOp0R1
+	MatchingOp1R0
+	MatchingOp1R1
+	MetricAdd0R0
+	MetricAdd0R1
+	MetricAdd1R0
+	MetricAdd1R1
+	MetricAdd1R2
+	MetricAdd1R3
+	MetricAdd1R4
+	MetricAnd0R0
+	MetricAnd0R1
+	MetricAnd1R0
+	MetricAnd1R1
+	MetricAnd1R2
+	MetricAnd1R3
+	MetricAnd1R4
+	MetricCmp0R0
+	MetricCmp0R1
+	MetricCmp1R0
+	MetricCmp1R1
+	MetricCmp1R2
+	MetricCmp1R3
+	MetricCmp1R4
+	MetricMul0R0
+	MetricMul0R1
+	MetricMul1R0
+	MetricMul1R1
+	MetricMul1R2
+	MetricMul1R3
+	MetricMul1R4
+	MetricOr0R0
+	MetricOr0R1
+	MetricOr1R0
+	MetricOr1R1
+	MetricOr1R2
+	MetricOr1R3
+	MetricOr1R4
+	MetricPow0R0
+	MetricPow0R1
+	MetricPow1R0
+	MetricPow1R1
+	MetricPow1R2
+	MetricPow1R3
+	MetricPow1R4
 	MetricQuery0R0
 	MetricQuery0R1
-	MetricQuery0R2
-	MetricQuery0R3
-	MetricQuery0R4
-	MetricQuery0R5
-	MetricQuery0R6
-	MetricQuery0R7
-	MetricQuery0R8
-	MetricQuery0R9
-	MetricQuery0R10
-	MetricQuery0R11
-	MetricQuery0R12
+	MetricTerm0R0
+	MetricTerm0R1
+	MetricTerm1R0
+	MetricTerm1R1
+	MetricTerm2R0
+	MetricTerm2R1
+	MetricTerm3R0
+	MetricTerm3R1
+	MetricTerm3R2
+	MetricTerm3R3
+	MetricUnary0R0
+	MetricUnary0R1
+	MetricUnary1R0
+	MetricUnary1R1
+	MetricUnary1R2
+	MulOp0R0
+	MulOp0R1
+	MulOp1R0
+	MulOp1R1
+	MulOp2R0
+	MulOp2R1
+	OrOp0R0
+	OrOp0R1
+	ParserStage0R0
+	ParserStage0R1
+	ParserStage0R2
+	ParserStage1R0
+	ParserStage1R1
+	ParserStage1R2
+	ParserStage2R0
+	ParserStage2R1
+	ParserStage2R2
+	ParserStage2R3
+	ParserStage3R0
+	ParserStage3R1
+	ParserStage3R2
+	ParserStage3R3
 	Pipeline0R0
 	Pipeline0R1
 	Pipeline1R0
 	Pipeline1R1
+	Pipeline2R0
+	Pipeline2R1
+	Pipeline3R0
+	Pipeline3R1
 	Pipelines0R0
 	Pipelines0R1
 	Pipelines1R0
@@ -99,10 +346,45 @@ const(
 	PipelinesMaybe0R0
 	PipelinesMaybe1R0
 	PipelinesMaybe1R1
+	PowOp0R0
+	PowOp0R1
 	Query0R0
 	Query0R1
 	Query1R0
 	Query1R1
+	RangeAggregation0R0
+	RangeAggregation0R1
+	RangeAggregation0R2
+	RangeAggregation0R3
+	RangeAggregation0R4
+	RangeAggregation0R5
+	RangeAggregation0R6
+	RangeAggregation0R7
+	RangeParamMaybe0R0
+	RangeParamMaybe1R0
+	RangeParamMaybe1R1
+	RangeParamMaybe1R2
+	UnwrapField0R0
+	UnwrapField0R1
+	UnwrapField1R0
+	UnwrapField1R1
+	UnwrapMaybe0R0
+	UnwrapMaybe1R0
+	UnwrapMaybe1R1
+	UnwrapMaybe1R2
+	UnwrapMaybe1R3
+	VectorAggregation0R0
+	VectorAggregation0R1
+	VectorAggregation0R2
+	VectorAggregation0R3
+	VectorAggregation0R4
+	VectorAggregation0R5
+	VectorAggregation0R6
+	VectorAggregation0R7
+	VectorParamMaybe0R0
+	VectorParamMaybe1R0
+	VectorParamMaybe1R1
+	VectorParamMaybe1R2
 )
 
 type Slot struct {
@@ -192,67 +474,237 @@ func (s *Slot) String() string {
 }
 
 var slots = map[Label]*Slot{ 
+	AddOp0R0: {
+		symbols.NT_AddOp, 0, 0, 
+		symbols.Symbols{  
+			symbols.T_6,
+		}, 
+		AddOp0R0, 
+	},
+	AddOp0R1: {
+		symbols.NT_AddOp, 0, 1, 
+		symbols.Symbols{  
+			symbols.T_6,
+		}, 
+		AddOp0R1, 
+	},
+	AddOp1R0: {
+		symbols.NT_AddOp, 1, 0, 
+		symbols.Symbols{  
+			symbols.T_8,
+		}, 
+		AddOp1R0, 
+	},
+	AddOp1R1: {
+		symbols.NT_AddOp, 1, 1, 
+		symbols.Symbols{  
+			symbols.T_8,
+		}, 
+		AddOp1R1, 
+	},
+	AndOp0R0: {
+		symbols.NT_AndOp, 0, 0, 
+		symbols.Symbols{  
+			symbols.T_18,
+		}, 
+		AndOp0R0, 
+	},
+	AndOp0R1: {
+		symbols.NT_AndOp, 0, 1, 
+		symbols.Symbols{  
+			symbols.T_18,
+		}, 
+		AndOp0R1, 
+	},
+	AndOp1R0: {
+		symbols.NT_AndOp, 1, 0, 
+		symbols.Symbols{  
+			symbols.T_36,
+		}, 
+		AndOp1R0, 
+	},
+	AndOp1R1: {
+		symbols.NT_AndOp, 1, 1, 
+		symbols.Symbols{  
+			symbols.T_36,
+		}, 
+		AndOp1R1, 
+	},
+	BinModifiers0R0: {
+		symbols.NT_BinModifiers, 0, 0, 
+		symbols.Symbols{  
+			symbols.NT_BoolMaybe, 
+			symbols.NT_MatchingMaybe,
+		}, 
+		BinModifiers0R0, 
+	},
+	BinModifiers0R1: {
+		symbols.NT_BinModifiers, 0, 1, 
+		symbols.Symbols{  
+			symbols.NT_BoolMaybe, 
+			symbols.NT_MatchingMaybe,
+		}, 
+		BinModifiers0R1, 
+	},
+	BinModifiers0R2: {
+		symbols.NT_BinModifiers, 0, 2, 
+		symbols.Symbols{  
+			symbols.NT_BoolMaybe, 
+			symbols.NT_MatchingMaybe,
+		}, 
+		BinModifiers0R2, 
+	},
+	BoolMaybe0R0: {
+		symbols.NT_BoolMaybe, 0, 0, 
+		symbols.Symbols{ 
+		}, 
+		BoolMaybe0R0, 
+	},
+	BoolMaybe1R0: {
+		symbols.NT_BoolMaybe, 1, 0, 
+		symbols.Symbols{  
+			symbols.T_19,
+		}, 
+		BoolMaybe1R0, 
+	},
+	BoolMaybe1R1: {
+		symbols.NT_BoolMaybe, 1, 1, 
+		symbols.Symbols{  
+			symbols.T_19,
+		}, 
+		BoolMaybe1R1, 
+	},
+	CmpOp0R0: {
+		symbols.NT_CmpOp, 0, 0, 
+		symbols.Symbols{  
+			symbols.T_13,
+		}, 
+		CmpOp0R0, 
+	},
+	CmpOp0R1: {
+		symbols.NT_CmpOp, 0, 1, 
+		symbols.Symbols{  
+			symbols.T_13,
+		}, 
+		CmpOp0R1, 
+	},
+	CmpOp1R0: {
+		symbols.NT_CmpOp, 1, 0, 
+		symbols.Symbols{  
+			symbols.T_0,
+		}, 
+		CmpOp1R0, 
+	},
+	CmpOp1R1: {
+		symbols.NT_CmpOp, 1, 1, 
+		symbols.Symbols{  
+			symbols.T_0,
+		}, 
+		CmpOp1R1, 
+	},
+	CmpOp2R0: {
+		symbols.NT_CmpOp, 2, 0, 
+		symbols.Symbols{  
+			symbols.T_15,
+		}, 
+		CmpOp2R0, 
+	},
+	CmpOp2R1: {
+		symbols.NT_CmpOp, 2, 1, 
+		symbols.Symbols{  
+			symbols.T_15,
+		}, 
+		CmpOp2R1, 
+	},
+	CmpOp3R0: {
+		symbols.NT_CmpOp, 3, 0, 
+		symbols.Symbols{  
+			symbols.T_10,
+		}, 
+		CmpOp3R0, 
+	},
+	CmpOp3R1: {
+		symbols.NT_CmpOp, 3, 1, 
+		symbols.Symbols{  
+			symbols.T_10,
+		}, 
+		CmpOp3R1, 
+	},
+	CmpOp4R0: {
+		symbols.NT_CmpOp, 4, 0, 
+		symbols.Symbols{  
+			symbols.T_16,
+		}, 
+		CmpOp4R0, 
+	},
+	CmpOp4R1: {
+		symbols.NT_CmpOp, 4, 1, 
+		symbols.Symbols{  
+			symbols.T_16,
+		}, 
+		CmpOp4R1, 
+	},
+	CmpOp5R0: {
+		symbols.NT_CmpOp, 5, 0, 
+		symbols.Symbols{  
+			symbols.T_11,
+		}, 
+		CmpOp5R0, 
+	},
+	CmpOp5R1: {
+		symbols.NT_CmpOp, 5, 1, 
+		symbols.Symbols{  
+			symbols.T_11,
+		}, 
+		CmpOp5R1, 
+	},
 	DataFilter0R0: {
 		symbols.NT_DataFilter, 0, 0, 
 		symbols.Symbols{  
-			symbols.T_19, 
-			symbols.T_15, 
+			symbols.T_35, 
 			symbols.NT_DataFilterOp, 
-			symbols.T_15,
+			symbols.NT_FilterValue,
 		}, 
 		DataFilter0R0, 
 	},
 	DataFilter0R1: {
 		symbols.NT_DataFilter, 0, 1, 
 		symbols.Symbols{  
-			symbols.T_19, 
-			symbols.T_15, 
+			symbols.T_35, 
 			symbols.NT_DataFilterOp, 
-			symbols.T_15,
+			symbols.NT_FilterValue,
 		}, 
 		DataFilter0R1, 
 	},
 	DataFilter0R2: {
 		symbols.NT_DataFilter, 0, 2, 
 		symbols.Symbols{  
-			symbols.T_19, 
-			symbols.T_15, 
+			symbols.T_35, 
 			symbols.NT_DataFilterOp, 
-			symbols.T_15,
+			symbols.NT_FilterValue,
 		}, 
 		DataFilter0R2, 
 	},
 	DataFilter0R3: {
 		symbols.NT_DataFilter, 0, 3, 
 		symbols.Symbols{  
-			symbols.T_19, 
-			symbols.T_15, 
+			symbols.T_35, 
 			symbols.NT_DataFilterOp, 
-			symbols.T_15,
+			symbols.NT_FilterValue,
 		}, 
 		DataFilter0R3, 
 	},
-	DataFilter0R4: {
-		symbols.NT_DataFilter, 0, 4, 
-		symbols.Symbols{  
-			symbols.T_19, 
-			symbols.T_15, 
-			symbols.NT_DataFilterOp, 
-			symbols.T_15,
-		}, 
-		DataFilter0R4, 
-	},
 	DataFilterOp0R0: {
 		symbols.NT_DataFilterOp, 0, 0, 
 		symbols.Symbols{  
-			symbols.T_7,
+			symbols.T_12,
 		}, 
 		DataFilterOp0R0, 
 	},
 	DataFilterOp0R1: {
 		symbols.NT_DataFilterOp, 0, 1, 
 		symbols.Symbols{  
-			symbols.T_7,
+			symbols.T_12,
 		}, 
 		DataFilterOp0R1, 
 	},
@@ -273,14 +725,14 @@ var slots = map[Label]*Slot{
 	DataFilterOp2R0: {
 		symbols.NT_DataFilterOp, 2, 0, 
 		symbols.Symbols{  
-			symbols.T_8,
+			symbols.T_14,
 		}, 
 		DataFilterOp2R0, 
 	},
 	DataFilterOp2R1: {
 		symbols.NT_DataFilterOp, 2, 1, 
 		symbols.Symbols{  
-			symbols.T_8,
+			symbols.T_14,
 		}, 
 		DataFilterOp2R1, 
 	},
@@ -301,608 +753,2252 @@ var slots = map[Label]*Slot{
 	DataFilterOp4R0: {
 		symbols.NT_DataFilterOp, 4, 0, 
 		symbols.Symbols{  
-			symbols.T_10,
+			symbols.T_16,
 		}, 
 		DataFilterOp4R0, 
 	},
 	DataFilterOp4R1: {
 		symbols.NT_DataFilterOp, 4, 1, 
 		symbols.Symbols{  
-			symbols.T_10,
+			symbols.T_16,
 		}, 
 		DataFilterOp4R1, 
 	},
 	DataFilterOp5R0: {
 		symbols.NT_DataFilterOp, 5, 0, 
 		symbols.Symbols{  
-			symbols.T_9,
+			symbols.T_15,
 		}, 
 		DataFilterOp5R0, 
 	},
 	DataFilterOp5R1: {
 		symbols.NT_DataFilterOp, 5, 1, 
 		symbols.Symbols{  
-			symbols.T_9,
+			symbols.T_15,
 		}, 
 		DataFilterOp5R1, 
 	},
 	DataFilterOp6R0: {
 		symbols.NT_DataFilterOp, 6, 0, 
 		symbols.Symbols{  
-			symbols.T_6,
+			symbols.T_11,
 		}, 
 		DataFilterOp6R0, 
 	},
 	DataFilterOp6R1: {
 		symbols.NT_DataFilterOp, 6, 1, 
 		symbols.Symbols{  
-			symbols.T_6,
+			symbols.T_11,
 		}, 
 		DataFilterOp6R1, 
 	},
 	DataFilterOp7R0: {
 		symbols.NT_DataFilterOp, 7, 0, 
 		symbols.Symbols{  
-			symbols.T_5,
+			symbols.T_10,
 		}, 
 		DataFilterOp7R0, 
 	},
 	DataFilterOp7R1: {
 		symbols.NT_DataFilterOp, 7, 1, 
 		symbols.Symbols{  
-			symbols.T_5,
+			symbols.T_10,
 		}, 
 		DataFilterOp7R1, 
 	},
-	LabelKey0R0: {
-		symbols.NT_LabelKey, 0, 0, 
+	FilterAnd0R0: {
+		symbols.NT_FilterAnd, 0, 0, 
 		symbols.Symbols{  
-			symbols.T_17,
+			symbols.NT_FilterNot,
 		}, 
-		LabelKey0R0, 
+		FilterAnd0R0, 
 	},
-	LabelKey0R1: {
-		symbols.NT_LabelKey, 0, 1, 
+	FilterAnd0R1: {
+		symbols.NT_FilterAnd, 0, 1, 
 		symbols.Symbols{  
-			symbols.T_17,
+			symbols.NT_FilterNot,
 		}, 
-		LabelKey0R1, 
+		FilterAnd0R1, 
 	},
-	LineFilter0R0: {
-		symbols.NT_LineFilter, 0, 0, 
+	FilterAnd1R0: {
+		symbols.NT_FilterAnd, 1, 0, 
 		symbols.Symbols{  
-			symbols.NT_LineFilterOp, 
-			symbols.T_15,
+			symbols.NT_FilterNot, 
+			symbols.T_18, 
+			symbols.NT_FilterAnd,
 		}, 
-		LineFilter0R0, 
+		FilterAnd1R0, 
 	},
-	LineFilter0R1: {
-		symbols.NT_LineFilter, 0, 1, 
+	FilterAnd1R1: {
+		symbols.NT_FilterAnd, 1, 1, 
 		symbols.Symbols{  
-			symbols.NT_LineFilterOp, 
-			symbols.T_15,
+			symbols.NT_FilterNot, 
+			symbols.T_18, 
+			symbols.NT_FilterAnd,
 		}, 
-		LineFilter0R1, 
+		FilterAnd1R1, 
 	},
-	LineFilter0R2: {
-		symbols.NT_LineFilter, 0, 2, 
+	FilterAnd1R2: {
+		symbols.NT_FilterAnd, 1, 2, 
 		symbols.Symbols{  
-			symbols.NT_LineFilterOp, 
-			symbols.T_15,
+			symbols.NT_FilterNot, 
+			symbols.T_18, 
+			symbols.NT_FilterAnd,
 		}, 
-		LineFilter0R2, 
+		FilterAnd1R2, 
 	},
-	LineFilterOp0R0: {
-		symbols.NT_LineFilterOp, 0, 0, 
+	FilterAnd1R3: {
+		symbols.NT_FilterAnd, 1, 3, 
 		symbols.Symbols{  
-			symbols.T_20,
+			symbols.NT_FilterNot, 
+			symbols.T_18, 
+			symbols.NT_FilterAnd,
 		}, 
-		LineFilterOp0R0, 
+		FilterAnd1R3, 
 	},
-	LineFilterOp0R1: {
-		symbols.NT_LineFilterOp, 0, 1, 
+	FilterAnd2R0: {
+		symbols.NT_FilterAnd, 2, 0, 
 		symbols.Symbols{  
-			symbols.T_20,
+			symbols.NT_FilterNot, 
+			symbols.T_7, 
+			symbols.NT_FilterAnd,
 		}, 
-		LineFilterOp0R1, 
+		FilterAnd2R0, 
 	},
-	LineFilterOp1R0: {
-		symbols.NT_LineFilterOp, 1, 0, 
+	FilterAnd2R1: {
+		symbols.NT_FilterAnd, 2, 1, 
 		symbols.Symbols{  
-			symbols.T_0,
+			symbols.NT_FilterNot, 
+			symbols.T_7, 
+			symbols.NT_FilterAnd,
 		}, 
-		LineFilterOp1R0, 
+		FilterAnd2R1, 
 	},
-	LineFilterOp1R1: {
-		symbols.NT_LineFilterOp, 1, 1, 
+	FilterAnd2R2: {
+		symbols.NT_FilterAnd, 2, 2, 
 		symbols.Symbols{  
-			symbols.T_0,
+			symbols.NT_FilterNot, 
+			symbols.T_7, 
+			symbols.NT_FilterAnd,
 		}, 
-		LineFilterOp1R1, 
+		FilterAnd2R2, 
 	},
-	LineFilterOp2R0: {
-		symbols.NT_LineFilterOp, 2, 0, 
+	FilterAnd2R3: {
+		symbols.NT_FilterAnd, 2, 3, 
 		symbols.Symbols{  
-			symbols.T_21,
+			symbols.NT_FilterNot, 
+			symbols.T_7, 
+			symbols.NT_FilterAnd,
 		}, 
-		LineFilterOp2R0, 
+		FilterAnd2R3, 
 	},
-	LineFilterOp2R1: {
-		symbols.NT_LineFilterOp, 2, 1, 
+	FilterExpr0R0: {
+		symbols.NT_FilterExpr, 0, 0, 
 		symbols.Symbols{  
-			symbols.T_21,
+			symbols.NT_FilterAnd,
 		}, 
-		LineFilterOp2R1, 
+		FilterExpr0R0, 
 	},
-	LineFilterOp3R0: {
-		symbols.NT_LineFilterOp, 3, 0, 
+	FilterExpr0R1: {
+		symbols.NT_FilterExpr, 0, 1, 
 		symbols.Symbols{  
-			symbols.T_1,
+			symbols.NT_FilterAnd,
 		}, 
-		LineFilterOp3R0, 
+		FilterExpr0R1, 
 	},
-	LineFilterOp3R1: {
-		symbols.NT_LineFilterOp, 3, 1, 
+	FilterExpr1R0: {
+		symbols.NT_FilterExpr, 1, 0, 
 		symbols.Symbols{  
-			symbols.T_1,
+			symbols.NT_FilterAnd, 
+			symbols.T_32, 
+			symbols.NT_FilterExpr,
 		}, 
-		LineFilterOp3R1, 
+		FilterExpr1R0, 
 	},
-	LogQuery0R0: {
-		symbols.NT_LogQuery, 0, 0, 
+	FilterExpr1R1: {
+		symbols.NT_FilterExpr, 1, 1, 
 		symbols.Symbols{  
-			symbols.NT_LogSelector, 
-			symbols.NT_PipelinesMaybe,
+			symbols.NT_FilterAnd, 
+			symbols.T_32, 
+			symbols.NT_FilterExpr,
 		}, 
-		LogQuery0R0, 
+		FilterExpr1R1, 
 	},
-	LogQuery0R1: {
-		symbols.NT_LogQuery, 0, 1, 
+	FilterExpr1R2: {
+		symbols.NT_FilterExpr, 1, 2, 
 		symbols.Symbols{  
-			symbols.NT_LogSelector, 
-			symbols.NT_PipelinesMaybe,
+			symbols.NT_FilterAnd, 
+			symbols.T_32, 
+			symbols.NT_FilterExpr,
 		}, 
-		LogQuery0R1, 
+		FilterExpr1R2, 
 	},
-	LogQuery0R2: {
-		symbols.NT_LogQuery, 0, 2, 
+	FilterExpr1R3: {
+		symbols.NT_FilterExpr, 1, 3, 
 		symbols.Symbols{  
-			symbols.NT_LogSelector, 
-			symbols.NT_PipelinesMaybe,
+			symbols.NT_FilterAnd, 
+			symbols.T_32, 
+			symbols.NT_FilterExpr,
 		}, 
-		LogQuery0R2, 
+		FilterExpr1R3, 
 	},
-	LogSelector0R0: {
-		symbols.NT_LogSelector, 0, 0, 
+	FilterNot0R0: {
+		symbols.NT_FilterNot, 0, 0, 
 		symbols.Symbols{  
-			symbols.T_18, 
-			symbols.NT_LogSelectorMembersMaybe, 
-			symbols.T_22,
+			symbols.NT_FilterTerm,
 		}, 
-		LogSelector0R0, 
+		FilterNot0R0, 
 	},
-	LogSelector0R1: {
-		symbols.NT_LogSelector, 0, 1, 
+	FilterNot0R1: {
+		symbols.NT_FilterNot, 0, 1, 
 		symbols.Symbols{  
-			symbols.T_18, 
-			symbols.NT_LogSelectorMembersMaybe, 
-			symbols.T_22,
+			symbols.NT_FilterTerm,
 		}, 
-		LogSelector0R1, 
+		FilterNot0R1, 
 	},
-	LogSelector0R2: {
-		symbols.NT_LogSelector, 0, 2, 
+	FilterNot1R0: {
+		symbols.NT_FilterNot, 1, 0, 
 		symbols.Symbols{  
-			symbols.T_18, 
-			symbols.NT_LogSelectorMembersMaybe, 
-			symbols.T_22,
+			symbols.T_29, 
+			symbols.NT_FilterNot,
 		}, 
-		LogSelector0R2, 
+		FilterNot1R0, 
 	},
-	LogSelector0R3: {
-		symbols.NT_LogSelector, 0, 3, 
+	FilterNot1R1: {
+		symbols.NT_FilterNot, 1, 1, 
 		symbols.Symbols{  
-			symbols.T_18, 
-			symbols.NT_LogSelectorMembersMaybe, 
-			symbols.T_22,
+			symbols.T_29, 
+			symbols.NT_FilterNot,
 		}, 
-		LogSelector0R3, 
+		FilterNot1R1, 
 	},
-	LogSelectorMember0R0: {
-		symbols.NT_LogSelectorMember, 0, 0, 
+	FilterNot1R2: {
+		symbols.NT_FilterNot, 1, 2, 
 		symbols.Symbols{  
-			symbols.NT_LabelKey, 
-			symbols.NT_LogSelectorOp, 
-			symbols.T_15,
+			symbols.T_29, 
+			symbols.NT_FilterNot,
 		}, 
-		LogSelectorMember0R0, 
+		FilterNot1R2, 
 	},
-	LogSelectorMember0R1: {
-		symbols.NT_LogSelectorMember, 0, 1, 
+	FilterStage0R0: {
+		symbols.NT_FilterStage, 0, 0, 
 		symbols.Symbols{  
-			symbols.NT_LabelKey, 
-			symbols.NT_LogSelectorOp, 
-			symbols.T_15,
+			symbols.T_41, 
+			symbols.NT_FilterExpr,
 		}, 
-		LogSelectorMember0R1, 
+		FilterStage0R0, 
 	},
-	LogSelectorMember0R2: {
-		symbols.NT_LogSelectorMember, 0, 2, 
+	FilterStage0R1: {
+		symbols.NT_FilterStage, 0, 1, 
 		symbols.Symbols{  
-			symbols.NT_LabelKey, 
-			symbols.NT_LogSelectorOp, 
-			symbols.T_15,
+			symbols.T_41, 
+			symbols.NT_FilterExpr,
 		}, 
-		LogSelectorMember0R2, 
+		FilterStage0R1, 
 	},
-	LogSelectorMember0R3: {
-		symbols.NT_LogSelectorMember, 0, 3, 
+	FilterStage0R2: {
+		symbols.NT_FilterStage, 0, 2, 
 		symbols.Symbols{  
-			symbols.NT_LabelKey, 
-			symbols.NT_LogSelectorOp, 
-			symbols.T_15,
+			symbols.T_41, 
+			symbols.NT_FilterExpr,
 		}, 
-		LogSelectorMember0R3, 
+		FilterStage0R2, 
 	},
-	LogSelectorMembers0R0: {
-		symbols.NT_LogSelectorMembers, 0, 0, 
+	FilterTerm0R0: {
+		symbols.NT_FilterTerm, 0, 0, 
 		symbols.Symbols{  
-			symbols.NT_LogSelectorMember,
+			symbols.NT_DataFilter,
 		}, 
-		LogSelectorMembers0R0, 
+		FilterTerm0R0, 
 	},
-	LogSelectorMembers0R1: {
-		symbols.NT_LogSelectorMembers, 0, 1, 
+	FilterTerm0R1: {
+		symbols.NT_FilterTerm, 0, 1, 
 		symbols.Symbols{  
-			symbols.NT_LogSelectorMember,
+			symbols.NT_DataFilter,
 		}, 
-		LogSelectorMembers0R1, 
+		FilterTerm0R1, 
 	},
-	LogSelectorMembers1R0: {
-		symbols.NT_LogSelectorMembers, 1, 0, 
+	FilterTerm1R0: {
+		symbols.NT_FilterTerm, 1, 0, 
 		symbols.Symbols{  
-			symbols.NT_LogSelectorMember, 
-			symbols.T_4, 
-			symbols.NT_LogSelectorMembers,
+			symbols.NT_LabelFilter,
 		}, 
-		LogSelectorMembers1R0, 
+		FilterTerm1R0, 
 	},
-	LogSelectorMembers1R1: {
-		symbols.NT_LogSelectorMembers, 1, 1, 
+	FilterTerm1R1: {
+		symbols.NT_FilterTerm, 1, 1, 
 		symbols.Symbols{  
-			symbols.NT_LogSelectorMember, 
-			symbols.T_4, 
-			symbols.NT_LogSelectorMembers,
+			symbols.NT_LabelFilter,
 		}, 
-		LogSelectorMembers1R1, 
+		FilterTerm1R1, 
 	},
-	LogSelectorMembers1R2: {
+	FilterTerm2R0: {
+		symbols.NT_FilterTerm, 2, 0, 
+		symbols.Symbols{  
+			symbols.T_3, 
+			symbols.NT_FilterExpr, 
+			symbols.T_4,
+		}, 
+		FilterTerm2R0, 
+	},
+	FilterTerm2R1: {
+		symbols.NT_FilterTerm, 2, 1, 
+		symbols.Symbols{  
+			symbols.T_3, 
+			symbols.NT_FilterExpr, 
+			symbols.T_4,
+		}, 
+		FilterTerm2R1, 
+	},
+	FilterTerm2R2: {
+		symbols.NT_FilterTerm, 2, 2, 
+		symbols.Symbols{  
+			symbols.T_3, 
+			symbols.NT_FilterExpr, 
+			symbols.T_4,
+		}, 
+		FilterTerm2R2, 
+	},
+	FilterTerm2R3: {
+		symbols.NT_FilterTerm, 2, 3, 
+		symbols.Symbols{  
+			symbols.T_3, 
+			symbols.NT_FilterExpr, 
+			symbols.T_4,
+		}, 
+		FilterTerm2R3, 
+	},
+	FilterValue0R0: {
+		symbols.NT_FilterValue, 0, 0, 
+		symbols.Symbols{  
+			symbols.T_35,
+		}, 
+		FilterValue0R0, 
+	},
+	FilterValue0R1: {
+		symbols.NT_FilterValue, 0, 1, 
+		symbols.Symbols{  
+			symbols.T_35,
+		}, 
+		FilterValue0R1, 
+	},
+	FilterValue1R0: {
+		symbols.NT_FilterValue, 1, 0, 
+		symbols.Symbols{  
+			symbols.T_30,
+		}, 
+		FilterValue1R0, 
+	},
+	FilterValue1R1: {
+		symbols.NT_FilterValue, 1, 1, 
+		symbols.Symbols{  
+			symbols.T_30,
+		}, 
+		FilterValue1R1, 
+	},
+	FormatStage0R0: {
+		symbols.NT_FormatStage, 0, 0, 
+		symbols.Symbols{  
+			symbols.T_41, 
+			symbols.T_27, 
+			symbols.T_35,
+		}, 
+		FormatStage0R0, 
+	},
+	FormatStage0R1: {
+		symbols.NT_FormatStage, 0, 1, 
+		symbols.Symbols{  
+			symbols.T_41, 
+			symbols.T_27, 
+			symbols.T_35,
+		}, 
+		FormatStage0R1, 
+	},
+	FormatStage0R2: {
+		symbols.NT_FormatStage, 0, 2, 
+		symbols.Symbols{  
+			symbols.T_41, 
+			symbols.T_27, 
+			symbols.T_35,
+		}, 
+		FormatStage0R2, 
+	},
+	FormatStage0R3: {
+		symbols.NT_FormatStage, 0, 3, 
+		symbols.Symbols{  
+			symbols.T_41, 
+			symbols.T_27, 
+			symbols.T_35,
+		}, 
+		FormatStage0R3, 
+	},
+	FormatStage1R0: {
+		symbols.NT_FormatStage, 1, 0, 
+		symbols.Symbols{  
+			symbols.T_41, 
+			symbols.T_26, 
+			symbols.NT_LabelFormats,
+		}, 
+		FormatStage1R0, 
+	},
+	FormatStage1R1: {
+		symbols.NT_FormatStage, 1, 1, 
+		symbols.Symbols{  
+			symbols.T_41, 
+			symbols.T_26, 
+			symbols.NT_LabelFormats,
+		}, 
+		FormatStage1R1, 
+	},
+	FormatStage1R2: {
+		symbols.NT_FormatStage, 1, 2, 
+		symbols.Symbols{  
+			symbols.T_41, 
+			symbols.T_26, 
+			symbols.NT_LabelFormats,
+		}, 
+		FormatStage1R2, 
+	},
+	FormatStage1R3: {
+		symbols.NT_FormatStage, 1, 3, 
+		symbols.Symbols{  
+			symbols.T_41, 
+			symbols.T_26, 
+			symbols.NT_LabelFormats,
+		}, 
+		FormatStage1R3, 
+	},
+	GroupModifierMaybe0R0: {
+		symbols.NT_GroupModifierMaybe, 0, 0, 
+		symbols.Symbols{ 
+		}, 
+		GroupModifierMaybe0R0, 
+	},
+	GroupModifierMaybe1R0: {
+		symbols.NT_GroupModifierMaybe, 1, 0, 
+		symbols.Symbols{  
+			symbols.NT_GroupSide,
+		}, 
+		GroupModifierMaybe1R0, 
+	},
+	GroupModifierMaybe1R1: {
+		symbols.NT_GroupModifierMaybe, 1, 1, 
+		symbols.Symbols{  
+			symbols.NT_GroupSide,
+		}, 
+		GroupModifierMaybe1R1, 
+	},
+	GroupModifierMaybe2R0: {
+		symbols.NT_GroupModifierMaybe, 2, 0, 
+		symbols.Symbols{  
+			symbols.NT_GroupSide, 
+			symbols.T_3, 
+			symbols.NT_LabelKeysMaybe, 
+			symbols.T_4,
+		}, 
+		GroupModifierMaybe2R0, 
+	},
+	GroupModifierMaybe2R1: {
+		symbols.NT_GroupModifierMaybe, 2, 1, 
+		symbols.Symbols{  
+			symbols.NT_GroupSide, 
+			symbols.T_3, 
+			symbols.NT_LabelKeysMaybe, 
+			symbols.T_4,
+		}, 
+		GroupModifierMaybe2R1, 
+	},
+	GroupModifierMaybe2R2: {
+		symbols.NT_GroupModifierMaybe, 2, 2, 
+		symbols.Symbols{  
+			symbols.NT_GroupSide, 
+			symbols.T_3, 
+			symbols.NT_LabelKeysMaybe, 
+			symbols.T_4,
+		}, 
+		GroupModifierMaybe2R2, 
+	},
+	GroupModifierMaybe2R3: {
+		symbols.NT_GroupModifierMaybe, 2, 3, 
+		symbols.Symbols{  
+			symbols.NT_GroupSide, 
+			symbols.T_3, 
+			symbols.NT_LabelKeysMaybe, 
+			symbols.T_4,
+		}, 
+		GroupModifierMaybe2R3, 
+	},
+	GroupModifierMaybe2R4: {
+		symbols.NT_GroupModifierMaybe, 2, 4, 
+		symbols.Symbols{  
+			symbols.NT_GroupSide, 
+			symbols.T_3, 
+			symbols.NT_LabelKeysMaybe, 
+			symbols.T_4,
+		}, 
+		GroupModifierMaybe2R4, 
+	},
+	GroupSide0R0: {
+		symbols.NT_GroupSide, 0, 0, 
+		symbols.Symbols{  
+			symbols.T_22,
+		}, 
+		GroupSide0R0, 
+	},
+	GroupSide0R1: {
+		symbols.NT_GroupSide, 0, 1, 
+		symbols.Symbols{  
+			symbols.T_22,
+		}, 
+		GroupSide0R1, 
+	},
+	GroupSide1R0: {
+		symbols.NT_GroupSide, 1, 0, 
+		symbols.Symbols{  
+			symbols.T_23,
+		}, 
+		GroupSide1R0, 
+	},
+	GroupSide1R1: {
+		symbols.NT_GroupSide, 1, 1, 
+		symbols.Symbols{  
+			symbols.T_23,
+		}, 
+		GroupSide1R1, 
+	},
+	Grouping0R0: {
+		symbols.NT_Grouping, 0, 0, 
+		symbols.Symbols{  
+			symbols.NT_GroupingOp, 
+			symbols.T_3, 
+			symbols.NT_LabelKeysMaybe, 
+			symbols.T_4,
+		}, 
+		Grouping0R0, 
+	},
+	Grouping0R1: {
+		symbols.NT_Grouping, 0, 1, 
+		symbols.Symbols{  
+			symbols.NT_GroupingOp, 
+			symbols.T_3, 
+			symbols.NT_LabelKeysMaybe, 
+			symbols.T_4,
+		}, 
+		Grouping0R1, 
+	},
+	Grouping0R2: {
+		symbols.NT_Grouping, 0, 2, 
+		symbols.Symbols{  
+			symbols.NT_GroupingOp, 
+			symbols.T_3, 
+			symbols.NT_LabelKeysMaybe, 
+			symbols.T_4,
+		}, 
+		Grouping0R2, 
+	},
+	Grouping0R3: {
+		symbols.NT_Grouping, 0, 3, 
+		symbols.Symbols{  
+			symbols.NT_GroupingOp, 
+			symbols.T_3, 
+			symbols.NT_LabelKeysMaybe, 
+			symbols.T_4,
+		}, 
+		Grouping0R3, 
+	},
+	Grouping0R4: {
+		symbols.NT_Grouping, 0, 4, 
+		symbols.Symbols{  
+			symbols.NT_GroupingOp, 
+			symbols.T_3, 
+			symbols.NT_LabelKeysMaybe, 
+			symbols.T_4,
+		}, 
+		Grouping0R4, 
+	},
+	GroupingMaybe0R0: {
+		symbols.NT_GroupingMaybe, 0, 0, 
+		symbols.Symbols{ 
+		}, 
+		GroupingMaybe0R0, 
+	},
+	GroupingMaybe1R0: {
+		symbols.NT_GroupingMaybe, 1, 0, 
+		symbols.Symbols{  
+			symbols.NT_Grouping,
+		}, 
+		GroupingMaybe1R0, 
+	},
+	GroupingMaybe1R1: {
+		symbols.NT_GroupingMaybe, 1, 1, 
+		symbols.Symbols{  
+			symbols.NT_Grouping,
+		}, 
+		GroupingMaybe1R1, 
+	},
+	GroupingOp0R0: {
+		symbols.NT_GroupingOp, 0, 0, 
+		symbols.Symbols{  
+			symbols.T_20,
+		}, 
+		GroupingOp0R0, 
+	},
+	GroupingOp0R1: {
+		symbols.NT_GroupingOp, 0, 1, 
+		symbols.Symbols{  
+			symbols.T_20,
+		}, 
+		GroupingOp0R1, 
+	},
+	GroupingOp1R0: {
+		symbols.NT_GroupingOp, 1, 0, 
+		symbols.Symbols{  
+			symbols.T_39,
+		}, 
+		GroupingOp1R0, 
+	},
+	GroupingOp1R1: {
+		symbols.NT_GroupingOp, 1, 1, 
+		symbols.Symbols{  
+			symbols.T_39,
+		}, 
+		GroupingOp1R1, 
+	},
+	LabelFilter0R0: {
+		symbols.NT_LabelFilter, 0, 0, 
+		symbols.Symbols{  
+			symbols.NT_LabelKey, 
+			symbols.NT_LabelFilterOp, 
+			symbols.NT_FilterValue,
+		}, 
+		LabelFilter0R0, 
+	},
+	LabelFilter0R1: {
+		symbols.NT_LabelFilter, 0, 1, 
+		symbols.Symbols{  
+			symbols.NT_LabelKey, 
+			symbols.NT_LabelFilterOp, 
+			symbols.NT_FilterValue,
+		}, 
+		LabelFilter0R1, 
+	},
+	LabelFilter0R2: {
+		symbols.NT_LabelFilter, 0, 2, 
+		symbols.Symbols{  
+			symbols.NT_LabelKey, 
+			symbols.NT_LabelFilterOp, 
+			symbols.NT_FilterValue,
+		}, 
+		LabelFilter0R2, 
+	},
+	LabelFilter0R3: {
+		symbols.NT_LabelFilter, 0, 3, 
+		symbols.Symbols{  
+			symbols.NT_LabelKey, 
+			symbols.NT_LabelFilterOp, 
+			symbols.NT_FilterValue,
+		}, 
+		LabelFilter0R3, 
+	},
+	LabelFilterOp0R0: {
+		symbols.NT_LabelFilterOp, 0, 0, 
+		symbols.Symbols{  
+			symbols.T_12,
+		}, 
+		LabelFilterOp0R0, 
+	},
+	LabelFilterOp0R1: {
+		symbols.NT_LabelFilterOp, 0, 1, 
+		symbols.Symbols{  
+			symbols.T_12,
+		}, 
+		LabelFilterOp0R1, 
+	},
+	LabelFilterOp1R0: {
+		symbols.NT_LabelFilterOp, 1, 0, 
+		symbols.Symbols{  
+			symbols.T_13,
+		}, 
+		LabelFilterOp1R0, 
+	},
+	LabelFilterOp1R1: {
+		symbols.NT_LabelFilterOp, 1, 1, 
+		symbols.Symbols{  
+			symbols.T_13,
+		}, 
+		LabelFilterOp1R1, 
+	},
+	LabelFilterOp2R0: {
+		symbols.NT_LabelFilterOp, 2, 0, 
+		symbols.Symbols{  
+			symbols.T_0,
+		}, 
+		LabelFilterOp2R0, 
+	},
+	LabelFilterOp2R1: {
+		symbols.NT_LabelFilterOp, 2, 1, 
+		symbols.Symbols{  
+			symbols.T_0,
+		}, 
+		LabelFilterOp2R1, 
+	},
+	LabelFilterOp3R0: {
+		symbols.NT_LabelFilterOp, 3, 0, 
+		symbols.Symbols{  
+			symbols.T_14,
+		}, 
+		LabelFilterOp3R0, 
+	},
+	LabelFilterOp3R1: {
+		symbols.NT_LabelFilterOp, 3, 1, 
+		symbols.Symbols{  
+			symbols.T_14,
+		}, 
+		LabelFilterOp3R1, 
+	},
+	LabelFilterOp4R0: {
+		symbols.NT_LabelFilterOp, 4, 0, 
+		symbols.Symbols{  
+			symbols.T_1,
+		}, 
+		LabelFilterOp4R0, 
+	},
+	LabelFilterOp4R1: {
+		symbols.NT_LabelFilterOp, 4, 1, 
+		symbols.Symbols{  
+			symbols.T_1,
+		}, 
+		LabelFilterOp4R1, 
+	},
+	LabelFilterOp5R0: {
+		symbols.NT_LabelFilterOp, 5, 0, 
+		symbols.Symbols{  
+			symbols.T_16,
+		}, 
+		LabelFilterOp5R0, 
+	},
+	LabelFilterOp5R1: {
+		symbols.NT_LabelFilterOp, 5, 1, 
+		symbols.Symbols{  
+			symbols.T_16,
+		}, 
+		LabelFilterOp5R1, 
+	},
+	LabelFilterOp6R0: {
+		symbols.NT_LabelFilterOp, 6, 0, 
+		symbols.Symbols{  
+			symbols.T_15,
+		}, 
+		LabelFilterOp6R0, 
+	},
+	LabelFilterOp6R1: {
+		symbols.NT_LabelFilterOp, 6, 1, 
+		symbols.Symbols{  
+			symbols.T_15,
+		}, 
+		LabelFilterOp6R1, 
+	},
+	LabelFilterOp7R0: {
+		symbols.NT_LabelFilterOp, 7, 0, 
+		symbols.Symbols{  
+			symbols.T_11,
+		}, 
+		LabelFilterOp7R0, 
+	},
+	LabelFilterOp7R1: {
+		symbols.NT_LabelFilterOp, 7, 1, 
+		symbols.Symbols{  
+			symbols.T_11,
+		}, 
+		LabelFilterOp7R1, 
+	},
+	LabelFilterOp8R0: {
+		symbols.NT_LabelFilterOp, 8, 0, 
+		symbols.Symbols{  
+			symbols.T_10,
+		}, 
+		LabelFilterOp8R0, 
+	},
+	LabelFilterOp8R1: {
+		symbols.NT_LabelFilterOp, 8, 1, 
+		symbols.Symbols{  
+			symbols.T_10,
+		}, 
+		LabelFilterOp8R1, 
+	},
+	LabelFormat0R0: {
+		symbols.NT_LabelFormat, 0, 0, 
+		symbols.Symbols{  
+			symbols.NT_LabelKey, 
+			symbols.T_12, 
+			symbols.NT_LabelFormatValue,
+		}, 
+		LabelFormat0R0, 
+	},
+	LabelFormat0R1: {
+		symbols.NT_LabelFormat, 0, 1, 
+		symbols.Symbols{  
+			symbols.NT_LabelKey, 
+			symbols.T_12, 
+			symbols.NT_LabelFormatValue,
+		}, 
+		LabelFormat0R1, 
+	},
+	LabelFormat0R2: {
+		symbols.NT_LabelFormat, 0, 2, 
+		symbols.Symbols{  
+			symbols.NT_LabelKey, 
+			symbols.T_12, 
+			symbols.NT_LabelFormatValue,
+		}, 
+		LabelFormat0R2, 
+	},
+	LabelFormat0R3: {
+		symbols.NT_LabelFormat, 0, 3, 
+		symbols.Symbols{  
+			symbols.NT_LabelKey, 
+			symbols.T_12, 
+			symbols.NT_LabelFormatValue,
+		}, 
+		LabelFormat0R3, 
+	},
+	LabelFormatValue0R0: {
+		symbols.NT_LabelFormatValue, 0, 0, 
+		symbols.Symbols{  
+			symbols.NT_LabelKey,
+		}, 
+		LabelFormatValue0R0, 
+	},
+	LabelFormatValue0R1: {
+		symbols.NT_LabelFormatValue, 0, 1, 
+		symbols.Symbols{  
+			symbols.NT_LabelKey,
+		}, 
+		LabelFormatValue0R1, 
+	},
+	LabelFormatValue1R0: {
+		symbols.NT_LabelFormatValue, 1, 0, 
+		symbols.Symbols{  
+			symbols.T_35,
+		}, 
+		LabelFormatValue1R0, 
+	},
+	LabelFormatValue1R1: {
+		symbols.NT_LabelFormatValue, 1, 1, 
+		symbols.Symbols{  
+			symbols.T_35,
+		}, 
+		LabelFormatValue1R1, 
+	},
+	LabelFormats0R0: {
+		symbols.NT_LabelFormats, 0, 0, 
+		symbols.Symbols{  
+			symbols.NT_LabelFormat,
+		}, 
+		LabelFormats0R0, 
+	},
+	LabelFormats0R1: {
+		symbols.NT_LabelFormats, 0, 1, 
+		symbols.Symbols{  
+			symbols.NT_LabelFormat,
+		}, 
+		LabelFormats0R1, 
+	},
+	LabelFormats1R0: {
+		symbols.NT_LabelFormats, 1, 0, 
+		symbols.Symbols{  
+			symbols.NT_LabelFormat, 
+			symbols.T_7, 
+			symbols.NT_LabelFormats,
+		}, 
+		LabelFormats1R0, 
+	},
+	LabelFormats1R1: {
+		symbols.NT_LabelFormats, 1, 1, 
+		symbols.Symbols{  
+			symbols.NT_LabelFormat, 
+			symbols.T_7, 
+			symbols.NT_LabelFormats,
+		}, 
+		LabelFormats1R1, 
+	},
+	LabelFormats1R2: {
+		symbols.NT_LabelFormats, 1, 2, 
+		symbols.Symbols{  
+			symbols.NT_LabelFormat, 
+			symbols.T_7, 
+			symbols.NT_LabelFormats,
+		}, 
+		LabelFormats1R2, 
+	},
+	LabelFormats1R3: {
+		symbols.NT_LabelFormats, 1, 3, 
+		symbols.Symbols{  
+			symbols.NT_LabelFormat, 
+			symbols.T_7, 
+			symbols.NT_LabelFormats,
+		}, 
+		LabelFormats1R3, 
+	},
+	LabelKey0R0: {
+		symbols.NT_LabelKey, 0, 0, 
+		symbols.Symbols{  
+			symbols.T_38,
+		}, 
+		LabelKey0R0, 
+	},
+	LabelKey0R1: {
+		symbols.NT_LabelKey, 0, 1, 
+		symbols.Symbols{  
+			symbols.T_38,
+		}, 
+		LabelKey0R1, 
+	},
+	LabelKey1R0: {
+		symbols.NT_LabelKey, 1, 0, 
+		symbols.Symbols{  
+			symbols.T_20,
+		}, 
+		LabelKey1R0, 
+	},
+	LabelKey1R1: {
+		symbols.NT_LabelKey, 1, 1, 
+		symbols.Symbols{  
+			symbols.T_20,
+		}, 
+		LabelKey1R1, 
+	},
+	LabelKey2R0: {
+		symbols.NT_LabelKey, 2, 0, 
+		symbols.Symbols{  
+			symbols.T_39,
+		}, 
+		LabelKey2R0, 
+	},
+	LabelKey2R1: {
+		symbols.NT_LabelKey, 2, 1, 
+		symbols.Symbols{  
+			symbols.T_39,
+		}, 
+		LabelKey2R1, 
+	},
+	LabelKey3R0: {
+		symbols.NT_LabelKey, 3, 0, 
+		symbols.Symbols{  
+			symbols.T_37,
+		}, 
+		LabelKey3R0, 
+	},
+	LabelKey3R1: {
+		symbols.NT_LabelKey, 3, 1, 
+		symbols.Symbols{  
+			symbols.T_37,
+		}, 
+		LabelKey3R1, 
+	},
+	LabelKey4R0: {
+		symbols.NT_LabelKey, 4, 0, 
+		symbols.Symbols{  
+			symbols.T_25,
+		}, 
+		LabelKey4R0, 
+	},
+	LabelKey4R1: {
+		symbols.NT_LabelKey, 4, 1, 
+		symbols.Symbols{  
+			symbols.T_25,
+		}, 
+		LabelKey4R1, 
+	},
+	LabelKey5R0: {
+		symbols.NT_LabelKey, 5, 0, 
+		symbols.Symbols{  
+			symbols.T_28,
+		}, 
+		LabelKey5R0, 
+	},
+	LabelKey5R1: {
+		symbols.NT_LabelKey, 5, 1, 
+		symbols.Symbols{  
+			symbols.T_28,
+		}, 
+		LabelKey5R1, 
+	},
+	LabelKey6R0: {
+		symbols.NT_LabelKey, 6, 0, 
+		symbols.Symbols{  
+			symbols.T_34,
+		}, 
+		LabelKey6R0, 
+	},
+	LabelKey6R1: {
+		symbols.NT_LabelKey, 6, 1, 
+		symbols.Symbols{  
+			symbols.T_34,
+		}, 
+		LabelKey6R1, 
+	},
+	LabelKey7R0: {
+		symbols.NT_LabelKey, 7, 0, 
+		symbols.Symbols{  
+			symbols.T_33,
+		}, 
+		LabelKey7R0, 
+	},
+	LabelKey7R1: {
+		symbols.NT_LabelKey, 7, 1, 
+		symbols.Symbols{  
+			symbols.T_33,
+		}, 
+		LabelKey7R1, 
+	},
+	LabelKey8R0: {
+		symbols.NT_LabelKey, 8, 0, 
+		symbols.Symbols{  
+			symbols.T_27,
+		}, 
+		LabelKey8R0, 
+	},
+	LabelKey8R1: {
+		symbols.NT_LabelKey, 8, 1, 
+		symbols.Symbols{  
+			symbols.T_27,
+		}, 
+		LabelKey8R1, 
+	},
+	LabelKey9R0: {
+		symbols.NT_LabelKey, 9, 0, 
+		symbols.Symbols{  
+			symbols.T_26,
+		}, 
+		LabelKey9R0, 
+	},
+	LabelKey9R1: {
+		symbols.NT_LabelKey, 9, 1, 
+		symbols.Symbols{  
+			symbols.T_26,
+		}, 
+		LabelKey9R1, 
+	},
+	LabelKeys0R0: {
+		symbols.NT_LabelKeys, 0, 0, 
+		symbols.Symbols{  
+			symbols.NT_LabelKey,
+		}, 
+		LabelKeys0R0, 
+	},
+	LabelKeys0R1: {
+		symbols.NT_LabelKeys, 0, 1, 
+		symbols.Symbols{  
+			symbols.NT_LabelKey,
+		}, 
+		LabelKeys0R1, 
+	},
+	LabelKeys1R0: {
+		symbols.NT_LabelKeys, 1, 0, 
+		symbols.Symbols{  
+			symbols.NT_LabelKey, 
+			symbols.T_7, 
+			symbols.NT_LabelKeys,
+		}, 
+		LabelKeys1R0, 
+	},
+	LabelKeys1R1: {
+		symbols.NT_LabelKeys, 1, 1, 
+		symbols.Symbols{  
+			symbols.NT_LabelKey, 
+			symbols.T_7, 
+			symbols.NT_LabelKeys,
+		}, 
+		LabelKeys1R1, 
+	},
+	LabelKeys1R2: {
+		symbols.NT_LabelKeys, 1, 2, 
+		symbols.Symbols{  
+			symbols.NT_LabelKey, 
+			symbols.T_7, 
+			symbols.NT_LabelKeys,
+		}, 
+		LabelKeys1R2, 
+	},
+	LabelKeys1R3: {
+		symbols.NT_LabelKeys, 1, 3, 
+		symbols.Symbols{  
+			symbols.NT_LabelKey, 
+			symbols.T_7, 
+			symbols.NT_LabelKeys,
+		}, 
+		LabelKeys1R3, 
+	},
+	LabelKeysMaybe0R0: {
+		symbols.NT_LabelKeysMaybe, 0, 0, 
+		symbols.Symbols{ 
+		}, 
+		LabelKeysMaybe0R0, 
+	},
+	LabelKeysMaybe1R0: {
+		symbols.NT_LabelKeysMaybe, 1, 0, 
+		symbols.Symbols{  
+			symbols.NT_LabelKeys,
+		}, 
+		LabelKeysMaybe1R0, 
+	},
+	LabelKeysMaybe1R1: {
+		symbols.NT_LabelKeysMaybe, 1, 1, 
+		symbols.Symbols{  
+			symbols.NT_LabelKeys,
+		}, 
+		LabelKeysMaybe1R1, 
+	},
+	LineFilter0R0: {
+		symbols.NT_LineFilter, 0, 0, 
+		symbols.Symbols{  
+			symbols.NT_LineFilterOp, 
+			symbols.NT_LineFilterValues,
+		}, 
+		LineFilter0R0, 
+	},
+	LineFilter0R1: {
+		symbols.NT_LineFilter, 0, 1, 
+		symbols.Symbols{  
+			symbols.NT_LineFilterOp, 
+			symbols.NT_LineFilterValues,
+		}, 
+		LineFilter0R1, 
+	},
+	LineFilter0R2: {
+		symbols.NT_LineFilter, 0, 2, 
+		symbols.Symbols{  
+			symbols.NT_LineFilterOp, 
+			symbols.NT_LineFilterValues,
+		}, 
+		LineFilter0R2, 
+	},
+	LineFilterOp0R0: {
+		symbols.NT_LineFilterOp, 0, 0, 
+		symbols.Symbols{  
+			symbols.T_42,
+		}, 
+		LineFilterOp0R0, 
+	},
+	LineFilterOp0R1: {
+		symbols.NT_LineFilterOp, 0, 1, 
+		symbols.Symbols{  
+			symbols.T_42,
+		}, 
+		LineFilterOp0R1, 
+	},
+	LineFilterOp1R0: {
+		symbols.NT_LineFilterOp, 1, 0, 
+		symbols.Symbols{  
+			symbols.T_0,
+		}, 
+		LineFilterOp1R0, 
+	},
+	LineFilterOp1R1: {
+		symbols.NT_LineFilterOp, 1, 1, 
+		symbols.Symbols{  
+			symbols.T_0,
+		}, 
+		LineFilterOp1R1, 
+	},
+	LineFilterOp2R0: {
+		symbols.NT_LineFilterOp, 2, 0, 
+		symbols.Symbols{  
+			symbols.T_43,
+		}, 
+		LineFilterOp2R0, 
+	},
+	LineFilterOp2R1: {
+		symbols.NT_LineFilterOp, 2, 1, 
+		symbols.Symbols{  
+			symbols.T_43,
+		}, 
+		LineFilterOp2R1, 
+	},
+	LineFilterOp3R0: {
+		symbols.NT_LineFilterOp, 3, 0, 
+		symbols.Symbols{  
+			symbols.T_1,
+		}, 
+		LineFilterOp3R0, 
+	},
+	LineFilterOp3R1: {
+		symbols.NT_LineFilterOp, 3, 1, 
+		symbols.Symbols{  
+			symbols.T_1,
+		}, 
+		LineFilterOp3R1, 
+	},
+	LineFilterValues0R0: {
+		symbols.NT_LineFilterValues, 0, 0, 
+		symbols.Symbols{  
+			symbols.T_35,
+		}, 
+		LineFilterValues0R0, 
+	},
+	LineFilterValues0R1: {
+		symbols.NT_LineFilterValues, 0, 1, 
+		symbols.Symbols{  
+			symbols.T_35,
+		}, 
+		LineFilterValues0R1, 
+	},
+	LineFilterValues1R0: {
+		symbols.NT_LineFilterValues, 1, 0, 
+		symbols.Symbols{  
+			symbols.T_35, 
+			symbols.T_32, 
+			symbols.NT_LineFilterValues,
+		}, 
+		LineFilterValues1R0, 
+	},
+	LineFilterValues1R1: {
+		symbols.NT_LineFilterValues, 1, 1, 
+		symbols.Symbols{  
+			symbols.T_35, 
+			symbols.T_32, 
+			symbols.NT_LineFilterValues,
+		}, 
+		LineFilterValues1R1, 
+	},
+	LineFilterValues1R2: {
+		symbols.NT_LineFilterValues, 1, 2, 
+		symbols.Symbols{  
+			symbols.T_35, 
+			symbols.T_32, 
+			symbols.NT_LineFilterValues,
+		}, 
+		LineFilterValues1R2, 
+	},
+	LineFilterValues1R3: {
+		symbols.NT_LineFilterValues, 1, 3, 
+		symbols.Symbols{  
+			symbols.T_35, 
+			symbols.T_32, 
+			symbols.NT_LineFilterValues,
+		}, 
+		LineFilterValues1R3, 
+	},
+	LogQuery0R0: {
+		symbols.NT_LogQuery, 0, 0, 
+		symbols.Symbols{  
+			symbols.NT_LogSelector, 
+			symbols.NT_PipelinesMaybe,
+		}, 
+		LogQuery0R0, 
+	},
+	LogQuery0R1: {
+		symbols.NT_LogQuery, 0, 1, 
+		symbols.Symbols{  
+			symbols.NT_LogSelector, 
+			symbols.NT_PipelinesMaybe,
+		}, 
+		LogQuery0R1, 
+	},
+	LogQuery0R2: {
+		symbols.NT_LogQuery, 0, 2, 
+		symbols.Symbols{  
+			symbols.NT_LogSelector, 
+			symbols.NT_PipelinesMaybe,
+		}, 
+		LogQuery0R2, 
+	},
+	LogSelector0R0: {
+		symbols.NT_LogSelector, 0, 0, 
+		symbols.Symbols{  
+			symbols.T_40, 
+			symbols.NT_LogSelectorMembersMaybe, 
+			symbols.T_44,
+		}, 
+		LogSelector0R0, 
+	},
+	LogSelector0R1: {
+		symbols.NT_LogSelector, 0, 1, 
+		symbols.Symbols{  
+			symbols.T_40, 
+			symbols.NT_LogSelectorMembersMaybe, 
+			symbols.T_44,
+		}, 
+		LogSelector0R1, 
+	},
+	LogSelector0R2: {
+		symbols.NT_LogSelector, 0, 2, 
+		symbols.Symbols{  
+			symbols.T_40, 
+			symbols.NT_LogSelectorMembersMaybe, 
+			symbols.T_44,
+		}, 
+		LogSelector0R2, 
+	},
+	LogSelector0R3: {
+		symbols.NT_LogSelector, 0, 3, 
+		symbols.Symbols{  
+			symbols.T_40, 
+			symbols.NT_LogSelectorMembersMaybe, 
+			symbols.T_44,
+		}, 
+		LogSelector0R3, 
+	},
+	LogSelectorMember0R0: {
+		symbols.NT_LogSelectorMember, 0, 0, 
+		symbols.Symbols{  
+			symbols.NT_LabelKey, 
+			symbols.NT_LogSelectorOp, 
+			symbols.T_35,
+		}, 
+		LogSelectorMember0R0, 
+	},
+	LogSelectorMember0R1: {
+		symbols.NT_LogSelectorMember, 0, 1, 
+		symbols.Symbols{  
+			symbols.NT_LabelKey, 
+			symbols.NT_LogSelectorOp, 
+			symbols.T_35,
+		}, 
+		LogSelectorMember0R1, 
+	},
+	LogSelectorMember0R2: {
+		symbols.NT_LogSelectorMember, 0, 2, 
+		symbols.Symbols{  
+			symbols.NT_LabelKey, 
+			symbols.NT_LogSelectorOp, 
+			symbols.T_35,
+		}, 
+		LogSelectorMember0R2, 
+	},
+	LogSelectorMember0R3: {
+		symbols.NT_LogSelectorMember, 0, 3, 
+		symbols.Symbols{  
+			symbols.NT_LabelKey, 
+			symbols.NT_LogSelectorOp, 
+			symbols.T_35,
+		}, 
+		LogSelectorMember0R3, 
+	},
+	LogSelectorMembers0R0: {
+		symbols.NT_LogSelectorMembers, 0, 0, 
+		symbols.Symbols{  
+			symbols.NT_LogSelectorMember,
+		}, 
+		LogSelectorMembers0R0, 
+	},
+	LogSelectorMembers0R1: {
+		symbols.NT_LogSelectorMembers, 0, 1, 
+		symbols.Symbols{  
+			symbols.NT_LogSelectorMember,
+		}, 
+		LogSelectorMembers0R1, 
+	},
+	LogSelectorMembers1R0: {
+		symbols.NT_LogSelectorMembers, 1, 0, 
+		symbols.Symbols{  
+			symbols.NT_LogSelectorMember, 
+			symbols.T_7, 
+			symbols.NT_LogSelectorMembers,
+		}, 
+		LogSelectorMembers1R0, 
+	},
+	LogSelectorMembers1R1: {
+		symbols.NT_LogSelectorMembers, 1, 1, 
+		symbols.Symbols{  
+			symbols.NT_LogSelectorMember, 
+			symbols.T_7, 
+			symbols.NT_LogSelectorMembers,
+		}, 
+		LogSelectorMembers1R1, 
+	},
+	LogSelectorMembers1R2: {
 		symbols.NT_LogSelectorMembers, 1, 2, 
 		symbols.Symbols{  
-			symbols.NT_LogSelectorMember, 
-			symbols.T_4, 
-			symbols.NT_LogSelectorMembers,
+			symbols.NT_LogSelectorMember, 
+			symbols.T_7, 
+			symbols.NT_LogSelectorMembers,
+		}, 
+		LogSelectorMembers1R2, 
+	},
+	LogSelectorMembers1R3: {
+		symbols.NT_LogSelectorMembers, 1, 3, 
+		symbols.Symbols{  
+			symbols.NT_LogSelectorMember, 
+			symbols.T_7, 
+			symbols.NT_LogSelectorMembers,
+		}, 
+		LogSelectorMembers1R3, 
+	},
+	LogSelectorMembersMaybe0R0: {
+		symbols.NT_LogSelectorMembersMaybe, 0, 0, 
+		symbols.Symbols{ 
+		}, 
+		LogSelectorMembersMaybe0R0, 
+	},
+	LogSelectorMembersMaybe1R0: {
+		symbols.NT_LogSelectorMembersMaybe, 1, 0, 
+		symbols.Symbols{  
+			symbols.NT_LogSelectorMembers,
+		}, 
+		LogSelectorMembersMaybe1R0, 
+	},
+	LogSelectorMembersMaybe1R1: {
+		symbols.NT_LogSelectorMembersMaybe, 1, 1, 
+		symbols.Symbols{  
+			symbols.NT_LogSelectorMembers,
+		}, 
+		LogSelectorMembersMaybe1R1, 
+	},
+	LogSelectorOp0R0: {
+		symbols.NT_LogSelectorOp, 0, 0, 
+		symbols.Symbols{  
+			symbols.T_12,
+		}, 
+		LogSelectorOp0R0, 
+	},
+	LogSelectorOp0R1: {
+		symbols.NT_LogSelectorOp, 0, 1, 
+		symbols.Symbols{  
+			symbols.T_12,
+		}, 
+		LogSelectorOp0R1, 
+	},
+	LogSelectorOp1R0: {
+		symbols.NT_LogSelectorOp, 1, 0, 
+		symbols.Symbols{  
+			symbols.T_0,
+		}, 
+		LogSelectorOp1R0, 
+	},
+	LogSelectorOp1R1: {
+		symbols.NT_LogSelectorOp, 1, 1, 
+		symbols.Symbols{  
+			symbols.T_0,
+		}, 
+		LogSelectorOp1R1, 
+	},
+	LogSelectorOp2R0: {
+		symbols.NT_LogSelectorOp, 2, 0, 
+		symbols.Symbols{  
+			symbols.T_14,
+		}, 
+		LogSelectorOp2R0, 
+	},
+	LogSelectorOp2R1: {
+		symbols.NT_LogSelectorOp, 2, 1, 
+		symbols.Symbols{  
+			symbols.T_14,
 		}, 
-		LogSelectorMembers1R2, 
+		LogSelectorOp2R1, 
 	},
-	LogSelectorMembers1R3: {
-		symbols.NT_LogSelectorMembers, 1, 3, 
+	LogSelectorOp3R0: {
+		symbols.NT_LogSelectorOp, 3, 0, 
 		symbols.Symbols{  
-			symbols.NT_LogSelectorMember, 
-			symbols.T_4, 
-			symbols.NT_LogSelectorMembers,
+			symbols.T_1,
 		}, 
-		LogSelectorMembers1R3, 
+		LogSelectorOp3R0, 
 	},
-	LogSelectorMembersMaybe0R0: {
-		symbols.NT_LogSelectorMembersMaybe, 0, 0, 
+	LogSelectorOp3R1: {
+		symbols.NT_LogSelectorOp, 3, 1, 
+		symbols.Symbols{  
+			symbols.T_1,
+		}, 
+		LogSelectorOp3R1, 
+	},
+	Matching0R0: {
+		symbols.NT_Matching, 0, 0, 
+		symbols.Symbols{  
+			symbols.NT_MatchingOp, 
+			symbols.T_3, 
+			symbols.NT_LabelKeysMaybe, 
+			symbols.T_4,
+		}, 
+		Matching0R0, 
+	},
+	Matching0R1: {
+		symbols.NT_Matching, 0, 1, 
+		symbols.Symbols{  
+			symbols.NT_MatchingOp, 
+			symbols.T_3, 
+			symbols.NT_LabelKeysMaybe, 
+			symbols.T_4,
+		}, 
+		Matching0R1, 
+	},
+	Matching0R2: {
+		symbols.NT_Matching, 0, 2, 
+		symbols.Symbols{  
+			symbols.NT_MatchingOp, 
+			symbols.T_3, 
+			symbols.NT_LabelKeysMaybe, 
+			symbols.T_4,
+		}, 
+		Matching0R2, 
+	},
+	Matching0R3: {
+		symbols.NT_Matching, 0, 3, 
+		symbols.Symbols{  
+			symbols.NT_MatchingOp, 
+			symbols.T_3, 
+			symbols.NT_LabelKeysMaybe, 
+			symbols.T_4,
+		}, 
+		Matching0R3, 
+	},
+	Matching0R4: {
+		symbols.NT_Matching, 0, 4, 
+		symbols.Symbols{  
+			symbols.NT_MatchingOp, 
+			symbols.T_3, 
+			symbols.NT_LabelKeysMaybe, 
+			symbols.T_4,
+		}, 
+		Matching0R4, 
+	},
+	MatchingMaybe0R0: {
+		symbols.NT_MatchingMaybe, 0, 0, 
 		symbols.Symbols{ 
 		}, 
-		LogSelectorMembersMaybe0R0, 
+		MatchingMaybe0R0, 
 	},
-	LogSelectorMembersMaybe1R0: {
-		symbols.NT_LogSelectorMembersMaybe, 1, 0, 
+	MatchingMaybe1R0: {
+		symbols.NT_MatchingMaybe, 1, 0, 
 		symbols.Symbols{  
-			symbols.NT_LogSelectorMembers,
+			symbols.NT_Matching, 
+			symbols.NT_GroupModifierMaybe,
 		}, 
-		LogSelectorMembersMaybe1R0, 
+		MatchingMaybe1R0, 
 	},
-	LogSelectorMembersMaybe1R1: {
-		symbols.NT_LogSelectorMembersMaybe, 1, 1, 
+	MatchingMaybe1R1: {
+		symbols.NT_MatchingMaybe, 1, 1, 
+		symbols.Symbols{  
+			symbols.NT_Matching, 
+			symbols.NT_GroupModifierMaybe,
+		}, 
+		MatchingMaybe1R1, 
+	},
+	MatchingMaybe1R2: {
+		symbols.NT_MatchingMaybe, 1, 2, 
+		symbols.Symbols{  
+			symbols.NT_Matching, 
+			symbols.NT_GroupModifierMaybe,
+		}, 
+		MatchingMaybe1R2, 
+	},
+	MatchingOp0R0: {
+		symbols.NT_MatchingOp, 0, 0, 
+		symbols.Symbols{  
+			symbols.T_31,
+		}, 
+		MatchingOp0R0, 
+	},
+	MatchingOp0R1: {
+		symbols.NT_MatchingOp, 0, 1, 
+		symbols.Symbols{  
+			symbols.T_31,
+		}, 
+		MatchingOp0R1, 
+	},
+	MatchingOp1R0: {
+		symbols.NT_MatchingOp, 1, 0, 
+		symbols.Symbols{  
+			symbols.T_24,
+		}, 
+		MatchingOp1R0, 
+	},
+	MatchingOp1R1: {
+		symbols.NT_MatchingOp, 1, 1, 
+		symbols.Symbols{  
+			symbols.T_24,
+		}, 
+		MatchingOp1R1, 
+	},
+	MetricAdd0R0: {
+		symbols.NT_MetricAdd, 0, 0, 
+		symbols.Symbols{  
+			symbols.NT_MetricMul,
+		}, 
+		MetricAdd0R0, 
+	},
+	MetricAdd0R1: {
+		symbols.NT_MetricAdd, 0, 1, 
+		symbols.Symbols{  
+			symbols.NT_MetricMul,
+		}, 
+		MetricAdd0R1, 
+	},
+	MetricAdd1R0: {
+		symbols.NT_MetricAdd, 1, 0, 
+		symbols.Symbols{  
+			symbols.NT_MetricAdd, 
+			symbols.NT_AddOp, 
+			symbols.NT_BinModifiers, 
+			symbols.NT_MetricMul,
+		}, 
+		MetricAdd1R0, 
+	},
+	MetricAdd1R1: {
+		symbols.NT_MetricAdd, 1, 1, 
+		symbols.Symbols{  
+			symbols.NT_MetricAdd, 
+			symbols.NT_AddOp, 
+			symbols.NT_BinModifiers, 
+			symbols.NT_MetricMul,
+		}, 
+		MetricAdd1R1, 
+	},
+	MetricAdd1R2: {
+		symbols.NT_MetricAdd, 1, 2, 
+		symbols.Symbols{  
+			symbols.NT_MetricAdd, 
+			symbols.NT_AddOp, 
+			symbols.NT_BinModifiers, 
+			symbols.NT_MetricMul,
+		}, 
+		MetricAdd1R2, 
+	},
+	MetricAdd1R3: {
+		symbols.NT_MetricAdd, 1, 3, 
+		symbols.Symbols{  
+			symbols.NT_MetricAdd, 
+			symbols.NT_AddOp, 
+			symbols.NT_BinModifiers, 
+			symbols.NT_MetricMul,
+		}, 
+		MetricAdd1R3, 
+	},
+	MetricAdd1R4: {
+		symbols.NT_MetricAdd, 1, 4, 
+		symbols.Symbols{  
+			symbols.NT_MetricAdd, 
+			symbols.NT_AddOp, 
+			symbols.NT_BinModifiers, 
+			symbols.NT_MetricMul,
+		}, 
+		MetricAdd1R4, 
+	},
+	MetricAnd0R0: {
+		symbols.NT_MetricAnd, 0, 0, 
+		symbols.Symbols{  
+			symbols.NT_MetricCmp,
+		}, 
+		MetricAnd0R0, 
+	},
+	MetricAnd0R1: {
+		symbols.NT_MetricAnd, 0, 1, 
+		symbols.Symbols{  
+			symbols.NT_MetricCmp,
+		}, 
+		MetricAnd0R1, 
+	},
+	MetricAnd1R0: {
+		symbols.NT_MetricAnd, 1, 0, 
+		symbols.Symbols{  
+			symbols.NT_MetricAnd, 
+			symbols.NT_AndOp, 
+			symbols.NT_BinModifiers, 
+			symbols.NT_MetricCmp,
+		}, 
+		MetricAnd1R0, 
+	},
+	MetricAnd1R1: {
+		symbols.NT_MetricAnd, 1, 1, 
+		symbols.Symbols{  
+			symbols.NT_MetricAnd, 
+			symbols.NT_AndOp, 
+			symbols.NT_BinModifiers, 
+			symbols.NT_MetricCmp,
+		}, 
+		MetricAnd1R1, 
+	},
+	MetricAnd1R2: {
+		symbols.NT_MetricAnd, 1, 2, 
+		symbols.Symbols{  
+			symbols.NT_MetricAnd, 
+			symbols.NT_AndOp, 
+			symbols.NT_BinModifiers, 
+			symbols.NT_MetricCmp,
+		}, 
+		MetricAnd1R2, 
+	},
+	MetricAnd1R3: {
+		symbols.NT_MetricAnd, 1, 3, 
+		symbols.Symbols{  
+			symbols.NT_MetricAnd, 
+			symbols.NT_AndOp, 
+			symbols.NT_BinModifiers, 
+			symbols.NT_MetricCmp,
+		}, 
+		MetricAnd1R3, 
+	},
+	MetricAnd1R4: {
+		symbols.NT_MetricAnd, 1, 4, 
+		symbols.Symbols{  
+			symbols.NT_MetricAnd, 
+			symbols.NT_AndOp, 
+			symbols.NT_BinModifiers, 
+			symbols.NT_MetricCmp,
+		}, 
+		MetricAnd1R4, 
+	},
+	MetricCmp0R0: {
+		symbols.NT_MetricCmp, 0, 0, 
+		symbols.Symbols{  
+			symbols.NT_MetricAdd,
+		}, 
+		MetricCmp0R0, 
+	},
+	MetricCmp0R1: {
+		symbols.NT_MetricCmp, 0, 1, 
+		symbols.Symbols{  
+			symbols.NT_MetricAdd,
+		}, 
+		MetricCmp0R1, 
+	},
+	MetricCmp1R0: {
+		symbols.NT_MetricCmp, 1, 0, 
+		symbols.Symbols{  
+			symbols.NT_MetricCmp, 
+			symbols.NT_CmpOp, 
+			symbols.NT_BinModifiers, 
+			symbols.NT_MetricAdd,
+		}, 
+		MetricCmp1R0, 
+	},
+	MetricCmp1R1: {
+		symbols.NT_MetricCmp, 1, 1, 
+		symbols.Symbols{  
+			symbols.NT_MetricCmp, 
+			symbols.NT_CmpOp, 
+			symbols.NT_BinModifiers, 
+			symbols.NT_MetricAdd,
+		}, 
+		MetricCmp1R1, 
+	},
+	MetricCmp1R2: {
+		symbols.NT_MetricCmp, 1, 2, 
+		symbols.Symbols{  
+			symbols.NT_MetricCmp, 
+			symbols.NT_CmpOp, 
+			symbols.NT_BinModifiers, 
+			symbols.NT_MetricAdd,
+		}, 
+		MetricCmp1R2, 
+	},
+	MetricCmp1R3: {
+		symbols.NT_MetricCmp, 1, 3, 
+		symbols.Symbols{  
+			symbols.NT_MetricCmp, 
+			symbols.NT_CmpOp, 
+			symbols.NT_BinModifiers, 
+			symbols.NT_MetricAdd,
+		}, 
+		MetricCmp1R3, 
+	},
+	MetricCmp1R4: {
+		symbols.NT_MetricCmp, 1, 4, 
+		symbols.Symbols{  
+			symbols.NT_MetricCmp, 
+			symbols.NT_CmpOp, 
+			symbols.NT_BinModifiers, 
+			symbols.NT_MetricAdd,
+		}, 
+		MetricCmp1R4, 
+	},
+	MetricMul0R0: {
+		symbols.NT_MetricMul, 0, 0, 
+		symbols.Symbols{  
+			symbols.NT_MetricUnary,
+		}, 
+		MetricMul0R0, 
+	},
+	MetricMul0R1: {
+		symbols.NT_MetricMul, 0, 1, 
+		symbols.Symbols{  
+			symbols.NT_MetricUnary,
+		}, 
+		MetricMul0R1, 
+	},
+	MetricMul1R0: {
+		symbols.NT_MetricMul, 1, 0, 
+		symbols.Symbols{  
+			symbols.NT_MetricMul, 
+			symbols.NT_MulOp, 
+			symbols.NT_BinModifiers, 
+			symbols.NT_MetricUnary,
+		}, 
+		MetricMul1R0, 
+	},
+	MetricMul1R1: {
+		symbols.NT_MetricMul, 1, 1, 
+		symbols.Symbols{  
+			symbols.NT_MetricMul, 
+			symbols.NT_MulOp, 
+			symbols.NT_BinModifiers, 
+			symbols.NT_MetricUnary,
+		}, 
+		MetricMul1R1, 
+	},
+	MetricMul1R2: {
+		symbols.NT_MetricMul, 1, 2, 
+		symbols.Symbols{  
+			symbols.NT_MetricMul, 
+			symbols.NT_MulOp, 
+			symbols.NT_BinModifiers, 
+			symbols.NT_MetricUnary,
+		}, 
+		MetricMul1R2, 
+	},
+	MetricMul1R3: {
+		symbols.NT_MetricMul, 1, 3, 
+		symbols.Symbols{  
+			symbols.NT_MetricMul, 
+			symbols.NT_MulOp, 
+			symbols.NT_BinModifiers, 
+			symbols.NT_MetricUnary,
+		}, 
+		MetricMul1R3, 
+	},
+	MetricMul1R4: {
+		symbols.NT_MetricMul, 1, 4, 
+		symbols.Symbols{  
+			symbols.NT_MetricMul, 
+			symbols.NT_MulOp, 
+			symbols.NT_BinModifiers, 
+			symbols.NT_MetricUnary,
+		}, 
+		MetricMul1R4, 
+	},
+	MetricOr0R0: {
+		symbols.NT_MetricOr, 0, 0, 
+		symbols.Symbols{  
+			symbols.NT_MetricAnd,
+		}, 
+		MetricOr0R0, 
+	},
+	MetricOr0R1: {
+		symbols.NT_MetricOr, 0, 1, 
+		symbols.Symbols{  
+			symbols.NT_MetricAnd,
+		}, 
+		MetricOr0R1, 
+	},
+	MetricOr1R0: {
+		symbols.NT_MetricOr, 1, 0, 
+		symbols.Symbols{  
+			symbols.NT_MetricOr, 
+			symbols.NT_OrOp, 
+			symbols.NT_BinModifiers, 
+			symbols.NT_MetricAnd,
+		}, 
+		MetricOr1R0, 
+	},
+	MetricOr1R1: {
+		symbols.NT_MetricOr, 1, 1, 
+		symbols.Symbols{  
+			symbols.NT_MetricOr, 
+			symbols.NT_OrOp, 
+			symbols.NT_BinModifiers, 
+			symbols.NT_MetricAnd,
+		}, 
+		MetricOr1R1, 
+	},
+	MetricOr1R2: {
+		symbols.NT_MetricOr, 1, 2, 
+		symbols.Symbols{  
+			symbols.NT_MetricOr, 
+			symbols.NT_OrOp, 
+			symbols.NT_BinModifiers, 
+			symbols.NT_MetricAnd,
+		}, 
+		MetricOr1R2, 
+	},
+	MetricOr1R3: {
+		symbols.NT_MetricOr, 1, 3, 
+		symbols.Symbols{  
+			symbols.NT_MetricOr, 
+			symbols.NT_OrOp, 
+			symbols.NT_BinModifiers, 
+			symbols.NT_MetricAnd,
+		}, 
+		MetricOr1R3, 
+	},
+	MetricOr1R4: {
+		symbols.NT_MetricOr, 1, 4, 
+		symbols.Symbols{  
+			symbols.NT_MetricOr, 
+			symbols.NT_OrOp, 
+			symbols.NT_BinModifiers, 
+			symbols.NT_MetricAnd,
+		}, 
+		MetricOr1R4, 
+	},
+	MetricPow0R0: {
+		symbols.NT_MetricPow, 0, 0, 
+		symbols.Symbols{  
+			symbols.NT_MetricTerm,
+		}, 
+		MetricPow0R0, 
+	},
+	MetricPow0R1: {
+		symbols.NT_MetricPow, 0, 1, 
+		symbols.Symbols{  
+			symbols.NT_MetricTerm,
+		}, 
+		MetricPow0R1, 
+	},
+	MetricPow1R0: {
+		symbols.NT_MetricPow, 1, 0, 
+		symbols.Symbols{  
+			symbols.NT_MetricTerm, 
+			symbols.NT_PowOp, 
+			symbols.NT_BinModifiers, 
+			symbols.NT_MetricUnary,
+		}, 
+		MetricPow1R0, 
+	},
+	MetricPow1R1: {
+		symbols.NT_MetricPow, 1, 1, 
+		symbols.Symbols{  
+			symbols.NT_MetricTerm, 
+			symbols.NT_PowOp, 
+			symbols.NT_BinModifiers, 
+			symbols.NT_MetricUnary,
+		}, 
+		MetricPow1R1, 
+	},
+	MetricPow1R2: {
+		symbols.NT_MetricPow, 1, 2, 
+		symbols.Symbols{  
+			symbols.NT_MetricTerm, 
+			symbols.NT_PowOp, 
+			symbols.NT_BinModifiers, 
+			symbols.NT_MetricUnary,
+		}, 
+		MetricPow1R2, 
+	},
+	MetricPow1R3: {
+		symbols.NT_MetricPow, 1, 3, 
+		symbols.Symbols{  
+			symbols.NT_MetricTerm, 
+			symbols.NT_PowOp, 
+			symbols.NT_BinModifiers, 
+			symbols.NT_MetricUnary,
+		}, 
+		MetricPow1R3, 
+	},
+	MetricPow1R4: {
+		symbols.NT_MetricPow, 1, 4, 
+		symbols.Symbols{  
+			symbols.NT_MetricTerm, 
+			symbols.NT_PowOp, 
+			symbols.NT_BinModifiers, 
+			symbols.NT_MetricUnary,
+		}, 
+		MetricPow1R4, 
+	},
+	MetricQuery0R0: {
+		symbols.NT_MetricQuery, 0, 0, 
+		symbols.Symbols{  
+			symbols.NT_MetricOr,
+		}, 
+		MetricQuery0R0, 
+	},
+	MetricQuery0R1: {
+		symbols.NT_MetricQuery, 0, 1, 
+		symbols.Symbols{  
+			symbols.NT_MetricOr,
+		}, 
+		MetricQuery0R1, 
+	},
+	MetricTerm0R0: {
+		symbols.NT_MetricTerm, 0, 0, 
+		symbols.Symbols{  
+			symbols.NT_RangeAggregation,
+		}, 
+		MetricTerm0R0, 
+	},
+	MetricTerm0R1: {
+		symbols.NT_MetricTerm, 0, 1, 
+		symbols.Symbols{  
+			symbols.NT_RangeAggregation,
+		}, 
+		MetricTerm0R1, 
+	},
+	MetricTerm1R0: {
+		symbols.NT_MetricTerm, 1, 0, 
+		symbols.Symbols{  
+			symbols.NT_VectorAggregation,
+		}, 
+		MetricTerm1R0, 
+	},
+	MetricTerm1R1: {
+		symbols.NT_MetricTerm, 1, 1, 
+		symbols.Symbols{  
+			symbols.NT_VectorAggregation,
+		}, 
+		MetricTerm1R1, 
+	},
+	MetricTerm2R0: {
+		symbols.NT_MetricTerm, 2, 0, 
+		symbols.Symbols{  
+			symbols.T_30,
+		}, 
+		MetricTerm2R0, 
+	},
+	MetricTerm2R1: {
+		symbols.NT_MetricTerm, 2, 1, 
+		symbols.Symbols{  
+			symbols.T_30,
+		}, 
+		MetricTerm2R1, 
+	},
+	MetricTerm3R0: {
+		symbols.NT_MetricTerm, 3, 0, 
+		symbols.Symbols{  
+			symbols.T_3, 
+			symbols.NT_MetricQuery, 
+			symbols.T_4,
+		}, 
+		MetricTerm3R0, 
+	},
+	MetricTerm3R1: {
+		symbols.NT_MetricTerm, 3, 1, 
+		symbols.Symbols{  
+			symbols.T_3, 
+			symbols.NT_MetricQuery, 
+			symbols.T_4,
+		}, 
+		MetricTerm3R1, 
+	},
+	MetricTerm3R2: {
+		symbols.NT_MetricTerm, 3, 2, 
+		symbols.Symbols{  
+			symbols.T_3, 
+			symbols.NT_MetricQuery, 
+			symbols.T_4,
+		}, 
+		MetricTerm3R2, 
+	},
+	MetricTerm3R3: {
+		symbols.NT_MetricTerm, 3, 3, 
+		symbols.Symbols{  
+			symbols.T_3, 
+			symbols.NT_MetricQuery, 
+			symbols.T_4,
+		}, 
+		MetricTerm3R3, 
+	},
+	MetricUnary0R0: {
+		symbols.NT_MetricUnary, 0, 0, 
+		symbols.Symbols{  
+			symbols.NT_MetricPow,
+		}, 
+		MetricUnary0R0, 
+	},
+	MetricUnary0R1: {
+		symbols.NT_MetricUnary, 0, 1, 
+		symbols.Symbols{  
+			symbols.NT_MetricPow,
+		}, 
+		MetricUnary0R1, 
+	},
+	MetricUnary1R0: {
+		symbols.NT_MetricUnary, 1, 0, 
+		symbols.Symbols{  
+			symbols.T_8, 
+			symbols.NT_MetricUnary,
+		}, 
+		MetricUnary1R0, 
+	},
+	MetricUnary1R1: {
+		symbols.NT_MetricUnary, 1, 1, 
+		symbols.Symbols{  
+			symbols.T_8, 
+			symbols.NT_MetricUnary,
+		}, 
+		MetricUnary1R1, 
+	},
+	MetricUnary1R2: {
+		symbols.NT_MetricUnary, 1, 2, 
+		symbols.Symbols{  
+			symbols.T_8, 
+			symbols.NT_MetricUnary,
+		}, 
+		MetricUnary1R2, 
+	},
+	MulOp0R0: {
+		symbols.NT_MulOp, 0, 0, 
 		symbols.Symbols{  
-			symbols.NT_LogSelectorMembers,
+			symbols.T_5,
 		}, 
-		LogSelectorMembersMaybe1R1, 
+		MulOp0R0, 
 	},
-	LogSelectorOp0R0: {
-		symbols.NT_LogSelectorOp, 0, 0, 
+	MulOp0R1: {
+		symbols.NT_MulOp, 0, 1, 
 		symbols.Symbols{  
-			symbols.T_7,
+			symbols.T_5,
 		}, 
-		LogSelectorOp0R0, 
+		MulOp0R1, 
 	},
-	LogSelectorOp0R1: {
-		symbols.NT_LogSelectorOp, 0, 1, 
+	MulOp1R0: {
+		symbols.NT_MulOp, 1, 0, 
 		symbols.Symbols{  
-			symbols.T_7,
+			symbols.T_9,
 		}, 
-		LogSelectorOp0R1, 
+		MulOp1R0, 
 	},
-	LogSelectorOp1R0: {
-		symbols.NT_LogSelectorOp, 1, 0, 
+	MulOp1R1: {
+		symbols.NT_MulOp, 1, 1, 
 		symbols.Symbols{  
-			symbols.T_0,
+			symbols.T_9,
 		}, 
-		LogSelectorOp1R0, 
+		MulOp1R1, 
 	},
-	LogSelectorOp1R1: {
-		symbols.NT_LogSelectorOp, 1, 1, 
+	MulOp2R0: {
+		symbols.NT_MulOp, 2, 0, 
 		symbols.Symbols{  
-			symbols.T_0,
+			symbols.T_2,
 		}, 
-		LogSelectorOp1R1, 
+		MulOp2R0, 
 	},
-	LogSelectorOp2R0: {
-		symbols.NT_LogSelectorOp, 2, 0, 
+	MulOp2R1: {
+		symbols.NT_MulOp, 2, 1, 
 		symbols.Symbols{  
-			symbols.T_8,
+			symbols.T_2,
 		}, 
-		LogSelectorOp2R0, 
+		MulOp2R1, 
 	},
-	LogSelectorOp2R1: {
-		symbols.NT_LogSelectorOp, 2, 1, 
+	OrOp0R0: {
+		symbols.NT_OrOp, 0, 0, 
 		symbols.Symbols{  
-			symbols.T_8,
+			symbols.T_32,
 		}, 
-		LogSelectorOp2R1, 
+		OrOp0R0, 
 	},
-	LogSelectorOp3R0: {
-		symbols.NT_LogSelectorOp, 3, 0, 
+	OrOp0R1: {
+		symbols.NT_OrOp, 0, 1, 
 		symbols.Symbols{  
-			symbols.T_1,
+			symbols.T_32,
 		}, 
-		LogSelectorOp3R0, 
+		OrOp0R1, 
 	},
-	LogSelectorOp3R1: {
-		symbols.NT_LogSelectorOp, 3, 1, 
+	ParserStage0R0: {
+		symbols.NT_ParserStage, 0, 0, 
 		symbols.Symbols{  
-			symbols.T_1,
+			symbols.T_41, 
+			symbols.T_25,
 		}, 
-		LogSelectorOp3R1, 
+		ParserStage0R0, 
 	},
-	MetricQuery0R0: {
-		symbols.NT_MetricQuery, 0, 0, 
+	ParserStage0R1: {
+		symbols.NT_ParserStage, 0, 1, 
 		symbols.Symbols{  
-			symbols.T_16, 
-			symbols.T_11, 
-			symbols.T_2, 
-			symbols.T_14, 
-			symbols.T_3, 
-			symbols.T_2, 
-			symbols.T_12, 
-			symbols.T_2, 
-			symbols.NT_LogQuery, 
-			symbols.T_13, 
-			symbols.T_3, 
-			symbols.T_3,
+			symbols.T_41, 
+			symbols.T_25,
 		}, 
-		MetricQuery0R0, 
+		ParserStage0R1, 
 	},
-	MetricQuery0R1: {
-		symbols.NT_MetricQuery, 0, 1, 
+	ParserStage0R2: {
+		symbols.NT_ParserStage, 0, 2, 
 		symbols.Symbols{  
-			symbols.T_16, 
-			symbols.T_11, 
-			symbols.T_2, 
-			symbols.T_14, 
-			symbols.T_3, 
-			symbols.T_2, 
-			symbols.T_12, 
-			symbols.T_2, 
-			symbols.NT_LogQuery, 
-			symbols.T_13, 
-			symbols.T_3, 
-			symbols.T_3,
+			symbols.T_41, 
+			symbols.T_25,
 		}, 
-		MetricQuery0R1, 
+		ParserStage0R2, 
 	},
-	MetricQuery0R2: {
-		symbols.NT_MetricQuery, 0, 2, 
+	ParserStage1R0: {
+		symbols.NT_ParserStage, 1, 0, 
 		symbols.Symbols{  
-			symbols.T_16, 
-			symbols.T_11, 
-			symbols.T_2, 
-			symbols.T_14, 
-			symbols.T_3, 
-			symbols.T_2, 
-			symbols.T_12, 
-			symbols.T_2, 
-			symbols.NT_LogQuery, 
-			symbols.T_13, 
-			symbols.T_3, 
-			symbols.T_3,
+			symbols.T_41, 
+			symbols.T_28,
 		}, 
-		MetricQuery0R2, 
+		ParserStage1R0, 
 	},
-	MetricQuery0R3: {
-		symbols.NT_MetricQuery, 0, 3, 
+	ParserStage1R1: {
+		symbols.NT_ParserStage, 1, 1, 
 		symbols.Symbols{  
-			symbols.T_16, 
-			symbols.T_11, 
-			symbols.T_2, 
-			symbols.T_14, 
-			symbols.T_3, 
-			symbols.T_2, 
-			symbols.T_12, 
-			symbols.T_2, 
-			symbols.NT_LogQuery, 
-			symbols.T_13, 
-			symbols.T_3, 
-			symbols.T_3,
+			symbols.T_41, 
+			symbols.T_28,
 		}, 
-		MetricQuery0R3, 
+		ParserStage1R1, 
 	},
-	MetricQuery0R4: {
-		symbols.NT_MetricQuery, 0, 4, 
+	ParserStage1R2: {
+		symbols.NT_ParserStage, 1, 2, 
 		symbols.Symbols{  
-			symbols.T_16, 
-			symbols.T_11, 
-			symbols.T_2, 
-			symbols.T_14, 
-			symbols.T_3, 
-			symbols.T_2, 
-			symbols.T_12, 
-			symbols.T_2, 
-			symbols.NT_LogQuery, 
-			symbols.T_13, 
-			symbols.T_3, 
-			symbols.T_3,
+			symbols.T_41, 
+			symbols.T_28,
 		}, 
-		MetricQuery0R4, 
+		ParserStage1R2, 
 	},
-	MetricQuery0R5: {
-		symbols.NT_MetricQuery, 0, 5, 
+	ParserStage2R0: {
+		symbols.NT_ParserStage, 2, 0, 
 		symbols.Symbols{  
-			symbols.T_16, 
-			symbols.T_11, 
-			symbols.T_2, 
-			symbols.T_14, 
-			symbols.T_3, 
-			symbols.T_2, 
-			symbols.T_12, 
-			symbols.T_2, 
-			symbols.NT_LogQuery, 
-			symbols.T_13, 
-			symbols.T_3, 
-			symbols.T_3,
+			symbols.T_41, 
+			symbols.T_34, 
+			symbols.T_35,
 		}, 
-		MetricQuery0R5, 
+		ParserStage2R0, 
 	},
-	MetricQuery0R6: {
-		symbols.NT_MetricQuery, 0, 6, 
+	ParserStage2R1: {
+		symbols.NT_ParserStage, 2, 1, 
 		symbols.Symbols{  
-			symbols.T_16, 
-			symbols.T_11, 
-			symbols.T_2, 
-			symbols.T_14, 
-			symbols.T_3, 
-			symbols.T_2, 
-			symbols.T_12, 
-			symbols.T_2, 
-			symbols.NT_LogQuery, 
-			symbols.T_13, 
-			symbols.T_3, 
-			symbols.T_3,
+			symbols.T_41, 
+			symbols.T_34, 
+			symbols.T_35,
 		}, 
-		MetricQuery0R6, 
+		ParserStage2R1, 
 	},
-	MetricQuery0R7: {
-		symbols.NT_MetricQuery, 0, 7, 
+	ParserStage2R2: {
+		symbols.NT_ParserStage, 2, 2, 
 		symbols.Symbols{  
-			symbols.T_16, 
-			symbols.T_11, 
-			symbols.T_2, 
-			symbols.T_14, 
-			symbols.T_3, 
-			symbols.T_2, 
-			symbols.T_12, 
-			symbols.T_2, 
-			symbols.NT_LogQuery, 
-			symbols.T_13, 
-			symbols.T_3, 
-			symbols.T_3,
+			symbols.T_41, 
+			symbols.T_34, 
+			symbols.T_35,
 		}, 
-		MetricQuery0R7, 
+		ParserStage2R2, 
 	},
-	MetricQuery0R8: {
-		symbols.NT_MetricQuery, 0, 8, 
+	ParserStage2R3: {
+		symbols.NT_ParserStage, 2, 3, 
 		symbols.Symbols{  
-			symbols.T_16, 
-			symbols.T_11, 
-			symbols.T_2, 
-			symbols.T_14, 
-			symbols.T_3, 
-			symbols.T_2, 
-			symbols.T_12, 
-			symbols.T_2, 
-			symbols.NT_LogQuery, 
-			symbols.T_13, 
-			symbols.T_3, 
-			symbols.T_3,
+			symbols.T_41, 
+			symbols.T_34, 
+			symbols.T_35,
 		}, 
-		MetricQuery0R8, 
+		ParserStage2R3, 
 	},
-	MetricQuery0R9: {
-		symbols.NT_MetricQuery, 0, 9, 
+	ParserStage3R0: {
+		symbols.NT_ParserStage, 3, 0, 
 		symbols.Symbols{  
-			symbols.T_16, 
-			symbols.T_11, 
-			symbols.T_2, 
-			symbols.T_14, 
-			symbols.T_3, 
-			symbols.T_2, 
-			symbols.T_12, 
-			symbols.T_2, 
-			symbols.NT_LogQuery, 
-			symbols.T_13, 
-			symbols.T_3, 
-			symbols.T_3,
+			symbols.T_41, 
+			symbols.T_33, 
+			symbols.T_35,
 		}, 
-		MetricQuery0R9, 
+		ParserStage3R0, 
 	},
-	MetricQuery0R10: {
-		symbols.NT_MetricQuery, 0, 10, 
+	ParserStage3R1: {
+		symbols.NT_ParserStage, 3, 1, 
 		symbols.Symbols{  
-			symbols.T_16, 
-			symbols.T_11, 
-			symbols.T_2, 
-			symbols.T_14, 
-			symbols.T_3, 
-			symbols.T_2, 
-			symbols.T_12, 
-			symbols.T_2, 
-			symbols.NT_LogQuery, 
-			symbols.T_13, 
-			symbols.T_3, 
-			symbols.T_3,
+			symbols.T_41, 
+			symbols.T_33, 
+			symbols.T_35,
 		}, 
-		MetricQuery0R10, 
+		ParserStage3R1, 
 	},
-	MetricQuery0R11: {
-		symbols.NT_MetricQuery, 0, 11, 
+	ParserStage3R2: {
+		symbols.NT_ParserStage, 3, 2, 
 		symbols.Symbols{  
-			symbols.T_16, 
-			symbols.T_11, 
-			symbols.T_2, 
-			symbols.T_14, 
-			symbols.T_3, 
-			symbols.T_2, 
-			symbols.T_12, 
-			symbols.T_2, 
-			symbols.NT_LogQuery, 
-			symbols.T_13, 
-			symbols.T_3, 
-			symbols.T_3,
+			symbols.T_41, 
+			symbols.T_33, 
+			symbols.T_35,
 		}, 
-		MetricQuery0R11, 
+		ParserStage3R2, 
 	},
-	MetricQuery0R12: {
-		symbols.NT_MetricQuery, 0, 12, 
+	ParserStage3R3: {
+		symbols.NT_ParserStage, 3, 3, 
 		symbols.Symbols{  
-			symbols.T_16, 
-			symbols.T_11, 
-			symbols.T_2, 
-			symbols.T_14, 
-			symbols.T_3, 
-			symbols.T_2, 
-			symbols.T_12, 
-			symbols.T_2, 
-			symbols.NT_LogQuery, 
-			symbols.T_13, 
-			symbols.T_3, 
-			symbols.T_3,
+			symbols.T_41, 
+			symbols.T_33, 
+			symbols.T_35,
 		}, 
-		MetricQuery0R12, 
+		ParserStage3R3, 
 	},
 	Pipeline0R0: {
 		symbols.NT_Pipeline, 0, 0, 
@@ -921,17 +3017,45 @@ var slots = map[Label]*Slot{
 	Pipeline1R0: {
 		symbols.NT_Pipeline, 1, 0, 
 		symbols.Symbols{  
-			symbols.NT_DataFilter,
+			symbols.NT_FilterStage,
 		}, 
 		Pipeline1R0, 
 	},
 	Pipeline1R1: {
 		symbols.NT_Pipeline, 1, 1, 
 		symbols.Symbols{  
-			symbols.NT_DataFilter,
+			symbols.NT_FilterStage,
 		}, 
 		Pipeline1R1, 
 	},
+	Pipeline2R0: {
+		symbols.NT_Pipeline, 2, 0, 
+		symbols.Symbols{  
+			symbols.NT_ParserStage,
+		}, 
+		Pipeline2R0, 
+	},
+	Pipeline2R1: {
+		symbols.NT_Pipeline, 2, 1, 
+		symbols.Symbols{  
+			symbols.NT_ParserStage,
+		}, 
+		Pipeline2R1, 
+	},
+	Pipeline3R0: {
+		symbols.NT_Pipeline, 3, 0, 
+		symbols.Symbols{  
+			symbols.NT_FormatStage,
+		}, 
+		Pipeline3R0, 
+	},
+	Pipeline3R1: {
+		symbols.NT_Pipeline, 3, 1, 
+		symbols.Symbols{  
+			symbols.NT_FormatStage,
+		}, 
+		Pipeline3R1, 
+	},
 	Pipelines0R0: {
 		symbols.NT_Pipelines, 0, 0, 
 		symbols.Symbols{  
@@ -990,6 +3114,20 @@ var slots = map[Label]*Slot{
 		}, 
 		PipelinesMaybe1R1, 
 	},
+	PowOp0R0: {
+		symbols.NT_PowOp, 0, 0, 
+		symbols.Symbols{  
+			symbols.T_17,
+		}, 
+		PowOp0R0, 
+	},
+	PowOp0R1: {
+		symbols.NT_PowOp, 0, 1, 
+		symbols.Symbols{  
+			symbols.T_17,
+		}, 
+		PowOp0R1, 
+	},
 	Query0R0: {
 		symbols.NT_Query, 0, 0, 
 		symbols.Symbols{  
@@ -1018,14 +3156,377 @@ var slots = map[Label]*Slot{
 		}, 
 		Query1R1, 
 	},
+	RangeAggregation0R0: {
+		symbols.NT_RangeAggregation, 0, 0, 
+		symbols.Symbols{  
+			symbols.T_38, 
+			symbols.T_3, 
+			symbols.NT_RangeParamMaybe, 
+			symbols.NT_LogQuery, 
+			symbols.NT_UnwrapMaybe, 
+			symbols.T_21, 
+			symbols.T_4,
+		}, 
+		RangeAggregation0R0, 
+	},
+	RangeAggregation0R1: {
+		symbols.NT_RangeAggregation, 0, 1, 
+		symbols.Symbols{  
+			symbols.T_38, 
+			symbols.T_3, 
+			symbols.NT_RangeParamMaybe, 
+			symbols.NT_LogQuery, 
+			symbols.NT_UnwrapMaybe, 
+			symbols.T_21, 
+			symbols.T_4,
+		}, 
+		RangeAggregation0R1, 
+	},
+	RangeAggregation0R2: {
+		symbols.NT_RangeAggregation, 0, 2, 
+		symbols.Symbols{  
+			symbols.T_38, 
+			symbols.T_3, 
+			symbols.NT_RangeParamMaybe, 
+			symbols.NT_LogQuery, 
+			symbols.NT_UnwrapMaybe, 
+			symbols.T_21, 
+			symbols.T_4,
+		}, 
+		RangeAggregation0R2, 
+	},
+	RangeAggregation0R3: {
+		symbols.NT_RangeAggregation, 0, 3, 
+		symbols.Symbols{  
+			symbols.T_38, 
+			symbols.T_3, 
+			symbols.NT_RangeParamMaybe, 
+			symbols.NT_LogQuery, 
+			symbols.NT_UnwrapMaybe, 
+			symbols.T_21, 
+			symbols.T_4,
+		}, 
+		RangeAggregation0R3, 
+	},
+	RangeAggregation0R4: {
+		symbols.NT_RangeAggregation, 0, 4, 
+		symbols.Symbols{  
+			symbols.T_38, 
+			symbols.T_3, 
+			symbols.NT_RangeParamMaybe, 
+			symbols.NT_LogQuery, 
+			symbols.NT_UnwrapMaybe, 
+			symbols.T_21, 
+			symbols.T_4,
+		}, 
+		RangeAggregation0R4, 
+	},
+	RangeAggregation0R5: {
+		symbols.NT_RangeAggregation, 0, 5, 
+		symbols.Symbols{  
+			symbols.T_38, 
+			symbols.T_3, 
+			symbols.NT_RangeParamMaybe, 
+			symbols.NT_LogQuery, 
+			symbols.NT_UnwrapMaybe, 
+			symbols.T_21, 
+			symbols.T_4,
+		}, 
+		RangeAggregation0R5, 
+	},
+	RangeAggregation0R6: {
+		symbols.NT_RangeAggregation, 0, 6, 
+		symbols.Symbols{  
+			symbols.T_38, 
+			symbols.T_3, 
+			symbols.NT_RangeParamMaybe, 
+			symbols.NT_LogQuery, 
+			symbols.NT_UnwrapMaybe, 
+			symbols.T_21, 
+			symbols.T_4,
+		}, 
+		RangeAggregation0R6, 
+	},
+	RangeAggregation0R7: {
+		symbols.NT_RangeAggregation, 0, 7, 
+		symbols.Symbols{  
+			symbols.T_38, 
+			symbols.T_3, 
+			symbols.NT_RangeParamMaybe, 
+			symbols.NT_LogQuery, 
+			symbols.NT_UnwrapMaybe, 
+			symbols.T_21, 
+			symbols.T_4,
+		}, 
+		RangeAggregation0R7, 
+	},
+	RangeParamMaybe0R0: {
+		symbols.NT_RangeParamMaybe, 0, 0, 
+		symbols.Symbols{ 
+		}, 
+		RangeParamMaybe0R0, 
+	},
+	RangeParamMaybe1R0: {
+		symbols.NT_RangeParamMaybe, 1, 0, 
+		symbols.Symbols{  
+			symbols.T_30, 
+			symbols.T_7,
+		}, 
+		RangeParamMaybe1R0, 
+	},
+	RangeParamMaybe1R1: {
+		symbols.NT_RangeParamMaybe, 1, 1, 
+		symbols.Symbols{  
+			symbols.T_30, 
+			symbols.T_7,
+		}, 
+		RangeParamMaybe1R1, 
+	},
+	RangeParamMaybe1R2: {
+		symbols.NT_RangeParamMaybe, 1, 2, 
+		symbols.Symbols{  
+			symbols.T_30, 
+			symbols.T_7,
+		}, 
+		RangeParamMaybe1R2, 
+	},
+	UnwrapField0R0: {
+		symbols.NT_UnwrapField, 0, 0, 
+		symbols.Symbols{  
+			symbols.T_38,
+		}, 
+		UnwrapField0R0, 
+	},
+	UnwrapField0R1: {
+		symbols.NT_UnwrapField, 0, 1, 
+		symbols.Symbols{  
+			symbols.T_38,
+		}, 
+		UnwrapField0R1, 
+	},
+	UnwrapField1R0: {
+		symbols.NT_UnwrapField, 1, 0, 
+		symbols.Symbols{  
+			symbols.T_35,
+		}, 
+		UnwrapField1R0, 
+	},
+	UnwrapField1R1: {
+		symbols.NT_UnwrapField, 1, 1, 
+		symbols.Symbols{  
+			symbols.T_35,
+		}, 
+		UnwrapField1R1, 
+	},
+	UnwrapMaybe0R0: {
+		symbols.NT_UnwrapMaybe, 0, 0, 
+		symbols.Symbols{ 
+		}, 
+		UnwrapMaybe0R0, 
+	},
+	UnwrapMaybe1R0: {
+		symbols.NT_UnwrapMaybe, 1, 0, 
+		symbols.Symbols{  
+			symbols.T_41, 
+			symbols.T_37, 
+			symbols.NT_UnwrapField,
+		}, 
+		UnwrapMaybe1R0, 
+	},
+	UnwrapMaybe1R1: {
+		symbols.NT_UnwrapMaybe, 1, 1, 
+		symbols.Symbols{  
+			symbols.T_41, 
+			symbols.T_37, 
+			symbols.NT_UnwrapField,
+		}, 
+		UnwrapMaybe1R1, 
+	},
+	UnwrapMaybe1R2: {
+		symbols.NT_UnwrapMaybe, 1, 2, 
+		symbols.Symbols{  
+			symbols.T_41, 
+			symbols.T_37, 
+			symbols.NT_UnwrapField,
+		}, 
+		UnwrapMaybe1R2, 
+	},
+	UnwrapMaybe1R3: {
+		symbols.NT_UnwrapMaybe, 1, 3, 
+		symbols.Symbols{  
+			symbols.T_41, 
+			symbols.T_37, 
+			symbols.NT_UnwrapField,
+		}, 
+		UnwrapMaybe1R3, 
+	},
+	VectorAggregation0R0: {
+		symbols.NT_VectorAggregation, 0, 0, 
+		symbols.Symbols{  
+			symbols.T_38, 
+			symbols.NT_GroupingMaybe, 
+			symbols.T_3, 
+			symbols.NT_VectorParamMaybe, 
+			symbols.NT_MetricQuery, 
+			symbols.T_4, 
+			symbols.NT_GroupingMaybe,
+		}, 
+		VectorAggregation0R0, 
+	},
+	VectorAggregation0R1: {
+		symbols.NT_VectorAggregation, 0, 1, 
+		symbols.Symbols{  
+			symbols.T_38, 
+			symbols.NT_GroupingMaybe, 
+			symbols.T_3, 
+			symbols.NT_VectorParamMaybe, 
+			symbols.NT_MetricQuery, 
+			symbols.T_4, 
+			symbols.NT_GroupingMaybe,
+		}, 
+		VectorAggregation0R1, 
+	},
+	VectorAggregation0R2: {
+		symbols.NT_VectorAggregation, 0, 2, 
+		symbols.Symbols{  
+			symbols.T_38, 
+			symbols.NT_GroupingMaybe, 
+			symbols.T_3, 
+			symbols.NT_VectorParamMaybe, 
+			symbols.NT_MetricQuery, 
+			symbols.T_4, 
+			symbols.NT_GroupingMaybe,
+		}, 
+		VectorAggregation0R2, 
+	},
+	VectorAggregation0R3: {
+		symbols.NT_VectorAggregation, 0, 3, 
+		symbols.Symbols{  
+			symbols.T_38, 
+			symbols.NT_GroupingMaybe, 
+			symbols.T_3, 
+			symbols.NT_VectorParamMaybe, 
+			symbols.NT_MetricQuery, 
+			symbols.T_4, 
+			symbols.NT_GroupingMaybe,
+		}, 
+		VectorAggregation0R3, 
+	},
+	VectorAggregation0R4: {
+		symbols.NT_VectorAggregation, 0, 4, 
+		symbols.Symbols{  
+			symbols.T_38, 
+			symbols.NT_GroupingMaybe, 
+			symbols.T_3, 
+			symbols.NT_VectorParamMaybe, 
+			symbols.NT_MetricQuery, 
+			symbols.T_4, 
+			symbols.NT_GroupingMaybe,
+		}, 
+		VectorAggregation0R4, 
+	},
+	VectorAggregation0R5: {
+		symbols.NT_VectorAggregation, 0, 5, 
+		symbols.Symbols{  
+			symbols.T_38, 
+			symbols.NT_GroupingMaybe, 
+			symbols.T_3, 
+			symbols.NT_VectorParamMaybe, 
+			symbols.NT_MetricQuery, 
+			symbols.T_4, 
+			symbols.NT_GroupingMaybe,
+		}, 
+		VectorAggregation0R5, 
+	},
+	VectorAggregation0R6: {
+		symbols.NT_VectorAggregation, 0, 6, 
+		symbols.Symbols{  
+			symbols.T_38, 
+			symbols.NT_GroupingMaybe, 
+			symbols.T_3, 
+			symbols.NT_VectorParamMaybe, 
+			symbols.NT_MetricQuery, 
+			symbols.T_4, 
+			symbols.NT_GroupingMaybe,
+		}, 
+		VectorAggregation0R6, 
+	},
+	VectorAggregation0R7: {
+		symbols.NT_VectorAggregation, 0, 7, 
+		symbols.Symbols{  
+			symbols.T_38, 
+			symbols.NT_GroupingMaybe, 
+			symbols.T_3, 
+			symbols.NT_VectorParamMaybe, 
+			symbols.NT_MetricQuery, 
+			symbols.T_4, 
+			symbols.NT_GroupingMaybe,
+		}, 
+		VectorAggregation0R7, 
+	},
+	VectorParamMaybe0R0: {
+		symbols.NT_VectorParamMaybe, 0, 0, 
+		symbols.Symbols{ 
+		}, 
+		VectorParamMaybe0R0, 
+	},
+	VectorParamMaybe1R0: {
+		symbols.NT_VectorParamMaybe, 1, 0, 
+		symbols.Symbols{  
+			symbols.T_30, 
+			symbols.T_7,
+		}, 
+		VectorParamMaybe1R0, 
+	},
+	VectorParamMaybe1R1: {
+		symbols.NT_VectorParamMaybe, 1, 1, 
+		symbols.Symbols{  
+			symbols.T_30, 
+			symbols.T_7,
+		}, 
+		VectorParamMaybe1R1, 
+	},
+	VectorParamMaybe1R2: {
+		symbols.NT_VectorParamMaybe, 1, 2, 
+		symbols.Symbols{  
+			symbols.T_30, 
+			symbols.T_7,
+		}, 
+		VectorParamMaybe1R2, 
+	},
 }
 
 var slotIndex = map[Index]Label { 
+	Index{ symbols.NT_AddOp,0,0 }: AddOp0R0,
+	Index{ symbols.NT_AddOp,0,1 }: AddOp0R1,
+	Index{ symbols.NT_AddOp,1,0 }: AddOp1R0,
+	Index{ symbols.NT_AddOp,1,1 }: AddOp1R1,
+	Index{ symbols.NT_AndOp,0,0 }: AndOp0R0,
+	Index{ symbols.NT_AndOp,0,1 }: AndOp0R1,
+	Index{ symbols.NT_AndOp,1,0 }: AndOp1R0,
+	Index{ symbols.NT_AndOp,1,1 }: AndOp1R1,
+	Index{ symbols.NT_BinModifiers,0,0 }: BinModifiers0R0,
+	Index{ symbols.NT_BinModifiers,0,1 }: BinModifiers0R1,
+	Index{ symbols.NT_BinModifiers,0,2 }: BinModifiers0R2,
+	Index{ symbols.NT_BoolMaybe,0,0 }: BoolMaybe0R0,
+	Index{ symbols.NT_BoolMaybe,1,0 }: BoolMaybe1R0,
+	Index{ symbols.NT_BoolMaybe,1,1 }: BoolMaybe1R1,
+	Index{ symbols.NT_CmpOp,0,0 }: CmpOp0R0,
+	Index{ symbols.NT_CmpOp,0,1 }: CmpOp0R1,
+	Index{ symbols.NT_CmpOp,1,0 }: CmpOp1R0,
+	Index{ symbols.NT_CmpOp,1,1 }: CmpOp1R1,
+	Index{ symbols.NT_CmpOp,2,0 }: CmpOp2R0,
+	Index{ symbols.NT_CmpOp,2,1 }: CmpOp2R1,
+	Index{ symbols.NT_CmpOp,3,0 }: CmpOp3R0,
+	Index{ symbols.NT_CmpOp,3,1 }: CmpOp3R1,
+	Index{ symbols.NT_CmpOp,4,0 }: CmpOp4R0,
+	Index{ symbols.NT_CmpOp,4,1 }: CmpOp4R1,
+	Index{ symbols.NT_CmpOp,5,0 }: CmpOp5R0,
+	Index{ symbols.NT_CmpOp,5,1 }: CmpOp5R1,
 	Index{ symbols.NT_DataFilter,0,0 }: DataFilter0R0,
 	Index{ symbols.NT_DataFilter,0,1 }: DataFilter0R1,
 	Index{ symbols.NT_DataFilter,0,2 }: DataFilter0R2,
 	Index{ symbols.NT_DataFilter,0,3 }: DataFilter0R3,
-	Index{ symbols.NT_DataFilter,0,4 }: DataFilter0R4,
 	Index{ symbols.NT_DataFilterOp,0,0 }: DataFilterOp0R0,
 	Index{ symbols.NT_DataFilterOp,0,1 }: DataFilterOp0R1,
 	Index{ symbols.NT_DataFilterOp,1,0 }: DataFilterOp1R0,
@@ -1042,8 +3543,139 @@ var slotIndex = map[Index]Label {
 	Index{ symbols.NT_DataFilterOp,6,1 }: DataFilterOp6R1,
 	Index{ symbols.NT_DataFilterOp,7,0 }: DataFilterOp7R0,
 	Index{ symbols.NT_DataFilterOp,7,1 }: DataFilterOp7R1,
+	Index{ symbols.NT_FilterAnd,0,0 }: FilterAnd0R0,
+	Index{ symbols.NT_FilterAnd,0,1 }: FilterAnd0R1,
+	Index{ symbols.NT_FilterAnd,1,0 }: FilterAnd1R0,
+	Index{ symbols.NT_FilterAnd,1,1 }: FilterAnd1R1,
+	Index{ symbols.NT_FilterAnd,1,2 }: FilterAnd1R2,
+	Index{ symbols.NT_FilterAnd,1,3 }: FilterAnd1R3,
+	Index{ symbols.NT_FilterAnd,2,0 }: FilterAnd2R0,
+	Index{ symbols.NT_FilterAnd,2,1 }: FilterAnd2R1,
+	Index{ symbols.NT_FilterAnd,2,2 }: FilterAnd2R2,
+	Index{ symbols.NT_FilterAnd,2,3 }: FilterAnd2R3,
+	Index{ symbols.NT_FilterExpr,0,0 }: FilterExpr0R0,
+	Index{ symbols.NT_FilterExpr,0,1 }: FilterExpr0R1,
+	Index{ symbols.NT_FilterExpr,1,0 }: FilterExpr1R0,
+	Index{ symbols.NT_FilterExpr,1,1 }: FilterExpr1R1,
+	Index{ symbols.NT_FilterExpr,1,2 }: FilterExpr1R2,
+	Index{ symbols.NT_FilterExpr,1,3 }: FilterExpr1R3,
+	Index{ symbols.NT_FilterNot,0,0 }: FilterNot0R0,
+	Index{ symbols.NT_FilterNot,0,1 }: FilterNot0R1,
+	Index{ symbols.NT_FilterNot,1,0 }: FilterNot1R0,
+	Index{ symbols.NT_FilterNot,1,1 }: FilterNot1R1,
+	Index{ symbols.NT_FilterNot,1,2 }: FilterNot1R2,
+	Index{ symbols.NT_FilterStage,0,0 }: FilterStage0R0,
+	Index{ symbols.NT_FilterStage,0,1 }: FilterStage0R1,
+	Index{ symbols.NT_FilterStage,0,2 }: FilterStage0R2,
+	Index{ symbols.NT_FilterTerm,0,0 }: FilterTerm0R0,
+	Index{ symbols.NT_FilterTerm,0,1 }: FilterTerm0R1,
+	Index{ symbols.NT_FilterTerm,1,0 }: FilterTerm1R0,
+	Index{ symbols.NT_FilterTerm,1,1 }: FilterTerm1R1,
+	Index{ symbols.NT_FilterTerm,2,0 }: FilterTerm2R0,
+	Index{ symbols.NT_FilterTerm,2,1 }: FilterTerm2R1,
+	Index{ symbols.NT_FilterTerm,2,2 }: FilterTerm2R2,
+	Index{ symbols.NT_FilterTerm,2,3 }: FilterTerm2R3,
+	Index{ symbols.NT_FilterValue,0,0 }: FilterValue0R0,
+	Index{ symbols.NT_FilterValue,0,1 }: FilterValue0R1,
+	Index{ symbols.NT_FilterValue,1,0 }: FilterValue1R0,
+	Index{ symbols.NT_FilterValue,1,1 }: FilterValue1R1,
+	Index{ symbols.NT_FormatStage,0,0 }: FormatStage0R0,
+	Index{ symbols.NT_FormatStage,0,1 }: FormatStage0R1,
+	Index{ symbols.NT_FormatStage,0,2 }: FormatStage0R2,
+	Index{ symbols.NT_FormatStage,0,3 }: FormatStage0R3,
+	Index{ symbols.NT_FormatStage,1,0 }: FormatStage1R0,
+	Index{ symbols.NT_FormatStage,1,1 }: FormatStage1R1,
+	Index{ symbols.NT_FormatStage,1,2 }: FormatStage1R2,
+	Index{ symbols.NT_FormatStage,1,3 }: FormatStage1R3,
+	Index{ symbols.NT_GroupModifierMaybe,0,0 }: GroupModifierMaybe0R0,
+	Index{ symbols.NT_GroupModifierMaybe,1,0 }: GroupModifierMaybe1R0,
+	Index{ symbols.NT_GroupModifierMaybe,1,1 }: GroupModifierMaybe1R1,
+	Index{ symbols.NT_GroupModifierMaybe,2,0 }: GroupModifierMaybe2R0,
+	Index{ symbols.NT_GroupModifierMaybe,2,1 }: GroupModifierMaybe2R1,
+	Index{ symbols.NT_GroupModifierMaybe,2,2 }: GroupModifierMaybe2R2,
+	Index{ symbols.NT_GroupModifierMaybe,2,3 }: GroupModifierMaybe2R3,
+	Index{ symbols.NT_GroupModifierMaybe,2,4 }: GroupModifierMaybe2R4,
+	Index{ symbols.NT_GroupSide,0,0 }: GroupSide0R0,
+	Index{ symbols.NT_GroupSide,0,1 }: GroupSide0R1,
+	Index{ symbols.NT_GroupSide,1,0 }: GroupSide1R0,
+	Index{ symbols.NT_GroupSide,1,1 }: GroupSide1R1,
+	Index{ symbols.NT_Grouping,0,0 }: Grouping0R0,
+	Index{ symbols.NT_Grouping,0,1 }: Grouping0R1,
+	Index{ symbols.NT_Grouping,0,2 }: Grouping0R2,
+	Index{ symbols.NT_Grouping,0,3 }: Grouping0R3,
+	Index{ symbols.NT_Grouping,0,4 }: Grouping0R4,
+	Index{ symbols.NT_GroupingMaybe,0,0 }: GroupingMaybe0R0,
+	Index{ symbols.NT_GroupingMaybe,1,0 }: GroupingMaybe1R0,
+	Index{ symbols.NT_GroupingMaybe,1,1 }: GroupingMaybe1R1,
+	Index{ symbols.NT_GroupingOp,0,0 }: GroupingOp0R0,
+	Index{ symbols.NT_GroupingOp,0,1 }: GroupingOp0R1,
+	Index{ symbols.NT_GroupingOp,1,0 }: GroupingOp1R0,
+	Index{ symbols.NT_GroupingOp,1,1 }: GroupingOp1R1,
+	Index{ symbols.NT_LabelFilter,0,0 }: LabelFilter0R0,
+	Index{ symbols.NT_LabelFilter,0,1 }: LabelFilter0R1,
+	Index{ symbols.NT_LabelFilter,0,2 }: LabelFilter0R2,
+	Index{ symbols.NT_LabelFilter,0,3 }: LabelFilter0R3,
+	Index{ symbols.NT_LabelFilterOp,0,0 }: LabelFilterOp0R0,
+	Index{ symbols.NT_LabelFilterOp,0,1 }: LabelFilterOp0R1,
+	Index{ symbols.NT_LabelFilterOp,1,0 }: LabelFilterOp1R0,
+	Index{ symbols.NT_LabelFilterOp,1,1 }: LabelFilterOp1R1,
+	Index{ symbols.NT_LabelFilterOp,2,0 }: LabelFilterOp2R0,
+	Index{ symbols.NT_LabelFilterOp,2,1 }: LabelFilterOp2R1,
+	Index{ symbols.NT_LabelFilterOp,3,0 }: LabelFilterOp3R0,
+	Index{ symbols.NT_LabelFilterOp,3,1 }: LabelFilterOp3R1,
+	Index{ symbols.NT_LabelFilterOp,4,0 }: LabelFilterOp4R0,
+	Index{ symbols.NT_LabelFilterOp,4,1 }: LabelFilterOp4R1,
+	Index{ symbols.NT_LabelFilterOp,5,0 }: LabelFilterOp5R0,
+	Index{ symbols.NT_LabelFilterOp,5,1 }: LabelFilterOp5R1,
+	Index{ symbols.NT_LabelFilterOp,6,0 }: LabelFilterOp6R0,
+	Index{ symbols.NT_LabelFilterOp,6,1 }: LabelFilterOp6R1,
+	Index{ symbols.NT_LabelFilterOp,7,0 }: LabelFilterOp7R0,
+	Index{ symbols.NT_LabelFilterOp,7,1 }: LabelFilterOp7R1,
+	Index{ symbols.NT_LabelFilterOp,8,0 }: LabelFilterOp8R0,
+	Index{ symbols.NT_LabelFilterOp,8,1 }: LabelFilterOp8R1,
+	Index{ symbols.NT_LabelFormat,0,0 }: LabelFormat0R0,
+	Index{ symbols.NT_LabelFormat,0,1 }: LabelFormat0R1,
+	Index{ symbols.NT_LabelFormat,0,2 }: LabelFormat0R2,
+	Index{ symbols.NT_LabelFormat,0,3 }: LabelFormat0R3,
+	Index{ symbols.NT_LabelFormatValue,0,0 }: LabelFormatValue0R0,
+	Index{ symbols.NT_LabelFormatValue,0,1 }: LabelFormatValue0R1,
+	Index{ symbols.NT_LabelFormatValue,1,0 }: LabelFormatValue1R0,
+	Index{ symbols.NT_LabelFormatValue,1,1 }: LabelFormatValue1R1,
+	Index{ symbols.NT_LabelFormats,0,0 }: LabelFormats0R0,
+	Index{ symbols.NT_LabelFormats,0,1 }: LabelFormats0R1,
+	Index{ symbols.NT_LabelFormats,1,0 }: LabelFormats1R0,
+	Index{ symbols.NT_LabelFormats,1,1 }: LabelFormats1R1,
+	Index{ symbols.NT_LabelFormats,1,2 }: LabelFormats1R2,
+	Index{ symbols.NT_LabelFormats,1,3 }: LabelFormats1R3,
 	Index{ symbols.NT_LabelKey,0,0 }: LabelKey0R0,
 	Index{ symbols.NT_LabelKey,0,1 }: LabelKey0R1,
+	Index{ symbols.NT_LabelKey,1,0 }: LabelKey1R0,
+	Index{ symbols.NT_LabelKey,1,1 }: LabelKey1R1,
+	Index{ symbols.NT_LabelKey,2,0 }: LabelKey2R0,
+	Index{ symbols.NT_LabelKey,2,1 }: LabelKey2R1,
+	Index{ symbols.NT_LabelKey,3,0 }: LabelKey3R0,
+	Index{ symbols.NT_LabelKey,3,1 }: LabelKey3R1,
+	Index{ symbols.NT_LabelKey,4,0 }: LabelKey4R0,
+	Index{ symbols.NT_LabelKey,4,1 }: LabelKey4R1,
+	Index{ symbols.NT_LabelKey,5,0 }: LabelKey5R0,
+	Index{ symbols.NT_LabelKey,5,1 }: LabelKey5R1,
+	Index{ symbols.NT_LabelKey,6,0 }: LabelKey6R0,
+	Index{ symbols.NT_LabelKey,6,1 }: LabelKey6R1,
+	Index{ symbols.NT_LabelKey,7,0 }: LabelKey7R0,
+	Index{ symbols.NT_LabelKey,7,1 }: LabelKey7R1,
+	Index{ symbols.NT_LabelKey,8,0 }: LabelKey8R0,
+	Index{ symbols.NT_LabelKey,8,1 }: LabelKey8R1,
+	Index{ symbols.NT_LabelKey,9,0 }: LabelKey9R0,
+	Index{ symbols.NT_LabelKey,9,1 }: LabelKey9R1,
+	Index{ symbols.NT_LabelKeys,0,0 }: LabelKeys0R0,
+	Index{ symbols.NT_LabelKeys,0,1 }: LabelKeys0R1,
+	Index{ symbols.NT_LabelKeys,1,0 }: LabelKeys1R0,
+	Index{ symbols.NT_LabelKeys,1,1 }: LabelKeys1R1,
+	Index{ symbols.NT_LabelKeys,1,2 }: LabelKeys1R2,
+	Index{ symbols.NT_LabelKeys,1,3 }: LabelKeys1R3,
+	Index{ symbols.NT_LabelKeysMaybe,0,0 }: LabelKeysMaybe0R0,
+	Index{ symbols.NT_LabelKeysMaybe,1,0 }: LabelKeysMaybe1R0,
+	Index{ symbols.NT_LabelKeysMaybe,1,1 }: LabelKeysMaybe1R1,
 	Index{ symbols.NT_LineFilter,0,0 }: LineFilter0R0,
 	Index{ symbols.NT_LineFilter,0,1 }: LineFilter0R1,
 	Index{ symbols.NT_LineFilter,0,2 }: LineFilter0R2,
@@ -1055,6 +3687,12 @@ var slotIndex = map[Index]Label {
 	Index{ symbols.NT_LineFilterOp,2,1 }: LineFilterOp2R1,
 	Index{ symbols.NT_LineFilterOp,3,0 }: LineFilterOp3R0,
 	Index{ symbols.NT_LineFilterOp,3,1 }: LineFilterOp3R1,
+	Index{ symbols.NT_LineFilterValues,0,0 }: LineFilterValues0R0,
+	Index{ symbols.NT_LineFilterValues,0,1 }: LineFilterValues0R1,
+	Index{ symbols.NT_LineFilterValues,1,0 }: LineFilterValues1R0,
+	Index{ symbols.NT_LineFilterValues,1,1 }: LineFilterValues1R1,
+	Index{ symbols.NT_LineFilterValues,1,2 }: LineFilterValues1R2,
+	Index{ symbols.NT_LineFilterValues,1,3 }: LineFilterValues1R3,
 	Index{ symbols.NT_LogQuery,0,0 }: LogQuery0R0,
 	Index{ symbols.NT_LogQuery,0,1 }: LogQuery0R1,
 	Index{ symbols.NT_LogQuery,0,2 }: LogQuery0R2,
@@ -1083,23 +3721,108 @@ var slotIndex = map[Index]Label {
 	Index{ symbols.NT_LogSelectorOp,2,1 }: LogSelectorOp2R1,
 	Index{ symbols.NT_LogSelectorOp,3,0 }: LogSelectorOp3R0,
 	Index{ symbols.NT_LogSelectorOp,3,1 }: LogSelectorOp3R1,
+	Index{ symbols.NT_Matching,0,0 }: Matching0R0,
+	Index{ symbols.NT_Matching,0,1 }: Matching0R1,
+	Index{ symbols.NT_Matching,0,2 }: Matching0R2,
+	Index{ symbols.NT_Matching,0,3 }: Matching0R3,
+	Index{ symbols.NT_Matching,0,4 }: Matching0R4,
+	Index{ symbols.NT_MatchingMaybe,0,0 }: MatchingMaybe0R0,
+	Index{ symbols.NT_MatchingMaybe,1,0 }: MatchingMaybe1R0,
+	Index{ symbols.NT_MatchingMaybe,1,1 }: MatchingMaybe1R1,
+	Index{ symbols.NT_MatchingMaybe,1,2 }: MatchingMaybe1R2,
+	Index{ symbols.NT_MatchingOp,0,0 }: MatchingOp0R0,
+	Index{ symbols.NT_MatchingOp,0,1 }: MatchingOp0R1,
+	Index{ symbols.NT_MatchingOp,1,0 }: MatchingOp1R0,
+	Index{ symbols.NT_MatchingOp,1,1 }: MatchingOp1R1,
+	Index{ symbols.NT_MetricAdd,0,0 }: MetricAdd0R0,
+	Index{ symbols.NT_MetricAdd,0,1 }: MetricAdd0R1,
+	Index{ symbols.NT_MetricAdd,1,0 }: MetricAdd1R0,
+	Index{ symbols.NT_MetricAdd,1,1 }: MetricAdd1R1,
+	Index{ symbols.NT_MetricAdd,1,2 }: MetricAdd1R2,
+	Index{ symbols.NT_MetricAdd,1,3 }: MetricAdd1R3,
+	Index{ symbols.NT_MetricAdd,1,4 }: MetricAdd1R4,
+	Index{ symbols.NT_MetricAnd,0,0 }: MetricAnd0R0,
+	Index{ symbols.NT_MetricAnd,0,1 }: MetricAnd0R1,
+	Index{ symbols.NT_MetricAnd,1,0 }: MetricAnd1R0,
+	Index{ symbols.NT_MetricAnd,1,1 }: MetricAnd1R1,
+	Index{ symbols.NT_MetricAnd,1,2 }: MetricAnd1R2,
+	Index{ symbols.NT_MetricAnd,1,3 }: MetricAnd1R3,
+	Index{ symbols.NT_MetricAnd,1,4 }: MetricAnd1R4,
+	Index{ symbols.NT_MetricCmp,0,0 }: MetricCmp0R0,
+	Index{ symbols.NT_MetricCmp,0,1 }: MetricCmp0R1,
+	Index{ symbols.NT_MetricCmp,1,0 }: MetricCmp1R0,
+	Index{ symbols.NT_MetricCmp,1,1 }: MetricCmp1R1,
+	Index{ symbols.NT_MetricCmp,1,2 }: MetricCmp1R2,
+	Index{ symbols.NT_MetricCmp,1,3 }: MetricCmp1R3,
+	Index{ symbols.NT_MetricCmp,1,4 }: MetricCmp1R4,
+	Index{ symbols.NT_MetricMul,0,0 }: MetricMul0R0,
+	Index{ symbols.NT_MetricMul,0,1 }: MetricMul0R1,
+	Index{ symbols.NT_MetricMul,1,0 }: MetricMul1R0,
+	Index{ symbols.NT_MetricMul,1,1 }: MetricMul1R1,
+	Index{ symbols.NT_MetricMul,1,2 }: MetricMul1R2,
+	Index{ symbols.NT_MetricMul,1,3 }: MetricMul1R3,
+	Index{ symbols.NT_MetricMul,1,4 }: MetricMul1R4,
+	Index{ symbols.NT_MetricOr,0,0 }: MetricOr0R0,
+	Index{ symbols.NT_MetricOr,0,1 }: MetricOr0R1,
+	Index{ symbols.NT_MetricOr,1,0 }: MetricOr1R0,
+	Index{ symbols.NT_MetricOr,1,1 }: MetricOr1R1,
+	Index{ symbols.NT_MetricOr,1,2 }: MetricOr1R2,
+	Index{ symbols.NT_MetricOr,1,3 }: MetricOr1R3,
+	Index{ symbols.NT_MetricOr,1,4 }: MetricOr1R4,
+	Index{ symbols.NT_MetricPow,0,0 }: MetricPow0R0,
+	Index{ symbols.NT_MetricPow,0,1 }: MetricPow0R1,
+	Index{ symbols.NT_MetricPow,1,0 }: MetricPow1R0,
+	Index{ symbols.NT_MetricPow,1,1 }: MetricPow1R1,
+	Index{ symbols.NT_MetricPow,1,2 }: MetricPow1R2,
+	Index{ symbols.NT_MetricPow,1,3 }: MetricPow1R3,
+	Index{ symbols.NT_MetricPow,1,4 }: MetricPow1R4,
 	Index{ symbols.NT_MetricQuery,0,0 }: MetricQuery0R0,
 	Index{ symbols.NT_MetricQuery,0,1 }: MetricQuery0R1,
-	Index{ symbols.NT_MetricQuery,0,2 }: MetricQuery0R2,
-	Index{ symbols.NT_MetricQuery,0,3 }: MetricQuery0R3,
-	Index{ symbols.NT_MetricQuery,0,4 }: MetricQuery0R4,
-	Index{ symbols.NT_MetricQuery,0,5 }: MetricQuery0R5,
-	Index{ symbols.NT_MetricQuery,0,6 }: MetricQuery0R6,
-	Index{ symbols.NT_MetricQuery,0,7 }: MetricQuery0R7,
-	Index{ symbols.NT_MetricQuery,0,8 }: MetricQuery0R8,
-	Index{ symbols.NT_MetricQuery,0,9 }: MetricQuery0R9,
-	Index{ symbols.NT_MetricQuery,0,10 }: MetricQuery0R10,
-	Index{ symbols.NT_MetricQuery,0,11 }: MetricQuery0R11,
-	Index{ symbols.NT_MetricQuery,0,12 }: MetricQuery0R12,
+	Index{ symbols.NT_MetricTerm,0,0 }: MetricTerm0R0,
+	Index{ symbols.NT_MetricTerm,0,1 }: MetricTerm0R1,
+	Index{ symbols.NT_MetricTerm,1,0 }: MetricTerm1R0,
+	Index{ symbols.NT_MetricTerm,1,1 }: MetricTerm1R1,
+	Index{ symbols.NT_MetricTerm,2,0 }: MetricTerm2R0,
+	Index{ symbols.NT_MetricTerm,2,1 }: MetricTerm2R1,
+	Index{ symbols.NT_MetricTerm,3,0 }: MetricTerm3R0,
+	Index{ symbols.NT_MetricTerm,3,1 }: MetricTerm3R1,
+	Index{ symbols.NT_MetricTerm,3,2 }: MetricTerm3R2,
+	Index{ symbols.NT_MetricTerm,3,3 }: MetricTerm3R3,
+	Index{ symbols.NT_MetricUnary,0,0 }: MetricUnary0R0,
+	Index{ symbols.NT_MetricUnary,0,1 }: MetricUnary0R1,
+	Index{ symbols.NT_MetricUnary,1,0 }: MetricUnary1R0,
+	Index{ symbols.NT_MetricUnary,1,1 }: MetricUnary1R1,
+	Index{ symbols.NT_MetricUnary,1,2 }: MetricUnary1R2,
+	Index{ symbols.NT_MulOp,0,0 }: MulOp0R0,
+	Index{ symbols.NT_MulOp,0,1 }: MulOp0R1,
+	Index{ symbols.NT_MulOp,1,0 }: MulOp1R0,
+	Index{ symbols.NT_MulOp,1,1 }: MulOp1R1,
+	Index{ symbols.NT_MulOp,2,0 }: MulOp2R0,
+	Index{ symbols.NT_MulOp,2,1 }: MulOp2R1,
+	Index{ symbols.NT_OrOp,0,0 }: OrOp0R0,
+	Index{ symbols.NT_OrOp,0,1 }: OrOp0R1,
+	Index{ symbols.NT_ParserStage,0,0 }: ParserStage0R0,
+	Index{ symbols.NT_ParserStage,0,1 }: ParserStage0R1,
+	Index{ symbols.NT_ParserStage,0,2 }: ParserStage0R2,
+	Index{ symbols.NT_ParserStage,1,0 }: ParserStage1R0,
+	Index{ symbols.NT_ParserStage,1,1 }: ParserStage1R1,
+	Index{ symbols.NT_ParserStage,1,2 }: ParserStage1R2,
+	Index{ symbols.NT_ParserStage,2,0 }: ParserStage2R0,
+	Index{ symbols.NT_ParserStage,2,1 }: ParserStage2R1,
+	Index{ symbols.NT_ParserStage,2,2 }: ParserStage2R2,
+	Index{ symbols.NT_ParserStage,2,3 }: ParserStage2R3,
+	Index{ symbols.NT_ParserStage,3,0 }: ParserStage3R0,
+	Index{ symbols.NT_ParserStage,3,1 }: ParserStage3R1,
+	Index{ symbols.NT_ParserStage,3,2 }: ParserStage3R2,
+	Index{ symbols.NT_ParserStage,3,3 }: ParserStage3R3,
 	Index{ symbols.NT_Pipeline,0,0 }: Pipeline0R0,
 	Index{ symbols.NT_Pipeline,0,1 }: Pipeline0R1,
 	Index{ symbols.NT_Pipeline,1,0 }: Pipeline1R0,
 	Index{ symbols.NT_Pipeline,1,1 }: Pipeline1R1,
+	Index{ symbols.NT_Pipeline,2,0 }: Pipeline2R0,
+	Index{ symbols.NT_Pipeline,2,1 }: Pipeline2R1,
+	Index{ symbols.NT_Pipeline,3,0 }: Pipeline3R0,
+	Index{ symbols.NT_Pipeline,3,1 }: Pipeline3R1,
 	Index{ symbols.NT_Pipelines,0,0 }: Pipelines0R0,
 	Index{ symbols.NT_Pipelines,0,1 }: Pipelines0R1,
 	Index{ symbols.NT_Pipelines,1,0 }: Pipelines1R0,
@@ -1108,10 +3831,45 @@ var slotIndex = map[Index]Label {
 	Index{ symbols.NT_PipelinesMaybe,0,0 }: PipelinesMaybe0R0,
 	Index{ symbols.NT_PipelinesMaybe,1,0 }: PipelinesMaybe1R0,
 	Index{ symbols.NT_PipelinesMaybe,1,1 }: PipelinesMaybe1R1,
+	Index{ symbols.NT_PowOp,0,0 }: PowOp0R0,
+	Index{ symbols.NT_PowOp,0,1 }: PowOp0R1,
 	Index{ symbols.NT_Query,0,0 }: Query0R0,
 	Index{ symbols.NT_Query,0,1 }: Query0R1,
 	Index{ symbols.NT_Query,1,0 }: Query1R0,
 	Index{ symbols.NT_Query,1,1 }: Query1R1,
+	Index{ symbols.NT_RangeAggregation,0,0 }: RangeAggregation0R0,
+	Index{ symbols.NT_RangeAggregation,0,1 }: RangeAggregation0R1,
+	Index{ symbols.NT_RangeAggregation,0,2 }: RangeAggregation0R2,
+	Index{ symbols.NT_RangeAggregation,0,3 }: RangeAggregation0R3,
+	Index{ symbols.NT_RangeAggregation,0,4 }: RangeAggregation0R4,
+	Index{ symbols.NT_RangeAggregation,0,5 }: RangeAggregation0R5,
+	Index{ symbols.NT_RangeAggregation,0,6 }: RangeAggregation0R6,
+	Index{ symbols.NT_RangeAggregation,0,7 }: RangeAggregation0R7,
+	Index{ symbols.NT_RangeParamMaybe,0,0 }: RangeParamMaybe0R0,
+	Index{ symbols.NT_RangeParamMaybe,1,0 }: RangeParamMaybe1R0,
+	Index{ symbols.NT_RangeParamMaybe,1,1 }: RangeParamMaybe1R1,
+	Index{ symbols.NT_RangeParamMaybe,1,2 }: RangeParamMaybe1R2,
+	Index{ symbols.NT_UnwrapField,0,0 }: UnwrapField0R0,
+	Index{ symbols.NT_UnwrapField,0,1 }: UnwrapField0R1,
+	Index{ symbols.NT_UnwrapField,1,0 }: UnwrapField1R0,
+	Index{ symbols.NT_UnwrapField,1,1 }: UnwrapField1R1,
+	Index{ symbols.NT_UnwrapMaybe,0,0 }: UnwrapMaybe0R0,
+	Index{ symbols.NT_UnwrapMaybe,1,0 }: UnwrapMaybe1R0,
+	Index{ symbols.NT_UnwrapMaybe,1,1 }: UnwrapMaybe1R1,
+	Index{ symbols.NT_UnwrapMaybe,1,2 }: UnwrapMaybe1R2,
+	Index{ symbols.NT_UnwrapMaybe,1,3 }: UnwrapMaybe1R3,
+	Index{ symbols.NT_VectorAggregation,0,0 }: VectorAggregation0R0,
+	Index{ symbols.NT_VectorAggregation,0,1 }: VectorAggregation0R1,
+	Index{ symbols.NT_VectorAggregation,0,2 }: VectorAggregation0R2,
+	Index{ symbols.NT_VectorAggregation,0,3 }: VectorAggregation0R3,
+	Index{ symbols.NT_VectorAggregation,0,4 }: VectorAggregation0R4,
+	Index{ symbols.NT_VectorAggregation,0,5 }: VectorAggregation0R5,
+	Index{ symbols.NT_VectorAggregation,0,6 }: VectorAggregation0R6,
+	Index{ symbols.NT_VectorAggregation,0,7 }: VectorAggregation0R7,
+	Index{ symbols.NT_VectorParamMaybe,0,0 }: VectorParamMaybe0R0,
+	Index{ symbols.NT_VectorParamMaybe,1,0 }: VectorParamMaybe1R0,
+	Index{ symbols.NT_VectorParamMaybe,1,1 }: VectorParamMaybe1R1,
+	Index{ symbols.NT_VectorParamMaybe,1,2 }: VectorParamMaybe1R2,
 }
 
 var alternates = map[symbols.NT][]Label{ 
@@ -1121,15 +3879,61 @@ var alternates = map[symbols.NT][]Label{
 	symbols.NT_LogSelectorMembersMaybe:[]Label{ LogSelectorMembersMaybe0R0,LogSelectorMembersMaybe1R0 },
 	symbols.NT_LogSelectorMembers:[]Label{ LogSelectorMembers0R0,LogSelectorMembers1R0 },
 	symbols.NT_LogSelectorMember:[]Label{ LogSelectorMember0R0 },
-	symbols.NT_LabelKey:[]Label{ LabelKey0R0 },
+	symbols.NT_LabelKey:[]Label{ LabelKey0R0,LabelKey1R0,LabelKey2R0,LabelKey3R0,LabelKey4R0,LabelKey5R0,LabelKey6R0,LabelKey7R0,LabelKey8R0,LabelKey9R0 },
 	symbols.NT_LogSelectorOp:[]Label{ LogSelectorOp0R0,LogSelectorOp1R0,LogSelectorOp2R0,LogSelectorOp3R0 },
 	symbols.NT_PipelinesMaybe:[]Label{ PipelinesMaybe0R0,PipelinesMaybe1R0 },
 	symbols.NT_Pipelines:[]Label{ Pipelines0R0,Pipelines1R0 },
-	symbols.NT_Pipeline:[]Label{ Pipeline0R0,Pipeline1R0 },
+	symbols.NT_Pipeline:[]Label{ Pipeline0R0,Pipeline1R0,Pipeline2R0,Pipeline3R0 },
 	symbols.NT_LineFilter:[]Label{ LineFilter0R0 },
 	symbols.NT_LineFilterOp:[]Label{ LineFilterOp0R0,LineFilterOp1R0,LineFilterOp2R0,LineFilterOp3R0 },
+	symbols.NT_LineFilterValues:[]Label{ LineFilterValues0R0,LineFilterValues1R0 },
+	symbols.NT_FilterStage:[]Label{ FilterStage0R0 },
+	symbols.NT_FilterExpr:[]Label{ FilterExpr0R0,FilterExpr1R0 },
+	symbols.NT_FilterAnd:[]Label{ FilterAnd0R0,FilterAnd1R0,FilterAnd2R0 },
+	symbols.NT_FilterNot:[]Label{ FilterNot0R0,FilterNot1R0 },
+	symbols.NT_FilterTerm:[]Label{ FilterTerm0R0,FilterTerm1R0,FilterTerm2R0 },
 	symbols.NT_DataFilter:[]Label{ DataFilter0R0 },
 	symbols.NT_DataFilterOp:[]Label{ DataFilterOp0R0,DataFilterOp1R0,DataFilterOp2R0,DataFilterOp3R0,DataFilterOp4R0,DataFilterOp5R0,DataFilterOp6R0,DataFilterOp7R0 },
+	symbols.NT_ParserStage:[]Label{ ParserStage0R0,ParserStage1R0,ParserStage2R0,ParserStage3R0 },
+	symbols.NT_LabelFilter:[]Label{ LabelFilter0R0 },
+	symbols.NT_LabelFilterOp:[]Label{ LabelFilterOp0R0,LabelFilterOp1R0,LabelFilterOp2R0,LabelFilterOp3R0,LabelFilterOp4R0,LabelFilterOp5R0,LabelFilterOp6R0,LabelFilterOp7R0,LabelFilterOp8R0 },
+	symbols.NT_FilterValue:[]Label{ FilterValue0R0,FilterValue1R0 },
+	symbols.NT_FormatStage:[]Label{ FormatStage0R0,FormatStage1R0 },
+	symbols.NT_LabelFormats:[]Label{ LabelFormats0R0,LabelFormats1R0 },
+	symbols.NT_LabelFormat:[]Label{ LabelFormat0R0 },
+	symbols.NT_LabelFormatValue:[]Label{ LabelFormatValue0R0,LabelFormatValue1R0 },
 	symbols.NT_MetricQuery:[]Label{ MetricQuery0R0 },
+	symbols.NT_MetricOr:[]Label{ MetricOr0R0,MetricOr1R0 },
+	symbols.NT_OrOp:[]Label{ OrOp0R0 },
+	symbols.NT_MetricAnd:[]Label{ MetricAnd0R0,MetricAnd1R0 },
+	symbols.NT_AndOp:[]Label{ AndOp0R0,AndOp1R0 },
+	symbols.NT_MetricCmp:[]Label{ MetricCmp0R0,MetricCmp1R0 },
+	symbols.NT_CmpOp:[]Label{ CmpOp0R0,CmpOp1R0,CmpOp2R0,CmpOp3R0,CmpOp4R0,CmpOp5R0 },
+	symbols.NT_MetricAdd:[]Label{ MetricAdd0R0,MetricAdd1R0 },
+	symbols.NT_AddOp:[]Label{ AddOp0R0,AddOp1R0 },
+	symbols.NT_MetricMul:[]Label{ MetricMul0R0,MetricMul1R0 },
+	symbols.NT_MulOp:[]Label{ MulOp0R0,MulOp1R0,MulOp2R0 },
+	symbols.NT_MetricUnary:[]Label{ MetricUnary0R0,MetricUnary1R0 },
+	symbols.NT_MetricPow:[]Label{ MetricPow0R0,MetricPow1R0 },
+	symbols.NT_PowOp:[]Label{ PowOp0R0 },
+	symbols.NT_MetricTerm:[]Label{ MetricTerm0R0,MetricTerm1R0,MetricTerm2R0,MetricTerm3R0 },
+	symbols.NT_BinModifiers:[]Label{ BinModifiers0R0 },
+	symbols.NT_BoolMaybe:[]Label{ BoolMaybe0R0,BoolMaybe1R0 },
+	symbols.NT_MatchingMaybe:[]Label{ MatchingMaybe0R0,MatchingMaybe1R0 },
+	symbols.NT_Matching:[]Label{ Matching0R0 },
+	symbols.NT_MatchingOp:[]Label{ MatchingOp0R0,MatchingOp1R0 },
+	symbols.NT_GroupModifierMaybe:[]Label{ GroupModifierMaybe0R0,GroupModifierMaybe1R0,GroupModifierMaybe2R0 },
+	symbols.NT_GroupSide:[]Label{ GroupSide0R0,GroupSide1R0 },
+	symbols.NT_RangeAggregation:[]Label{ RangeAggregation0R0 },
+	symbols.NT_RangeParamMaybe:[]Label{ RangeParamMaybe0R0,RangeParamMaybe1R0 },
+	symbols.NT_UnwrapMaybe:[]Label{ UnwrapMaybe0R0,UnwrapMaybe1R0 },
+	symbols.NT_UnwrapField:[]Label{ UnwrapField0R0,UnwrapField1R0 },
+	symbols.NT_VectorAggregation:[]Label{ VectorAggregation0R0 },
+	symbols.NT_VectorParamMaybe:[]Label{ VectorParamMaybe0R0,VectorParamMaybe1R0 },
+	symbols.NT_GroupingMaybe:[]Label{ GroupingMaybe0R0,GroupingMaybe1R0 },
+	symbols.NT_Grouping:[]Label{ Grouping0R0 },
+	symbols.NT_GroupingOp:[]Label{ GroupingOp0R0,GroupingOp1R0 },
+	symbols.NT_LabelKeysMaybe:[]Label{ LabelKeysMaybe0R0,LabelKeysMaybe1R0 },
+	symbols.NT_LabelKeys:[]Label{ LabelKeys0R0,LabelKeys1R0 },
 }
 
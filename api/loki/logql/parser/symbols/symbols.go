@@ -19,22 +19,68 @@ func (T) isSymbol() {}
 // NT is the type of non-terminals symbols
 type NT int
 const( 
-	NT_DataFilter NT = iota
+	NT_AddOp NT = iota
+	NT_AndOp 
+	NT_BinModifiers 
+	NT_BoolMaybe 
+	NT_CmpOp 
+	NT_DataFilter 
 	NT_DataFilterOp 
+	NT_FilterAnd 
+	NT_FilterExpr 
+	NT_FilterNot 
+	NT_FilterStage 
+	NT_FilterTerm 
+	NT_FilterValue 
+	NT_FormatStage 
+	NT_GroupModifierMaybe 
+	NT_GroupSide 
+	NT_Grouping 
+	NT_GroupingMaybe 
+	NT_GroupingOp 
+	NT_LabelFilter 
+	NT_LabelFilterOp 
+	NT_LabelFormat 
+	NT_LabelFormatValue 
+	NT_LabelFormats 
 	NT_LabelKey 
+	NT_LabelKeys 
+	NT_LabelKeysMaybe 
 	NT_LineFilter 
 	NT_LineFilterOp 
+	NT_LineFilterValues 
 	NT_LogQuery 
 	NT_LogSelector 
 	NT_LogSelectorMember 
 	NT_LogSelectorMembers 
 	NT_LogSelectorMembersMaybe 
 	NT_LogSelectorOp 
+	NT_Matching 
+	NT_MatchingMaybe 
+	NT_MatchingOp 
+	NT_MetricAdd 
+	NT_MetricAnd 
+	NT_MetricCmp 
+	NT_MetricMul 
+	NT_MetricOr 
+	NT_MetricPow 
 	NT_MetricQuery 
+	NT_MetricTerm 
+	NT_MetricUnary 
+	NT_MulOp 
+	NT_OrOp 
+	NT_ParserStage 
 	NT_Pipeline 
 	NT_Pipelines 
 	NT_PipelinesMaybe 
+	NT_PowOp 
 	NT_Query 
+	NT_RangeAggregation 
+	NT_RangeParamMaybe 
+	NT_UnwrapField 
+	NT_UnwrapMaybe 
+	NT_VectorAggregation 
+	NT_VectorParamMaybe 
 )
 
 // T is the type of terminals symbols
@@ -42,27 +88,49 @@ type T int
 const( 
 	T_0 T = iota // != 
 	T_1  // !~ 
-	T_2  // ( 
-	T_3  // ) 
-	T_4  // , 
-	T_5  // < 
-	T_6  // <= 
-	T_7  // = 
-	T_8  // =~ 
-	T_9  // > 
-	T_10  // >= 
-	T_11  // by 
-	T_12  // count_over_time 
-	T_13  // duration 
-	T_14  // level 
-	T_15  // string 
-	T_16  // sum 
-	T_17  // var_name 
-	T_18  // { 
-	T_19  // | 
-	T_20  // |= 
-	T_21  // |~ 
-	T_22  // } 
+	T_2  // % 
+	T_3  // ( 
+	T_4  // ) 
+	T_5  // * 
+	T_6  // + 
+	T_7  // , 
+	T_8  // - 
+	T_9  // / 
+	T_10  // < 
+	T_11  // <= 
+	T_12  // = 
+	T_13  // == 
+	T_14  // =~ 
+	T_15  // > 
+	T_16  // >= 
+	T_17  // ^ 
+	T_18  // and 
+	T_19  // bool 
+	T_20  // by 
+	T_21  // duration 
+	T_22  // group_left 
+	T_23  // group_right 
+	T_24  // ignoring 
+	T_25  // json 
+	T_26  // label_format 
+	T_27  // line_format 
+	T_28  // logfmt 
+	T_29  // not 
+	T_30  // num 
+	T_31  // on 
+	T_32  // or 
+	T_33  // pattern 
+	T_34  // regexp 
+	T_35  // string 
+	T_36  // unless 
+	T_37  // unwrap 
+	T_38  // var_name 
+	T_39  // without 
+	T_40  // { 
+	T_41  // | 
+	T_42  // |= 
+	T_43  // |~ 
+	T_44  // } 
 )
 
 type Symbols []Symbol
@@ -130,65 +198,179 @@ func ToNT(sym string) NT {
 }
 
 var ntToString = []string { 
+	"AddOp", /* NT_AddOp */
+	"AndOp", /* NT_AndOp */
+	"BinModifiers", /* NT_BinModifiers */
+	"BoolMaybe", /* NT_BoolMaybe */
+	"CmpOp", /* NT_CmpOp */
 	"DataFilter", /* NT_DataFilter */
 	"DataFilterOp", /* NT_DataFilterOp */
+	"FilterAnd", /* NT_FilterAnd */
+	"FilterExpr", /* NT_FilterExpr */
+	"FilterNot", /* NT_FilterNot */
+	"FilterStage", /* NT_FilterStage */
+	"FilterTerm", /* NT_FilterTerm */
+	"FilterValue", /* NT_FilterValue */
+	"FormatStage", /* NT_FormatStage */
+	"GroupModifierMaybe", /* NT_GroupModifierMaybe */
+	"GroupSide", /* NT_GroupSide */
+	"Grouping", /* NT_Grouping */
+	"GroupingMaybe", /* NT_GroupingMaybe */
+	"GroupingOp", /* NT_GroupingOp */
+	"LabelFilter", /* NT_LabelFilter */
+	"LabelFilterOp", /* NT_LabelFilterOp */
+	"LabelFormat", /* NT_LabelFormat */
+	"LabelFormatValue", /* NT_LabelFormatValue */
+	"LabelFormats", /* NT_LabelFormats */
 	"LabelKey", /* NT_LabelKey */
+	"LabelKeys", /* NT_LabelKeys */
+	"LabelKeysMaybe", /* NT_LabelKeysMaybe */
 	"LineFilter", /* NT_LineFilter */
 	"LineFilterOp", /* NT_LineFilterOp */
+	"LineFilterValues", /* NT_LineFilterValues */
 	"LogQuery", /* NT_LogQuery */
 	"LogSelector", /* NT_LogSelector */
 	"LogSelectorMember", /* NT_LogSelectorMember */
 	"LogSelectorMembers", /* NT_LogSelectorMembers */
 	"LogSelectorMembersMaybe", /* NT_LogSelectorMembersMaybe */
 	"LogSelectorOp", /* NT_LogSelectorOp */
+	"Matching", /* NT_Matching */
+	"MatchingMaybe", /* NT_MatchingMaybe */
+	"MatchingOp", /* NT_MatchingOp */
+	"MetricAdd", /* NT_MetricAdd */
+	"MetricAnd", /* NT_MetricAnd */
+	"MetricCmp", /* NT_MetricCmp */
+	"MetricMul", /* NT_MetricMul */
+	"MetricOr", /* NT_MetricOr */
+	"MetricPow", /* NT_MetricPow */
 	"MetricQuery", /* NT_MetricQuery */
+	"MetricTerm", /* NT_MetricTerm */
+	"MetricUnary", /* NT_MetricUnary */
+	"MulOp", /* NT_MulOp */
+	"OrOp", /* NT_OrOp */
+	"ParserStage", /* NT_ParserStage */
 	"Pipeline", /* NT_Pipeline */
 	"Pipelines", /* NT_Pipelines */
 	"PipelinesMaybe", /* NT_PipelinesMaybe */
-	"Query", /* NT_Query */ 
+	"PowOp", /* NT_PowOp */
+	"Query", /* NT_Query */
+	"RangeAggregation", /* NT_RangeAggregation */
+	"RangeParamMaybe", /* NT_RangeParamMaybe */
+	"UnwrapField", /* NT_UnwrapField */
+	"UnwrapMaybe", /* NT_UnwrapMaybe */
+	"VectorAggregation", /* NT_VectorAggregation */
+	"VectorParamMaybe", /* NT_VectorParamMaybe */ 
 }
 
 var tToString = []string { 
 	"!=", /* T_0 */
 	"!~", /* T_1 */
-	"(", /* T_2 */
-	")", /* T_3 */
-	",", /* T_4 */
-	"<", /* T_5 */
-	"<=", /* T_6 */
-	"=", /* T_7 */
-	"=~", /* T_8 */
-	">", /* T_9 */
-	">=", /* T_10 */
-	"by", /* T_11 */
-	"count_over_time", /* T_12 */
-	"duration", /* T_13 */
-	"level", /* T_14 */
-	"string", /* T_15 */
-	"sum", /* T_16 */
-	"var_name", /* T_17 */
-	"{", /* T_18 */
-	"|", /* T_19 */
-	"|=", /* T_20 */
-	"|~", /* T_21 */
-	"}", /* T_22 */ 
+	"%", /* T_2 */
+	"(", /* T_3 */
+	")", /* T_4 */
+	"*", /* T_5 */
+	"+", /* T_6 */
+	",", /* T_7 */
+	"-", /* T_8 */
+	"/", /* T_9 */
+	"<", /* T_10 */
+	"<=", /* T_11 */
+	"=", /* T_12 */
+	"==", /* T_13 */
+	"=~", /* T_14 */
+	">", /* T_15 */
+	">=", /* T_16 */
+	"^", /* T_17 */
+	"and", /* T_18 */
+	"bool", /* T_19 */
+	"by", /* T_20 */
+	"duration", /* T_21 */
+	"group_left", /* T_22 */
+	"group_right", /* T_23 */
+	"ignoring", /* T_24 */
+	"json", /* T_25 */
+	"label_format", /* T_26 */
+	"line_format", /* T_27 */
+	"logfmt", /* T_28 */
+	"not", /* T_29 */
+	"num", /* T_30 */
+	"on", /* T_31 */
+	"or", /* T_32 */
+	"pattern", /* T_33 */
+	"regexp", /* T_34 */
+	"string", /* T_35 */
+	"unless", /* T_36 */
+	"unwrap", /* T_37 */
+	"var_name", /* T_38 */
+	"without", /* T_39 */
+	"{", /* T_40 */
+	"|", /* T_41 */
+	"|=", /* T_42 */
+	"|~", /* T_43 */
+	"}", /* T_44 */ 
 }
 
 var stringNT = map[string]NT{ 
+	"AddOp":NT_AddOp,
+	"AndOp":NT_AndOp,
+	"BinModifiers":NT_BinModifiers,
+	"BoolMaybe":NT_BoolMaybe,
+	"CmpOp":NT_CmpOp,
 	"DataFilter":NT_DataFilter,
 	"DataFilterOp":NT_DataFilterOp,
+	"FilterAnd":NT_FilterAnd,
+	"FilterExpr":NT_FilterExpr,
+	"FilterNot":NT_FilterNot,
+	"FilterStage":NT_FilterStage,
+	"FilterTerm":NT_FilterTerm,
+	"FilterValue":NT_FilterValue,
+	"FormatStage":NT_FormatStage,
+	"GroupModifierMaybe":NT_GroupModifierMaybe,
+	"GroupSide":NT_GroupSide,
+	"Grouping":NT_Grouping,
+	"GroupingMaybe":NT_GroupingMaybe,
+	"GroupingOp":NT_GroupingOp,
+	"LabelFilter":NT_LabelFilter,
+	"LabelFilterOp":NT_LabelFilterOp,
+	"LabelFormat":NT_LabelFormat,
+	"LabelFormatValue":NT_LabelFormatValue,
+	"LabelFormats":NT_LabelFormats,
 	"LabelKey":NT_LabelKey,
+	"LabelKeys":NT_LabelKeys,
+	"LabelKeysMaybe":NT_LabelKeysMaybe,
 	"LineFilter":NT_LineFilter,
 	"LineFilterOp":NT_LineFilterOp,
+	"LineFilterValues":NT_LineFilterValues,
 	"LogQuery":NT_LogQuery,
 	"LogSelector":NT_LogSelector,
 	"LogSelectorMember":NT_LogSelectorMember,
 	"LogSelectorMembers":NT_LogSelectorMembers,
 	"LogSelectorMembersMaybe":NT_LogSelectorMembersMaybe,
 	"LogSelectorOp":NT_LogSelectorOp,
+	"Matching":NT_Matching,
+	"MatchingMaybe":NT_MatchingMaybe,
+	"MatchingOp":NT_MatchingOp,
+	"MetricAdd":NT_MetricAdd,
+	"MetricAnd":NT_MetricAnd,
+	"MetricCmp":NT_MetricCmp,
+	"MetricMul":NT_MetricMul,
+	"MetricOr":NT_MetricOr,
+	"MetricPow":NT_MetricPow,
 	"MetricQuery":NT_MetricQuery,
+	"MetricTerm":NT_MetricTerm,
+	"MetricUnary":NT_MetricUnary,
+	"MulOp":NT_MulOp,
+	"OrOp":NT_OrOp,
+	"ParserStage":NT_ParserStage,
 	"Pipeline":NT_Pipeline,
 	"Pipelines":NT_Pipelines,
 	"PipelinesMaybe":NT_PipelinesMaybe,
+	"PowOp":NT_PowOp,
 	"Query":NT_Query,
+	"RangeAggregation":NT_RangeAggregation,
+	"RangeParamMaybe":NT_RangeParamMaybe,
+	"UnwrapField":NT_UnwrapField,
+	"UnwrapMaybe":NT_UnwrapMaybe,
+	"VectorAggregation":NT_VectorAggregation,
+	"VectorParamMaybe":NT_VectorParamMaybe,
 }
@@ -137,27 +137,49 @@ const(
     EOF  // $ 
     T_0  // != 
     T_1  // !~ 
-    T_2  // ( 
-    T_3  // ) 
-    T_4  // , 
-    T_5  // < 
-    T_6  // <= 
-    T_7  // = 
-    T_8  // =~ 
-    T_9  // > 
-    T_10  // >= 
-    T_11  // by 
-    T_12  // count_over_time 
-    T_13  // duration 
-    T_14  // level 
-    T_15  // string 
-    T_16  // sum 
-    T_17  // var_name 
-    T_18  // { 
-    T_19  // | 
-    T_20  // |= 
-    T_21  // |~ 
-    T_22  // } 
+    T_2  // % 
+    T_3  // ( 
+    T_4  // ) 
+    T_5  // * 
+    T_6  // + 
+    T_7  // , 
+    T_8  // - 
+    T_9  // / 
+    T_10  // < 
+    T_11  // <= 
+    T_12  // = 
+    T_13  // == 
+    T_14  // =~ 
+    T_15  // > 
+    T_16  // >= 
+    T_17  // ^ 
+    T_18  // and 
+    T_19  // bool 
+    T_20  // by 
+    T_21  // duration 
+    T_22  // group_left 
+    T_23  // group_right 
+    T_24  // ignoring 
+    T_25  // json 
+    T_26  // label_format 
+    T_27  // line_format 
+    T_28  // logfmt 
+    T_29  // not 
+    T_30  // num 
+    T_31  // on 
+    T_32  // or 
+    T_33  // pattern 
+    T_34  // regexp 
+    T_35  // string 
+    T_36  // unless 
+    T_37  // unwrap 
+    T_38  // var_name 
+    T_39  // without 
+    T_40  // { 
+    T_41  // | 
+    T_42  // |= 
+    T_43  // |~ 
+    T_44  // } 
 )
 
 var TypeToString = []string{ 
@@ -186,6 +208,28 @@ var TypeToString = []string{
     "T_20",
     "T_21",
     "T_22",
+    "T_23",
+    "T_24",
+    "T_25",
+    "T_26",
+    "T_27",
+    "T_28",
+    "T_29",
+    "T_30",
+    "T_31",
+    "T_32",
+    "T_33",
+    "T_34",
+    "T_35",
+    "T_36",
+    "T_37",
+    "T_38",
+    "T_39",
+    "T_40",
+    "T_41",
+    "T_42",
+    "T_43",
+    "T_44",
 }
 
 var StringToType = map[string] Type { 
@@ -214,6 +258,28 @@ var StringToType = map[string] Type {
     "T_20" : T_20, 
     "T_21" : T_21, 
     "T_22" : T_22, 
+    "T_23" : T_23, 
+    "T_24" : T_24, 
+    "T_25" : T_25, 
+    "T_26" : T_26, 
+    "T_27" : T_27, 
+    "T_28" : T_28, 
+    "T_29" : T_29, 
+    "T_30" : T_30, 
+    "T_31" : T_31, 
+    "T_32" : T_32, 
+    "T_33" : T_33, 
+    "T_34" : T_34, 
+    "T_35" : T_35, 
+    "T_36" : T_36, 
+    "T_37" : T_37, 
+    "T_38" : T_38, 
+    "T_39" : T_39, 
+    "T_40" : T_40, 
+    "T_41" : T_41, 
+    "T_42" : T_42, 
+    "T_43" : T_43, 
+    "T_44" : T_44, 
 }
 
 var TypeToID = []string { 
@@ -221,22 +287,44 @@ var TypeToID = []string {
     "$", 
     "!=", 
     "!~", 
+    "%", 
     "(", 
     ")", 
+    "*", 
+    "+", 
     ",", 
+    "-", 
+    "/", 
     "<", 
     "<=", 
     "=", 
+    "==", 
     "=~", 
     ">", 
     ">=", 
+    "^", 
+    "and", 
+    "bool", 
     "by", 
-    "count_over_time", 
     "duration", 
-    "level", 
+    "group_left", 
+    "group_right", 
+    "ignoring", 
+    "json", 
+    "label_format", 
+    "line_format", 
+    "logfmt", 
+    "not", 
+    "num", 
+    "on", 
+    "or", 
+    "pattern", 
+    "regexp", 
     "string", 
-    "sum", 
+    "unless", 
+    "unwrap", 
     "var_name", 
+    "without", 
     "{", 
     "|", 
     "|=", 
@@ -249,27 +337,49 @@ var IDToType = map[string]Type {
     "$": 1, 
     "!=": 2, 
     "!~": 3, 
-    "(": 4, 
-    ")": 5, 
-    ",": 6, 
-    "<": 7, 
-    "<=": 8, 
-    "=": 9, 
-    "=~": 10, 
-    ">": 11, 
-    ">=": 12, 
-    "by": 13, 
-    "count_over_time": 14, 
-    "duration": 15, 
-    "level": 16, 
-    "string": 17, 
-    "sum": 18, 
-    "var_name": 19, 
-    "{": 20, 
-    "|": 21, 
-    "|=": 22, 
-    "|~": 23, 
-    "}": 24, 
+    "%": 4, 
+    "(": 5, 
+    ")": 6, 
+    "*": 7, 
+    "+": 8, 
+    ",": 9, 
+    "-": 10, 
+    "/": 11, 
+    "<": 12, 
+    "<=": 13, 
+    "=": 14, 
+    "==": 15, 
+    "=~": 16, 
+    ">": 17, 
+    ">=": 18, 
+    "^": 19, 
+    "and": 20, 
+    "bool": 21, 
+    "by": 22, 
+    "duration": 23, 
+    "group_left": 24, 
+    "group_right": 25, 
+    "ignoring": 26, 
+    "json": 27, 
+    "label_format": 28, 
+    "line_format": 29, 
+    "logfmt": 30, 
+    "not": 31, 
+    "num": 32, 
+    "on": 33, 
+    "or": 34, 
+    "pattern": 35, 
+    "regexp": 36, 
+    "string": 37, 
+    "unless": 38, 
+    "unwrap": 39, 
+    "var_name": 40, 
+    "without": 41, 
+    "{": 42, 
+    "|": 43, 
+    "|=": 44, 
+    "|~": 45, 
+    "}": 46, 
 }
 
 var Suppress = []bool { 
@@ -298,5 +408,27 @@ var Suppress = []bool {
     false, 
     false, 
     false, 
+    false, 
+    false, 
+    false, 
+    false, 
+    false, 
+    false, 
+    false, 
+    false, 
+    false, 
+    false, 
+    false, 
+    false, 
+    false, 
+    false, 
+    false, 
+    false, 
+    false, 
+    false, 
+    false, 
+    false, 
+    false, 
+    false, 
 }
 
@@ -1,8 +1,11 @@
 package loki
 
 import (
+	"context"
 	"testing"
+	"time"
 
+	"github.com/commentlens/loghouse/storage"
 	"github.com/stretchr/testify/require"
 )
 
@@ -109,3 +112,59 @@ func TestRegexpExtractLiterals(t *testing.T) {
 		require.Equal(t, test.want, got)
 	}
 }
+
+func TestReadFilterExpr(t *testing.T) {
+	r := testReader{
+		{Labels: map[string]string{"app": "x"}, Time: time.Unix(1, 0), Data: []byte(`{"level": "error", "status": 200}`)},
+		{Labels: map[string]string{"app": "x"}, Time: time.Unix(2, 0), Data: []byte(`{"level": "info", "status": 503}`)},
+		{Labels: map[string]string{"app": "x"}, Time: time.Unix(3, 0), Data: []byte(`{"level": "info", "status": 200}`)},
+		{Labels: map[string]string{"app": "x"}, Time: time.Unix(4, 0), Data: []byte(`panic: nil map`)},
+	}
+	for _, test := range []struct {
+		query    string
+		want     []int64
+		contains []string
+	}{
+		{
+			query:    `{app="x"} |= "error" or "panic"`,
+			want:     []int64{1, 4},
+			contains: nil,
+		},
+		{
+			query:    `{app="x"} != "error" or "panic"`,
+			want:     []int64{2, 3},
+			contains: nil,
+		},
+		{
+			query:    `{app="x"} | "level"="error" or "status">=500`,
+			want:     []int64{1, 2},
+			contains: nil,
+		},
+		{
+			query:    `{app="x"} | json | level="error" or status>=500`,
+			want:     []int64{1, 2},
+			contains: nil,
+		},
+		{
+			query:    `{app="x"} | "level"="info" and not ("status">=500)`,
+			want:     []int64{3},
+			contains: []string{"level", "info"},
+		},
+		{
+			query:    `{app="x"} | ("level"="info", "status"="200") or ("level"="error" and "status"="200")`,
+			want:     []int64{1, 3},
+			contains: []string{"level", "status", "200"},
+		},
+	} {
+		var got []int64
+		ropts := &storage.ReadOptions{
+			ResultFunc: func(e storage.LogEntry) {
+				got = append(got, e.Time.Unix())
+			},
+		}
+		err := logqlRead(context.Background(), r, ropts, test.query)
+		require.NoError(t, err, test.query)
+		require.Equal(t, test.want, got, test.query)
+		require.Equal(t, test.contains, ropts.Contains, test.query)
+	}
+}
@@ -0,0 +1,752 @@
+package loki
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/commentlens/loghouse/api/loki/logql/parser/bsr"
+	"github.com/commentlens/loghouse/api/loki/logql/parser/symbols"
+	"github.com/commentlens/loghouse/storage"
+	"github.com/tidwall/gjson"
+)
+
+const (
+	MetricMaxSteps = 11000
+)
+
+var (
+	errUnknownFunction = errors.New("logql: unknown function")
+	errTooManySteps    = errors.New("logql: too many steps")
+)
+
+type logqlMetric struct {
+	op string
+	// range aggregation
+	query    bsr.BSR
+	rng      time.Duration
+	quantile float64
+	unwrap   string
+	// vector aggregation
+	param    int
+	grouping []string
+	without  bool
+	inner    *logqlMetric
+	// binary operation
+	lhs        *logqlMetric
+	rhs        *logqlMetric
+	returnBool bool
+	on         bool
+	matching   []string
+	group      string
+	include    []string
+	// scalar
+	isScalar bool
+	scalar   float64
+}
+
+func logqlParseMetric(query string) (*logqlMetric, error) {
+	root, err := logqlParse(query)
+	if err != nil {
+		return nil, err
+	}
+	var node bsr.BSR
+	var ok bool
+	logqlWalk(root, func(n bsr.BSR) error {
+		if !ok && n.Label.Slot().NT == symbols.NT_MetricQuery {
+			node = n
+			ok = true
+		}
+		return nil
+	})
+	if !ok {
+		return nil, nil
+	}
+	return logqlNewMetric(node)
+}
+
+func logqlParseDuration(s string) (time.Duration, error) {
+	s = strings.TrimSpace(strings.TrimSuffix(strings.TrimPrefix(s, "["), "]"))
+	var mul time.Duration = 1
+	switch {
+	case strings.HasSuffix(s, "d"):
+		s, mul = strings.TrimSuffix(s, "d"), 24*time.Hour
+	case strings.HasSuffix(s, "w"):
+		s, mul = strings.TrimSuffix(s, "w"), 7*24*time.Hour
+	}
+	var d time.Duration
+	if mul != 1 {
+		n, err := strconv.ParseUint(s, 10, 64)
+		if err != nil {
+			return 0, fmt.Errorf("logql: %w %s", err, s)
+		}
+		d = time.Duration(n) * mul
+	} else {
+		var err error
+		d, err = time.ParseDuration(s)
+		if err != nil {
+			return 0, fmt.Errorf("logql: %w", err)
+		}
+	}
+	if d <= 0 {
+		return 0, fmt.Errorf("logql: invalid duration %s", s)
+	}
+	return d, nil
+}
+
+func logqlParseLabelKeys(node bsr.BSR) []string {
+	keys := []string{}
+	if node.Alternate() == 0 {
+		return keys
+	}
+	node = node.GetNTChildI(0)
+	for {
+		keys = append(keys, node.GetNTChildI(0).GetTChildI(0).LiteralString())
+		if node.Alternate() == 0 {
+			break
+		}
+		node = node.GetNTChildI(2)
+	}
+	return keys
+}
+
+func logqlParseGrouping(node bsr.BSR, m *logqlMetric) {
+	if node.Alternate() == 0 {
+		return
+	}
+	grouping := node.GetNTChildI(0)
+	m.without = grouping.GetNTChildI(0).GetTChildI(0).LiteralString() == "without"
+	m.grouping = logqlParseLabelKeys(grouping.GetNTChildI(2))
+}
+
+func logqlNewMetric(node bsr.BSR) (*logqlMetric, error) {
+	switch node.Label.Slot().NT {
+	case symbols.NT_MetricQuery:
+		return logqlNewMetric(node.GetNTChildI(0))
+	case symbols.NT_MetricOr, symbols.NT_MetricAnd, symbols.NT_MetricCmp, symbols.NT_MetricAdd, symbols.NT_MetricMul, symbols.NT_MetricPow:
+		if node.Alternate() == 0 {
+			return logqlNewMetric(node.GetNTChildI(0))
+		}
+		return logqlNewBinary(node)
+	case symbols.NT_MetricUnary:
+		if node.Alternate() == 0 {
+			return logqlNewMetric(node.GetNTChildI(0))
+		}
+		m, err := logqlNewMetric(node.GetNTChildI(1))
+		if err != nil {
+			return nil, err
+		}
+		if m.isScalar {
+			m.scalar = -m.scalar
+			return m, nil
+		}
+		return &logqlMetric{
+			op:  "*",
+			lhs: m,
+			rhs: &logqlMetric{isScalar: true, scalar: -1},
+		}, nil
+	case symbols.NT_MetricTerm:
+		switch node.Alternate() {
+		case 2:
+			v, err := strconv.ParseFloat(node.GetTChildI(0).LiteralString(), 64)
+			if err != nil {
+				return nil, fmt.Errorf("logql: %w", err)
+			}
+			return &logqlMetric{isScalar: true, scalar: v}, nil
+		case 3:
+			return logqlNewMetric(node.GetNTChildI(1))
+		default:
+			return logqlNewMetric(node.GetNTChildI(0))
+		}
+	}
+	op := node.GetTChildI(0).LiteralString()
+	switch node.Label.Slot().NT {
+	case symbols.NT_RangeAggregation:
+		m := &logqlMetric{op: op, query: node.GetNTChildI(3)}
+		unwrap := node.GetNTChildI(4)
+		if unwrap.Alternate() != 0 {
+			field := unwrap.GetNTChildI(2)
+			m.unwrap = field.GetTChildI(0).LiteralString()
+			if field.Alternate() == 1 {
+				s, err := logqlUnquote(m.unwrap)
+				if err != nil {
+					return nil, err
+				}
+				m.unwrap = s
+			}
+		}
+		switch op {
+		case "count_over_time", "rate", "bytes_over_time", "bytes_rate", "absent_over_time":
+			if m.unwrap != "" {
+				return nil, fmt.Errorf("logql: %s does not take unwrap", op)
+			}
+		case "sum_over_time", "avg_over_time", "max_over_time", "min_over_time", "quantile_over_time", "stddev_over_time":
+			if m.unwrap == "" {
+				return nil, fmt.Errorf("logql: %s requires unwrap", op)
+			}
+		default:
+			return nil, fmt.Errorf("%w %s", errUnknownFunction, op)
+		}
+		param := node.GetNTChildI(2)
+		if param.Alternate() == 0 {
+			if op == "quantile_over_time" {
+				return nil, fmt.Errorf("logql: %s requires a parameter", op)
+			}
+		} else {
+			if op != "quantile_over_time" {
+				return nil, fmt.Errorf("logql: %s takes no parameter", op)
+			}
+			q, err := strconv.ParseFloat(param.GetTChildI(0).LiteralString(), 64)
+			if err != nil {
+				return nil, fmt.Errorf("logql: %w", err)
+			}
+			if q > 1 {
+				return nil, fmt.Errorf("logql: invalid quantile %v", q)
+			}
+			m.quantile = q
+		}
+		rng, err := logqlParseDuration(node.GetTChildI(5).LiteralString())
+		if err != nil {
+			return nil, err
+		}
+		m.rng = rng
+		return m, nil
+	default:
+		m := &logqlMetric{op: op}
+		switch op {
+		case "sum", "avg", "min", "max", "count":
+		case "topk", "bottomk":
+		default:
+			return nil, fmt.Errorf("%w %s", errUnknownFunction, op)
+		}
+		logqlParseGrouping(node.GetNTChildI(1), m)
+		if m.grouping == nil {
+			logqlParseGrouping(node.GetNTChildI(6), m)
+		}
+		param := node.GetNTChildI(3)
+		if param.Alternate() == 0 {
+			if op == "topk" || op == "bottomk" {
+				return nil, fmt.Errorf("logql: %s requires a parameter", op)
+			}
+		} else {
+			if !(op == "topk" || op == "bottomk") {
+				return nil, fmt.Errorf("logql: %s takes no parameter", op)
+			}
+			n, err := strconv.Atoi(param.GetTChildI(0).LiteralString())
+			if err != nil {
+				return nil, fmt.Errorf("logql: %w", err)
+			}
+			m.param = n
+		}
+		inner, err := logqlNewMetric(node.GetNTChildI(4))
+		if err != nil {
+			return nil, err
+		}
+		if inner.isScalar {
+			return nil, fmt.Errorf("logql: %s expects a vector", op)
+		}
+		m.inner = inner
+		return m, nil
+	}
+}
+
+type logqlPoint struct {
+	t int64
+	v float64
+}
+
+// logqlSegment is a block summary, counted only by windows that hold all of
+// start and end.
+type logqlSegment struct {
+	start int64
+	end   int64
+	count float64
+}
+
+type logqlStream struct {
+	labels   map[string]string
+	points   []logqlPoint
+	segments []logqlSegment
+}
+
+// window returns the points in (start, end]. points must be sorted.
+func (s *logqlStream) window(start, end int64) []logqlPoint {
+	i := sort.Search(len(s.points), func(i int) bool { return s.points[i].t > start })
+	j := sort.Search(len(s.points), func(i int) bool { return s.points[i].t > end })
+	return s.points[i:j]
+}
+
+// sum returns the total value in (start, end]. points must be sorted, with v
+// being the running total.
+func (s *logqlStream) sum(start, end int64) float64 {
+	i := sort.Search(len(s.points), func(i int) bool { return s.points[i].t > start })
+	j := sort.Search(len(s.points), func(i int) bool { return s.points[i].t > end })
+	var v float64
+	if j > 0 {
+		v += s.points[j-1].v
+	}
+	if i > 0 {
+		v -= s.points[i-1].v
+	}
+	// segments lie either inside or outside of every window.
+	for _, seg := range s.segments {
+		if start < seg.start && seg.end <= end {
+			v += seg.count
+		}
+	}
+	return v
+}
+
+type logqlSeries struct {
+	labels map[string]string
+	values map[int]float64
+}
+
+func logqlReadMetric(ctx context.Context, r storage.Reader, m *logqlMetric, start, end time.Time, step time.Duration) ([]*Matrix, error) {
+	steps := int(end.Sub(start)/step) + 1
+	if steps > MetricMaxSteps {
+		return nil, fmt.Errorf("%w: %d > %d", errTooManySteps, steps, MetricMaxSteps)
+	}
+	v, err := m.eval(ctx, r, start, step, steps)
+	if err != nil {
+		return nil, err
+	}
+	if v.isScalar {
+		values := make(map[int]float64)
+		for i := 0; i < steps; i++ {
+			values[i] = v.scalar
+		}
+		v.series = []*logqlSeries{{labels: map[string]string{}, values: values}}
+	}
+	return logqlMatrix(v.series, start, step), nil
+}
+
+func logqlReadInstant(ctx context.Context, r storage.Reader, m *logqlMetric, ts time.Time) (interface{}, error) {
+	v, err := m.eval(ctx, r, ts, time.Second, 1)
+	if err != nil {
+		return nil, err
+	}
+	if v.isScalar {
+		return Scalar{
+			float64(ts.UnixNano()) / 1e9,
+			strconv.FormatFloat(v.scalar, 'f', -1, 64),
+		}, nil
+	}
+	matrix := logqlMatrix(v.series, ts, time.Second)
+	vector := make([]*Vector, 0, len(matrix))
+	for _, m := range matrix {
+		vector = append(vector, &Vector{
+			Metric: m.Metric,
+			Value:  m.Values[0],
+		})
+	}
+	return vector, nil
+}
+
+type logqlValue struct {
+	series   []*logqlSeries
+	isScalar bool
+	scalar   float64
+}
+
+func (m *logqlMetric) eval(ctx context.Context, r storage.Reader, start time.Time, step time.Duration, steps int) (*logqlValue, error) {
+	switch {
+	case m.isScalar:
+		return &logqlValue{isScalar: true, scalar: m.scalar}, nil
+	case m.lhs != nil:
+		lhs, err := m.lhs.eval(ctx, r, start, step, steps)
+		if err != nil {
+			return nil, err
+		}
+		rhs, err := m.rhs.eval(ctx, r, start, step, steps)
+		if err != nil {
+			return nil, err
+		}
+		return logqlBinary(m, lhs, rhs, steps)
+	case m.inner != nil:
+		inner, err := m.inner.eval(ctx, r, start, step, steps)
+		if err != nil {
+			return nil, err
+		}
+		series, err := logqlAggregate(m, inner.series, steps)
+		if err != nil {
+			return nil, err
+		}
+		return &logqlValue{series: series}, nil
+	default:
+		series, err := logqlReadRange(ctx, r, m, start, step, steps)
+		if err != nil {
+			return nil, err
+		}
+		return &logqlValue{series: series}, nil
+	}
+}
+
+func logqlReadRange(ctx context.Context, r storage.Reader, ra *logqlMetric, start time.Time, step time.Duration, steps int) ([]*logqlSeries, error) {
+	end := start.Add(time.Duration(steps-1) * step)
+
+	var mu sync.Mutex
+	streams := make(map[string]*logqlStream)
+	getStream := func(labels map[string]string) (*logqlStream, error) {
+		h, err := storage.HashLabels(labels)
+		if err != nil {
+			return nil, err
+		}
+		s, ok := streams[h]
+		if !ok {
+			s = &logqlStream{labels: labels}
+			streams[h] = s
+		}
+		return s, nil
+	}
+	isBytes := ra.op == "bytes_over_time" || ra.op == "bytes_rate"
+	ropts := &storage.ReadOptions{
+		Start: start.Add(-ra.rng),
+		End:   end,
+		SummaryFunc: func(sum storage.LogSummary) bool {
+			if isBytes || ra.unwrap != "" || sum.Start.IsZero() || sum.End.IsZero() {
+				return true
+			}
+			if !logqlWholeWindows(sum.Start.UnixNano(), sum.End.UnixNano(), start.UnixNano(), step, ra.rng, steps) {
+				return true
+			}
+			mu.Lock()
+			defer mu.Unlock()
+			s, err := getStream(sum.Labels)
+			if err != nil {
+				return true
+			}
+			s.segments = append(s.segments, logqlSegment{
+				start: sum.Start.UnixNano(),
+				end:   sum.End.UnixNano(),
+				count: float64(sum.Count),
+			})
+			return false
+		},
+		ResultFunc: func(e storage.LogEntry) {
+			v := float64(1)
+			switch {
+			case ra.unwrap != "":
+				var ok bool
+				v, ok = logqlUnwrap(e, ra.unwrap)
+				if !ok {
+					return
+				}
+			case isBytes:
+				v = float64(len(e.Data))
+			}
+			mu.Lock()
+			defer mu.Unlock()
+			s, err := getStream(e.Labels)
+			if err != nil {
+				return
+			}
+			s.points = append(s.points, logqlPoint{t: e.Time.UnixNano(), v: v})
+		},
+	}
+	err := logqlReadQuery(ctx, r, ropts, ra.query)
+	if err != nil {
+		return nil, err
+	}
+
+	var series []*logqlSeries
+	for _, s := range streams {
+		sort.Slice(s.points, func(i, j int) bool { return s.points[i].t < s.points[j].t })
+		if ra.unwrap != "" {
+			continue
+		}
+		for i := 1; i < len(s.points); i++ {
+			s.points[i].v += s.points[i-1].v
+		}
+	}
+	if ra.op == "absent_over_time" {
+		absent := &logqlSeries{
+			labels: make(map[string]string),
+			values: make(map[int]float64),
+		}
+		for k, v := range ropts.Labels {
+			absent.labels[k] = v
+		}
+		for i := 0; i < steps; i++ {
+			t := start.Add(time.Duration(i) * step).UnixNano()
+			var total float64
+			for _, s := range streams {
+				total += s.sum(t-int64(ra.rng), t)
+			}
+			if total == 0 {
+				absent.values[i] = 1
+			}
+		}
+		if len(absent.values) > 0 {
+			series = append(series, absent)
+		}
+	} else {
+		for _, s := range streams {
+			sr := &logqlSeries{
+				labels: s.labels,
+				values: make(map[int]float64),
+			}
+			for i := 0; i < steps; i++ {
+				t := start.Add(time.Duration(i) * step).UnixNano()
+				if ra.unwrap != "" {
+					ps := s.window(t-int64(ra.rng), t)
+					if len(ps) > 0 {
+						sr.values[i] = logqlOverTime(ra, ps)
+					}
+					continue
+				}
+				v := s.sum(t-int64(ra.rng), t)
+				if v <= 0 {
+					continue
+				}
+				switch ra.op {
+				case "rate", "bytes_rate":
+					v /= ra.rng.Seconds()
+				}
+				sr.values[i] = v
+			}
+			if len(sr.values) > 0 {
+				series = append(series, sr)
+			}
+		}
+	}
+	return series, nil
+}
+
+// logqlWholeWindows reports whether entries in [lo, hi] lie either inside or
+// outside of every window (t-rng, t], with t from first by step, so that
+// their count can be used without reading them.
+func logqlWholeWindows(lo, hi, first int64, step, rng time.Duration, steps int) bool {
+	// windows with lo <= t < hi+rng may cut through.
+	i := int((lo - first) / int64(step))
+	if i < 0 {
+		i = 0
+	}
+	for ; i < steps; i++ {
+		t := first + int64(i)*int64(step)
+		if t < lo {
+			continue
+		}
+		if t-int64(rng) >= hi {
+			break
+		}
+		if !(t-int64(rng) < lo && hi <= t) {
+			return false
+		}
+	}
+	return true
+}
+
+func logqlUnwrap(e storage.LogEntry, field string) (float64, bool) {
+	v := gjson.GetBytes(e.Data, field)
+	switch v.Type {
+	case gjson.Number:
+		return v.Float(), true
+	case gjson.String:
+		f, err := strconv.ParseFloat(v.Str, 64)
+		if err != nil {
+			return 0, false
+		}
+		return f, true
+	}
+	return 0, false
+}
+
+// logqlOverTime aggregates the unwrapped values of a non-empty window.
+func logqlOverTime(m *logqlMetric, ps []logqlPoint) float64 {
+	var sum float64
+	for _, p := range ps {
+		sum += p.v
+	}
+	n := float64(len(ps))
+	switch m.op {
+	case "sum_over_time":
+		return sum
+	case "avg_over_time":
+		return sum / n
+	case "max_over_time", "min_over_time":
+		v := ps[0].v
+		for _, p := range ps[1:] {
+			if m.op == "max_over_time" && p.v > v || m.op == "min_over_time" && p.v < v {
+				v = p.v
+			}
+		}
+		return v
+	case "stddev_over_time":
+		mean := sum / n
+		var variance float64
+		for _, p := range ps {
+			variance += (p.v - mean) * (p.v - mean)
+		}
+		return math.Sqrt(variance / n)
+	case "quantile_over_time":
+		if m.quantile < 0 {
+			return math.Inf(-1)
+		}
+		vs := make([]float64, len(ps))
+		for i, p := range ps {
+			vs[i] = p.v
+		}
+		sort.Float64s(vs)
+		rank := m.quantile * (n - 1)
+		lo := math.Floor(rank)
+		hi := math.Ceil(rank)
+		return vs[int(lo)] + (vs[int(hi)]-vs[int(lo)])*(rank-lo)
+	}
+	return 0
+}
+
+func logqlGroupLabels(m *logqlMetric, labels map[string]string) map[string]string {
+	group := make(map[string]string)
+	if m.without {
+		for k, v := range labels {
+			group[k] = v
+		}
+		for _, k := range m.grouping {
+			delete(group, k)
+		}
+		return group
+	}
+	for _, k := range m.grouping {
+		if v, ok := labels[k]; ok {
+			group[k] = v
+		}
+	}
+	return group
+}
+
+func logqlAggregate(m *logqlMetric, series []*logqlSeries, steps int) ([]*logqlSeries, error) {
+	sort.Slice(series, func(i, j int) bool {
+		return fmt.Sprint(series[i].labels) < fmt.Sprint(series[j].labels)
+	})
+	type group struct {
+		labels map[string]string
+		series []*logqlSeries
+	}
+	var keys []string
+	groups := make(map[string]*group)
+	for _, s := range series {
+		labels := logqlGroupLabels(m, s.labels)
+		h, err := storage.HashLabels(labels)
+		if err != nil {
+			return nil, err
+		}
+		g, ok := groups[h]
+		if !ok {
+			g = &group{labels: labels}
+			groups[h] = g
+			keys = append(keys, h)
+		}
+		g.series = append(g.series, s)
+	}
+	var out []*logqlSeries
+	for _, h := range keys {
+		g := groups[h]
+		switch m.op {
+		case "topk", "bottomk":
+			selected := make(map[*logqlSeries]*logqlSeries)
+			for i := 0; i < steps; i++ {
+				var vs []*logqlSeries
+				for _, s := range g.series {
+					if _, ok := s.values[i]; ok {
+						vs = append(vs, s)
+					}
+				}
+				sort.SliceStable(vs, func(a, b int) bool {
+					if m.op == "topk" {
+						return vs[a].values[i] > vs[b].values[i]
+					}
+					return vs[a].values[i] < vs[b].values[i]
+				})
+				if len(vs) > m.param {
+					vs = vs[:m.param]
+				}
+				for _, s := range vs {
+					sel, ok := selected[s]
+					if !ok {
+						sel = &logqlSeries{
+							labels: s.labels,
+							values: make(map[int]float64),
+						}
+						selected[s] = sel
+						out = append(out, sel)
+					}
+					sel.values[i] = s.values[i]
+				}
+			}
+		default:
+			agg := &logqlSeries{
+				labels: g.labels,
+				values: make(map[int]float64),
+			}
+			for i := 0; i < steps; i++ {
+				var n int
+				var v float64
+				for _, s := range g.series {
+					sv, ok := s.values[i]
+					if !ok {
+						continue
+					}
+					switch {
+					case n == 0:
+						v = sv
+					case m.op == "min" && sv < v:
+						v = sv
+					case m.op == "max" && sv > v:
+						v = sv
+					case m.op == "sum" || m.op == "avg":
+						v += sv
+					}
+					n++
+				}
+				if n == 0 {
+					continue
+				}
+				switch m.op {
+				case "avg":
+					v /= float64(n)
+				case "count":
+					v = float64(n)
+				}
+				agg.values[i] = v
+			}
+			if len(agg.values) > 0 {
+				out = append(out, agg)
+			}
+		}
+	}
+	return out, nil
+}
+
+func logqlMatrix(series []*logqlSeries, start time.Time, step time.Duration) []*Matrix {
+	matrix := make([]*Matrix, 0, len(series))
+	for _, s := range series {
+		var steps []int
+		for i := range s.values {
+			steps = append(steps, i)
+		}
+		sort.Ints(steps)
+		values := make([][]interface{}, 0, len(steps))
+		for _, i := range steps {
+			t := start.Add(time.Duration(i) * step)
+			values = append(values, []interface{}{
+				float64(t.UnixNano()) / 1e9,
+				strconv.FormatFloat(s.values[i], 'f', -1, 64),
+			})
+		}
+		matrix = append(matrix, &Matrix{
+			Metric: s.labels,
+			Values: values,
+		})
+	}
+	sort.Slice(matrix, func(i, j int) bool {
+		return fmt.Sprint(matrix[i].Metric) < fmt.Sprint(matrix[j].Metric)
+	})
+	return matrix
+}
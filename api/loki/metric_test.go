@@ -0,0 +1,394 @@
+package loki
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/commentlens/loghouse/api/loki/logql/parser/bsr"
+	"github.com/commentlens/loghouse/storage"
+	"github.com/stretchr/testify/require"
+)
+
+type testReader []storage.LogEntry
+
+func (r testReader) Read(ctx context.Context, opts *storage.ReadOptions) error {
+	for _, e := range r {
+		if storage.MatchLogEntry(e, opts) {
+			opts.ResultFunc(e)
+		}
+	}
+	return nil
+}
+
+// clearQuery drops parse nodes so that metrics compare by value.
+func clearQuery(m *logqlMetric) {
+	if m == nil {
+		return
+	}
+	m.query = bsr.BSR{}
+	clearQuery(m.inner)
+	clearQuery(m.lhs)
+	clearQuery(m.rhs)
+}
+
+func TestParseMetric(t *testing.T) {
+	for _, test := range []struct {
+		in   string
+		want *logqlMetric
+		err  bool
+	}{
+		{
+			in: `{app="a"} |= "x"`,
+		},
+		{
+			in:   `count_over_time({app="a"}[5m])`,
+			want: &logqlMetric{op: "count_over_time", rng: 5 * time.Minute},
+		},
+		{
+			in:   `rate({app="a"} |= "x" [1d])`,
+			want: &logqlMetric{op: "rate", rng: 24 * time.Hour},
+		},
+		{
+			in: `sum by (host, by) (bytes_rate({app="a"}[30s]))`,
+			want: &logqlMetric{
+				op:       "sum",
+				grouping: []string{"host", "by"},
+				inner:    &logqlMetric{op: "bytes_rate", rng: 30 * time.Second},
+			},
+		},
+		{
+			in: `topk(2, count_over_time({app="a"}[1m])) without (host)`,
+			want: &logqlMetric{
+				op:       "topk",
+				param:    2,
+				grouping: []string{"host"},
+				without:  true,
+				inner:    &logqlMetric{op: "count_over_time", rng: time.Minute},
+			},
+		},
+		{
+			in:  `topk(count_over_time({app="a"}[1m]))`,
+			err: true,
+		},
+		{
+			in:  `sum(2, count_over_time({app="a"}[1m]))`,
+			err: true,
+		},
+		{
+			in:  `foo_over_time({app="a"}[1m])`,
+			err: true,
+		},
+		{
+			in:   `quantile_over_time(0.99, {app="a"} | "level" = "error" | unwrap latency [1m])`,
+			want: &logqlMetric{op: "quantile_over_time", rng: time.Minute, quantile: 0.99, unwrap: "latency"},
+		},
+		{
+			in:   "max_over_time({app=\"a\"} | unwrap `req.size` [1m])",
+			want: &logqlMetric{op: "max_over_time", rng: time.Minute, unwrap: "req.size"},
+		},
+		{
+			in:  `sum_over_time({app="a"}[1m])`,
+			err: true,
+		},
+		{
+			in:  `count_over_time({app="a"} | unwrap latency [1m])`,
+			err: true,
+		},
+		{
+			in:  `avg_over_time(0.5, {app="a"} | unwrap latency [1m])`,
+			err: true,
+		},
+		{
+			in:  `rate({app="a"}[0d])`,
+			err: true,
+		},
+		{
+			in:  `rate({app="a"}[0w])`,
+			err: true,
+		},
+		{
+			in:  `rate({app="a"}[0s])`,
+			err: true,
+		},
+	} {
+		got, err := logqlParseMetric(test.in)
+		if test.err {
+			require.Error(t, err, test.in)
+			continue
+		}
+		require.NoError(t, err, test.in)
+		clearQuery(got)
+		require.Equal(t, test.want, got, test.in)
+	}
+}
+
+func TestReadMetric(t *testing.T) {
+	start := time.Unix(1000, 0)
+	var r testReader
+	for i := 0; i < 6; i++ {
+		for _, host := range []string{"a", "b"} {
+			if host == "b" && i%2 == 1 {
+				continue
+			}
+			r = append(r, storage.LogEntry{
+				Labels: map[string]string{"app": "x", "host": host},
+				Time:   start.Add(time.Duration(i) * 10 * time.Second),
+				Data:   []byte("data" + host),
+			})
+		}
+	}
+	end := start.Add(50 * time.Second)
+
+	for _, test := range []struct {
+		query string
+		want  []*Matrix
+	}{
+		{
+			query: `count_over_time({app="x", host="a"}[20s])`,
+			want: []*Matrix{
+				{
+					Metric: map[string]string{"app": "x", "host": "a"},
+					Values: [][]interface{}{
+						{float64(1000), "1"},
+						{float64(1020), "2"},
+						{float64(1040), "2"},
+					},
+				},
+			},
+		},
+		{
+			query: `sum by (app) (count_over_time({app="x"} |= "data" [20s]))`,
+			want: []*Matrix{
+				{
+					Metric: map[string]string{"app": "x"},
+					Values: [][]interface{}{
+						{float64(1000), "2"},
+						{float64(1020), "3"},
+						{float64(1040), "3"},
+					},
+				},
+			},
+		},
+		{
+			query: `sum without (host) (bytes_rate({app="x"}[20s]))`,
+			want: []*Matrix{
+				{
+					Metric: map[string]string{"app": "x"},
+					Values: [][]interface{}{
+						{float64(1000), "0.5"},
+						{float64(1020), "0.75"},
+						{float64(1040), "0.75"},
+					},
+				},
+			},
+		},
+		{
+			query: `topk(1, count_over_time({app="x"}[20s]))`,
+			want: []*Matrix{
+				{
+					Metric: map[string]string{"app": "x", "host": "a"},
+					Values: [][]interface{}{
+						{float64(1000), "1"},
+						{float64(1020), "2"},
+						{float64(1040), "2"},
+					},
+				},
+			},
+		},
+		{
+			query: `absent_over_time({app="y"}[20s])`,
+			want: []*Matrix{
+				{
+					Metric: map[string]string{"app": "y"},
+					Values: [][]interface{}{
+						{float64(1000), "1"},
+						{float64(1020), "1"},
+						{float64(1040), "1"},
+					},
+				},
+			},
+		},
+	} {
+		m, err := logqlParseMetric(test.query)
+		require.NoError(t, err)
+		got, err := logqlReadMetric(context.Background(), r, m, start, end, 20*time.Second)
+		require.NoError(t, err)
+		require.Equal(t, test.want, got, test.query)
+	}
+}
+
+func TestReadMetricUnwrap(t *testing.T) {
+	start := time.Unix(1000, 0)
+	var r testReader
+	for i, data := range []string{
+		`{"latency": 1}`,
+		`{"latency": "3"}`,
+		`{"latency": 2}`,
+		`{"latency": 2}`,
+		`{"latency": "slow"}`,
+		`{"latency": 6}`,
+		`plain text`,
+	} {
+		r = append(r, storage.LogEntry{
+			Labels: map[string]string{"app": "x"},
+			Time:   start.Add(time.Duration(i) * 10 * time.Second),
+			Data:   []byte(data),
+		})
+	}
+	end := start.Add(50 * time.Second)
+
+	for _, test := range []struct {
+		query string
+		want  []string
+	}{
+		{
+			query: `sum_over_time({app="x"} | unwrap latency [30s])`,
+			want:  []string{"6", "8"},
+		},
+		{
+			query: `avg_over_time({app="x"} | unwrap latency [30s])`,
+			want:  []string{"2", "4"},
+		},
+		{
+			query: `max_over_time({app="x"} | unwrap latency [30s])`,
+			want:  []string{"3", "6"},
+		},
+		{
+			query: `min_over_time({app="x"} | unwrap latency [30s])`,
+			want:  []string{"1", "2"},
+		},
+		{
+			query: `quantile_over_time(0.5, {app="x"} | unwrap latency [30s])`,
+			want:  []string{"2", "4"},
+		},
+		{
+			query: `stddev_over_time({app="x"} | unwrap latency [30s])`,
+			want:  []string{"0.816496580927726", "2"},
+		},
+	} {
+		m, err := logqlParseMetric(test.query)
+		require.NoError(t, err)
+		got, err := logqlReadMetric(context.Background(), r, m, start.Add(20*time.Second), end, 30*time.Second)
+		require.NoError(t, err)
+		require.Len(t, got, 1)
+		var values []string
+		for _, v := range got[0].Values {
+			values = append(values, v[1].(string))
+		}
+		require.Equal(t, test.want, values, test.query)
+	}
+}
+
+func TestReadInstant(t *testing.T) {
+	ts := time.Unix(1000, 0)
+	r := testReader{
+		{Labels: map[string]string{"app": "x"}, Time: ts.Add(-time.Second), Data: []byte("a")},
+		{Labels: map[string]string{"app": "y"}, Time: ts.Add(-2 * time.Second), Data: []byte("b")},
+		{Labels: map[string]string{"app": "y"}, Time: ts.Add(time.Second), Data: []byte("c")},
+	}
+	for _, test := range []struct {
+		query string
+		want  interface{}
+	}{
+		{
+			query: `count_over_time({app=~".+"}[10s])`,
+			want: []*Vector{
+				{Metric: map[string]string{"app": "x"}, Value: []interface{}{float64(1000), "1"}},
+				{Metric: map[string]string{"app": "y"}, Value: []interface{}{float64(1000), "1"}},
+			},
+		},
+		{
+			query: `count_over_time({app="z"}[10s])`,
+			want:  []*Vector{},
+		},
+		{
+			query: `1 + 2`,
+			want:  Scalar{float64(1000), "3"},
+		},
+	} {
+		m, err := logqlParseMetric(test.query)
+		require.NoError(t, err, test.query)
+		got, err := logqlReadInstant(context.Background(), r, m, ts)
+		require.NoError(t, err, test.query)
+		require.Equal(t, test.want, got, test.query)
+	}
+}
+
+func TestParseTime(t *testing.T) {
+	for _, in := range []string{"1000", "1000000000000", "1000.0", "1970-01-01T00:16:40Z"} {
+		got, err := parseTime(in)
+		require.NoError(t, err, in)
+		require.True(t, got.Equal(time.Unix(1000, 0)), in)
+	}
+	got, err := parseTime("1700000000")
+	require.NoError(t, err)
+	require.True(t, got.Equal(time.Unix(1700000000, 0)))
+	got, err = parseTime("1700000000000000000")
+	require.NoError(t, err)
+	require.True(t, got.Equal(time.Unix(1700000000, 0)))
+	_, err = parseTime("yesterday")
+	require.Error(t, err)
+}
+
+// blockReader offers each block to SummaryFunc before reading its entries.
+type blockReader [][]storage.LogEntry
+
+func (r blockReader) Read(ctx context.Context, opts *storage.ReadOptions) error {
+	for _, es := range r {
+		if opts.SummaryFunc != nil && !opts.SummaryFunc(storage.LogSummary{
+			Labels: es[0].Labels,
+			Start:  es[0].Time,
+			End:    es[len(es)-1].Time,
+			Count:  uint64(len(es)),
+		}) {
+			continue
+		}
+		err := testReader(es).Read(ctx, opts)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func TestReadMetricSummary(t *testing.T) {
+	start := time.Unix(1000, 0)
+	labels := map[string]string{"app": "x"}
+	entry := func(sec int) storage.LogEntry {
+		return storage.LogEntry{Labels: labels, Time: time.Unix(int64(sec), 0), Data: []byte("x")}
+	}
+	// the first block lies in one window, the second cuts through two.
+	r := blockReader{
+		{entry(1005), entry(1015)},
+		{entry(1015), entry(1025)},
+	}
+	m, err := logqlParseMetric(`count_over_time({app="x"}[20s])`)
+	require.NoError(t, err)
+	got, err := logqlReadMetric(context.Background(), r, m, start, start.Add(40*time.Second), 20*time.Second)
+	require.NoError(t, err)
+	require.Equal(t, []*Matrix{
+		{
+			Metric: labels,
+			Values: [][]interface{}{
+				{float64(1020), "3"},
+				{float64(1040), "1"},
+			},
+		},
+	}, got)
+
+	second := int64(time.Second)
+	for _, test := range []struct {
+		lo, hi int64
+		want   bool
+	}{
+		{1005, 1015, true},
+		{1015, 1025, false},
+		{1001, 1020, true},
+		{1000, 1010, false},
+		{1041, 1050, true},
+		{900, 950, true},
+	} {
+		require.Equal(t, test.want, logqlWholeWindows(test.lo*second, test.hi*second, 1000*second, 20*time.Second, 20*time.Second, 3), test.lo)
+	}
+}
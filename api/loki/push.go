@@ -0,0 +1,224 @@
+package loki
+
+import (
+	"compress/gzip"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/klauspost/compress/s2"
+	"google.golang.org/protobuf/encoding/protowire"
+)
+
+var (
+	errUnsupportedContentType = errors.New("push: unsupported content type")
+	errInvalidLabels          = errors.New("push: invalid labels")
+)
+
+func decodePushRequest(r *http.Request) (*PushRequest, error) {
+	contentType := r.Header.Get("Content-Type")
+	if contentType == "" {
+		contentType = "application/json"
+	}
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return nil, err
+	}
+	var body io.Reader = r.Body
+	if r.Header.Get("Content-Encoding") == "gzip" {
+		gr, err := gzip.NewReader(r.Body)
+		if err != nil {
+			return nil, err
+		}
+		defer gr.Close()
+		body = gr
+	}
+	switch mediaType {
+	case "application/json":
+		var pr PushRequest
+		err := json.NewDecoder(body).Decode(&pr)
+		if err != nil {
+			return nil, err
+		}
+		return &pr, nil
+	case "application/x-protobuf":
+		b, err := io.ReadAll(body)
+		if err != nil {
+			return nil, err
+		}
+		b, err = s2.Decode(nil, b)
+		if err != nil {
+			return nil, err
+		}
+		return decodeProtoPushRequest(b)
+	default:
+		return nil, fmt.Errorf("%w %s", errUnsupportedContentType, mediaType)
+	}
+}
+
+// https://github.com/grafana/loki/blob/main/pkg/push/push.proto
+func decodeProtoPushRequest(b []byte) (*PushRequest, error) {
+	var pr PushRequest
+	err := protoRange(b, func(num protowire.Number, typ protowire.Type, val []byte) error {
+		if num != 1 || typ != protowire.BytesType {
+			return nil
+		}
+		stream, err := decodeProtoStream(val)
+		if err != nil {
+			return err
+		}
+		pr.Streams = append(pr.Streams, stream)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &pr, nil
+}
+
+func decodeProtoStream(b []byte) (*Stream, error) {
+	var stream Stream
+	err := protoRange(b, func(num protowire.Number, typ protowire.Type, val []byte) error {
+		if typ != protowire.BytesType {
+			return nil
+		}
+		switch num {
+		case 1:
+			labels, err := parseLabels(string(val))
+			if err != nil {
+				return err
+			}
+			stream.Stream = labels
+		case 2:
+			v, err := decodeProtoEntry(val)
+			if err != nil {
+				return err
+			}
+			stream.Values = append(stream.Values, v)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &stream, nil
+}
+
+func decodeProtoEntry(b []byte) ([]string, error) {
+	var nsec int64
+	var line string
+	err := protoRange(b, func(num protowire.Number, typ protowire.Type, val []byte) error {
+		if typ != protowire.BytesType {
+			return nil
+		}
+		switch num {
+		case 1:
+			n, err := decodeProtoTimestamp(val)
+			if err != nil {
+				return err
+			}
+			nsec = n
+		case 2:
+			line = string(val)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return []string{strconv.FormatInt(nsec, 10), line}, nil
+}
+
+func decodeProtoTimestamp(b []byte) (int64, error) {
+	var sec, nsec int64
+	err := protoRange(b, func(num protowire.Number, typ protowire.Type, val []byte) error {
+		if typ != protowire.VarintType {
+			return nil
+		}
+		n, _ := protowire.ConsumeVarint(val)
+		switch num {
+		case 1:
+			sec = int64(n)
+		case 2:
+			nsec = int64(int32(n))
+		}
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+	return sec*1e9 + nsec, nil
+}
+
+// protoRange calls f for every field in b. Length-delimited fields are passed
+// without their length prefix, other fields are passed in their wire encoding.
+func protoRange(b []byte, f func(protowire.Number, protowire.Type, []byte) error) error {
+	for len(b) > 0 {
+		num, typ, n := protowire.ConsumeTag(b)
+		if n < 0 {
+			return protowire.ParseError(n)
+		}
+		b = b[n:]
+		val := b
+		switch typ {
+		case protowire.BytesType:
+			val, n = protowire.ConsumeBytes(b)
+		default:
+			n = protowire.ConsumeFieldValue(num, typ, b)
+			if n >= 0 {
+				val = b[:n]
+			}
+		}
+		if n < 0 {
+			return protowire.ParseError(n)
+		}
+		b = b[n:]
+		err := f(num, typ, val)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// parseLabels parses labels in prometheus text format, e.g. {app="test", role="test2"}.
+func parseLabels(s string) (map[string]string, error) {
+	s = strings.TrimSpace(s)
+	if !(strings.HasPrefix(s, "{") && strings.HasSuffix(s, "}")) {
+		return nil, fmt.Errorf("%w %s", errInvalidLabels, s)
+	}
+	rest := s[1 : len(s)-1]
+	labels := make(map[string]string)
+	for {
+		rest = strings.TrimSpace(rest)
+		if rest == "" {
+			break
+		}
+		i := strings.IndexByte(rest, '=')
+		if i < 0 {
+			return nil, fmt.Errorf("%w %s", errInvalidLabels, s)
+		}
+		key := strings.TrimSpace(rest[:i])
+		rest = strings.TrimSpace(rest[i+1:])
+		quoted, err := strconv.QuotedPrefix(rest)
+		if err != nil {
+			return nil, fmt.Errorf("%w %s", errInvalidLabels, s)
+		}
+		val, err := strconv.Unquote(quoted)
+		if err != nil {
+			return nil, fmt.Errorf("%w %s", errInvalidLabels, s)
+		}
+		labels[key] = val
+		rest = strings.TrimSpace(rest[len(quoted):])
+		if !strings.HasPrefix(rest, ",") && rest != "" {
+			return nil, fmt.Errorf("%w %s", errInvalidLabels, s)
+		}
+		rest = strings.TrimPrefix(rest, ",")
+	}
+	return labels, nil
+}
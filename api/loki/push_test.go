@@ -0,0 +1,116 @@
+package loki
+
+import (
+	"bytes"
+	"compress/gzip"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/klauspost/compress/s2"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/encoding/protowire"
+)
+
+func TestParseLabels(t *testing.T) {
+	for _, test := range []struct {
+		in   string
+		want map[string]string
+	}{
+		{
+			in:   `{}`,
+			want: map[string]string{},
+		},
+		{
+			in:   `{app="test"}`,
+			want: map[string]string{"app": "test"},
+		},
+		{
+			in:   `{app="test", role="test2"}`,
+			want: map[string]string{"app": "test", "role": "test2"},
+		},
+		{
+			in:   `{msg="a \"quoted\", value"}`,
+			want: map[string]string{"msg": `a "quoted", value`},
+		},
+	} {
+		got, err := parseLabels(test.in)
+		require.NoError(t, err)
+		require.Equal(t, test.want, got)
+	}
+
+	for _, in := range []string{``, `app="test"`, `{app=test}`, `{app="test" role="test2"}`} {
+		_, err := parseLabels(in)
+		require.ErrorIs(t, err, errInvalidLabels)
+	}
+}
+
+func TestDecodePushRequest(t *testing.T) {
+	want := &PushRequest{
+		Streams: []*Stream{
+			{
+				Stream: map[string]string{"app": "test"},
+				Values: [][]string{
+					{"1670000000000000001", `{"test":1}`},
+					{"1670000000123456789", `plain text`},
+				},
+			},
+		},
+	}
+
+	appendTimestamp := func(b []byte, sec, nsec uint64) []byte {
+		var ts []byte
+		ts = protowire.AppendTag(ts, 1, protowire.VarintType)
+		ts = protowire.AppendVarint(ts, sec)
+		ts = protowire.AppendTag(ts, 2, protowire.VarintType)
+		ts = protowire.AppendVarint(ts, nsec)
+		b = protowire.AppendTag(b, 1, protowire.BytesType)
+		return protowire.AppendBytes(b, ts)
+	}
+	var entry1, entry2, stream, pr []byte
+	entry1 = appendTimestamp(entry1, 1670000000, 1)
+	entry1 = protowire.AppendTag(entry1, 2, protowire.BytesType)
+	entry1 = protowire.AppendString(entry1, `{"test":1}`)
+	entry2 = appendTimestamp(entry2, 1670000000, 123456789)
+	entry2 = protowire.AppendTag(entry2, 2, protowire.BytesType)
+	entry2 = protowire.AppendString(entry2, `plain text`)
+	stream = protowire.AppendTag(stream, 1, protowire.BytesType)
+	stream = protowire.AppendString(stream, `{app="test"}`)
+	stream = protowire.AppendTag(stream, 2, protowire.BytesType)
+	stream = protowire.AppendBytes(stream, entry1)
+	stream = protowire.AppendTag(stream, 2, protowire.BytesType)
+	stream = protowire.AppendBytes(stream, entry2)
+	stream = protowire.AppendTag(stream, 3, protowire.VarintType)
+	stream = protowire.AppendVarint(stream, 42)
+	pr = protowire.AppendTag(pr, 1, protowire.BytesType)
+	pr = protowire.AppendBytes(pr, stream)
+
+	r := httptest.NewRequest("POST", "/loki/api/v1/push", bytes.NewReader(s2.EncodeSnappy(nil, pr)))
+	r.Header.Set("Content-Type", "application/x-protobuf")
+	got, err := decodePushRequest(r)
+	require.NoError(t, err)
+	require.Equal(t, want, got)
+
+	jsonBody := `{"streams":[{"stream":{"app":"test"},"values":[["1670000000000000001","{\"test\":1}"],["1670000000123456789","plain text"]]}]}`
+	r = httptest.NewRequest("POST", "/loki/api/v1/push", bytes.NewReader([]byte(jsonBody)))
+	r.Header.Set("Content-Type", "application/json")
+	got, err = decodePushRequest(r)
+	require.NoError(t, err)
+	require.Equal(t, want, got)
+
+	buf := new(bytes.Buffer)
+	gw := gzip.NewWriter(buf)
+	_, err = gw.Write([]byte(jsonBody))
+	require.NoError(t, err)
+	require.NoError(t, gw.Close())
+	r = httptest.NewRequest("POST", "/loki/api/v1/push", buf)
+	r.Header.Set("Content-Type", "application/json")
+	r.Header.Set("Content-Encoding", "gzip")
+	got, err = decodePushRequest(r)
+	require.NoError(t, err)
+	require.Equal(t, want, got)
+
+	r = httptest.NewRequest("POST", "/loki/api/v1/push", bytes.NewReader(nil))
+	r.Header.Set("Content-Type", "text/plain")
+	_, err = decodePushRequest(r)
+	require.ErrorIs(t, err, errUnsupportedContentType)
+}
@@ -0,0 +1,445 @@
+package loki
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"text/template"
+
+	"github.com/commentlens/loghouse/api/loki/logql/parser/bsr"
+	"github.com/commentlens/loghouse/storage"
+	"github.com/tidwall/gjson"
+)
+
+var (
+	errInvalidPattern = errors.New("logql: invalid pattern")
+)
+
+func logqlParserStage(node bsr.BSR) (func(e *storage.LogEntry) bool, error) {
+	op := node.GetTChildI(1).LiteralString()
+	switch op {
+	case "json":
+		return func(e *storage.LogEntry) bool {
+			logqlExtractLabels(e, logqlJSON(e.Data))
+			return true
+		}, nil
+	case "logfmt":
+		return func(e *storage.LogEntry) bool {
+			logqlExtractLabels(e, logqlLogfmt(e.Data))
+			return true
+		}, nil
+	}
+	val, err := logqlUnquote(node.GetTChildI(2).LiteralString())
+	if err != nil {
+		return nil, err
+	}
+	switch op {
+	case "regexp":
+		re, err := regexp.Compile(val)
+		if err != nil {
+			return nil, fmt.Errorf("logql: %w", err)
+		}
+		var named bool
+		for _, name := range re.SubexpNames() {
+			if name != "" {
+				named = true
+			}
+		}
+		if !named {
+			return nil, fmt.Errorf("logql: regexp %s has no named group", val)
+		}
+		return func(e *storage.LogEntry) bool {
+			m := re.FindSubmatch(e.Data)
+			if m == nil {
+				return true
+			}
+			extracted := make(map[string]string)
+			for i, name := range re.SubexpNames() {
+				if name != "" {
+					extracted[name] = string(m[i])
+				}
+			}
+			logqlExtractLabels(e, extracted)
+			return true
+		}, nil
+	default:
+		p, err := logqlParsePattern(val)
+		if err != nil {
+			return nil, err
+		}
+		return func(e *storage.LogEntry) bool {
+			logqlExtractLabels(e, p.match(string(e.Data)))
+			return true
+		}, nil
+	}
+}
+
+func logqlLabelFilter(key, op, val string, isNum bool) (func(e *storage.LogEntry) bool, error) {
+	var fVal float64
+	switch op {
+	case "=~", "!~":
+		if isNum {
+			return nil, fmt.Errorf("logql: %s requires a string", op)
+		}
+	case "=", "==", "!=":
+	default:
+		isNum = true
+	}
+	if isNum {
+		var err error
+		fVal, err = strconv.ParseFloat(val, 64)
+		if err != nil {
+			return nil, fmt.Errorf("logql: %w", err)
+		}
+		return func(e *storage.LogEntry) bool {
+			v, ok := e.Labels[key]
+			if !ok {
+				return false
+			}
+			f, err := strconv.ParseFloat(v, 64)
+			if err != nil {
+				return false
+			}
+			switch op {
+			case "=", "==":
+				return f == fVal
+			case "!=":
+				return f != fVal
+			case ">=":
+				return f >= fVal
+			case ">":
+				return f > fVal
+			case "<=":
+				return f <= fVal
+			default:
+				return f < fVal
+			}
+		}, nil
+	}
+	switch op {
+	case "=~", "!~":
+		re, err := regexp.Compile("^(?:" + val + ")$")
+		if err != nil {
+			return nil, fmt.Errorf("logql: %w", err)
+		}
+		return func(e *storage.LogEntry) bool {
+			return re.MatchString(e.Labels[key]) == (op == "=~")
+		}, nil
+	default:
+		return func(e *storage.LogEntry) bool {
+			return (e.Labels[key] == val) == (op != "!=")
+		}, nil
+	}
+}
+
+var logqlTemplateFuncs = template.FuncMap{
+	"ToLower":    strings.ToLower,
+	"ToUpper":    strings.ToUpper,
+	"Replace":    strings.Replace,
+	"Trim":       strings.Trim,
+	"TrimLeft":   strings.TrimLeft,
+	"TrimRight":  strings.TrimRight,
+	"TrimPrefix": strings.TrimPrefix,
+	"TrimSuffix": strings.TrimSuffix,
+	"TrimSpace":  strings.TrimSpace,
+	"lower":      strings.ToLower,
+	"upper":      strings.ToUpper,
+	"contains":   func(substr, s string) bool { return strings.Contains(s, substr) },
+	"hasPrefix":  func(prefix, s string) bool { return strings.HasPrefix(s, prefix) },
+	"hasSuffix":  func(suffix, s string) bool { return strings.HasSuffix(s, suffix) },
+}
+
+func logqlTemplate(s string) (*template.Template, error) {
+	tmpl, err := template.New("").Option("missingkey=zero").Funcs(logqlTemplateFuncs).Parse(s)
+	if err != nil {
+		return nil, fmt.Errorf("logql: %w", err)
+	}
+	return tmpl, nil
+}
+
+func logqlExecute(tmpl *template.Template, e *storage.LogEntry) (string, bool) {
+	var b strings.Builder
+	err := tmpl.Execute(&b, e.Labels)
+	if err != nil {
+		return "", false
+	}
+	return b.String(), true
+}
+
+// logqlFormatStage rewrites the line or labels of an entry with text/template,
+// given the entry labels as data. An entry is left unchanged if its template
+// fails.
+func logqlFormatStage(node bsr.BSR) (func(e *storage.LogEntry) bool, error) {
+	if node.Alternate() == 0 {
+		val, err := logqlUnquote(node.GetTChildI(2).LiteralString())
+		if err != nil {
+			return nil, err
+		}
+		tmpl, err := logqlTemplate(val)
+		if err != nil {
+			return nil, err
+		}
+		return func(e *storage.LogEntry) bool {
+			if line, ok := logqlExecute(tmpl, e); ok {
+				e.Data = storage.LogEntryData(line)
+			}
+			return true
+		}, nil
+	}
+	type labelFormat struct {
+		dst  string
+		src  string
+		tmpl *template.Template
+	}
+	var formats []labelFormat
+	seen := make(map[string]bool)
+	node = node.GetNTChildI(2)
+	for {
+		format := node.GetNTChildI(0)
+		lf := labelFormat{dst: format.GetNTChildI(0).GetTChildI(0).LiteralString()}
+		if seen[lf.dst] {
+			return nil, fmt.Errorf("logql: label_format %s set twice", lf.dst)
+		}
+		seen[lf.dst] = true
+		val := format.GetNTChildI(2)
+		if val.Alternate() == 0 {
+			lf.src = val.GetNTChildI(0).GetTChildI(0).LiteralString()
+		} else {
+			s, err := logqlUnquote(val.GetTChildI(0).LiteralString())
+			if err != nil {
+				return nil, err
+			}
+			lf.tmpl, err = logqlTemplate(s)
+			if err != nil {
+				return nil, err
+			}
+		}
+		formats = append(formats, lf)
+		if node.Alternate() == 0 {
+			break
+		}
+		node = node.GetNTChildI(2)
+	}
+	return func(e *storage.LogEntry) bool {
+		labels := make(map[string]string, len(e.Labels))
+		for k, v := range e.Labels {
+			labels[k] = v
+		}
+		for _, lf := range formats {
+			if lf.tmpl != nil {
+				if v, ok := logqlExecute(lf.tmpl, e); ok {
+					labels[lf.dst] = v
+				}
+				continue
+			}
+			v, ok := e.Labels[lf.src]
+			if !ok {
+				continue
+			}
+			if !seen[lf.src] {
+				delete(labels, lf.src)
+			}
+			labels[lf.dst] = v
+		}
+		e.Labels = labels
+		return true
+	}, nil
+}
+
+func logqlSanitizeLabel(k string) string {
+	return strings.Map(func(r rune) rune {
+		if r == '_' || 'a' <= r && r <= 'z' || 'A' <= r && r <= 'Z' || '0' <= r && r <= '9' {
+			return r
+		}
+		return '_'
+	}, k)
+}
+
+// logqlExtractLabels adds extracted labels to a copy of the entry labels.
+// Labels clashing with stream labels get an _extracted suffix.
+func logqlExtractLabels(e *storage.LogEntry, extracted map[string]string) {
+	if len(extracted) == 0 {
+		return
+	}
+	labels := make(map[string]string, len(e.Labels)+len(extracted))
+	for k, v := range e.Labels {
+		labels[k] = v
+	}
+	for k, v := range extracted {
+		k = logqlSanitizeLabel(k)
+		if k == "" {
+			continue
+		}
+		if _, ok := e.Labels[k]; ok {
+			k += "_extracted"
+		}
+		labels[k] = v
+	}
+	e.Labels = labels
+}
+
+// logqlJSON flattens nested objects with _ and skips arrays.
+func logqlJSON(data []byte) map[string]string {
+	if !gjson.ValidBytes(data) {
+		return nil
+	}
+	root := gjson.ParseBytes(data)
+	if !root.IsObject() {
+		return nil
+	}
+	extracted := make(map[string]string)
+	var walk func(prefix string, v gjson.Result)
+	walk = func(prefix string, v gjson.Result) {
+		v.ForEach(func(key, value gjson.Result) bool {
+			k := key.String()
+			if prefix != "" {
+				k = prefix + "_" + k
+			}
+			switch {
+			case value.IsObject():
+				walk(k, value)
+			case value.IsArray():
+			default:
+				extracted[k] = value.String()
+			}
+			return true
+		})
+	}
+	walk("", root)
+	return extracted
+}
+
+func logqlLogfmt(data []byte) map[string]string {
+	extracted := make(map[string]string)
+	s := string(data)
+	for len(s) > 0 {
+		s = strings.TrimLeft(s, " \t\n")
+		i := strings.IndexAny(s, "= \t\n")
+		if i < 0 {
+			i = len(s)
+		}
+		key := s[:i]
+		s = s[i:]
+		if !strings.HasPrefix(s, "=") {
+			if key != "" {
+				extracted[key] = ""
+			}
+			continue
+		}
+		s = s[1:]
+		var val string
+		if strings.HasPrefix(s, `"`) {
+			j := 1
+			for j < len(s) && s[j] != '"' {
+				if s[j] == '\\' {
+					j++
+				}
+				j++
+			}
+			if j >= len(s) {
+				j = len(s) - 1
+			}
+			uq, err := strconv.Unquote(s[:j+1])
+			if err != nil {
+				uq = strings.Trim(s[:j+1], `"`)
+			}
+			val = uq
+			s = s[j+1:]
+		} else {
+			j := strings.IndexAny(s, " \t\n")
+			if j < 0 {
+				j = len(s)
+			}
+			val = s[:j]
+			s = s[j:]
+		}
+		if key != "" {
+			extracted[key] = val
+		}
+	}
+	return extracted
+}
+
+type logqlPatternPart struct {
+	literal string
+	capture string
+}
+
+type logqlPattern []logqlPatternPart
+
+func isPatternName(s string) bool {
+	if s == "" {
+		return false
+	}
+	for i, r := range s {
+		if !(r == '_' || 'a' <= r && r <= 'z' || 'A' <= r && r <= 'Z' || i > 0 && '0' <= r && r <= '9') {
+			return false
+		}
+	}
+	return true
+}
+
+// logqlParsePattern parses captures like <name> and literals in between.
+// <_> captures without extracting.
+func logqlParsePattern(s string) (logqlPattern, error) {
+	var p logqlPattern
+	var literal strings.Builder
+	var named bool
+	for len(s) > 0 {
+		if s[0] == '<' {
+			if i := strings.IndexByte(s, '>'); i > 0 && isPatternName(s[1:i]) {
+				if literal.Len() > 0 {
+					p = append(p, logqlPatternPart{literal: literal.String()})
+					literal.Reset()
+				}
+				if len(p) > 0 && p[len(p)-1].capture != "" {
+					return nil, fmt.Errorf("%w: consecutive captures", errInvalidPattern)
+				}
+				name := s[1:i]
+				if name != "_" {
+					named = true
+				}
+				p = append(p, logqlPatternPart{capture: name})
+				s = s[i+1:]
+				continue
+			}
+		}
+		literal.WriteByte(s[0])
+		s = s[1:]
+	}
+	if literal.Len() > 0 {
+		p = append(p, logqlPatternPart{literal: literal.String()})
+	}
+	if !named {
+		return nil, fmt.Errorf("%w: no named capture", errInvalidPattern)
+	}
+	return p, nil
+}
+
+func (p logqlPattern) match(line string) map[string]string {
+	extracted := make(map[string]string)
+	for i, part := range p {
+		if part.capture == "" {
+			if !strings.HasPrefix(line, part.literal) {
+				return nil
+			}
+			line = line[len(part.literal):]
+			continue
+		}
+		val := line
+		if i+1 < len(p) {
+			j := strings.Index(line, p[i+1].literal)
+			if j < 0 {
+				return nil
+			}
+			val = line[:j]
+		}
+		line = line[len(val):]
+		if part.capture != "_" {
+			extracted[part.capture] = val
+		}
+	}
+	return extracted
+}
@@ -0,0 +1,215 @@
+package loki
+
+import (
+	"context"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/commentlens/loghouse/storage"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLogfmt(t *testing.T) {
+	require.Equal(t, map[string]string{
+		"level":  "info",
+		"msg":    `hello "world"`,
+		"status": "200",
+		"debug":  "",
+		"empty":  "",
+	}, logqlLogfmt([]byte(`level=info msg="hello \"world\"" status=200 debug empty=`)))
+}
+
+func TestJSON(t *testing.T) {
+	require.Equal(t, map[string]string{
+		"level":         "info",
+		"req_status":    "200",
+		"req_header_ua": "curl",
+	}, logqlJSON([]byte(`{"level":"info","req":{"status":200,"header":{"ua":"curl"}},"tags":["a"]}`)))
+	require.Nil(t, logqlJSON([]byte(`plain text`)))
+}
+
+func TestPattern(t *testing.T) {
+	p, err := logqlParsePattern(`<ip> - <_> [<ts>] "<method> <path> <_>" <status>`)
+	require.NoError(t, err)
+	require.Equal(t, map[string]string{
+		"ip":     "1.2.3.4",
+		"ts":     "10/Oct/2000:13:55:36",
+		"method": "GET",
+		"path":   "/index.html",
+		"status": "200 2326",
+	}, p.match(`1.2.3.4 - frank [10/Oct/2000:13:55:36] "GET /index.html HTTP/1.0" 200 2326`))
+	require.Nil(t, p.match(`garbage`))
+
+	for _, in := range []string{`<a><b>`, `<_> foo`, `no captures`} {
+		_, err := logqlParsePattern(in)
+		require.Error(t, err, in)
+	}
+}
+
+func TestReadParserStage(t *testing.T) {
+	r := testReader{
+		{
+			Labels: map[string]string{"app": "x"},
+			Time:   time.Unix(1, 0),
+			Data:   []byte(`{"status": 500, "app": "y", "path": "/a"}`),
+		},
+		{
+			Labels: map[string]string{"app": "x"},
+			Time:   time.Unix(2, 0),
+			Data:   []byte(`{"status": 200, "path": "/b"}`),
+		},
+		{
+			Labels: map[string]string{"app": "x"},
+			Time:   time.Unix(3, 0),
+			Data:   []byte(`status=503 path=/c`),
+		},
+		{
+			Labels: map[string]string{"app": "x"},
+			Time:   time.Unix(4, 0),
+			Data:   []byte(`GET /d 502`),
+		},
+	}
+	for _, test := range []struct {
+		query string
+		want  []map[string]string
+	}{
+		{
+			query: `{app="x"} | json | status >= 500`,
+			want: []map[string]string{
+				{"app": "x", "app_extracted": "y", "status": "500", "path": "/a"},
+			},
+		},
+		{
+			query: `{app="x"} | logfmt | status > 500 | path = "/c"`,
+			want: []map[string]string{
+				{"app": "x", "status": "503", "path": "/c"},
+			},
+		},
+		{
+			query: `{app="x"} |= "GET" | regexp "(?P<method>\\w+) (?P<path>\\S+)" | path =~ "/d"`,
+			want: []map[string]string{
+				{"app": "x", "method": "GET", "path": "/d"},
+			},
+		},
+		{
+			query: `{app="x"} | pattern "<_> <path> <status>" | status == 502`,
+			want: []map[string]string{
+				{"app": "x", "path": "/d", "status": "502"},
+			},
+		},
+		{
+			query: `{app="x"} | json | path != "/a"`,
+			want: []map[string]string{
+				{"app": "x", "status": "200", "path": "/b"},
+				{"app": "x"},
+				{"app": "x"},
+			},
+		},
+	} {
+		var got []storage.LogEntry
+		err := logqlRead(context.Background(), r, &storage.ReadOptions{
+			ResultFunc: func(e storage.LogEntry) {
+				got = append(got, e)
+			},
+		}, test.query)
+		require.NoError(t, err, test.query)
+		sort.Slice(got, func(i, j int) bool { return got[i].Time.Before(got[j].Time) })
+		var labels []map[string]string
+		for _, e := range got {
+			labels = append(labels, e.Labels)
+		}
+		require.Equal(t, test.want, labels, test.query)
+	}
+	for _, e := range r {
+		require.Equal(t, map[string]string{"app": "x"}, e.Labels)
+	}
+}
+
+func TestReadMetricParserStage(t *testing.T) {
+	start := time.Unix(1000, 0)
+	var r testReader
+	for i, status := range []string{"200", "500", "200", "404"} {
+		r = append(r, storage.LogEntry{
+			Labels: map[string]string{"app": "x"},
+			Time:   start.Add(time.Duration(i) * time.Second),
+			Data:   []byte(`level=info status=` + status),
+		})
+	}
+	query := `sum by (status) (count_over_time({app="x"} | logfmt [10s]))`
+	m, err := logqlParseMetric(query)
+	require.NoError(t, err)
+	got, err := logqlReadMetric(context.Background(), r, m, start.Add(5*time.Second), start.Add(5*time.Second), time.Second)
+	require.NoError(t, err)
+	require.Equal(t, []*Matrix{
+		{Metric: map[string]string{"status": "200"}, Values: [][]interface{}{{float64(1005), "2"}}},
+		{Metric: map[string]string{"status": "404"}, Values: [][]interface{}{{float64(1005), "1"}}},
+		{Metric: map[string]string{"status": "500"}, Values: [][]interface{}{{float64(1005), "1"}}},
+	}, got)
+}
+
+func TestReadFormatStage(t *testing.T) {
+	r := testReader{
+		{
+			Labels: map[string]string{"app": "x"},
+			Time:   time.Unix(1, 0),
+			Data:   []byte(`{"level": "error", "msg": "disk full", "host": "a"}`),
+		},
+		{
+			Labels: map[string]string{"app": "x"},
+			Time:   time.Unix(2, 0),
+			Data:   []byte(`{"level": "info", "msg": "ok"}`),
+		},
+	}
+	for _, test := range []struct {
+		query  string
+		labels []map[string]string
+		lines  []string
+	}{
+		{
+			query: `{app="x"} | json | line_format "{{.level | ToUpper}} {{.msg}}{{if .host}} on {{.host}}{{end}}" |= "ERROR"`,
+			labels: []map[string]string{
+				{"app": "x", "level": "error", "msg": "disk full", "host": "a"},
+			},
+			lines: []string{"ERROR disk full on a"},
+		},
+		{
+			query: `{app="x"} | json | label_format severity=level, app="{{.app}}-{{.level}}", msg=msg`,
+			labels: []map[string]string{
+				{"app": "x-error", "severity": "error", "msg": "disk full", "host": "a"},
+				{"app": "x-info", "severity": "info", "msg": "ok"},
+			},
+			lines: []string{
+				`{"level": "error", "msg": "disk full", "host": "a"}`,
+				`{"level": "info", "msg": "ok"}`,
+			},
+		},
+	} {
+		var got []storage.LogEntry
+		err := logqlRead(context.Background(), r, &storage.ReadOptions{
+			ResultFunc: func(e storage.LogEntry) {
+				got = append(got, e)
+			},
+		}, test.query)
+		require.NoError(t, err, test.query)
+		sort.Slice(got, func(i, j int) bool { return got[i].Time.Before(got[j].Time) })
+		var labels []map[string]string
+		var lines []string
+		for _, e := range got {
+			labels = append(labels, e.Labels)
+			lines = append(lines, string(e.Data))
+		}
+		require.Equal(t, test.labels, labels, test.query)
+		require.Equal(t, test.lines, lines, test.query)
+	}
+
+	for _, query := range []string{
+		`{app="x"} | line_format "{{.level"`,
+		`{app="x"} | label_format a=b, a=c`,
+	} {
+		err := logqlRead(context.Background(), r, &storage.ReadOptions{
+			ResultFunc: func(storage.LogEntry) {},
+		}, query)
+		require.Error(t, err, query)
+	}
+}
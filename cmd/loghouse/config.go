@@ -0,0 +1,277 @@
+package main
+
+import (
+	"flag"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/commentlens/loghouse/api/loki"
+	"github.com/commentlens/loghouse/storage/chunkio"
+	"github.com/commentlens/loghouse/storage/filesystem"
+	"github.com/commentlens/loghouse/storage/objstore"
+	"github.com/commentlens/loghouse/storage/tenant"
+	"gopkg.in/yaml.v3"
+)
+
+type Config struct {
+	HTTPListen      string `yaml:"http_listen"`
+	DebugListen     string `yaml:"debug_listen"`
+	ReadLimit       uint64 `yaml:"read_limit"`
+	ReadConcurrency int    `yaml:"read_concurrency"`
+	LabelLimit      uint64 `yaml:"label_limit"`
+	// CacheSize bounds the bytes of chunk headers, indices and blocks kept in
+	// memory across tenants.
+	CacheSize int64 `yaml:"cache_size"`
+
+	DataDir                  string        `yaml:"data_dir"`
+	CompactInterval          time.Duration `yaml:"compact_interval"`
+	CompactChunkMinAge       time.Duration `yaml:"compact_chunk_min_age"`
+	CompactChunkMaxAge       time.Duration `yaml:"compact_chunk_max_age"`
+	CompactChunkMinSize      int64         `yaml:"compact_chunk_min_size"`
+	CompactChunkMaxSize      int64         `yaml:"compact_chunk_max_size"`
+	CompactEmptyDirRemoveAge time.Duration `yaml:"compact_empty_dir_remove_age"`
+	CompactChunkRemoveAge    time.Duration `yaml:"compact_chunk_remove_age"`
+	CompactCompression       string        `yaml:"compact_compression"`
+	CompactCompressionLevel  int           `yaml:"compact_compression_level"`
+
+	RecompressAge              time.Duration `yaml:"recompress_age"`
+	RecompressCompression      string        `yaml:"recompress_compression"`
+	RecompressCompressionLevel int           `yaml:"recompress_compression_level"`
+
+	// S3Bucket, if set, receives compacted chunks older than RemoteAge.
+	S3Endpoint        string        `yaml:"s3_endpoint"`
+	S3Region          string        `yaml:"s3_region"`
+	S3Bucket          string        `yaml:"s3_bucket"`
+	S3AccessKeyID     string        `yaml:"s3_access_key_id"`
+	S3SecretAccessKey string        `yaml:"s3_secret_access_key"`
+	RemoteAge         time.Duration `yaml:"remote_age"`
+
+	Retention        []filesystem.RetentionRule `yaml:"retention"`
+	RetentionMaxSize int64                      `yaml:"retention_max_size"`
+
+	MaxTenants   int                     `yaml:"max_tenants"`
+	Limits       LimitsConfig            `yaml:"limits"`
+	TenantLimits map[string]LimitsConfig `yaml:"tenant_limits"`
+
+	cache *filesystem.Cache
+}
+
+type LimitsConfig struct {
+	IngestRate    float64       `yaml:"ingest_rate"`
+	IngestBurst   int           `yaml:"ingest_burst"`
+	MaxStreams    int           `yaml:"max_streams"`
+	MaxQueryLimit uint64        `yaml:"max_query_limit"`
+	MaxQueryRange time.Duration `yaml:"max_query_range"`
+}
+
+func (c LimitsConfig) limits() tenant.Limits {
+	return tenant.Limits(c)
+}
+
+// merge overrides c with the non-zero limits of o.
+func (c LimitsConfig) merge(o LimitsConfig) LimitsConfig {
+	if o.IngestRate != 0 {
+		c.IngestRate = o.IngestRate
+	}
+	if o.IngestBurst != 0 {
+		c.IngestBurst = o.IngestBurst
+	}
+	if o.MaxStreams != 0 {
+		c.MaxStreams = o.MaxStreams
+	}
+	if o.MaxQueryLimit != 0 {
+		c.MaxQueryLimit = o.MaxQueryLimit
+	}
+	if o.MaxQueryRange != 0 {
+		c.MaxQueryRange = o.MaxQueryRange
+	}
+	return c
+}
+
+func defaultConfig() *Config {
+	return &Config{
+		HTTPListen:      ":3100",
+		DebugListen:     ":6060",
+		ReadLimit:       loki.ReadLimit,
+		ReadConcurrency: loki.ReadConcurrency,
+		LabelLimit:      1000,
+		CacheSize:       filesystem.CacheSize,
+
+		DataDir:                  filesystem.DataDir,
+		CompactInterval:          filesystem.CompactInterval,
+		CompactChunkMinAge:       filesystem.CompactChunkMinAge,
+		CompactChunkMaxAge:       filesystem.CompactChunkMaxAge,
+		CompactChunkMinSize:      filesystem.CompactChunkMinSize,
+		CompactChunkMaxSize:      filesystem.CompactChunkMaxSize,
+		CompactEmptyDirRemoveAge: filesystem.CompactEmptyDirRemoveAge,
+		CompactChunkRemoveAge:    filesystem.CompactChunkRemoveAge,
+		CompactCompression:       filesystem.CompactCompression,
+		RemoteAge:                filesystem.RemoteAge,
+
+		MaxTenants: tenant.MaxTenants,
+	}
+}
+
+func (c *Config) flagSet(name string, configFile *string) *flag.FlagSet {
+	fs := flag.NewFlagSet(name, flag.ExitOnError)
+	fs.StringVar(configFile, "config", *configFile, "path to yaml config file")
+	fs.StringVar(&c.HTTPListen, "http-listen", c.HTTPListen, "loki api listen address")
+	fs.StringVar(&c.DebugListen, "debug-listen", c.DebugListen, "pprof listen address, empty to disable")
+	fs.Uint64Var(&c.ReadLimit, "read-limit", c.ReadLimit, "default number of entries returned by a query")
+	fs.IntVar(&c.ReadConcurrency, "read-concurrency", c.ReadConcurrency, "number of chunks read in parallel by a query")
+	fs.Uint64Var(&c.LabelLimit, "label-limit", c.LabelLimit, "max number of values returned per label, 0 for unlimited")
+	fs.Int64Var(&c.CacheSize, "cache-size", c.CacheSize, "size in bytes of the chunk cache, 0 to disable")
+	fs.StringVar(&c.DataDir, "data-dir", c.DataDir, "data directory")
+	fs.DurationVar(&c.CompactInterval, "compact-interval", c.CompactInterval, "interval between compactions")
+	fs.DurationVar(&c.CompactChunkMinAge, "compact-chunk-min-age", c.CompactChunkMinAge, "age after which a chunk may be compacted")
+	fs.DurationVar(&c.CompactChunkMaxAge, "compact-chunk-max-age", c.CompactChunkMaxAge, "age after which a chunk is compacted")
+	fs.Int64Var(&c.CompactChunkMinSize, "compact-chunk-min-size", c.CompactChunkMinSize, "size in bytes after which a chunk may be compacted")
+	fs.Int64Var(&c.CompactChunkMaxSize, "compact-chunk-max-size", c.CompactChunkMaxSize, "size in bytes after which a chunk is compacted")
+	fs.DurationVar(&c.CompactEmptyDirRemoveAge, "compact-empty-dir-remove-age", c.CompactEmptyDirRemoveAge, "age after which empty incompact dirs are removed")
+	fs.DurationVar(&c.CompactChunkRemoveAge, "compact-chunk-remove-age", c.CompactChunkRemoveAge, "age after which streams matching no retention rule are removed")
+	fs.StringVar(&c.CompactCompression, "compact-compression", c.CompactCompression, "compression of compacted chunks: s2 or columnar-<codec>, with codec one of "+strings.Join(chunkio.Codecs(), ", "))
+	fs.IntVar(&c.CompactCompressionLevel, "compact-compression-level", c.CompactCompressionLevel, "codec level of compacted chunks, 0 for the default")
+	fs.DurationVar(&c.RecompressAge, "recompress-age", c.RecompressAge, "age after which compacted chunks are recompressed, 0 to disable")
+	fs.StringVar(&c.RecompressCompression, "recompress-compression", c.RecompressCompression, "compression of recompressed chunks, as compact-compression")
+	fs.IntVar(&c.RecompressCompressionLevel, "recompress-compression-level", c.RecompressCompressionLevel, "codec level of recompressed chunks, 0 for the default")
+	fs.StringVar(&c.S3Endpoint, "s3-endpoint", c.S3Endpoint, "url of the s3-compatible service, like https://s3.amazonaws.com")
+	fs.StringVar(&c.S3Region, "s3-region", c.S3Region, "region of the s3 bucket")
+	fs.StringVar(&c.S3Bucket, "s3-bucket", c.S3Bucket, "s3 bucket of old compacted chunks, empty to keep them on disk")
+	fs.StringVar(&c.S3AccessKeyID, "s3-access-key-id", c.S3AccessKeyID, "s3 access key id")
+	fs.StringVar(&c.S3SecretAccessKey, "s3-secret-access-key", c.S3SecretAccessKey, "s3 secret access key")
+	fs.DurationVar(&c.RemoteAge, "remote-age", c.RemoteAge, "age after which compacted chunks are moved to the s3 bucket")
+	fs.IntVar(&c.MaxTenants, "max-tenants", c.MaxTenants, "tenants created by pushes, 0 to disable")
+	fs.Float64Var(&c.Limits.IngestRate, "limits-ingest-rate", c.Limits.IngestRate, "log bytes per second accepted per tenant, 0 to disable")
+	fs.IntVar(&c.Limits.IngestBurst, "limits-ingest-burst", c.Limits.IngestBurst, "log bytes accepted per tenant in a burst")
+	fs.IntVar(&c.Limits.MaxStreams, "limits-max-streams", c.Limits.MaxStreams, "incompact streams per tenant, 0 to disable")
+	fs.Uint64Var(&c.Limits.MaxQueryLimit, "limits-max-query-limit", c.Limits.MaxQueryLimit, "entries returned by a query per tenant, 0 to disable")
+	fs.DurationVar(&c.Limits.MaxQueryRange, "limits-max-query-range", c.Limits.MaxQueryRange, "time range of a query per tenant, 0 to disable")
+	fs.Int64Var(&c.RetentionMaxSize, "retention-max-size", c.RetentionMaxSize, "size in bytes after which the oldest compacted chunks are removed, 0 to disable")
+	return fs
+}
+
+func envName(flagName string) string {
+	return "LOGHOUSE_" + strings.ToUpper(strings.ReplaceAll(flagName, "-", "_"))
+}
+
+// loadConfig builds the config from defaults, the yaml config file, LOGHOUSE_*
+// environment variables and flags, each overriding the previous. setup, if not
+// nil, registers additional flags.
+func loadConfig(name string, args []string, setup func(*flag.FlagSet)) (*Config, error) {
+	configFile := os.Getenv(envName("config"))
+	fs := defaultConfig().flagSet(name, &configFile)
+	if setup != nil {
+		setup(fs)
+	}
+	err := fs.Parse(args)
+	if err != nil {
+		return nil, err
+	}
+
+	c := defaultConfig()
+	if configFile != "" {
+		b, err := os.ReadFile(configFile)
+		if err != nil {
+			return nil, err
+		}
+		err = yaml.Unmarshal(b, c)
+		if err != nil {
+			return nil, err
+		}
+	}
+	fs = c.flagSet(name, &configFile)
+	if setup != nil {
+		setup(fs)
+	}
+	var envErr error
+	fs.VisitAll(func(f *flag.Flag) {
+		if v, ok := os.LookupEnv(envName(f.Name)); ok && envErr == nil {
+			envErr = fs.Set(f.Name, v)
+		}
+	})
+	if envErr != nil {
+		return nil, envErr
+	}
+	err = fs.Parse(args)
+	if err != nil {
+		return nil, err
+	}
+	err = chunkio.CheckCompression(c.CompactCompression)
+	if err != nil {
+		return nil, err
+	}
+	if c.RecompressCompression != "" {
+		err := chunkio.CheckCompression(c.RecompressCompression)
+		if err != nil {
+			return nil, err
+		}
+	}
+	for i, rule := range c.Retention {
+		if rule.Selector == "" {
+			continue
+		}
+		match, err := loki.ParseSelector(rule.Selector)
+		if err != nil {
+			return nil, err
+		}
+		c.Retention[i].Match = match
+	}
+	return c, nil
+}
+
+func (c *Config) storageOptions(dataDir string) *filesystem.Options {
+	opts := filesystem.NewOptions(dataDir)
+	opts.CompactInterval = c.CompactInterval
+	opts.CompactChunkMinAge = c.CompactChunkMinAge
+	opts.CompactChunkMaxAge = c.CompactChunkMaxAge
+	opts.CompactChunkMinSize = c.CompactChunkMinSize
+	opts.CompactChunkMaxSize = c.CompactChunkMaxSize
+	opts.CompactEmptyDirRemoveAge = c.CompactEmptyDirRemoveAge
+	opts.CompactChunkRemoveAge = c.CompactChunkRemoveAge
+	opts.CompactCompression = c.CompactCompression
+	opts.CompactCompressionLevel = c.CompactCompressionLevel
+	opts.RecompressAge = c.RecompressAge
+	opts.RecompressCompression = c.RecompressCompression
+	opts.RecompressCompressionLevel = c.RecompressCompressionLevel
+	if c.S3Bucket != "" {
+		// tenants keep their chunks under their data dir relative to the root.
+		prefix, err := filepath.Rel(c.DataDir, dataDir)
+		if err != nil || prefix == "." {
+			prefix = ""
+		} else {
+			prefix = filepath.ToSlash(prefix) + "/"
+		}
+		opts.Remote = filesystem.NewRemote(objstore.WithPrefix(objstore.NewS3(&objstore.S3Options{
+			Endpoint:        c.S3Endpoint,
+			Region:          c.S3Region,
+			Bucket:          c.S3Bucket,
+			AccessKeyID:     c.S3AccessKeyID,
+			SecretAccessKey: c.S3SecretAccessKey,
+		}), prefix), c.cache)
+	}
+	opts.RemoteAge = c.RemoteAge
+	opts.Retention = c.Retention
+	opts.RetentionMaxSize = c.RetentionMaxSize
+	opts.Cache = c.cache
+	return opts
+}
+
+func (c *Config) tenantRegistry() *tenant.Registry {
+	tenantLimits := make(map[string]tenant.Limits)
+	for id, limits := range c.TenantLimits {
+		tenantLimits[id] = c.Limits.merge(limits).limits()
+	}
+	if c.CacheSize > 0 {
+		c.cache = filesystem.NewCache(c.CacheSize)
+	}
+	return tenant.NewRegistry(&tenant.Options{
+		DataDir:      c.DataDir,
+		Storage:      c.storageOptions,
+		LabelLimit:   c.LabelLimit,
+		MaxTenants:   c.MaxTenants,
+		Limits:       c.Limits.limits(),
+		TenantLimits: tenantLimits,
+	})
+}
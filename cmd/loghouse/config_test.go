@@ -0,0 +1,91 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/commentlens/loghouse/storage/filesystem"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoadConfig(t *testing.T) {
+	configFile := filepath.Join(t.TempDir(), "loghouse.yaml")
+	err := os.WriteFile(configFile, []byte("data_dir: /var/lib/loghouse\nhttp_listen: :3200\ncompact_chunk_max_age: 4h\nread_limit: 50\nretention:\n  - labels: {env: prod}\n    age: 2160h\n"), 0666)
+	require.NoError(t, err)
+
+	t.Setenv("LOGHOUSE_HTTP_LISTEN", ":3300")
+	c, err := loadConfig("test", []string{"-config", configFile, "-read-limit", "10"}, nil)
+	require.NoError(t, err)
+
+	want := defaultConfig()
+	want.DataDir = "/var/lib/loghouse"
+	want.HTTPListen = ":3300"
+	want.CompactChunkMaxAge = 4 * time.Hour
+	want.ReadLimit = 10
+	want.Retention = []filesystem.RetentionRule{
+		{
+			Labels: map[string]string{"env": "prod"},
+			Age:    90 * 24 * time.Hour,
+		},
+	}
+	require.Equal(t, want, c)
+	require.Equal(t, "/var/lib/loghouse/incompact", c.storageOptions(c.DataDir).WriteDir)
+}
+
+func TestLoadConfigRetention(t *testing.T) {
+	configFile := filepath.Join(t.TempDir(), "loghouse.yaml")
+	err := os.WriteFile(configFile, []byte("retention:\n  - selector: '{app=~\"web|api\"}'\n    age: 24h\n"), 0666)
+	require.NoError(t, err)
+	c, err := loadConfig("test", []string{"-config", configFile}, nil)
+	require.NoError(t, err)
+	require.Len(t, c.Retention, 1)
+	require.Equal(t, 24*time.Hour, c.Retention[0].Age)
+	require.True(t, c.Retention[0].Match(map[string]string{"app": "api"}))
+	require.False(t, c.Retention[0].Match(map[string]string{"app": "db"}))
+
+	err = os.WriteFile(configFile, []byte("retention:\n  - selector: '{app=~\"(\"}'\n    age: 24h\n"), 0666)
+	require.NoError(t, err)
+	_, err = loadConfig("test", []string{"-config", configFile}, nil)
+	require.Error(t, err)
+}
+
+func TestLoadConfigCompression(t *testing.T) {
+	c, err := loadConfig("test", []string{"-recompress-age", "168h", "-recompress-compression", "columnar-gzip"}, nil)
+	require.NoError(t, err)
+	opts := c.storageOptions(c.DataDir)
+	require.Equal(t, "s2", opts.CompactCompression)
+	require.Equal(t, "columnar-gzip", opts.RecompressCompression)
+	require.Equal(t, 168*time.Hour, opts.RecompressAge)
+
+	c, err = loadConfig("test", []string{"-compact-compression", "columnar-zstd"}, nil)
+	require.NoError(t, err)
+	require.Equal(t, "columnar-zstd", c.storageOptions(c.DataDir).CompactCompression)
+
+	_, err = loadConfig("test", []string{"-compact-compression", "lz4"}, nil)
+	require.Error(t, err)
+}
+
+func TestStorageOptionsRemote(t *testing.T) {
+	c := defaultConfig()
+	require.Nil(t, c.storageOptions(c.DataDir).Remote)
+	c.S3Bucket = "logs"
+	opts := c.storageOptions(c.DataDir)
+	require.NotNil(t, opts.Remote)
+	require.Equal(t, filesystem.RemoteAge, opts.RemoteAge)
+}
+
+func TestStorageOptionsCache(t *testing.T) {
+	c := defaultConfig()
+	c.tenantRegistry()
+	a := c.storageOptions(filepath.Join(c.DataDir, "a"))
+	b := c.storageOptions(filepath.Join(c.DataDir, "b"))
+	require.NotNil(t, a.Cache)
+	require.Same(t, a.Cache, b.Cache)
+
+	c = defaultConfig()
+	c.CacheSize = 0
+	c.tenantRegistry()
+	require.Nil(t, c.storageOptions(c.DataDir).Cache)
+}
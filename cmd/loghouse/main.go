@@ -2,6 +2,7 @@ package main
 
 import (
 	"context"
+	"expvar"
 	"net/http"
 	"os"
 	"os/signal"
@@ -10,17 +11,30 @@ import (
 	_ "net/http/pprof"
 
 	"github.com/commentlens/loghouse/api/loki"
-	"github.com/commentlens/loghouse/storage/filesystem"
-	"github.com/commentlens/loghouse/storage/label"
 	"github.com/sirupsen/logrus"
 	"golang.org/x/sync/errgroup"
 )
 
 func main() {
-	go http.ListenAndServe(":6060", nil)
-
 	logrus.SetFormatter(&logrus.JSONFormatter{})
+	if len(os.Args) > 1 && os.Args[1] == "verify" {
+		verify(os.Args[2:])
+		return
+	}
+	if len(os.Args) > 1 && os.Args[1] == "recompress" {
+		recompress(os.Args[2:])
+		return
+	}
+
 	log := logrus.StandardLogger()
+	c, err := loadConfig(os.Args[0], os.Args[1:], nil)
+	if err != nil {
+		log.WithError(err).Fatal("config")
+	}
+	if c.DebugListen != "" {
+		go http.ListenAndServe(c.DebugListen, nil)
+	}
+
 	log.Info("started")
 	defer log.Info("stopped")
 
@@ -32,14 +46,24 @@ func main() {
 		<-c
 	}()
 
-	w := filesystem.NewCompactWriter()
-	srv := &http.Server{Addr: ":3100", Handler: loki.NewServer(&loki.ServerOptions{
-		StorageWriter: w,
-		LabelStore:    label.NewStore(1000),
+	tenants := c.tenantRegistry()
+	if c.cache != nil {
+		expvar.Publish("cache", expvar.Func(func() interface{} {
+			return c.cache.Stats()
+		}))
+	}
+	err = tenants.Open()
+	if err != nil {
+		log.WithError(err).Fatal("recover")
+	}
+	srv := &http.Server{Addr: c.HTTPListen, Handler: loki.NewServer(&loki.ServerOptions{
+		Tenants:         tenants,
+		ReadLimit:       c.ReadLimit,
+		ReadConcurrency: c.ReadConcurrency,
 	})}
 	g, ctx := errgroup.WithContext(ctx)
 	g.Go(func() error {
-		return w.BackgroundCompact(ctx)
+		return tenants.Run(ctx)
 	})
 	g.Go(func() error {
 		return srv.ListenAndServe()
@@ -48,7 +72,7 @@ func main() {
 		<-ctx.Done()
 		return srv.Shutdown(context.Background())
 	})
-	err := g.Wait()
+	err = g.Wait()
 	if err != nil {
 		log.WithError(err).Warn("stopped with error")
 	}
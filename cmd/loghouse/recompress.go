@@ -0,0 +1,38 @@
+package main
+
+import (
+	"github.com/commentlens/loghouse/storage/filesystem"
+	"github.com/sirupsen/logrus"
+)
+
+// recompress rewrites old compacted chunks of all tenants with the recompress
+// options. It must not run while the server compacts the same data dir.
+func recompress(args []string) {
+	log := logrus.StandardLogger()
+
+	c, err := loadConfig("recompress", args, nil)
+	if err != nil {
+		log.WithError(err).Fatal("config")
+	}
+	if c.RecompressAge <= 0 || c.RecompressCompression == "" {
+		log.Fatal("recompress-age and recompress-compression are required")
+	}
+	tenants := c.tenantRegistry()
+	ids, err := tenants.IDs()
+	if err != nil {
+		log.WithError(err).Fatal("recompress")
+	}
+	var total int
+	for _, id := range ids {
+		storageOptions, err := tenants.StorageOptions(id)
+		if err != nil {
+			log.WithError(err).Fatal("recompress")
+		}
+		n, err := filesystem.Recompress(storageOptions)
+		if err != nil {
+			log.WithError(err).Fatal("recompress")
+		}
+		total += n
+	}
+	log.WithField("chunks", total).Info("recompressed")
+}
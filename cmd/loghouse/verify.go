@@ -0,0 +1,53 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"os"
+
+	"github.com/commentlens/loghouse/storage/filesystem"
+	"github.com/sirupsen/logrus"
+)
+
+func verify(args []string) {
+	log := logrus.StandardLogger()
+
+	var repair bool
+	c, err := loadConfig("verify", args, func(fs *flag.FlagSet) {
+		fs.BoolVar(&repair, "repair", false, "truncate torn chunks, rebuild damaged indices and quarantine unrepairable chunks")
+	})
+	if err != nil {
+		log.WithError(err).Fatal("config")
+	}
+	tenants := c.tenantRegistry()
+	ids, err := tenants.IDs()
+	if err != nil {
+		log.WithError(err).Fatal("verify")
+	}
+	var results []*filesystem.VerifyResult
+	for _, id := range ids {
+		storageOptions, err := tenants.StorageOptions(id)
+		if err != nil {
+			log.WithError(err).Fatal("verify")
+		}
+		tenantResults, err := filesystem.Verify(context.Background(), &filesystem.VerifyOptions{
+			Storage: storageOptions,
+			Repair:  repair,
+		})
+		if err != nil {
+			log.WithError(err).Fatal("verify")
+		}
+		results = append(results, tenantResults...)
+	}
+	var damaged int
+	for _, result := range results {
+		log.WithError(result.Err).WithField("repaired", result.Repaired).Warn(result.Chunk)
+		if !result.Repaired {
+			damaged++
+		}
+	}
+	log.WithField("damaged", damaged).Info("verified")
+	if damaged > 0 {
+		os.Exit(1)
+	}
+}
@@ -0,0 +1,187 @@
+package chunkio
+
+import (
+	"encoding/binary"
+	"errors"
+	"hash/crc32"
+	"io"
+
+	"github.com/commentlens/loghouse/storage/tlv"
+)
+
+var (
+	ErrChecksumMismatch = errors.New("checksum mismatch")
+)
+
+var crcTable = crc32.MakeTable(crc32.Castagnoli)
+
+type crcReader struct {
+	r   io.Reader
+	crc uint32
+	n   uint64
+}
+
+func (r *crcReader) Read(p []byte) (int, error) {
+	n, err := r.r.Read(p)
+	r.crc = crc32.Update(r.crc, crcTable, p[:n])
+	r.n += uint64(n)
+	return n, err
+}
+
+type crcWriter struct {
+	w   io.Writer
+	crc uint32
+}
+
+func (w *crcWriter) Write(p []byte) (int, error) {
+	n, err := w.w.Write(p)
+	w.crc = crc32.Update(w.crc, crcTable, p[:n])
+	return n, err
+}
+
+func encodeChecksum(w io.Writer, crc uint32) error {
+	var b [4]byte
+	binary.BigEndian.PutUint32(b[:], crc)
+	return tlv.NewWriter(w).Write(tlvTypeChecksum, b[:])
+}
+
+func decodeChecksum(val io.Reader) (uint32, error) {
+	var b [4]byte
+	_, err := io.ReadFull(val, b[:])
+	if err != nil {
+		return 0, err
+	}
+	return binary.BigEndian.Uint32(b[:]), nil
+}
+
+// WriteChecksum seals b, the uncompressed data written since the previous
+// checksum, with a checksum record.
+func WriteChecksum(w io.Writer, b []byte) error {
+	return encodeChecksum(w, crc32.Checksum(b, crcTable))
+}
+
+// VerifyData scans uncompressed data and returns the size of its longest valid
+// prefix. If the data contains checksum records, the prefix ends at the last
+// checksum that matches; otherwise it ends after the last complete entry.
+func VerifyData(r io.Reader) (uint64, bool, error) {
+	buf := newBuffer()
+	defer recycleBuffer(buf)
+
+	cr := &crcReader{r: r}
+	tr := tlv.NewReader(cr)
+	var size, sizeChecksum uint64
+	var checksummed bool
+	err := func() error {
+		readValue := func(val io.Reader) error {
+			buf.Reset()
+			_, err := buf.ReadFrom(val)
+			return err
+		}
+		for {
+			crc := cr.crc
+			typ, val, err := tr.Read()
+			if err != nil {
+				return err
+			}
+			switch typ {
+			case tlvTypeChecksum:
+				sum, err := decodeChecksum(val)
+				if err != nil {
+					return err
+				}
+				if sum != crc {
+					return ErrChecksumMismatch
+				}
+				checksummed = true
+				sizeChecksum = cr.n
+				cr.crc = 0
+				continue
+			case tlvTypeStart, tlvTypeStartNano, tlvTypeTimeDelta:
+			default:
+				return ErrUnexpectedTLVType
+			}
+			err = readValue(val)
+			if err != nil {
+				return err
+			}
+			typ, val, err = tr.Read()
+			if err != nil {
+				return err
+			}
+			if !(typ == tlvTypeString || typ == tlvTypeText) {
+				return ErrUnexpectedTLVType
+			}
+			err = readValue(val)
+			if err != nil {
+				return err
+			}
+			size = cr.n
+		}
+	}()
+	switch {
+	case errors.Is(err, io.EOF):
+	case errors.Is(err, io.ErrUnexpectedEOF):
+	case errors.Is(err, ErrChecksumMismatch):
+	case errors.Is(err, ErrUnexpectedTLVType):
+	default:
+		return 0, false, err
+	}
+	if checksummed {
+		return sizeChecksum, true, nil
+	}
+	return size, false, nil
+}
+
+// Segment is a range of uncompressed data sealed by one checksum.
+type Segment struct {
+	Offset uint64
+	Size   uint64
+}
+
+// VerifySegments scans uncompressed data and returns its segments whose
+// checksum matches, skipping those that mismatch. torn reports whether the
+// scan ended at the end of data or inside a record, as an interrupted append
+// leaves it, rather than at a record that cannot be parsed.
+func VerifySegments(r io.Reader) ([]Segment, bool, error) {
+	cr := &crcReader{r: r}
+	tr := tlv.NewReader(cr)
+	var segs []Segment
+	var start uint64
+	err := func() error {
+		for {
+			crc := cr.crc
+			typ, val, err := tr.Read()
+			if err != nil {
+				return err
+			}
+			switch typ {
+			case tlvTypeChecksum:
+				sum, err := decodeChecksum(val)
+				if err != nil {
+					return err
+				}
+				if sum == crc {
+					segs = append(segs, Segment{Offset: start, Size: cr.n - start})
+				}
+				start = cr.n
+				cr.crc = 0
+				continue
+			case tlvTypeStart, tlvTypeStartNano, tlvTypeTimeDelta, tlvTypeString, tlvTypeText:
+			default:
+				return ErrUnexpectedTLVType
+			}
+			_, err = io.Copy(io.Discard, val)
+			if err != nil {
+				return err
+			}
+		}
+	}()
+	switch {
+	case errors.Is(err, io.EOF), errors.Is(err, io.ErrUnexpectedEOF):
+		return segs, true, nil
+	case errors.Is(err, ErrUnexpectedTLVType):
+		return segs, false, nil
+	default:
+		return nil, false, err
+	}
+}
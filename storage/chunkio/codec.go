@@ -0,0 +1,157 @@
+package chunkio
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/klauspost/compress/gzip"
+	"github.com/klauspost/compress/s2"
+	"github.com/klauspost/compress/zstd"
+)
+
+var (
+	ErrUnknownCompression = errors.New("unknown compression")
+)
+
+// Codec compresses whole columnar blocks. level is codec specific, zero for
+// the default.
+type Codec interface {
+	Encode(b []byte, level int) ([]byte, error)
+	Decode(b []byte) ([]byte, error)
+}
+
+var codecs = struct {
+	sync.RWMutex
+	m map[string]Codec
+}{m: make(map[string]Codec)}
+
+// RegisterCodec makes a codec available as the columnar compression
+// "columnar-<name>".
+func RegisterCodec(name string, codec Codec) {
+	codecs.Lock()
+	defer codecs.Unlock()
+	codecs.m[name] = codec
+}
+
+// Codecs returns the names of registered codecs.
+func Codecs() []string {
+	codecs.RLock()
+	defer codecs.RUnlock()
+
+	var names []string
+	for name := range codecs.m {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+func lookupCodec(compression string) (Codec, error) {
+	if strings.HasPrefix(compression, columnarPrefix) {
+		codecs.RLock()
+		codec, ok := codecs.m[strings.TrimPrefix(compression, columnarPrefix)]
+		codecs.RUnlock()
+		if ok {
+			return codec, nil
+		}
+	}
+	return nil, fmt.Errorf("%w %q", ErrUnknownCompression, compression)
+}
+
+// CheckCompression returns an error if blocks cannot be written with
+// compression.
+func CheckCompression(compression string) error {
+	if compression == CompressionS2 {
+		return nil
+	}
+	_, err := lookupCodec(compression)
+	return err
+}
+
+func init() {
+	RegisterCodec("none", noneCodec{})
+	RegisterCodec("s2", s2Codec{})
+	RegisterCodec("zstd", zstdCodec{})
+	RegisterCodec("gzip", gzipCodec{})
+}
+
+type noneCodec struct{}
+
+func (noneCodec) Encode(b []byte, level int) ([]byte, error) { return b, nil }
+
+func (noneCodec) Decode(b []byte) ([]byte, error) { return b, nil }
+
+type s2Codec struct{}
+
+func (s2Codec) Encode(b []byte, level int) ([]byte, error) {
+	if level > 1 {
+		return s2.EncodeBetter(nil, b), nil
+	}
+	return s2.Encode(nil, b), nil
+}
+
+func (s2Codec) Decode(b []byte) ([]byte, error) { return s2.Decode(nil, b) }
+
+type zstdCodec struct{}
+
+var zstdDecoder, _ = zstd.NewReader(nil)
+
+func (zstdCodec) Encode(b []byte, level int) ([]byte, error) {
+	opts := []zstd.EOption{zstd.WithEncoderConcurrency(1)}
+	if level != 0 {
+		opts = append(opts, zstd.WithEncoderLevel(zstd.EncoderLevelFromZstd(level)))
+	}
+	enc, err := zstd.NewWriter(nil, opts...)
+	if err != nil {
+		return nil, err
+	}
+	b = enc.EncodeAll(b, nil)
+	return b, enc.Close()
+}
+
+func (zstdCodec) Decode(b []byte) ([]byte, error) {
+	b, err := zstdDecoder.DecodeAll(b, nil)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrCorruptData, err)
+	}
+	return b, nil
+}
+
+type gzipCodec struct{}
+
+func (gzipCodec) Encode(b []byte, level int) ([]byte, error) {
+	if level == 0 {
+		level = gzip.DefaultCompression
+	}
+	buf := new(bytes.Buffer)
+	w, err := gzip.NewWriterLevel(buf, level)
+	if err != nil {
+		return nil, err
+	}
+	_, err = w.Write(b)
+	if err != nil {
+		return nil, err
+	}
+	err = w.Close()
+	if err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+func (gzipCodec) Decode(b []byte) ([]byte, error) {
+	r, err := gzip.NewReader(bytes.NewReader(b))
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrCorruptData, err)
+	}
+	b, err = io.ReadAll(r)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrCorruptData, err)
+	}
+	return b, nil
+}
@@ -0,0 +1,200 @@
+package chunkio
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"errors"
+	"io"
+	"time"
+
+	"github.com/commentlens/loghouse/storage"
+	"github.com/commentlens/loghouse/storage/tlv"
+)
+
+// Compression of data blocks. Row blocks are a stream of time and data
+// records; columnar blocks store all times, then all sizes, then all data,
+// compressed as a whole by the codec named after columnarPrefix.
+const (
+	CompressionS2           = "s2"
+	CompressionColumnarNone = columnarPrefix + "none"
+	CompressionColumnarS2   = columnarPrefix + "s2"
+	CompressionColumnarZstd = columnarPrefix + "zstd"
+	CompressionColumnarGzip = columnarPrefix + "gzip"
+	columnarPrefix          = "columnar-"
+)
+
+var (
+	ErrCorruptData = errors.New("corrupt data")
+)
+
+// WriteColumnarData writes es as one columnar block. level is passed to the
+// codec.
+func WriteColumnarData(w io.Writer, es []storage.LogEntry, compression string, level int) error {
+	codec, err := lookupCodec(compression)
+	if err != nil {
+		return err
+	}
+	b, err := encodeColumnar(es)
+	if err != nil {
+		return err
+	}
+	b, err = codec.Encode(b, level)
+	if err != nil {
+		return err
+	}
+	_, err = w.Write(b)
+	return err
+}
+
+func encodeColumnar(es []storage.LogEntry) ([]byte, error) {
+	var times, sizes, data []byte
+	var prev, delta int64
+	for i, e := range es {
+		t := e.Time.UnixNano()
+		if i == 0 {
+			times = binary.BigEndian.AppendUint64(times, uint64(t))
+		} else {
+			times = binary.AppendVarint(times, t-prev-delta)
+			delta = t - prev
+		}
+		prev = t
+		sizes = binary.AppendUvarint(sizes, uint64(len(e.Data)))
+		data = append(data, e.Data...)
+	}
+	buf := new(bytes.Buffer)
+	err := encodeUint64(buf, tlvTypeCount, uint64(len(es)))
+	if err != nil {
+		return nil, err
+	}
+	tw := tlv.NewWriter(buf)
+	for _, col := range []struct {
+		typ uint64
+		b   []byte
+	}{
+		{tlvTypeStartNano, times},
+		{tlvTypeSize, sizes},
+		{tlvTypeString, data},
+	} {
+		err := tw.Write(col.typ, col.b)
+		if err != nil {
+			return nil, err
+		}
+	}
+	err = WriteChecksum(buf, buf.Bytes())
+	if err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+func readColumnar(ctx context.Context, hdr *Header, val io.Reader, opts *storage.ReadOptions) error {
+	buf := newBuffer()
+	defer recycleBuffer(buf)
+	_, err := buf.ReadFrom(val)
+	if err != nil {
+		return err
+	}
+	codec, err := lookupCodec(hdr.Compression)
+	if err != nil {
+		return err
+	}
+	b, err := codec.Decode(buf.Bytes())
+	if err != nil {
+		return err
+	}
+
+	var n uint64
+	var times, sizes, data []byte
+	var checksummed bool
+	cr := &crcReader{r: bytes.NewReader(b)}
+	tr := tlv.NewReader(cr)
+	for {
+		crc := cr.crc
+		typ, val, err := tr.Read()
+		if err != nil {
+			if errors.Is(err, io.EOF) {
+				break
+			}
+			return err
+		}
+		if checksummed {
+			return ErrUnexpectedTLVType
+		}
+		var col *[]byte
+		switch typ {
+		case tlvTypeCount:
+			n, err = decodeUint64(val)
+			if err != nil {
+				return err
+			}
+			continue
+		case tlvTypeChecksum:
+			sum, err := decodeChecksum(val)
+			if err != nil {
+				return err
+			}
+			if sum != crc {
+				return ErrChecksumMismatch
+			}
+			checksummed = true
+			continue
+		case tlvTypeStartNano:
+			col = &times
+		case tlvTypeSize:
+			col = &sizes
+		case tlvTypeString:
+			col = &data
+		default:
+			return ErrUnexpectedTLVType
+		}
+		*col, err = io.ReadAll(val)
+		if err != nil {
+			return err
+		}
+	}
+	if !checksummed {
+		return ErrChecksumMismatch
+	}
+
+	// entries keep slices of data, which is not reused.
+	var prev, delta int64
+	for i := uint64(0); i < n; i++ {
+		if i == 0 {
+			if len(times) < 8 {
+				return ErrCorruptData
+			}
+			prev = int64(binary.BigEndian.Uint64(times))
+			times = times[8:]
+		} else {
+			dod, k := binary.Varint(times)
+			if k <= 0 {
+				return ErrCorruptData
+			}
+			times = times[k:]
+			delta += dod
+			prev += delta
+		}
+		size, k := binary.Uvarint(sizes)
+		if k <= 0 || size > uint64(len(data)) {
+			return ErrCorruptData
+		}
+		sizes = sizes[k:]
+		e := storage.LogEntry{
+			Labels: hdr.Labels,
+			Time:   time.Unix(0, prev).UTC(),
+			Data:   data[:size:size],
+		}
+		data = data[size:]
+		if !storage.MatchLogEntry(e, opts) {
+			continue
+		}
+		opts.ResultFunc(e)
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		default:
+		}
+	}
+	return nil
+}
@@ -5,6 +5,7 @@ import (
 	"context"
 	"errors"
 	"io"
+	"time"
 
 	"github.com/commentlens/loghouse/storage"
 	"github.com/commentlens/loghouse/storage/tlv"
@@ -23,23 +24,47 @@ func WriteData(w io.Writer, es []storage.LogEntry, compress bool) error {
 	return nil
 }
 
+// WriteBlock writes es as one block with compression, as named by
+// Header.Compression.
+func WriteBlock(w io.Writer, es []storage.LogEntry, compression string, level int) error {
+	if compression == CompressionS2 {
+		return WriteData(w, es, true)
+	}
+	return WriteColumnarData(w, es, compression, level)
+}
+
 func encodeData(es []storage.LogEntry, compress bool) ([]byte, error) {
 	buf := new(bytes.Buffer)
 	var w io.Writer = buf
 	if compress {
 		w = s2.NewWriter(w)
 	}
-	tw := tlv.NewWriter(w)
-	for _, e := range es {
-		err := encodeTime(w, tlvTypeStart, e.Time)
+	cw := &crcWriter{w: w}
+	tw := tlv.NewWriter(cw)
+	// the first entry time is stored in full, the others as delta of delta.
+	var prev, delta int64
+	for i, e := range es {
+		t := e.Time.UnixNano()
+		var err error
+		if i == 0 {
+			err = encodeTime(cw, tlvTypeStartNano, e.Time)
+		} else {
+			err = encodeTimeDelta(cw, tlvTypeTimeDelta, t-prev-delta)
+			delta = t - prev
+		}
 		if err != nil {
 			return nil, err
 		}
+		prev = t
 		err = tw.Write(tlvTypeString, e.Data)
 		if err != nil {
 			return nil, err
 		}
 	}
+	err := encodeChecksum(w, cw.crc)
+	if err != nil {
+		return nil, err
+	}
 	if wc, ok := w.(io.WriteCloser); ok {
 		err := wc.Close()
 		if err != nil {
@@ -50,17 +75,26 @@ func encodeData(es []storage.LogEntry, compress bool) ([]byte, error) {
 }
 
 func ReadData(ctx context.Context, hdr *Header, val io.Reader, opts *storage.ReadOptions) error {
-	buf := newBuffer()
-	defer recycleBuffer(buf)
 	switch hdr.Compression {
-	case "s2":
+	case "":
+	case CompressionS2:
 		s2r := newS2Reader()
 		defer recycleS2Reader(s2r)
 		s2r.Reset(val)
 		val = s2r
+	default:
+		return readColumnar(ctx, hdr, val, opts)
 	}
-	tr := tlv.NewReader(val)
+	buf := newBuffer()
+	defer recycleBuffer(buf)
+	cr := &crcReader{r: val}
+	tr := tlv.NewReader(cr)
+	sealed := true
+	// prev and delta are reset by full entry times.
+	var prev, delta int64
+	var based bool
 	for {
+		crc := cr.crc
 		typTime, valTime, err := tr.Read()
 		if err != nil {
 			if errors.Is(err, io.EOF) {
@@ -68,18 +102,53 @@ func ReadData(ctx context.Context, hdr *Header, val io.Reader, opts *storage.Rea
 			}
 			return err
 		}
-		if typTime != tlvTypeStart {
-			return ErrUnexpectedTLVType
+		if typTime == tlvTypeChecksum {
+			sum, err := decodeChecksum(valTime)
+			if err != nil {
+				return err
+			}
+			if sum != crc {
+				return ErrChecksumMismatch
+			}
+			cr.crc = 0
+			sealed = true
+			based = false
+			continue
 		}
-		t, err := decodeTime(valTime)
-		if err != nil {
-			return err
+		var t time.Time
+		switch typTime {
+		case tlvTypeStart:
+			t, err = decodeTimeMilli(valTime)
+			if err != nil {
+				return err
+			}
+			prev, delta, based = t.UnixNano(), 0, true
+		case tlvTypeStartNano:
+			t, err = decodeTime(valTime)
+			if err != nil {
+				return err
+			}
+			prev, delta, based = t.UnixNano(), 0, true
+		case tlvTypeTimeDelta:
+			if !based {
+				return ErrUnexpectedTLVType
+			}
+			dod, err := decodeTimeDelta(valTime)
+			if err != nil {
+				return err
+			}
+			delta += dod
+			prev += delta
+			t = time.Unix(0, prev).UTC()
+		default:
+			return ErrUnexpectedTLVType
 		}
+		sealed = false
 		typStr, valStr, err := tr.Read()
 		if err != nil {
 			return err
 		}
-		if typStr != tlvTypeString {
+		if !(typStr == tlvTypeString || typStr == tlvTypeText) {
 			return ErrUnexpectedTLVType
 		}
 		buf.Reset()
@@ -106,5 +175,8 @@ func ReadData(ctx context.Context, hdr *Header, val io.Reader, opts *storage.Rea
 		default:
 		}
 	}
+	if hdr.Version >= 2 && !sealed {
+		return ErrChecksumMismatch
+	}
 	return nil
 }
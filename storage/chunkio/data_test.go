@@ -0,0 +1,218 @@
+package chunkio
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/commentlens/loghouse/storage"
+	"github.com/commentlens/loghouse/storage/tlv"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDataEncode(t *testing.T) {
+	now := time.Now().UTC().Truncate(time.Millisecond)
+	labels := map[string]string{"app": "test"}
+	es := []storage.LogEntry{
+		{
+			Labels: labels,
+			Time:   now,
+			Data:   []byte(`{"test":1}`),
+		},
+		{
+			Labels: labels,
+			Time:   now.Add(time.Millisecond),
+			Data:   []byte(`level=error msg="Connection Refused"`),
+		},
+		{
+			Labels: labels,
+			Time:   now.Add(2 * time.Millisecond),
+			Data:   []byte(`{truncated`),
+		},
+	}
+	for _, compress := range []bool{false, true} {
+		buf := new(bytes.Buffer)
+		err := WriteData(buf, es, compress)
+		require.NoError(t, err)
+
+		hdr := &Header{Labels: labels}
+		if compress {
+			hdr.Compression = "s2"
+		}
+		var got []storage.LogEntry
+		err = ReadData(context.Background(), hdr, buf, &storage.ReadOptions{
+			ResultFunc: func(e storage.LogEntry) {
+				got = append(got, e)
+			},
+		})
+		require.NoError(t, err)
+		require.Equal(t, es, got)
+	}
+
+	var data [][]byte
+	for _, e := range es {
+		data = append(data, e.Data)
+	}
+	var index Index
+	err := index.Build(data)
+	require.NoError(t, err)
+	require.True(t, index.Contains("connection refused"))
+	require.True(t, index.Contains("truncated"))
+}
+
+func TestDataNano(t *testing.T) {
+	now := time.Now().UTC()
+	es := []storage.LogEntry{
+		{Time: now, Data: []byte(`a`)},
+		{Time: now.Add(1), Data: []byte(`b`)},
+		{Time: now.Add(1), Data: []byte(`c`)},
+		{Time: now.Add(-time.Second), Data: []byte(`d`)},
+		{Time: now.Add(time.Hour + 7), Data: []byte(`e`)},
+	}
+	buf := new(bytes.Buffer)
+	err := WriteData(buf, es[:2], true)
+	require.NoError(t, err)
+	err = WriteData(buf, es[2:], true)
+	require.NoError(t, err)
+
+	var got []storage.LogEntry
+	err = ReadData(context.Background(), &Header{Compression: "s2"}, buf, &storage.ReadOptions{
+		ResultFunc: func(e storage.LogEntry) {
+			got = append(got, e)
+		},
+	})
+	require.NoError(t, err)
+	require.Equal(t, es, got)
+
+	// data written before version 3 stores full times in milliseconds.
+	legacy := new(bytes.Buffer)
+	cw := &crcWriter{w: legacy}
+	err = encodeUint64(cw, tlvTypeStart, uint64(now.UnixMilli()))
+	require.NoError(t, err)
+	err = tlv.NewWriter(cw).Write(tlvTypeText, []byte(`a`))
+	require.NoError(t, err)
+	err = encodeChecksum(legacy, cw.crc)
+	require.NoError(t, err)
+	got = nil
+	err = ReadData(context.Background(), &Header{Version: 2}, legacy, &storage.ReadOptions{
+		ResultFunc: func(e storage.LogEntry) {
+			got = append(got, e)
+		},
+	})
+	require.NoError(t, err)
+	require.Equal(t, []storage.LogEntry{{Time: now.Truncate(time.Millisecond), Data: []byte(`a`)}}, got)
+}
+
+func TestVerifyData(t *testing.T) {
+	now := time.Now().UTC().Truncate(time.Millisecond)
+	es := []storage.LogEntry{
+		{
+			Time: now,
+			Data: []byte(`{"test":1}`),
+		},
+		{
+			Time: now.Add(time.Millisecond),
+			Data: []byte(`plain text`),
+		},
+	}
+	buf := new(bytes.Buffer)
+	err := WriteData(buf, es, false)
+	require.NoError(t, err)
+	size := uint64(buf.Len())
+
+	sealed, checksummed, err := VerifyData(bytes.NewReader(buf.Bytes()))
+	require.NoError(t, err)
+	require.True(t, checksummed)
+	require.Equal(t, size, sealed)
+
+	err = WriteData(buf, es, false)
+	require.NoError(t, err)
+	torn := buf.Bytes()[:buf.Len()-3]
+	sealed, checksummed, err = VerifyData(bytes.NewReader(torn))
+	require.NoError(t, err)
+	require.True(t, checksummed)
+	require.Equal(t, size, sealed)
+
+	segs, tornEnd, err := VerifySegments(bytes.NewReader(torn))
+	require.NoError(t, err)
+	require.True(t, tornEnd)
+	require.Equal(t, []Segment{{Offset: 0, Size: size}}, segs)
+
+	corrupt := append([]byte(nil), buf.Bytes()...)
+	corrupt[bytes.Index(corrupt, []byte(`plain text`))] = 'P'
+	segs, tornEnd, err = VerifySegments(bytes.NewReader(corrupt))
+	require.NoError(t, err)
+	require.True(t, tornEnd)
+	require.Equal(t, []Segment{{Offset: size, Size: size}}, segs)
+
+	err = ReadData(context.Background(), &Header{}, bytes.NewReader(corrupt), &storage.ReadOptions{
+		ResultFunc: func(storage.LogEntry) {},
+	})
+	require.ErrorIs(t, err, ErrChecksumMismatch)
+}
+
+func TestColumnarData(t *testing.T) {
+	now := time.Now().UTC()
+	labels := map[string]string{"app": "test"}
+	var es []storage.LogEntry
+	for i := 0; i < 1000; i++ {
+		es = append(es, storage.LogEntry{
+			Labels: labels,
+			Time:   now.Add(time.Duration(i) * time.Millisecond),
+			Data:   []byte(fmt.Sprintf(`{"level":"info","msg":"request %d","status":200}`, i)),
+		})
+	}
+	es = append(es, storage.LogEntry{Labels: labels, Time: now.Add(-1), Data: []byte(`plain text`)})
+	row := new(bytes.Buffer)
+	err := WriteData(row, es, true)
+	require.NoError(t, err)
+
+	for _, codec := range Codecs() {
+		compression := columnarPrefix + codec
+		require.NoError(t, CheckCompression(compression))
+		buf := new(bytes.Buffer)
+		err := WriteColumnarData(buf, es, compression, 0)
+		require.NoError(t, err)
+		if codec != "none" {
+			require.Less(t, buf.Len(), row.Len())
+		}
+		b := buf.Bytes()
+
+		hdr := &Header{Labels: labels, Compression: compression}
+		var got []storage.LogEntry
+		err = ReadData(context.Background(), hdr, bytes.NewReader(b), &storage.ReadOptions{
+			ResultFunc: func(e storage.LogEntry) {
+				got = append(got, e)
+			},
+		})
+		require.NoError(t, err)
+		require.Equal(t, es, got)
+
+		got = nil
+		err = ReadData(context.Background(), hdr, bytes.NewReader(b), &storage.ReadOptions{
+			Start: now.Add(10 * time.Millisecond),
+			FilterFunc: func(e storage.LogEntry) bool {
+				return bytes.Contains(e.Data, []byte("request 1"))
+			},
+			ResultFunc: func(e storage.LogEntry) {
+				got = append(got, e)
+			},
+		})
+		require.NoError(t, err)
+		require.Len(t, got, 110)
+
+		corrupt := append([]byte(nil), b...)
+		corrupt[len(corrupt)/2] ^= 0xff
+		err = ReadData(context.Background(), hdr, bytes.NewReader(corrupt), &storage.ReadOptions{
+			ResultFunc: func(storage.LogEntry) {},
+		})
+		require.Error(t, err)
+	}
+
+	err = WriteColumnarData(new(bytes.Buffer), es, "columnar-lz4", 0)
+	require.ErrorIs(t, err, ErrUnknownCompression)
+	require.ErrorIs(t, CheckCompression("lz4"), ErrUnknownCompression)
+	require.NoError(t, CheckCompression(CompressionS2))
+}
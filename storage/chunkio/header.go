@@ -10,7 +10,13 @@ import (
 	"github.com/commentlens/loghouse/storage/tlv"
 )
 
+// FormatVersion is the version of headers, indices and data blocks written by
+// this package. Version 1 is the original format without checksums. Version 3
+// stores times in nanoseconds instead of milliseconds.
+const FormatVersion = 3
+
 type Header struct {
+	Version     uint64
 	OffsetStart uint64
 	Size        uint64
 	Labels      map[string]string
@@ -24,6 +30,9 @@ func MatchHeader(hdr *Header, opts *storage.ReadOptions) bool {
 	if !storage.MatchLabels(hdr.Labels, opts.Labels) {
 		return false
 	}
+	if opts.LabelFunc != nil && !opts.LabelFunc(hdr.Labels) {
+		return false
+	}
 	if !opts.Start.IsZero() && !hdr.End.IsZero() && opts.Start.After(hdr.End) {
 		return false
 	}
@@ -57,7 +66,15 @@ func ReadHeader(r io.Reader) (*Header, error) {
 func encodeHeader(hdr *Header) ([]byte, error) {
 	buf := new(bytes.Buffer)
 
-	err := encodeUint64(buf, tlvTypeOffsetStart, hdr.OffsetStart)
+	version := hdr.Version
+	if version == 0 {
+		version = FormatVersion
+	}
+	err := encodeUint64(buf, tlvTypeVersion, version)
+	if err != nil {
+		return nil, err
+	}
+	err = encodeUint64(buf, tlvTypeOffsetStart, hdr.OffsetStart)
 	if err != nil {
 		return nil, err
 	}
@@ -72,19 +89,19 @@ func encodeHeader(hdr *Header) ([]byte, error) {
 		}
 	}
 	if !hdr.Start.IsZero() {
-		err := encodeTime(buf, tlvTypeStart, hdr.Start)
+		err := encodeTime(buf, tlvTypeStartNano, hdr.Start)
 		if err != nil {
 			return nil, err
 		}
 	}
 	if !hdr.End.IsZero() {
-		err := encodeTime(buf, tlvTypeEnd, hdr.End)
+		err := encodeTime(buf, tlvTypeEndNano, hdr.End)
 		if err != nil {
 			return nil, err
 		}
 	}
 	if hdr.Compression != "" {
-		err := encodeString(buf, tlvTypeCompression, "s2")
+		err := encodeString(buf, tlvTypeCompression, hdr.Compression)
 		if err != nil {
 			return nil, err
 		}
@@ -95,13 +112,20 @@ func encodeHeader(hdr *Header) ([]byte, error) {
 			return nil, err
 		}
 	}
+	err = WriteChecksum(buf, buf.Bytes())
+	if err != nil {
+		return nil, err
+	}
 	return buf.Bytes(), nil
 }
 
 func decodeHeader(val io.Reader) (*Header, error) {
-	var hdr Header
-	tr := tlv.NewReader(val)
+	hdr := Header{Version: 1}
+	cr := &crcReader{r: val}
+	tr := tlv.NewReader(cr)
+	var checksummed bool
 	for {
+		crc := cr.crc
 		typ, val, err := tr.Read()
 		if err != nil {
 			if errors.Is(err, io.EOF) {
@@ -109,7 +133,25 @@ func decodeHeader(val io.Reader) (*Header, error) {
 			}
 			return nil, err
 		}
+		if checksummed {
+			return nil, ErrUnexpectedTLVType
+		}
 		switch typ {
+		case tlvTypeVersion:
+			n, err := decodeUint64(val)
+			if err != nil {
+				return nil, err
+			}
+			hdr.Version = n
+		case tlvTypeChecksum:
+			sum, err := decodeChecksum(val)
+			if err != nil {
+				return nil, err
+			}
+			if sum != crc {
+				return nil, ErrChecksumMismatch
+			}
+			checksummed = true
 		case tlvTypeOffsetStart:
 			n, err := decodeUint64(val)
 			if err != nil {
@@ -129,12 +171,24 @@ func decodeHeader(val io.Reader) (*Header, error) {
 			}
 			hdr.Labels = m
 		case tlvTypeStart:
-			t, err := decodeTime(val)
+			t, err := decodeTimeMilli(val)
 			if err != nil {
 				return nil, err
 			}
 			hdr.Start = t
 		case tlvTypeEnd:
+			t, err := decodeTimeMilli(val)
+			if err != nil {
+				return nil, err
+			}
+			hdr.End = t
+		case tlvTypeStartNano:
+			t, err := decodeTime(val)
+			if err != nil {
+				return nil, err
+			}
+			hdr.Start = t
+		case tlvTypeEndNano:
 			t, err := decodeTime(val)
 			if err != nil {
 				return nil, err
@@ -156,5 +210,8 @@ func decodeHeader(val io.Reader) (*Header, error) {
 			return nil, ErrUnexpectedTLVType
 		}
 	}
+	if hdr.Version >= 2 && !checksummed {
+		return nil, ErrChecksumMismatch
+	}
 	return &hdr, nil
 }
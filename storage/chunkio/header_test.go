@@ -0,0 +1,47 @@
+package chunkio
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/commentlens/loghouse/storage/tlv"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHeaderEncode(t *testing.T) {
+	hdr := &Header{
+		Version:     FormatVersion,
+		OffsetStart: 10,
+		Size:        20,
+		Labels:      map[string]string{"app": "test"},
+		Start:       time.Unix(0, 1670000000000000001).UTC(),
+		End:         time.Unix(0, 1670000001000000002).UTC(),
+		Compression: "s2",
+		Count:       3,
+	}
+	buf := new(bytes.Buffer)
+	err := WriteHeader(buf, hdr)
+	require.NoError(t, err)
+	b := append([]byte(nil), buf.Bytes()...)
+
+	got, err := ReadHeader(buf)
+	require.NoError(t, err)
+	require.Equal(t, hdr, got)
+
+	b[len(b)-8] ^= 0xff
+	_, err = ReadHeader(bytes.NewReader(b))
+	require.ErrorIs(t, err, ErrChecksumMismatch)
+
+	legacy := new(bytes.Buffer)
+	err = encodeUint64(legacy, tlvTypeSize, 20)
+	require.NoError(t, err)
+	err = encodeUint64(legacy, tlvTypeStart, 1670000000000)
+	require.NoError(t, err)
+	buf.Reset()
+	err = tlv.NewWriter(buf).Write(tlvTypeHeader, legacy.Bytes())
+	require.NoError(t, err)
+	got, err = ReadHeader(buf)
+	require.NoError(t, err)
+	require.Equal(t, &Header{Version: 1, Size: 20, Start: time.UnixMilli(1670000000000).UTC()}, got)
+}
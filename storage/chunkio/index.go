@@ -3,6 +3,7 @@ package chunkio
 import (
 	"bytes"
 	"encoding/binary"
+	"errors"
 	"io"
 	"runtime"
 	"sync"
@@ -134,7 +135,7 @@ func WriteIndex(w io.Writer, index *Index) error {
 		return err
 	}
 	tw := tlv.NewWriter(w)
-	return tw.Write(tlvTypeIndex, b)
+	return tw.Write(tlvTypeIndexV2, b)
 }
 
 func ReadIndex(r io.Reader) (*Index, error) {
@@ -143,17 +144,85 @@ func ReadIndex(r io.Reader) (*Index, error) {
 	if err != nil {
 		return nil, err
 	}
-	if typ != tlvTypeIndex {
+	switch typ {
+	case tlvTypeIndex:
+		return decodeIndexV1(val)
+	case tlvTypeIndexV2:
+		return decodeIndex(val)
+	default:
 		return nil, ErrUnexpectedTLVType
 	}
-	return decodeIndex(val)
 }
 
 func encodeIndex(index *Index) ([]byte, error) {
-	return encodeFilter(index.filter)
+	b, err := encodeFilter(index.filter)
+	if err != nil {
+		return nil, err
+	}
+	buf := new(bytes.Buffer)
+	err = encodeUint64(buf, tlvTypeVersion, FormatVersion)
+	if err != nil {
+		return nil, err
+	}
+	err = tlv.NewWriter(buf).Write(tlvTypeString, b)
+	if err != nil {
+		return nil, err
+	}
+	err = WriteChecksum(buf, buf.Bytes())
+	if err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
 }
 
 func decodeIndex(val io.Reader) (*Index, error) {
+	var index *Index
+	var checksummed bool
+	cr := &crcReader{r: val}
+	tr := tlv.NewReader(cr)
+	for {
+		crc := cr.crc
+		typ, val, err := tr.Read()
+		if err != nil {
+			if errors.Is(err, io.EOF) {
+				break
+			}
+			return nil, err
+		}
+		if checksummed {
+			return nil, ErrUnexpectedTLVType
+		}
+		switch typ {
+		case tlvTypeVersion:
+			_, err := decodeUint64(val)
+			if err != nil {
+				return nil, err
+			}
+		case tlvTypeString:
+			index, err = decodeIndexV1(val)
+			if err != nil {
+				return nil, err
+			}
+		case tlvTypeChecksum:
+			sum, err := decodeChecksum(val)
+			if err != nil {
+				return nil, err
+			}
+			if sum != crc {
+				return nil, ErrChecksumMismatch
+			}
+			checksummed = true
+		default:
+			return nil, ErrUnexpectedTLVType
+		}
+	}
+	if !checksummed || index == nil {
+		return nil, ErrChecksumMismatch
+	}
+	return index, nil
+}
+
+func decodeIndexV1(val io.Reader) (*Index, error) {
 	buf := newBuffer()
 	defer recycleBuffer(buf)
 
@@ -211,6 +280,9 @@ func encodeFilter(f *xorfilter.BinaryFuse8) ([]byte, error) {
 }
 
 func decodeFilter(b []byte) (*xorfilter.BinaryFuse8, error) {
+	if len(b) < 24 {
+		return nil, io.ErrUnexpectedEOF
+	}
 	var f xorfilter.BinaryFuse8
 	f.Seed = binary.BigEndian.Uint64(b[0:8])
 	f.SegmentLength = binary.BigEndian.Uint32(b[8:12])
@@ -5,6 +5,7 @@ import (
 	"testing"
 
 	"github.com/cespare/xxhash/v2"
+	"github.com/commentlens/loghouse/storage/tlv"
 	"github.com/stretchr/testify/require"
 )
 
@@ -115,3 +116,28 @@ func TestIndexEncode(t *testing.T) {
 		}
 	}
 }
+
+func TestIndexChecksum(t *testing.T) {
+	var index Index
+	err := index.Build([][]byte{
+		[]byte(`123456`),
+	})
+	require.NoError(t, err)
+
+	b, err := encodeFilter(index.filter)
+	require.NoError(t, err)
+	buf := new(bytes.Buffer)
+	err = tlv.NewWriter(buf).Write(tlvTypeIndex, b)
+	require.NoError(t, err)
+	legacy, err := ReadIndex(buf)
+	require.NoError(t, err)
+	require.Equal(t, &index, legacy)
+
+	buf.Reset()
+	err = WriteIndex(buf, &index)
+	require.NoError(t, err)
+	corrupt := buf.Bytes()
+	corrupt[len(corrupt)-8] ^= 0xff
+	_, err = ReadIndex(bytes.NewReader(corrupt))
+	require.ErrorIs(t, err, ErrChecksumMismatch)
+}
@@ -0,0 +1,212 @@
+package chunkio
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"sort"
+	"time"
+
+	"github.com/commentlens/loghouse/storage"
+	"github.com/commentlens/loghouse/storage/tlv"
+)
+
+// Meta summarizes a compacted chunk: the time range and count of its entries,
+// and the values of every label in its series. HeaderSize is the size of the
+// header file it was built from.
+type Meta struct {
+	Start      time.Time
+	End        time.Time
+	Count      uint64
+	Labels     map[string][]string
+	HeaderSize uint64
+}
+
+func NewMeta(hdrs []*Header, headerSize uint64) *Meta {
+	meta := &Meta{
+		Labels:     make(map[string][]string),
+		HeaderSize: headerSize,
+	}
+	values := make(map[string]map[string]struct{})
+	for _, hdr := range hdrs {
+		if meta.Start.IsZero() || hdr.Start.Before(meta.Start) {
+			meta.Start = hdr.Start
+		}
+		if hdr.End.After(meta.End) {
+			meta.End = hdr.End
+		}
+		meta.Count += hdr.Count
+		for k, v := range hdr.Labels {
+			if values[k] == nil {
+				values[k] = make(map[string]struct{})
+			}
+			values[k][v] = struct{}{}
+		}
+	}
+	for k, vs := range values {
+		for v := range vs {
+			meta.Labels[k] = append(meta.Labels[k], v)
+		}
+		sort.Strings(meta.Labels[k])
+	}
+	return meta
+}
+
+// MatchMeta reports whether a chunk summarized by meta may have entries
+// matching opts.
+func MatchMeta(meta *Meta, opts *storage.ReadOptions) bool {
+	for k, v := range opts.Labels {
+		vs := meta.Labels[k]
+		i := sort.SearchStrings(vs, v)
+		if i == len(vs) || vs[i] != v {
+			return false
+		}
+	}
+	if !opts.Start.IsZero() && !meta.End.IsZero() && opts.Start.After(meta.End) {
+		return false
+	}
+	if !opts.End.IsZero() && !meta.Start.IsZero() && opts.End.Before(meta.Start) {
+		return false
+	}
+	return true
+}
+
+func WriteMeta(w io.Writer, meta *Meta) error {
+	buf := new(bytes.Buffer)
+	if !meta.Start.IsZero() {
+		err := encodeTime(buf, tlvTypeStartNano, meta.Start)
+		if err != nil {
+			return err
+		}
+	}
+	if !meta.End.IsZero() {
+		err := encodeTime(buf, tlvTypeEndNano, meta.End)
+		if err != nil {
+			return err
+		}
+	}
+	err := encodeUint64(buf, tlvTypeCount, meta.Count)
+	if err != nil {
+		return err
+	}
+	err = encodeUint64(buf, tlvTypeSize, meta.HeaderSize)
+	if err != nil {
+		return err
+	}
+	keys := make([]string, 0, len(meta.Labels))
+	for k := range meta.Labels {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		// a label is its name followed by its values.
+		lbuf := new(bytes.Buffer)
+		for _, s := range append([]string{k}, meta.Labels[k]...) {
+			err := encodeString(lbuf, tlvTypeString, s)
+			if err != nil {
+				return err
+			}
+		}
+		err := tlv.NewWriter(buf).Write(tlvTypeLabels, lbuf.Bytes())
+		if err != nil {
+			return err
+		}
+	}
+	err = WriteChecksum(buf, buf.Bytes())
+	if err != nil {
+		return err
+	}
+	return tlv.NewWriter(w).Write(tlvTypeMeta, buf.Bytes())
+}
+
+func ReadMeta(r io.Reader) (*Meta, error) {
+	typ, val, err := tlv.NewReader(r).Read()
+	if err != nil {
+		return nil, err
+	}
+	if typ != tlvTypeMeta {
+		return nil, ErrUnexpectedTLVType
+	}
+	meta := Meta{Labels: make(map[string][]string)}
+	cr := &crcReader{r: val}
+	tr := tlv.NewReader(cr)
+	var checksummed bool
+	for {
+		crc := cr.crc
+		typ, val, err := tr.Read()
+		if err != nil {
+			if errors.Is(err, io.EOF) {
+				break
+			}
+			return nil, err
+		}
+		if checksummed {
+			return nil, ErrUnexpectedTLVType
+		}
+		switch typ {
+		case tlvTypeStartNano:
+			t, err := decodeTime(val)
+			if err != nil {
+				return nil, err
+			}
+			meta.Start = t
+		case tlvTypeEndNano:
+			t, err := decodeTime(val)
+			if err != nil {
+				return nil, err
+			}
+			meta.End = t
+		case tlvTypeCount:
+			n, err := decodeUint64(val)
+			if err != nil {
+				return nil, err
+			}
+			meta.Count = n
+		case tlvTypeSize:
+			n, err := decodeUint64(val)
+			if err != nil {
+				return nil, err
+			}
+			meta.HeaderSize = n
+		case tlvTypeLabels:
+			var ss []string
+			lr := tlv.NewReader(val)
+			for {
+				typ, val, err := lr.Read()
+				if err != nil {
+					if errors.Is(err, io.EOF) {
+						break
+					}
+					return nil, err
+				}
+				if typ != tlvTypeString {
+					return nil, ErrUnexpectedTLVType
+				}
+				s, err := decodeString(val)
+				if err != nil {
+					return nil, err
+				}
+				ss = append(ss, s)
+			}
+			if len(ss) == 0 {
+				return nil, ErrUnexpectedTLVType
+			}
+			meta.Labels[ss[0]] = ss[1:]
+		case tlvTypeChecksum:
+			sum, err := decodeChecksum(val)
+			if err != nil {
+				return nil, err
+			}
+			if sum != crc {
+				return nil, ErrChecksumMismatch
+			}
+			checksummed = true
+		default:
+			return nil, ErrUnexpectedTLVType
+		}
+	}
+	if !checksummed {
+		return nil, ErrChecksumMismatch
+	}
+	return &meta, nil
+}
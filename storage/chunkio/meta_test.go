@@ -0,0 +1,63 @@
+package chunkio
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/commentlens/loghouse/storage"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMeta(t *testing.T) {
+	start := time.UnixMilli(1670000000000).UTC()
+	meta := NewMeta([]*Header{
+		{
+			Labels: map[string]string{"app": "b", "env": "prod"},
+			Start:  start.Add(time.Minute),
+			End:    start.Add(time.Hour),
+			Count:  2,
+		},
+		{
+			Labels: map[string]string{"app": "a"},
+			Start:  start,
+			End:    start.Add(time.Minute),
+			Count:  1,
+		},
+	}, 123)
+	require.Equal(t, &Meta{
+		Start:      start,
+		End:        start.Add(time.Hour),
+		Count:      3,
+		Labels:     map[string][]string{"app": {"a", "b"}, "env": {"prod"}},
+		HeaderSize: 123,
+	}, meta)
+
+	buf := new(bytes.Buffer)
+	err := WriteMeta(buf, meta)
+	require.NoError(t, err)
+	b := append([]byte(nil), buf.Bytes()...)
+
+	got, err := ReadMeta(buf)
+	require.NoError(t, err)
+	require.Equal(t, meta, got)
+
+	b[len(b)-8] ^= 0xff
+	_, err = ReadMeta(bytes.NewReader(b))
+	require.Error(t, err)
+
+	for _, test := range []struct {
+		opts *storage.ReadOptions
+		want bool
+	}{
+		{opts: &storage.ReadOptions{}, want: true},
+		{opts: &storage.ReadOptions{Labels: map[string]string{"app": "a", "env": "prod"}}, want: true},
+		{opts: &storage.ReadOptions{Labels: map[string]string{"app": "c"}}, want: false},
+		{opts: &storage.ReadOptions{Labels: map[string]string{"host": "a"}}, want: false},
+		{opts: &storage.ReadOptions{Start: start.Add(2 * time.Hour)}, want: false},
+		{opts: &storage.ReadOptions{End: start.Add(-time.Hour)}, want: false},
+		{opts: &storage.ReadOptions{Start: start.Add(30 * time.Minute), End: start.Add(2 * time.Hour)}, want: true},
+	} {
+		require.Equal(t, test.want, MatchMeta(meta, test.opts))
+	}
+}
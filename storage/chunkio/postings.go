@@ -0,0 +1,110 @@
+package chunkio
+
+import (
+	"bytes"
+	"errors"
+	"io"
+
+	"github.com/commentlens/loghouse/storage/tlv"
+)
+
+// ChunkSeries lists the series of a compacted chunk by their headers. Size is
+// the size of the header file it was read from.
+type ChunkSeries struct {
+	Name   string
+	Size   uint64
+	Series []*Header
+}
+
+func WriteChunkSeries(w io.Writer, cs *ChunkSeries) error {
+	buf := new(bytes.Buffer)
+	err := encodeString(buf, tlvTypeString, cs.Name)
+	if err != nil {
+		return err
+	}
+	err = encodeUint64(buf, tlvTypeSize, cs.Size)
+	if err != nil {
+		return err
+	}
+	for _, hdr := range cs.Series {
+		b, err := encodeHeader(&Header{
+			Labels: hdr.Labels,
+			Start:  hdr.Start,
+			End:    hdr.End,
+		})
+		if err != nil {
+			return err
+		}
+		err = tlv.NewWriter(buf).Write(tlvTypeHeader, b)
+		if err != nil {
+			return err
+		}
+	}
+	err = WriteChecksum(buf, buf.Bytes())
+	if err != nil {
+		return err
+	}
+	return tlv.NewWriter(w).Write(tlvTypeChunkSeries, buf.Bytes())
+}
+
+func ReadChunkSeries(r io.Reader) (*ChunkSeries, error) {
+	typ, val, err := tlv.NewReader(r).Read()
+	if err != nil {
+		return nil, err
+	}
+	if typ != tlvTypeChunkSeries {
+		return nil, ErrUnexpectedTLVType
+	}
+	var cs ChunkSeries
+	cr := &crcReader{r: val}
+	tr := tlv.NewReader(cr)
+	var checksummed bool
+	for {
+		crc := cr.crc
+		typ, val, err := tr.Read()
+		if err != nil {
+			if errors.Is(err, io.EOF) {
+				break
+			}
+			return nil, err
+		}
+		if checksummed {
+			return nil, ErrUnexpectedTLVType
+		}
+		switch typ {
+		case tlvTypeString:
+			s, err := decodeString(val)
+			if err != nil {
+				return nil, err
+			}
+			cs.Name = s
+		case tlvTypeSize:
+			n, err := decodeUint64(val)
+			if err != nil {
+				return nil, err
+			}
+			cs.Size = n
+		case tlvTypeHeader:
+			hdr, err := decodeHeader(val)
+			if err != nil {
+				return nil, err
+			}
+			cs.Series = append(cs.Series, hdr)
+		case tlvTypeChecksum:
+			sum, err := decodeChecksum(val)
+			if err != nil {
+				return nil, err
+			}
+			if sum != crc {
+				return nil, ErrChecksumMismatch
+			}
+			checksummed = true
+		default:
+			return nil, ErrUnexpectedTLVType
+		}
+	}
+	if !checksummed {
+		return nil, ErrChecksumMismatch
+	}
+	return &cs, nil
+}
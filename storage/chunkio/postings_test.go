@@ -0,0 +1,40 @@
+package chunkio
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestChunkSeriesEncode(t *testing.T) {
+	cs := &ChunkSeries{
+		Name: "01GN0000000000000000000000",
+		Size: 123,
+		Series: []*Header{
+			{
+				Version: FormatVersion,
+				Labels:  map[string]string{"app": "a"},
+				Start:   time.UnixMilli(1670000000000).UTC(),
+				End:     time.UnixMilli(1670000001000).UTC(),
+			},
+			{
+				Version: FormatVersion,
+				Labels:  map[string]string{"app": "b"},
+			},
+		},
+	}
+	buf := new(bytes.Buffer)
+	err := WriteChunkSeries(buf, cs)
+	require.NoError(t, err)
+	b := append([]byte(nil), buf.Bytes()...)
+
+	got, err := ReadChunkSeries(buf)
+	require.NoError(t, err)
+	require.Equal(t, cs, got)
+
+	b[len(b)-8] ^= 0xff
+	_, err = ReadChunkSeries(bytes.NewReader(b))
+	require.Error(t, err)
+}
@@ -26,6 +26,16 @@ const (
 	tlvTypeCompression
 	tlvTypeCount
 	tlvTypeIndex
+	// tlvTypeText is reserved; read as tlvTypeString.
+	tlvTypeText
+	tlvTypeChecksum
+	tlvTypeVersion
+	tlvTypeIndexV2
+	tlvTypeChunkSeries
+	tlvTypeMeta
+	tlvTypeStartNano
+	tlvTypeEndNano
+	tlvTypeTimeDelta
 )
 
 func encodeString(w io.Writer, typ uint64, s string) error {
@@ -57,14 +67,27 @@ func decodeUint64(val io.Reader) (uint64, error) {
 		return 0, err
 	}
 	b := buf.Bytes()
+	if len(b) != 8 {
+		return 0, io.ErrUnexpectedEOF
+	}
 	return binary.BigEndian.Uint64(b), nil
 }
 
 func encodeTime(w io.Writer, typ uint64, t time.Time) error {
-	return encodeUint64(w, typ, uint64(t.UnixMilli()))
+	return encodeUint64(w, typ, uint64(t.UnixNano()))
 }
 
 func decodeTime(val io.Reader) (time.Time, error) {
+	n, err := decodeUint64(val)
+	if err != nil {
+		return time.Time{}, err
+	}
+	return time.Unix(0, int64(n)).UTC(), nil
+}
+
+// decodeTimeMilli decodes tlvTypeStart and tlvTypeEnd, which hold milliseconds
+// in chunks written before version 3.
+func decodeTimeMilli(val io.Reader) (time.Time, error) {
 	n, err := decodeUint64(val)
 	if err != nil {
 		return time.Time{}, err
@@ -72,6 +95,29 @@ func decodeTime(val io.Reader) (time.Time, error) {
 	return time.UnixMilli(int64(n)).UTC(), nil
 }
 
+// encodeTimeDelta encodes the delta of delta of an entry time as a zigzag
+// varint.
+func encodeTimeDelta(w io.Writer, typ uint64, dod int64) error {
+	var b [binary.MaxVarintLen64]byte
+	n := binary.PutVarint(b[:], dod)
+	return tlv.NewWriter(w).Write(typ, b[:n])
+}
+
+func decodeTimeDelta(val io.Reader) (int64, error) {
+	buf := newBuffer()
+	defer recycleBuffer(buf)
+	_, err := buf.ReadFrom(val)
+	if err != nil {
+		return 0, err
+	}
+	b := buf.Bytes()
+	dod, n := binary.Varint(b)
+	if n <= 0 || n != len(b) {
+		return 0, io.ErrUnexpectedEOF
+	}
+	return dod, nil
+}
+
 func encodeMap(w io.Writer, typ uint64, m map[string]string) error {
 	buf := new(bytes.Buffer)
 	for k, v := range m {
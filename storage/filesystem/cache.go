@@ -0,0 +1,236 @@
+package filesystem
+
+import (
+	"container/list"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"sync"
+
+	"github.com/commentlens/loghouse/storage"
+	"github.com/commentlens/loghouse/storage/chunkio"
+	"github.com/commentlens/loghouse/storage/tlv"
+)
+
+const (
+	CacheSize = 256 << 20
+)
+
+// Cache is an in-memory LRU of chunk headers, indices and decoded blocks,
+// bounded by their approximate size in bytes. Local files are keyed by path,
+// size and modification time, so rewritten chunks miss instead of returning
+// stale entries. It may be shared by readers of all tenants; a nil Cache
+// caches nothing.
+type Cache struct {
+	maxBytes int64
+
+	mu    sync.Mutex
+	ll    *list.List
+	items map[string]*list.Element
+	stats CacheStats
+}
+
+type CacheStats struct {
+	Hits      uint64
+	Misses    uint64
+	Evictions uint64
+	Entries   int
+	Bytes     int64
+}
+
+type cacheItem struct {
+	key   string
+	value interface{}
+	size  int64
+}
+
+func NewCache(maxBytes int64) *Cache {
+	return &Cache{
+		maxBytes: maxBytes,
+		ll:       list.New(),
+		items:    make(map[string]*list.Element),
+	}
+}
+
+func (c *Cache) Stats() CacheStats {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	stats := c.stats
+	stats.Entries = c.ll.Len()
+	return stats
+}
+
+// get returns the value of key, calling load on a miss. load returns the
+// value and its size in bytes.
+func (c *Cache) get(key string, load func() (interface{}, int64, error)) (interface{}, error) {
+	if c == nil {
+		v, _, err := load()
+		return v, err
+	}
+	c.mu.Lock()
+	if el, ok := c.items[key]; ok {
+		c.ll.MoveToFront(el)
+		c.stats.Hits++
+		c.mu.Unlock()
+		return el.Value.(*cacheItem).value, nil
+	}
+	c.stats.Misses++
+	c.mu.Unlock()
+
+	v, size, err := load()
+	if err != nil {
+		return nil, err
+	}
+	c.add(key, v, size)
+	return v, nil
+}
+
+func (c *Cache) add(key string, v interface{}, size int64) {
+	if size > c.maxBytes {
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if _, ok := c.items[key]; ok {
+		// loaded concurrently.
+		return
+	}
+	c.items[key] = c.ll.PushFront(&cacheItem{key: key, value: v, size: size})
+	c.stats.Bytes += size
+	for c.stats.Bytes > c.maxBytes {
+		el := c.ll.Back()
+		item := el.Value.(*cacheItem)
+		c.ll.Remove(el)
+		delete(c.items, item.key)
+		c.stats.Bytes -= item.size
+		c.stats.Evictions++
+	}
+}
+
+// fileKey identifies the current version of a local file.
+func fileKey(kind, path string) (string, error) {
+	fi, err := os.Stat(path)
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("%s:%s@%d.%d", kind, path, fi.Size(), fi.ModTime().UnixNano()), nil
+}
+
+// cachedBlock returns the entries of a block, reading them unfiltered with
+// read on a miss, and passes those matching opts to ResultFunc.
+func (c *Cache) cachedBlock(ctx context.Context, key string, read func(*storage.ReadOptions) error, opts *storage.ReadOptions) error {
+	v, err := c.get(key, func() (interface{}, int64, error) {
+		var es []storage.LogEntry
+		var size int64
+		err := read(&storage.ReadOptions{
+			ResultFunc: func(e storage.LogEntry) {
+				es = append(es, e)
+				size += int64(len(e.Data)) + 64
+			},
+		})
+		return es, size, err
+	})
+	if err != nil {
+		return err
+	}
+	for _, e := range v.([]storage.LogEntry) {
+		if !storage.MatchLogEntry(e, opts) {
+			continue
+		}
+		opts.ResultFunc(e)
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		default:
+		}
+	}
+	return nil
+}
+
+// headers returns the headers of a chunk dir.
+func (c *Cache) headers(dir string) ([]*chunkio.Header, error) {
+	path := fmt.Sprintf("%s/%s", dir, CompactHeaderFile)
+	if c == nil {
+		return readHeaderFile(path)
+	}
+	key, err := fileKey("header", path)
+	if err != nil {
+		return nil, err
+	}
+	v, err := c.get(key, func() (interface{}, int64, error) {
+		hdrs, err := readHeaderFile(path)
+		var size int64
+		for _, hdr := range hdrs {
+			size += headerSize(hdr)
+		}
+		return hdrs, size, err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return v.([]*chunkio.Header), nil
+}
+
+// indices returns the indices of a compacted chunk dir, one per block.
+func (c *Cache) indices(dir string) ([]*chunkio.Index, error) {
+	path := fmt.Sprintf("%s/%s", dir, CompactIndexFile)
+	if c == nil {
+		indices, _, err := readIndexFile(path)
+		return indices, err
+	}
+	key, err := fileKey("index", path)
+	if err != nil {
+		return nil, err
+	}
+	v, err := c.get(key, func() (interface{}, int64, error) {
+		return readIndexFile(path)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return v.([]*chunkio.Index), nil
+}
+
+func readIndexFile(path string) ([]*chunkio.Index, int64, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer f.Close()
+
+	buf := chunkio.NewBuffer()
+	defer chunkio.RecycleBuffer(buf)
+	var indices []*chunkio.Index
+	var size int64
+	tr := tlv.NewReader(f)
+	for {
+		off, n, err := tr.ReadSection()
+		if err != nil {
+			if errors.Is(err, io.EOF) {
+				break
+			}
+			return nil, 0, err
+		}
+		buf.Reset(io.NewSectionReader(f, int64(off), int64(n)))
+		index, err := chunkio.ReadIndex(buf)
+		if err != nil {
+			return nil, 0, err
+		}
+		indices = append(indices, index)
+		size += int64(n)
+	}
+	return indices, size, nil
+}
+
+// headerSize estimates the memory used by a header.
+func headerSize(hdr *chunkio.Header) int64 {
+	size := int64(128)
+	for k, v := range hdr.Labels {
+		size += int64(len(k) + len(v) + 32)
+	}
+	return size
+}
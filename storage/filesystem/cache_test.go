@@ -0,0 +1,119 @@
+package filesystem
+
+import (
+	"context"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/commentlens/loghouse/storage"
+	"github.com/commentlens/loghouse/storage/chunkio"
+	"github.com/commentlens/loghouse/storage/objstore"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCache(t *testing.T) {
+	c := NewCache(10)
+	load := func(v string) func() (interface{}, int64, error) {
+		return func() (interface{}, int64, error) {
+			return v, int64(len(v)), nil
+		}
+	}
+	for _, test := range []struct {
+		key   string
+		value string
+		want  string
+	}{
+		{key: "a", value: "aaaa", want: "aaaa"},
+		{key: "b", value: "bbbb", want: "bbbb"},
+		{key: "a", value: "xxxx", want: "aaaa"},
+		// evicts b, the least recently used.
+		{key: "c", value: "cccc", want: "cccc"},
+		{key: "b", value: "yyyy", want: "yyyy"},
+		// larger than the cache.
+		{key: "d", value: "ddddddddddd", want: "ddddddddddd"},
+		{key: "d", value: "zz", want: "zz"},
+	} {
+		v, err := c.get(test.key, load(test.value))
+		require.NoError(t, err)
+		require.Equal(t, test.want, v)
+	}
+	require.Equal(t, CacheStats{Hits: 1, Misses: 6, Evictions: 2, Entries: 3, Bytes: 10}, c.Stats())
+
+	var nilCache *Cache
+	v, err := nilCache.get("a", load("a"))
+	require.NoError(t, err)
+	require.Equal(t, "a", v)
+}
+
+func TestCachedRead(t *testing.T) {
+	opts := NewOptions(t.TempDir())
+	opts.Cache = NewCache(CacheSize)
+	opts.Remote = NewRemote(objstore.NewMemBucket(), opts.Cache)
+	c := compactor{opts: opts}
+	start := now().Add(-time.Hour)
+
+	compact := func(es []storage.LogEntry) {
+		err := NewWriter(opts).Write(es)
+		require.NoError(t, err)
+		err = filepath.WalkDir(opts.WriteDir, func(path string, d fs.DirEntry, err error) error {
+			if err != nil {
+				return nil
+			}
+			oldTime := now().Add(-2 * CompactChunkMaxAge)
+			return os.Chtimes(path, oldTime, oldTime)
+		})
+		require.NoError(t, err)
+		chunks, err := c.FindCompactibleChunk()
+		require.NoError(t, err)
+		err = c.SwapChunk(chunks)
+		require.NoError(t, err)
+		err = c.Compact()
+		require.NoError(t, err)
+	}
+	opts.RemoteAge = time.Nanosecond
+	compact([]storage.LogEntry{
+		{Labels: map[string]string{"app": "api"}, Time: start, Data: []byte("1")},
+		{Labels: map[string]string{"app": "web"}, Time: start.Add(2 * time.Second), Data: []byte("3")},
+	})
+	opts.RemoteAge = time.Hour
+	compact([]storage.LogEntry{
+		{Labels: map[string]string{"app": "api"}, Time: start.Add(time.Second), Data: []byte("2 error")},
+	})
+
+	read := func(ropts *storage.ReadOptions) []string {
+		var got []string
+		ropts.ResultFunc = func(e storage.LogEntry) {
+			got = append(got, string(e.Data))
+		}
+		err := NewCompactReader(&CompactReaderOptions{Storage: opts}).Read(context.Background(), ropts)
+		require.NoError(t, err)
+		return got
+	}
+	// remote headers are cached by the compactor.
+	stats := opts.Cache.Stats()
+	require.Equal(t, []string{"1", "2 error", "3"}, read(&storage.ReadOptions{}))
+	require.Greater(t, opts.Cache.Stats().Misses, stats.Misses)
+	stats = opts.Cache.Stats()
+
+	// cached blocks are filtered by each read.
+	require.Equal(t, []string{"1", "2 error", "3"}, read(&storage.ReadOptions{}))
+	require.Equal(t, []string{"2 error", "3"}, read(&storage.ReadOptions{Start: start.Add(time.Second)}))
+	require.Equal(t, []string{"2 error"}, read(&storage.ReadOptions{Contains: []string{"error"}, FilterFunc: func(e storage.LogEntry) bool {
+		return string(e.Data) == "2 error"
+	}}))
+	require.Equal(t, stats.Misses+1, opts.Cache.Stats().Misses)
+	require.Greater(t, opts.Cache.Stats().Hits, stats.Hits)
+
+	// rewritten chunks are read again.
+	opts.RecompressAge = time.Nanosecond
+	opts.RecompressCompression = chunkio.CompressionColumnarGzip
+	n, err := Recompress(opts)
+	require.NoError(t, err)
+	require.Equal(t, 1, n)
+	misses := opts.Cache.Stats().Misses
+	require.Equal(t, []string{"1", "2 error", "3"}, read(&storage.ReadOptions{}))
+	require.Greater(t, opts.Cache.Stats().Misses, misses)
+}
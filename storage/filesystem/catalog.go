@@ -0,0 +1,404 @@
+package filesystem
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/commentlens/loghouse/storage"
+	"github.com/commentlens/loghouse/storage/chunkio"
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	CatalogFile    = "series.loghouse"
+	CatalogTmpFile = "series.loghouse.tmp"
+)
+
+// Series is a stream with the time range of its entries. A zero Start or End
+// means the bound is unknown.
+type Series struct {
+	Hash   string
+	Labels map[string]string
+	Start  time.Time
+	End    time.Time
+}
+
+// Catalog maps stream hashes to series, persisted as a file of headers next
+// to the write and compact dirs. Changes are saved by the compactor, so
+// incompact streams are rescanned on open.
+type Catalog struct {
+	file string
+
+	mu    sync.Mutex
+	m     map[string]*catalogEntry
+	dirty bool
+	// noRemote is set if the catalog was built while the remote was
+	// unavailable, so that its streams are added by a later compaction.
+	noRemote bool
+}
+
+type catalogEntry struct {
+	Series
+	updated time.Time
+}
+
+// OpenCatalog loads the catalog of opts, building it from chunk headers if it
+// does not exist yet.
+func OpenCatalog(opts *Options) (*Catalog, error) {
+	c := &Catalog{
+		file: filepath.Join(filepath.Dir(opts.WriteDir), CatalogFile),
+		m:    make(map[string]*catalogEntry),
+	}
+	err := c.load()
+	if errors.Is(err, os.ErrNotExist) {
+		err = c.build(opts)
+	}
+	if err != nil {
+		return nil, err
+	}
+	err = c.scanIncompact(opts)
+	if err != nil {
+		return nil, err
+	}
+	return c, c.Save()
+}
+
+func (c *Catalog) load() error {
+	hdrs, err := readHeaderFile(c.file)
+	if err != nil {
+		return err
+	}
+	for _, hdr := range hdrs {
+		h, err := storage.HashLabels(hdr.Labels)
+		if err != nil {
+			return err
+		}
+		c.m[h] = &catalogEntry{Series: Series{
+			Hash:   h,
+			Labels: hdr.Labels,
+			Start:  hdr.Start,
+			End:    hdr.End,
+		}}
+	}
+	return nil
+}
+
+func (c *Catalog) build(opts *Options) error {
+	for _, dir := range []string{opts.WriteDir, opts.CompactDir} {
+		ds, err := osReadDir(dir)
+		if err != nil {
+			if errors.Is(err, os.ErrNotExist) {
+				continue
+			}
+			return err
+		}
+		for _, d := range ds {
+			if !d.IsDir() {
+				continue
+			}
+			hdrs, err := readHeaders(fmt.Sprintf("%s/%s", dir, d.Name()))
+			if err != nil {
+				if errors.Is(err, os.ErrNotExist) {
+					continue
+				}
+				return err
+			}
+			for _, hdr := range hdrs {
+				h, err := storage.HashLabels(hdr.Labels)
+				if err != nil {
+					return err
+				}
+				c.add(h, hdr.Labels, hdr.Start, hdr.End, time.Time{})
+			}
+		}
+	}
+	err := c.addRemote(opts)
+	if err != nil {
+		logrus.WithError(err).Warn("build catalog without remote chunks")
+		c.noRemote = true
+	}
+	return nil
+}
+
+// addRemote adds the streams of uploaded chunks.
+func (c *Catalog) addRemote(opts *Options) error {
+	hdrs, err := remoteHeaders(opts)
+	if err != nil {
+		return err
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for _, hdr := range hdrs {
+		h, err := storage.HashLabels(hdr.Labels)
+		if err != nil {
+			return err
+		}
+		c.add(h, hdr.Labels, hdr.Start, hdr.End, time.Time{})
+	}
+	c.noRemote = false
+	return nil
+}
+
+// scanIncompact widens series by the entries of incompact chunks, whose
+// headers have no time range and whose later writes may not have been saved.
+func (c *Catalog) scanIncompact(opts *Options) error {
+	ds, err := osReadDir(opts.WriteDir)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil
+		}
+		return err
+	}
+	for _, d := range ds {
+		if !d.IsDir() {
+			continue
+		}
+		dir := fmt.Sprintf("%s/%s", opts.WriteDir, d.Name())
+		hdrs, err := readHeaders(dir)
+		if err != nil {
+			if errors.Is(err, os.ErrNotExist) {
+				continue
+			}
+			return err
+		}
+		for _, hdr := range hdrs {
+			var start, end time.Time
+			err := readFileBlock(context.Background(), fmt.Sprintf("%s/%s", dir, WriteChunkFile), hdr, &storage.ReadOptions{
+				ResultFunc: func(e storage.LogEntry) {
+					if start.IsZero() || e.Time.Before(start) {
+						start = e.Time
+					}
+					if e.Time.After(end) {
+						end = e.Time
+					}
+				},
+			})
+			if err != nil {
+				if errors.Is(err, os.ErrNotExist) {
+					continue
+				}
+				return err
+			}
+			h, err := storage.HashLabels(hdr.Labels)
+			if err != nil {
+				return err
+			}
+			c.add(h, hdr.Labels, start, end, time.Time{})
+		}
+	}
+	return nil
+}
+
+func (c *Catalog) add(hash string, labels map[string]string, start, end, updated time.Time) {
+	e, ok := c.m[hash]
+	if !ok {
+		c.m[hash] = &catalogEntry{
+			Series: Series{
+				Hash:   hash,
+				Labels: labels,
+				Start:  start,
+				End:    end,
+			},
+			updated: updated,
+		}
+		c.dirty = true
+		return
+	}
+	// zero bounds of incompact headers are unknown and must not widen.
+	if !start.IsZero() && (e.Start.IsZero() || start.Before(e.Start)) {
+		e.Start = start
+		c.dirty = true
+	}
+	if !end.IsZero() && end.After(e.End) {
+		e.End = end
+		c.dirty = true
+	}
+	e.updated = updated
+}
+
+// Add records entries written to the stream hash.
+func (c *Catalog) Add(hash string, es []storage.LogEntry) {
+	if len(es) == 0 {
+		return
+	}
+	start, end := es[0].Time, es[0].Time
+	for _, e := range es[1:] {
+		if e.Time.Before(start) {
+			start = e.Time
+		}
+		if e.Time.After(end) {
+			end = e.Time
+		}
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.add(hash, es[0].Labels, start, end, time.Now())
+}
+
+func (c *Catalog) Get(hash string) (Series, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	e, ok := c.m[hash]
+	if !ok {
+		return Series{}, false
+	}
+	return e.Series, true
+}
+
+// Series returns all series sorted by hash.
+func (c *Catalog) Series() []Series {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	series := make([]Series, 0, len(c.m))
+	for _, e := range c.m {
+		series = append(series, e.Series)
+	}
+	sort.Slice(series, func(i, j int) bool { return series[i].Hash < series[j].Hash })
+	return series
+}
+
+// retain removes series not in live that were not written since.
+func (c *Catalog) retain(live map[string]bool, since time.Time) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for h, e := range c.m {
+		if !live[h] && e.updated.Before(since) {
+			delete(c.m, h)
+			c.dirty = true
+		}
+	}
+}
+
+// Save writes the catalog if it changed. Readers are only blocked while it is
+// encoded.
+func (c *Catalog) Save() error {
+	buf, err := c.encode()
+	if buf == nil || err != nil {
+		return err
+	}
+	err = c.write(buf.Bytes())
+	if err != nil {
+		c.mu.Lock()
+		c.dirty = true
+		c.mu.Unlock()
+	}
+	return err
+}
+
+// encode returns the catalog file if it changed, and marks it saved.
+func (c *Catalog) encode() (*bytes.Buffer, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if !c.dirty {
+		return nil, nil
+	}
+	hashes := make([]string, 0, len(c.m))
+	for h := range c.m {
+		hashes = append(hashes, h)
+	}
+	sort.Strings(hashes)
+	buf := new(bytes.Buffer)
+	for _, h := range hashes {
+		e := c.m[h]
+		err := chunkio.WriteHeader(buf, &chunkio.Header{
+			Labels: e.Labels,
+			Start:  e.Start,
+			End:    e.End,
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+	c.dirty = false
+	return buf, nil
+}
+
+func (c *Catalog) write(b []byte) error {
+	dir := filepath.Dir(c.file)
+	err := os.MkdirAll(dir, 0777)
+	if err != nil {
+		return err
+	}
+	tmpFile := filepath.Join(dir, CatalogTmpFile)
+	err = func() error {
+		f, err := os.OpenFile(tmpFile, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0777)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+
+		_, err = f.Write(b)
+		if err != nil {
+			return err
+		}
+		return f.Sync()
+	}()
+	if err != nil {
+		return err
+	}
+	err = os.Rename(tmpFile, c.file)
+	if err != nil {
+		return err
+	}
+	return syncDir(dir)
+}
+
+// liveStreams returns the hashes of streams that have chunks in opts, or nil
+// if the remote cannot be read.
+func liveStreams(opts *Options) (map[string]bool, error) {
+	live := make(map[string]bool)
+	hashes, err := ListStreams(opts)
+	if err != nil {
+		return nil, err
+	}
+	for _, h := range hashes {
+		live[h] = true
+	}
+	ds, err := osReadDir(opts.CompactDir)
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return nil, err
+	}
+	for _, d := range ds {
+		if !d.IsDir() {
+			continue
+		}
+		hdrs, err := readHeaders(fmt.Sprintf("%s/%s", opts.CompactDir, d.Name()))
+		if err != nil {
+			if errors.Is(err, os.ErrNotExist) {
+				continue
+			}
+			return nil, err
+		}
+		for _, hdr := range hdrs {
+			h, err := storage.HashLabels(hdr.Labels)
+			if err != nil {
+				return nil, err
+			}
+			live[h] = true
+		}
+	}
+	hdrs, err := remoteHeaders(opts)
+	if err != nil {
+		logrus.WithError(err).Warn("list remote streams")
+		return nil, nil
+	}
+	for _, hdr := range hdrs {
+		h, err := storage.HashLabels(hdr.Labels)
+		if err != nil {
+			return nil, err
+		}
+		live[h] = true
+	}
+	return live, nil
+}
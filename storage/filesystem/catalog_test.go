@@ -0,0 +1,114 @@
+package filesystem
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/commentlens/loghouse/storage"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCatalog(t *testing.T) {
+	dataDir := t.TempDir()
+	opts := NewOptions(dataDir)
+	start := now().Add(-time.Hour)
+	es := []storage.LogEntry{
+		{Labels: map[string]string{"app": "a"}, Time: start.Add(time.Second), Data: []byte("1")},
+		{Labels: map[string]string{"app": "a"}, Time: start, Data: []byte("2")},
+		{Labels: map[string]string{"app": "b"}, Time: start.Add(time.Minute), Data: []byte("3")},
+	}
+	err := NewWriter(opts).Write(es)
+	require.NoError(t, err)
+
+	// without a catalog file, it is built from headers, and incompact bounds
+	// from their entries.
+	catalog, err := OpenCatalog(opts)
+	require.NoError(t, err)
+	require.Len(t, catalog.Series(), 2)
+	for _, s := range catalog.Series() {
+		require.False(t, s.Start.IsZero())
+	}
+
+	opts.Catalog = catalog
+	err = NewWriter(opts).Write(es)
+	require.NoError(t, err)
+	err = NewWriter(opts).Write([]storage.LogEntry{
+		{Labels: map[string]string{"app": "c"}, Time: start, Data: []byte("4")},
+	})
+	require.NoError(t, err)
+
+	// new streams are saved right away, bounds by the compactor.
+	reopened, err := OpenCatalog(NewOptions(dataDir))
+	require.NoError(t, err)
+	require.Len(t, reopened.Series(), 3)
+	c := compactor{opts: opts}
+	err = c.Compact()
+	require.NoError(t, err)
+	reopened, err = OpenCatalog(NewOptions(dataDir))
+	require.NoError(t, err)
+	require.Equal(t, catalog.Series(), reopened.Series())
+	hash, err := storage.HashLabels(map[string]string{"app": "a"})
+	require.NoError(t, err)
+	s, ok := reopened.Get(hash)
+	require.True(t, ok)
+	require.Equal(t, map[string]string{"app": "a"}, s.Labels)
+	require.True(t, s.Start.Equal(start))
+	require.True(t, s.End.Equal(start.Add(time.Second)))
+
+	// the reader skips incompact streams by catalog labels and bounds.
+	var got []storage.LogEntry
+	err = NewCompactReader(&CompactReaderOptions{Storage: opts}).Read(context.Background(), &storage.ReadOptions{
+		Start: start.Add(30 * time.Second),
+		ResultFunc: func(e storage.LogEntry) {
+			got = append(got, e)
+		},
+	})
+	require.NoError(t, err)
+	require.Len(t, got, 2)
+	for _, e := range got {
+		require.Equal(t, "3", string(e.Data))
+	}
+
+	// streams without chunks are pruned.
+	err = os.RemoveAll(filepath.Join(opts.WriteDir, hash))
+	require.NoError(t, err)
+	err = c.Compact()
+	require.NoError(t, err)
+	_, ok = catalog.Get(hash)
+	require.False(t, ok)
+	require.Len(t, catalog.Series(), 2)
+}
+
+func TestCatalogReopen(t *testing.T) {
+	dataDir := t.TempDir()
+	opts := NewOptions(dataDir)
+	catalog, err := OpenCatalog(opts)
+	require.NoError(t, err)
+	opts.Catalog = catalog
+	start := now().Add(-time.Hour)
+	for _, e := range []storage.LogEntry{
+		{Labels: map[string]string{"app": "a"}, Time: start, Data: []byte("1")},
+		{Labels: map[string]string{"app": "a"}, Time: start.Add(time.Minute), Data: []byte("2")},
+	} {
+		err := NewWriter(opts).Write([]storage.LogEntry{e})
+		require.NoError(t, err)
+	}
+
+	// nothing was saved; incompact streams are rescanned.
+	require.NoFileExists(t, catalog.file)
+	opts = NewOptions(dataDir)
+	opts.Catalog, err = OpenCatalog(opts)
+	require.NoError(t, err)
+	var got []string
+	err = NewCompactReader(&CompactReaderOptions{Storage: opts}).Read(context.Background(), &storage.ReadOptions{
+		Start: start.Add(30 * time.Second),
+		ResultFunc: func(e storage.LogEntry) {
+			got = append(got, string(e.Data))
+		},
+	})
+	require.NoError(t, err)
+	require.Equal(t, []string{"2"}, got)
+}
@@ -1,118 +1,383 @@
 package filesystem
 
 import (
+	"container/heap"
 	"context"
 	"errors"
 	"os"
+	"path/filepath"
+	"sort"
 	"sync"
 
 	"github.com/commentlens/loghouse/storage"
+	"github.com/commentlens/loghouse/storage/chunkio"
 	"github.com/sirupsen/logrus"
 )
 
 type CompactReaderOptions struct {
+	Storage     *Options
 	ReaderCount int
-	Reverse     bool
+	// Ordered merges blocks so that ResultFunc is called in time order, or
+	// newest first if Reverse is set. Otherwise blocks are streamed to
+	// ResultFunc by ReaderCount goroutines concurrently.
+	Ordered bool
+	Reverse bool
 }
 
+// NewCompactReader returns a reader over incompact and compacted chunks.
 func NewCompactReader(opts *CompactReaderOptions) storage.Reader {
+	readerCount := opts.ReaderCount
+	if readerCount < 1 {
+		readerCount = 1
+	}
 	return &compactReader{
-		readerCount: opts.ReaderCount,
+		writeDir:    opts.Storage.WriteDir,
+		compactDir:  opts.Storage.CompactDir,
+		readerCount: readerCount,
+		ordered:     opts.Ordered,
 		reverse:     opts.Reverse,
+		catalog:     opts.Storage.Catalog,
+		postings:    opts.Storage.Postings,
+		remote:      opts.Storage.Remote,
+		cache:       opts.Storage.Cache,
 	}
 }
 
 type compactReader struct {
+	writeDir    string
+	compactDir  string
 	readerCount int
+	ordered     bool
 	reverse     bool
+	catalog     *Catalog
+	postings    *Postings
+	remote      *Remote
+	cache       *Cache
 }
 
-func (r *compactReader) read(ctx context.Context, chunks []string, opts *storage.ReadOptions) error {
-	var wg sync.WaitGroup
-	defer wg.Wait()
+// block is a header of a local chunk file, or of a remote chunk name.
+type block struct {
+	chunk  string
+	remote bool
+	hdr    *chunkio.Header
+}
 
-	chIn := make(chan string)
-	defer close(chIn)
+type mergeEntry struct {
+	storage.LogEntry
+	seq int
+}
+
+type mergeHeap struct {
+	es      []mergeEntry
+	reverse bool
+}
+
+func (h *mergeHeap) Len() int { return len(h.es) }
+
+func (h *mergeHeap) Less(i, j int) bool {
+	a, b := h.es[i], h.es[j]
+	if !a.Time.Equal(b.Time) {
+		return a.Time.Before(b.Time) != h.reverse
+	}
+	return (a.seq < b.seq) != h.reverse
+}
+
+func (h *mergeHeap) Swap(i, j int) { h.es[i], h.es[j] = h.es[j], h.es[i] }
+
+func (h *mergeHeap) Push(x interface{}) { h.es = append(h.es, x.(mergeEntry)) }
+
+func (h *mergeHeap) Pop() interface{} {
+	e := h.es[len(h.es)-1]
+	h.es = h.es[:len(h.es)-1]
+	return e
+}
+
+// before reports whether b may hold entries that come before t in read
+// order. Incompact chunks have no time range, so they always may.
+func (r *compactReader) before(b block, t storage.LogEntry) bool {
+	if r.reverse {
+		return b.hdr.End.IsZero() || !b.hdr.End.Before(t.Time)
+	}
+	return b.hdr.Start.IsZero() || !b.hdr.Start.After(t.Time)
+}
+
+func (r *compactReader) matchHeaders(ctx context.Context, chunk block, opts *storage.ReadOptions) ([]*chunkio.Header, error) {
+	if !chunk.remote {
+		return matchHeaders(chunk.chunk, opts, r.cache)
+	}
+	hdrs, err := r.remote.Headers(ctx, chunk.chunk)
+	if err != nil {
+		return nil, err
+	}
+	var matched []*chunkio.Header
+	for _, hdr := range hdrs {
+		if matchHeader(hdr, opts) {
+			matched = append(matched, hdr)
+		}
+	}
+	return matched, nil
+}
 
+// remoteName returns the remote name of a local compacted chunk, which may
+// have been uploaded and removed since it was listed.
+func (r *compactReader) remoteName(chunk string) (string, bool) {
+	if r.remote == nil || filepath.Dir(filepath.Dir(chunk)) != r.compactDir {
+		return "", false
+	}
+	return filepath.Base(filepath.Dir(chunk)), true
+}
+
+func (r *compactReader) blocks(ctx context.Context, chunks []block, opts *storage.ReadOptions) ([]block, error) {
+	var wg sync.WaitGroup
+	var mu sync.Mutex
+	var blocks []block
+	chIn := make(chan block)
 	for i := 0; i < r.readerCount; i++ {
 		wg.Add(1)
 		go func() {
 			defer wg.Done()
 
 			for chunk := range chIn {
-				cr := NewReader([]string{chunk})
-				if r.reverse {
-					var es []storage.LogEntry
-					nopts := *opts
-					nopts.ResultFunc = func(e storage.LogEntry) {
-						es = append(es, e)
-					}
-					err := cr.Read(ctx, &nopts)
-					if err != nil && !errors.Is(err, context.Canceled) {
-						logrus.WithError(err).Warn(chunk)
-					}
-					for i := len(es) - 1; i >= 0; i-- {
-						opts.ResultFunc(es[i])
-					}
-				} else {
-					err := cr.Read(ctx, opts)
-					if err != nil && !errors.Is(err, context.Canceled) {
-						logrus.WithError(err).Warn(chunk)
+				if name, ok := r.remoteName(chunk.chunk); ok && !chunk.remote {
+					if _, err := os.Stat(chunk.chunk); errors.Is(err, os.ErrNotExist) {
+						chunk = block{chunk: name, remote: true}
 					}
 				}
+				hdrs, err := r.matchHeaders(ctx, chunk, opts)
+				if err != nil {
+					logrus.WithError(err).Warn(chunk.chunk)
+					continue
+				}
+				mu.Lock()
+				for _, hdr := range hdrs {
+					blocks = append(blocks, block{chunk: chunk.chunk, remote: chunk.remote, hdr: hdr})
+				}
+				mu.Unlock()
+			}
+		}()
+	}
+	err := func() error {
+		defer close(chIn)
+		for _, chunk := range chunks {
+			select {
+			case <-ctx.Done():
+				return ctx.Err()
+			case chIn <- chunk:
+			}
+		}
+		return nil
+	}()
+	wg.Wait()
+	if err != nil {
+		return nil, err
+	}
+	// blocks are loaded in this order; incompact chunks go first.
+	sort.SliceStable(blocks, func(i, j int) bool {
+		a, b := blocks[i].hdr, blocks[j].hdr
+		if r.reverse {
+			if a.End.IsZero() || b.End.IsZero() {
+				return a.End.IsZero() && !b.End.IsZero()
+			}
+			return a.End.After(b.End)
+		}
+		if a.Start.IsZero() || b.Start.IsZero() {
+			return a.Start.IsZero() && !b.Start.IsZero()
+		}
+		return a.Start.Before(b.Start)
+	})
+	return blocks, nil
+}
+
+// load reads blocks concurrently into memory.
+func (r *compactReader) load(ctx context.Context, blocks []block, opts *storage.ReadOptions) [][]storage.LogEntry {
+	results := make([][]storage.LogEntry, len(blocks))
+	var wg sync.WaitGroup
+	for i, b := range blocks {
+		wg.Add(1)
+		go func(i int, b block) {
+			defer wg.Done()
+
+			nopts := *opts
+			nopts.ResultFunc = func(e storage.LogEntry) {
+				results[i] = append(results[i], e)
+			}
+			r.readBlock(ctx, b, &nopts)
+		}(i, b)
+	}
+	wg.Wait()
+	return results
+}
+
+// stream reads blocks concurrently, passing their entries to ResultFunc as
+// they are read.
+func (r *compactReader) stream(ctx context.Context, blocks []block, opts *storage.ReadOptions) error {
+	var wg sync.WaitGroup
+	chIn := make(chan block)
+	for i := 0; i < r.readerCount; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			for b := range chIn {
+				r.readBlock(ctx, b, opts)
 			}
 		}()
 	}
-	for _, chunk := range chunks {
+	defer wg.Wait()
+	defer close(chIn)
+
+	for _, b := range blocks {
 		select {
 		case <-ctx.Done():
 			return ctx.Err()
-		case chIn <- chunk:
+		case chIn <- b:
 		}
 	}
 	return nil
 }
 
-func (r *compactReader) Read(ctx context.Context, opts *storage.ReadOptions) error {
-	var dirs []string
-	if r.reverse {
-		dirs = []string{WriteDir, CompactDir}
+// readBlock reads a block, from the remote if its local chunk was uploaded
+// and removed since it was listed. Errors are logged.
+func (r *compactReader) readBlock(ctx context.Context, b block, opts *storage.ReadOptions) {
+	var err error
+	if b.remote {
+		err = r.remote.readBlock(ctx, b.chunk, b.hdr, opts, r.cache)
 	} else {
-		dirs = []string{CompactDir, WriteDir}
+		err = readBlock(ctx, b.chunk, b.hdr, opts, r.cache)
+		if name, ok := r.remoteName(b.chunk); ok && errors.Is(err, os.ErrNotExist) {
+			err = r.remote.readBlock(ctx, name, b.hdr, opts, r.cache)
+		}
+	}
+	if err != nil && !errors.Is(err, context.Canceled) {
+		logrus.WithError(err).Warn(b.chunk)
+	}
+}
+
+// matchMeta reports whether a compacted chunk dir may match opts by its meta,
+// without opening its headers. Dirs without a current meta may.
+func (r *compactReader) matchMeta(dir string, opts *storage.ReadOptions) bool {
+	meta, err := readMeta(dir)
+	if err != nil {
+		if !errors.Is(err, os.ErrNotExist) {
+			logrus.WithError(err).Warn(dir)
+		}
+		return true
 	}
-	for _, dir := range dirs {
-		chunks, err := findSortFiles(dir, WriteChunkFile, func(ds []os.DirEntry) lessFunc {
-			defaultLessFunc := func() lessFunc {
-				if r.reverse {
-					return func(i, j int) bool { return ds[i].Name() > ds[j].Name() }
+	return meta == nil || chunkio.MatchMeta(meta, opts)
+}
+
+// matchChunk reports whether a compacted chunk may match opts by its postings,
+// or else by the meta in dir, if local.
+func (r *compactReader) matchChunk(name, dir string, candidates map[string]bool, indexed func(string) bool, opts *storage.ReadOptions) bool {
+	if r.postings != nil && indexed(name) {
+		return candidates[name]
+	}
+	return dir == "" || r.matchMeta(dir, opts)
+}
+
+// Read streams matching blocks to ResultFunc, or merges them if ordered. A
+// merged block is only read once the next entry to return could come after
+// its start (or end, if reverse), so that callers cancelling at a limit skip
+// the remaining blocks.
+func (r *compactReader) Read(ctx context.Context, opts *storage.ReadOptions) error {
+	var candidates map[string]bool
+	var indexed func(string) bool
+	if r.postings != nil {
+		candidates, indexed = r.postings.Candidates(opts)
+	}
+	// remote chunks are listed before and after local ones, so that chunks
+	// uploaded and removed locally in between are not missed. If the bucket
+	// cannot be listed, only local chunks are read.
+	remote := make(map[string]bool)
+	listRemote := func() {
+		if r.remote == nil {
+			return
+		}
+		names, err := r.remote.Chunks(ctx)
+		if err != nil {
+			logrus.WithError(err).Warn("list remote chunks")
+			return
+		}
+		for _, name := range names {
+			remote[name] = true
+		}
+	}
+	listRemote()
+	var chunks []block
+	local := make(map[string]bool)
+	for _, dir := range []string{r.writeDir, r.compactDir} {
+		paths, err := findFiles(dir, WriteChunkFile)
+		if err != nil {
+			return err
+		}
+		for _, path := range paths {
+			// incompact dirs are named by stream hash.
+			if dir == r.writeDir && r.catalog != nil {
+				if s, ok := r.catalog.Get(filepath.Base(filepath.Dir(path))); ok && !chunkio.MatchHeader(&chunkio.Header{
+					Labels: s.Labels,
+					Start:  s.Start,
+					End:    s.End,
+				}, opts) {
+					continue
 				}
-				return func(i, j int) bool { return ds[i].Name() < ds[j].Name() }
 			}
-			switch dir {
-			case WriteDir:
-				var mts []int64
-				for _, d := range ds {
-					fi, err := d.Info()
-					if err != nil {
-						return defaultLessFunc()
-					}
-					mts = append(mts, fi.ModTime().UnixMilli())
+			if dir == r.compactDir {
+				name := filepath.Base(filepath.Dir(path))
+				local[name] = true
+				if !r.matchChunk(name, filepath.Dir(path), candidates, indexed, opts) {
+					continue
 				}
-				if r.reverse {
-					return func(i, j int) bool { return mts[i] > mts[j] }
+			}
+			chunks = append(chunks, block{chunk: path})
+		}
+	}
+	listRemote()
+	names := make([]string, 0, len(remote))
+	for name := range remote {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		// a chunk is both local and remote until its upload completes.
+		if local[name] || !r.matchChunk(name, "", candidates, indexed, opts) {
+			continue
+		}
+		chunks = append(chunks, block{chunk: name, remote: true})
+	}
+	blocks, err := r.blocks(ctx, chunks, opts)
+	if err != nil {
+		return err
+	}
+	if !r.ordered {
+		return r.stream(ctx, blocks, opts)
+	}
+	h := &mergeHeap{reverse: r.reverse}
+	var seq int
+	for {
+		var n int
+		for n < r.readerCount && n < len(blocks) && (h.Len() == 0 && n == 0 || h.Len() > 0 && r.before(blocks[n], h.es[0].LogEntry)) {
+			n++
+		}
+		if n > 0 {
+			for _, es := range r.load(ctx, blocks[:n], opts) {
+				for _, e := range es {
+					heap.Push(h, mergeEntry{LogEntry: e, seq: seq})
+					seq++
 				}
-				return func(i, j int) bool { return mts[i] < mts[j] }
-			default:
-				return defaultLessFunc()
 			}
-		})
-		if err != nil {
-			return err
+			blocks = blocks[n:]
+			if err := ctx.Err(); err != nil {
+				return err
+			}
+			continue
 		}
-		err = r.read(ctx, chunks, opts)
-		if err != nil {
+		if h.Len() == 0 {
+			return nil
+		}
+		e := heap.Pop(h).(mergeEntry)
+		opts.ResultFunc(e.LogEntry)
+		if err := ctx.Err(); err != nil {
 			return err
 		}
 	}
-	return nil
 }
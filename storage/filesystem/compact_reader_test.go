@@ -0,0 +1,103 @@
+package filesystem
+
+import (
+	"context"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/commentlens/loghouse/storage"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCompactReaderOrder(t *testing.T) {
+	opts := NewOptions(t.TempDir())
+	w := NewWriter(opts)
+	start := now().Add(-time.Hour)
+	entry := func(app string, sec int) storage.LogEntry {
+		return storage.LogEntry{
+			Labels: map[string]string{"app": app},
+			Time:   start.Add(time.Duration(sec) * time.Second),
+			Data:   []byte(app),
+		}
+	}
+	err := w.Write([]storage.LogEntry{entry("a", 1), entry("a", 4), entry("b", 8), entry("b", 2)})
+	require.NoError(t, err)
+
+	// compact the chunks written so far.
+	err = filepath.WalkDir(opts.WriteDir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return nil
+		}
+		oldTime := now().Add(-2 * CompactChunkMaxAge)
+		return os.Chtimes(path, oldTime, oldTime)
+	})
+	require.NoError(t, err)
+	c := compactor{opts: opts}
+	chunks, err := c.FindCompactibleChunk()
+	require.NoError(t, err)
+	err = c.SwapChunk(chunks)
+	require.NoError(t, err)
+	err = c.Compact()
+	require.NoError(t, err)
+	chunks, err = findFiles(opts.CompactDir, WriteChunkFile)
+	require.NoError(t, err)
+	require.Len(t, chunks, 1)
+
+	err = w.Write([]storage.LogEntry{entry("a", 7), entry("b", 5), entry("c", 3), entry("c", 9)})
+	require.NoError(t, err)
+
+	for _, test := range []struct {
+		reverse bool
+		limit   int
+		want    []int
+	}{
+		{want: []int{1, 2, 3, 4, 5, 7, 8, 9}},
+		{reverse: true, want: []int{9, 8, 7, 5, 4, 3, 2, 1}},
+		{limit: 3, want: []int{1, 2, 3}},
+		{reverse: true, limit: 3, want: []int{9, 8, 7}},
+	} {
+		ctx, cancel := context.WithCancel(context.Background())
+		var got []int
+		r := NewCompactReader(&CompactReaderOptions{
+			Storage:     opts,
+			ReaderCount: 2,
+			Ordered:     true,
+			Reverse:     test.reverse,
+		})
+		err := r.Read(ctx, &storage.ReadOptions{
+			ResultFunc: func(e storage.LogEntry) {
+				got = append(got, int(e.Time.Sub(start)/time.Second))
+				if len(got) == test.limit {
+					cancel()
+				}
+			},
+		})
+		cancel()
+		if test.limit > 0 {
+			require.ErrorIs(t, err, context.Canceled)
+		} else {
+			require.NoError(t, err)
+		}
+		require.Equal(t, test.want, got)
+	}
+
+	// unordered reads stream every entry.
+	var mu sync.Mutex
+	var got []int
+	err = NewCompactReader(&CompactReaderOptions{
+		Storage:     opts,
+		ReaderCount: 2,
+	}).Read(context.Background(), &storage.ReadOptions{
+		ResultFunc: func(e storage.LogEntry) {
+			mu.Lock()
+			defer mu.Unlock()
+			got = append(got, int(e.Time.Sub(start)/time.Second))
+		},
+	})
+	require.NoError(t, err)
+	require.ElementsMatch(t, []int{1, 2, 3, 4, 5, 7, 8, 9}, got)
+}
@@ -8,15 +8,15 @@ import (
 	"github.com/commentlens/loghouse/storage"
 )
 
-const (
-	compactBackgroundInterval = time.Minute
-)
-
-func NewCompactWriter() interface {
+func NewCompactWriter(opts *Options) interface {
 	storage.Writer
 	BackgroundCompact(context.Context) error
+	Recover() error
 } {
-	return &compactWriter{}
+	return &compactWriter{
+		w: writer{opts: opts},
+		c: compactor{opts: opts},
+	}
 }
 
 type compactWriter struct {
@@ -26,7 +26,7 @@ type compactWriter struct {
 }
 
 func (w *compactWriter) BackgroundCompact(ctx context.Context) error {
-	ticker := time.NewTicker(compactBackgroundInterval)
+	ticker := time.NewTicker(w.c.opts.CompactInterval)
 	defer ticker.Stop()
 
 	for {
@@ -59,6 +59,12 @@ func (w *compactWriter) BackgroundCompact(ctx context.Context) error {
 	}
 }
 
+func (w *compactWriter) Recover() error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.w.recover()
+}
+
 func (w *compactWriter) Write(es []storage.LogEntry) error {
 	w.mu.Lock()
 	defer w.mu.Unlock()
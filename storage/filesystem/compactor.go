@@ -15,10 +15,12 @@ import (
 	"github.com/commentlens/loghouse/storage/chunkio"
 	"github.com/commentlens/loghouse/storage/tlv"
 	"github.com/oklog/ulid/v2"
+	"github.com/sirupsen/logrus"
 )
 
 const (
 	CompactDir               = "data/compact"
+	CompactInterval          = time.Minute
 	CompactTmpFile           = "chunk.loghouse.tmp"
 	CompactHeaderFile        = "header.loghouse"
 	CompactIndexFile         = "index.loghouse"
@@ -28,47 +30,99 @@ const (
 	CompactChunkMaxSize      = 1024 * 1024 * 80
 	CompactEmptyDirRemoveAge = time.Minute
 	CompactChunkRemoveAge    = 31 * 24 * time.Hour
+	CompactCompression       = chunkio.CompressionS2
+	RemoteAge                = 7 * 24 * time.Hour
 )
 
-type compactor struct{}
-
-func (*compactor) Compact() error {
-	return compact()
-}
-
-func (*compactor) FindCompactibleChunk() ([]string, error) {
-	return findCompactibleChunk()
-}
-
-func (*compactor) SwapChunk(chunks []string) error {
-	return swapChunk(chunks)
+type compactor struct {
+	opts *Options
 }
 
-func compact() error {
-	chunks, err := findFiles(WriteDir, CompactTmpFile)
+func (c *compactor) Compact() error {
+	chunks, err := findFiles(c.opts.WriteDir, CompactTmpFile)
 	if err != nil {
 		return err
 	}
-	err = compactChunks(chunks)
+	err = c.compactChunks(chunks)
 	if err != nil {
 		return err
 	}
-	err = removeEmptyDir(WriteDir, CompactEmptyDirRemoveAge)
+	err = removeEmptyDir(c.opts.WriteDir, c.opts.CompactEmptyDirRemoveAge)
+	if err != nil {
+		return err
+	}
+	err = c.applyRetention()
+	if err != nil {
+		return err
+	}
+	_, err = c.recompress()
+	if err != nil {
+		return err
+	}
+	if c.opts.Remote != nil {
+		// the bucket may be unavailable for a while; the next compaction
+		// retries.
+		err = c.retainRemote()
+		if err != nil {
+			logrus.WithError(err).Warn("retain remote chunks")
+		}
+	}
+	err = c.pruneCatalog()
 	if err != nil {
 		return err
 	}
-	err = removeOldChunk(CompactDir, CompactChunkRemoveAge)
+	err = rebuildIndex(c.opts.CompactDir)
 	if err != nil {
 		return err
 	}
-	err = rebuildIndex(CompactDir)
+	err = rebuildMeta(c.opts.CompactDir)
 	if err != nil {
 		return err
 	}
+	if c.opts.Remote != nil {
+		err = c.uploadChunks()
+		if err != nil {
+			logrus.WithError(err).Warn("upload chunks")
+		}
+	}
+	if c.opts.Postings != nil {
+		return c.opts.Postings.update(c.opts.CompactDir, c.opts.Remote)
+	}
 	return nil
 }
 
-func compactChunks(chunks []string) error {
+// pruneCatalog removes streams left without chunks by retention and saves the
+// catalog. Nothing is removed while the remote cannot be read.
+func (c *compactor) pruneCatalog() error {
+	if c.opts.Catalog == nil {
+		return nil
+	}
+	c.opts.Catalog.mu.Lock()
+	noRemote := c.opts.Catalog.noRemote
+	c.opts.Catalog.mu.Unlock()
+	if noRemote {
+		err := c.opts.Catalog.addRemote(c.opts)
+		if err != nil {
+			logrus.WithError(err).Warn("add remote streams to catalog")
+		}
+	}
+	since := time.Now()
+	live, err := liveStreams(c.opts)
+	if err != nil {
+		return err
+	}
+	if live != nil {
+		c.opts.Catalog.retain(live, since)
+	}
+	// an unsaved catalog stays dirty and is saved by the next compaction.
+	err = c.opts.Catalog.Save()
+	if err != nil {
+		logrus.WithError(err).Warn("save catalog")
+	}
+	return nil
+}
+
+func (c *compactor) compactChunks(chunks []string) error {
 	chunkID := ulid.Make().String()
 	var bytesTotal uint64
 	for _, chunk := range chunks {
@@ -87,17 +141,21 @@ func compactChunks(chunks []string) error {
 		}
 		sort.SliceStable(es, func(i, j int) bool { return es[i].Time.Before(es[j].Time) })
 
+		compression := c.opts.CompactCompression
+		if compression == "" {
+			compression = chunkio.CompressionS2
+		}
 		buf := new(bytes.Buffer)
-		err = chunkio.WriteData(buf, es, true)
+		err = chunkio.WriteBlock(buf, es, compression, c.opts.CompactCompressionLevel)
 		if err != nil {
 			return err
 		}
-		err = os.MkdirAll(fmt.Sprintf("%s/%s", CompactDir, chunkID), 0777)
+		err = os.MkdirAll(fmt.Sprintf("%s/%s", c.opts.CompactDir, chunkID), 0777)
 		if err != nil {
 			return err
 		}
 		err = func() error {
-			f, err := os.OpenFile(fmt.Sprintf("%s/%s/%s", CompactDir, chunkID, WriteChunkFile), os.O_WRONLY|os.O_APPEND|os.O_CREATE, 0777)
+			f, err := os.OpenFile(fmt.Sprintf("%s/%s/%s", c.opts.CompactDir, chunkID, WriteChunkFile), os.O_WRONLY|os.O_APPEND|os.O_CREATE, 0777)
 			if err != nil {
 				return err
 			}
@@ -107,27 +165,31 @@ func compactChunks(chunks []string) error {
 			if err != nil {
 				return err
 			}
-			return nil
+			return f.Sync()
 		}()
 		if err != nil {
 			return err
 		}
 		err = func() error {
-			f, err := os.OpenFile(fmt.Sprintf("%s/%s/%s", CompactDir, chunkID, CompactHeaderFile), os.O_WRONLY|os.O_APPEND|os.O_CREATE, 0777)
+			f, err := os.OpenFile(fmt.Sprintf("%s/%s/%s", c.opts.CompactDir, chunkID, CompactHeaderFile), os.O_WRONLY|os.O_APPEND|os.O_CREATE, 0777)
 			if err != nil {
 				return err
 			}
 			defer f.Close()
 
-			return chunkio.WriteHeader(f, &chunkio.Header{
+			err = chunkio.WriteHeader(f, &chunkio.Header{
 				OffsetStart: bytesTotal,
 				Size:        uint64(buf.Len()),
 				Labels:      es[0].Labels,
 				Start:       es[0].Time,
 				End:         es[len(es)-1].Time,
-				Compression: "s2",
+				Compression: compression,
 				Count:       uint64(len(es)),
 			})
+			if err != nil {
+				return err
+			}
+			return f.Sync()
 		}()
 		if err != nil {
 			return err
@@ -137,7 +199,7 @@ func compactChunks(chunks []string) error {
 			return err
 		}
 		bytesTotal += uint64(buf.Len())
-		if bytesTotal < CompactChunkMaxSize {
+		if bytesTotal < uint64(c.opts.CompactChunkMaxSize) {
 			continue
 		}
 		chunkID = ulid.Make().String()
@@ -146,36 +208,36 @@ func compactChunks(chunks []string) error {
 	return nil
 }
 
-func chunkCompactible(chunk string) (uint8, error) {
+func (c *compactor) chunkCompactible(chunk string) (uint8, error) {
 	fi, err := os.Stat(chunk)
 	if err != nil {
 		return 0, err
 	}
 	fsize := fi.Size()
-	if fsize >= CompactChunkMaxSize {
+	if fsize >= c.opts.CompactChunkMaxSize {
 		return 2, nil
 	}
-	if fsize >= CompactChunkMinSize {
+	if fsize >= c.opts.CompactChunkMinSize {
 		return 1, nil
 	}
 	age := time.Since(fi.ModTime())
-	if age >= CompactChunkMaxAge {
+	if age >= c.opts.CompactChunkMaxAge {
 		return 2, nil
 	}
-	if age >= CompactChunkMinAge {
+	if age >= c.opts.CompactChunkMinAge {
 		return 1, nil
 	}
 	return 0, nil
 }
 
-func findCompactibleChunk() ([]string, error) {
-	chunks, err := findFiles(WriteDir, WriteChunkFile)
+func (c *compactor) FindCompactibleChunk() ([]string, error) {
+	chunks, err := findFiles(c.opts.WriteDir, WriteChunkFile)
 	if err != nil {
 		return nil, err
 	}
 	var nowChunks, laterChunks []string
 	for _, chunk := range chunks {
-		status, err := chunkCompactible(chunk)
+		status, err := c.chunkCompactible(chunk)
 		if err != nil {
 			return nil, err
 		}
@@ -194,7 +256,7 @@ func findCompactibleChunk() ([]string, error) {
 	return swappable, nil
 }
 
-func swapChunk(chunks []string) error {
+func (c *compactor) SwapChunk(chunks []string) error {
 	for _, chunk := range chunks {
 		err := os.Rename(chunk, fmt.Sprintf("%s/%s", filepath.Dir(chunk), CompactTmpFile))
 		if err != nil {
@@ -245,36 +307,6 @@ func removeEmptyDir(dir string, after time.Duration) error {
 	return nil
 }
 
-func removeOldChunk(dir string, after time.Duration) error {
-	ds, err := osReadDir(dir)
-	if err != nil {
-		if errors.Is(err, os.ErrNotExist) {
-			return nil
-		}
-		return err
-	}
-	for _, d := range ds {
-		err := func() error {
-			if !d.IsDir() {
-				return nil
-			}
-			chunkID, err := ulid.ParseStrict(d.Name())
-			if err != nil {
-				return err
-			}
-			if time.Since(time.UnixMilli(int64(chunkID.Time()))) < after {
-				return nil
-			}
-			path := fmt.Sprintf("%s/%s", dir, d.Name())
-			return os.RemoveAll(path)
-		}()
-		if err != nil {
-			return err
-		}
-	}
-	return nil
-}
-
 func rebuildIndex(dir string) error {
 	ds, err := osReadDir(dir)
 	if err != nil {
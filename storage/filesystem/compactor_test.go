@@ -44,7 +44,7 @@ func TestCompactor(t *testing.T) {
 	os.RemoveAll(WriteDir)
 	os.RemoveAll(CompactDir)
 
-	w := NewWriter()
+	w := NewWriter(NewOptions(DataDir))
 
 	es := []storage.LogEntry{
 		{
@@ -75,7 +75,7 @@ func TestCompactor(t *testing.T) {
 	err := w.Write(es)
 	require.NoError(t, err)
 
-	c := compactor{}
+	c := compactor{opts: NewOptions(DataDir)}
 	chunks, err := c.FindCompactibleChunk()
 	require.NoError(t, err)
 	err = c.SwapChunk(chunks)
@@ -108,7 +108,7 @@ func TestCompactor(t *testing.T) {
 	dirs, files, err = dirfiles(CompactDir)
 	require.NoError(t, err)
 	require.Len(t, dirs, 2)
-	require.Len(t, files, 3)
+	require.Len(t, files, 4)
 
 	err = markChunkCompactible()
 	require.NoError(t, err)
@@ -126,7 +126,7 @@ func TestCompactor(t *testing.T) {
 	dirs, files, err = dirfiles(CompactDir)
 	require.NoError(t, err)
 	require.Len(t, dirs, 2)
-	require.Len(t, files, 3)
+	require.Len(t, files, 4)
 
 	es2 := []storage.LogEntry{
 		{
@@ -156,7 +156,7 @@ func TestCompactor(t *testing.T) {
 	dirs, files, err = dirfiles(CompactDir)
 	require.NoError(t, err)
 	require.Len(t, dirs, 2)
-	require.Len(t, files, 3)
+	require.Len(t, files, 4)
 
 	chunks, err = findFiles(CompactDir, WriteChunkFile)
 	require.NoError(t, err)
@@ -191,7 +191,7 @@ func TestCompactReadWriter(t *testing.T) {
 	os.RemoveAll(WriteDir)
 	os.RemoveAll(CompactDir)
 
-	w := NewCompactWriter()
+	w := NewCompactWriter(NewOptions(DataDir))
 	es := []storage.LogEntry{
 		{
 			Labels: map[string]string{
@@ -222,6 +222,7 @@ func TestCompactReadWriter(t *testing.T) {
 	require.NoError(t, err)
 
 	r := NewCompactReader(&CompactReaderOptions{
+		Storage:     NewOptions(DataDir),
 		ReaderCount: 1,
 		Reverse:     false,
 	})
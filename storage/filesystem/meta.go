@@ -0,0 +1,113 @@
+package filesystem
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/commentlens/loghouse/storage/chunkio"
+)
+
+const (
+	CompactMetaFile    = "meta.loghouse"
+	CompactMetaTmpFile = "meta.loghouse.tmp"
+)
+
+// readMeta returns the meta of a compacted chunk dir, or nil if it is missing
+// or older than the header file.
+func readMeta(dir string) (*chunkio.Meta, error) {
+	fi, err := os.Stat(fmt.Sprintf("%s/%s", dir, CompactHeaderFile))
+	if err != nil {
+		return nil, err
+	}
+	f, err := os.Open(fmt.Sprintf("%s/%s", dir, CompactMetaFile))
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	defer f.Close()
+
+	buf := chunkio.NewBuffer()
+	defer chunkio.RecycleBuffer(buf)
+	buf.Reset(f)
+	meta, err := chunkio.ReadMeta(buf)
+	if err != nil {
+		return nil, err
+	}
+	if meta.HeaderSize != uint64(fi.Size()) {
+		return nil, nil
+	}
+	return meta, nil
+}
+
+// rebuildMeta writes the meta of compacted chunk dirs that are missing one or
+// were rewritten since.
+func rebuildMeta(compactDir string) error {
+	ds, err := osReadDir(compactDir)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil
+		}
+		return err
+	}
+	for _, d := range ds {
+		if !d.IsDir() {
+			continue
+		}
+		dir := fmt.Sprintf("%s/%s", compactDir, d.Name())
+		meta, err := readMeta(dir)
+		if errors.Is(err, os.ErrNotExist) {
+			continue
+		}
+		// meta is derived from headers, so unreadable meta is rebuilt.
+		if err == nil && meta != nil {
+			continue
+		}
+		err = buildMeta(dir)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func buildMeta(dir string) error {
+	fi, err := os.Stat(fmt.Sprintf("%s/%s", dir, CompactHeaderFile))
+	if err != nil {
+		return err
+	}
+	hdrs, err := readHeaders(dir)
+	if err != nil {
+		return err
+	}
+	buf := new(bytes.Buffer)
+	err = chunkio.WriteMeta(buf, chunkio.NewMeta(hdrs, uint64(fi.Size())))
+	if err != nil {
+		return err
+	}
+	tmpFile := fmt.Sprintf("%s/%s", dir, CompactMetaTmpFile)
+	err = func() error {
+		f, err := os.OpenFile(tmpFile, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0777)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+
+		_, err = f.Write(buf.Bytes())
+		if err != nil {
+			return err
+		}
+		return f.Sync()
+	}()
+	if err != nil {
+		return err
+	}
+	err = os.Rename(tmpFile, fmt.Sprintf("%s/%s", dir, CompactMetaFile))
+	if err != nil {
+		return err
+	}
+	return syncDir(dir)
+}
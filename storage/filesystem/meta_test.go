@@ -0,0 +1,79 @@
+package filesystem
+
+import (
+	"io/fs"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/commentlens/loghouse/storage"
+	"github.com/commentlens/loghouse/storage/chunkio"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMeta(t *testing.T) {
+	opts := NewOptions(t.TempDir())
+	c := compactor{opts: opts}
+	start := now().Add(-time.Hour)
+
+	err := NewWriter(opts).Write([]storage.LogEntry{
+		{Labels: map[string]string{"app": "api"}, Time: start, Data: []byte("1")},
+		{Labels: map[string]string{"app": "web"}, Time: start.Add(time.Minute), Data: []byte("2")},
+	})
+	require.NoError(t, err)
+	err = filepath.WalkDir(opts.WriteDir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return nil
+		}
+		oldTime := now().Add(-2 * CompactChunkMaxAge)
+		return os.Chtimes(path, oldTime, oldTime)
+	})
+	require.NoError(t, err)
+	chunks, err := c.FindCompactibleChunk()
+	require.NoError(t, err)
+	err = c.SwapChunk(chunks)
+	require.NoError(t, err)
+	err = c.Compact()
+	require.NoError(t, err)
+
+	chunks, err = findFiles(opts.CompactDir, WriteChunkFile)
+	require.NoError(t, err)
+	require.Len(t, chunks, 1)
+	dir := filepath.Dir(chunks[0])
+	meta, err := readMeta(dir)
+	require.NoError(t, err)
+	require.NotNil(t, meta)
+	require.True(t, meta.Start.Equal(start))
+	require.True(t, meta.End.Equal(start.Add(time.Minute)))
+	require.Equal(t, uint64(2), meta.Count)
+	require.Equal(t, map[string][]string{"app": {"api", "web"}}, meta.Labels)
+
+	r := &compactReader{}
+	require.True(t, r.matchMeta(dir, &storage.ReadOptions{Start: start, End: start}))
+	require.False(t, r.matchMeta(dir, &storage.ReadOptions{Start: start.Add(time.Hour)}))
+	require.False(t, r.matchMeta(dir, &storage.ReadOptions{Labels: map[string]string{"app": "db"}}))
+
+	// a changed header file makes the meta stale until the next compaction.
+	f, err := os.OpenFile(filepath.Join(dir, CompactHeaderFile), os.O_WRONLY|os.O_APPEND, 0777)
+	require.NoError(t, err)
+	err = chunkio.WriteHeader(f, &chunkio.Header{
+		Labels: map[string]string{"app": "db"},
+		Start:  start.Add(time.Hour),
+		End:    start.Add(time.Hour),
+	})
+	require.NoError(t, err)
+	require.NoError(t, f.Close())
+	meta, err = readMeta(dir)
+	require.NoError(t, err)
+	require.Nil(t, meta)
+	require.True(t, r.matchMeta(dir, &storage.ReadOptions{Labels: map[string]string{"app": "db"}}))
+
+	err = rebuildMeta(opts.CompactDir)
+	require.NoError(t, err)
+	meta, err = readMeta(dir)
+	require.NoError(t, err)
+	require.NotNil(t, meta)
+	require.True(t, meta.End.Equal(start.Add(time.Hour)))
+	require.True(t, r.matchMeta(dir, &storage.ReadOptions{Start: start.Add(time.Hour)}))
+}
@@ -0,0 +1,71 @@
+package filesystem
+
+import (
+	"path/filepath"
+	"time"
+)
+
+const (
+	DataDir = "data"
+)
+
+type Options struct {
+	WriteDir                 string
+	CompactDir               string
+	QuarantineDir            string
+	StagingDir               string
+	CompactInterval          time.Duration
+	CompactChunkMinAge       time.Duration
+	CompactChunkMaxAge       time.Duration
+	CompactChunkMinSize      int64
+	CompactChunkMaxSize      int64
+	CompactEmptyDirRemoveAge time.Duration
+	CompactChunkRemoveAge    time.Duration
+	// CompactCompression is the chunkio compression of compacted blocks, s2 if
+	// empty. CompactCompressionLevel is the zstd level, zero for the default.
+	CompactCompression      string
+	CompactCompressionLevel int
+	// RecompressAge, if set, rewrites compacted chunks older than it with
+	// RecompressCompression at RecompressCompressionLevel.
+	RecompressAge              time.Duration
+	RecompressCompression      string
+	RecompressCompressionLevel int
+	// Remote, if set, receives compacted chunks created more than RemoteAge
+	// ago, which are then removed from the compact dir.
+	Remote    *Remote
+	RemoteAge time.Duration
+	// Retention rules are matched in order against stream labels. Streams
+	// matching no rule are kept for CompactChunkRemoveAge.
+	Retention []RetentionRule
+	// RetentionMaxSize evicts the oldest compacted chunks once the data size
+	// exceeds it. Zero means unlimited.
+	RetentionMaxSize int64
+	// Catalog, if set, is kept up to date by writers and compactors, and lets
+	// readers skip incompact streams.
+	Catalog *Catalog
+	// Postings, if set, is kept up to date by compactors, and lets readers
+	// skip compacted chunks.
+	Postings *Postings
+	// Cache, if set, keeps headers, indices and compacted blocks read by
+	// compact readers in memory.
+	Cache *Cache
+}
+
+// NewOptions returns the default options with all directories under dataDir.
+func NewOptions(dataDir string) *Options {
+	return &Options{
+		WriteDir:                 filepath.Join(dataDir, filepath.Base(WriteDir)),
+		CompactDir:               filepath.Join(dataDir, filepath.Base(CompactDir)),
+		QuarantineDir:            filepath.Join(dataDir, filepath.Base(QuarantineDir)),
+		StagingDir:               filepath.Join(dataDir, filepath.Base(StagingDir)),
+		CompactInterval:          CompactInterval,
+		CompactChunkMinAge:       CompactChunkMinAge,
+		CompactChunkMaxAge:       CompactChunkMaxAge,
+		CompactChunkMinSize:      CompactChunkMinSize,
+		CompactChunkMaxSize:      CompactChunkMaxSize,
+		CompactEmptyDirRemoveAge: CompactEmptyDirRemoveAge,
+		CompactChunkRemoveAge:    CompactChunkRemoveAge,
+		CompactCompression:       CompactCompression,
+		RemoteAge:                RemoteAge,
+	}
+}
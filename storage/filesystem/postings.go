@@ -0,0 +1,270 @@
+package filesystem
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+
+	"github.com/commentlens/loghouse/storage"
+	"github.com/commentlens/loghouse/storage/chunkio"
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	PostingsFile    = "postings.loghouse"
+	PostingsTmpFile = "postings.loghouse.tmp"
+)
+
+// Postings indexes local and remote compacted chunks by label pair, with the labels and time
+// range of every series in them, so that readers find candidate chunks
+// without opening their headers. It is updated by the compactor and
+// persisted next to the compact dir.
+type Postings struct {
+	file string
+
+	mu     sync.RWMutex
+	chunks map[string]*chunkio.ChunkSeries
+	// pairs maps label names and values to chunk names.
+	pairs map[string]map[string]map[string]struct{}
+}
+
+// OpenPostings loads the postings of opts. Missing postings are built by the
+// next compaction.
+func OpenPostings(opts *Options) (*Postings, error) {
+	p := &Postings{
+		file:   filepath.Join(filepath.Dir(opts.CompactDir), PostingsFile),
+		chunks: make(map[string]*chunkio.ChunkSeries),
+	}
+	err := func() error {
+		f, err := os.Open(p.file)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+
+		buf := chunkio.NewBuffer()
+		defer chunkio.RecycleBuffer(buf)
+		buf.Reset(f)
+		for {
+			cs, err := chunkio.ReadChunkSeries(buf)
+			if err != nil {
+				if errors.Is(err, io.EOF) {
+					break
+				}
+				return err
+			}
+			p.chunks[cs.Name] = cs
+		}
+		return nil
+	}()
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return nil, err
+	}
+	p.index()
+	return p, nil
+}
+
+func (p *Postings) index() {
+	p.pairs = make(map[string]map[string]map[string]struct{})
+	for name, cs := range p.chunks {
+		for _, hdr := range cs.Series {
+			for k, v := range hdr.Labels {
+				if p.pairs[k] == nil {
+					p.pairs[k] = make(map[string]map[string]struct{})
+				}
+				if p.pairs[k][v] == nil {
+					p.pairs[k][v] = make(map[string]struct{})
+				}
+				p.pairs[k][v][name] = struct{}{}
+			}
+		}
+	}
+}
+
+// Candidates returns the indexed chunks with a series that may match opts,
+// and a func reporting whether a chunk was indexed at the time.
+func (p *Postings) Candidates(opts *storage.ReadOptions) (map[string]bool, func(name string) bool) {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+
+	// chunks is replaced, never modified, by update.
+	chunks := p.chunks
+	indexed := func(name string) bool {
+		_, ok := chunks[name]
+		return ok
+	}
+
+	// chunks with all label pairs of opts.
+	var names map[string]struct{}
+	first := true
+	for k, v := range opts.Labels {
+		posting := p.pairs[k][v]
+		if first {
+			names = posting
+			first = false
+			continue
+		}
+		next := make(map[string]struct{})
+		for name := range names {
+			if _, ok := posting[name]; ok {
+				next[name] = struct{}{}
+			}
+		}
+		names = next
+	}
+	candidates := make(map[string]bool)
+	check := func(name string) {
+		for _, hdr := range p.chunks[name].Series {
+			if chunkio.MatchHeader(hdr, opts) {
+				candidates[name] = true
+				return
+			}
+		}
+	}
+	if len(opts.Labels) == 0 {
+		for name := range p.chunks {
+			check(name)
+		}
+		return candidates, indexed
+	}
+	for name := range names {
+		check(name)
+	}
+	return candidates, indexed
+}
+
+// update indexes the chunks in the compact dir and the remote, reading the
+// headers of chunks that are new or changed, and saves the postings if any
+// did. remote may be nil.
+func (p *Postings) update(compactDir string, remote *Remote) error {
+	ds, err := osReadDir(compactDir)
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return err
+	}
+	p.mu.RLock()
+	old := p.chunks
+	p.mu.RUnlock()
+
+	chunks := make(map[string]*chunkio.ChunkSeries)
+	changed := false
+	for _, d := range ds {
+		if !d.IsDir() {
+			continue
+		}
+		dir := fmt.Sprintf("%s/%s", compactDir, d.Name())
+		fi, err := os.Stat(fmt.Sprintf("%s/%s", dir, CompactHeaderFile))
+		if err != nil {
+			if errors.Is(err, os.ErrNotExist) {
+				continue
+			}
+			return err
+		}
+		if cs, ok := old[d.Name()]; ok && cs.Size == uint64(fi.Size()) {
+			chunks[d.Name()] = cs
+			continue
+		}
+		hdrs, err := readHeaders(dir)
+		if err != nil {
+			return err
+		}
+		chunks[d.Name()] = &chunkio.ChunkSeries{
+			Name:   d.Name(),
+			Size:   uint64(fi.Size()),
+			Series: hdrs,
+		}
+		changed = true
+	}
+	if remote != nil {
+		ctx := context.Background()
+		names, err := remote.Chunks(ctx)
+		if err != nil {
+			// keep the chunks indexed before, which only narrow reads of
+			// listed chunks.
+			logrus.WithError(err).Warn("list remote chunks")
+			for name, cs := range old {
+				if _, ok := chunks[name]; !ok {
+					chunks[name] = cs
+				}
+			}
+		}
+		for _, name := range names {
+			if _, ok := chunks[name]; ok {
+				continue
+			}
+			// uploaded chunks are never rewritten.
+			if cs, ok := old[name]; ok {
+				chunks[name] = cs
+				continue
+			}
+			hdrs, err := remote.Headers(ctx, name)
+			if err != nil {
+				if !errors.Is(err, os.ErrNotExist) {
+					logrus.WithError(err).Warn(name)
+				}
+				continue
+			}
+			chunks[name] = &chunkio.ChunkSeries{
+				Name:   name,
+				Series: hdrs,
+			}
+			changed = true
+		}
+	}
+	if !changed && len(chunks) == len(old) {
+		return nil
+	}
+	names := make([]string, 0, len(chunks))
+	for name := range chunks {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	buf := new(bytes.Buffer)
+	for _, name := range names {
+		err := chunkio.WriteChunkSeries(buf, chunks[name])
+		if err != nil {
+			return err
+		}
+	}
+	dir := filepath.Dir(p.file)
+	err = os.MkdirAll(dir, 0777)
+	if err != nil {
+		return err
+	}
+	tmpFile := filepath.Join(dir, PostingsTmpFile)
+	err = func() error {
+		f, err := os.OpenFile(tmpFile, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0777)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+
+		_, err = f.Write(buf.Bytes())
+		if err != nil {
+			return err
+		}
+		return f.Sync()
+	}()
+	if err != nil {
+		return err
+	}
+	err = os.Rename(tmpFile, p.file)
+	if err != nil {
+		return err
+	}
+	err = syncDir(dir)
+	if err != nil {
+		return err
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.chunks = chunks
+	p.index()
+	return nil
+}
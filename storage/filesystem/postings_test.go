@@ -0,0 +1,119 @@
+package filesystem
+
+import (
+	"context"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/commentlens/loghouse/storage"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPostings(t *testing.T) {
+	dataDir := t.TempDir()
+	opts := NewOptions(dataDir)
+	postings, err := OpenPostings(opts)
+	require.NoError(t, err)
+	opts.Postings = postings
+	c := compactor{opts: opts}
+	start := now().Add(-time.Hour)
+
+	// each compaction writes one chunk.
+	compact := func(es []storage.LogEntry) string {
+		err := NewWriter(opts).Write(es)
+		require.NoError(t, err)
+		err = filepath.WalkDir(opts.WriteDir, func(path string, d fs.DirEntry, err error) error {
+			if err != nil {
+				return nil
+			}
+			oldTime := now().Add(-2 * CompactChunkMaxAge)
+			return os.Chtimes(path, oldTime, oldTime)
+		})
+		require.NoError(t, err)
+		before, err := findFiles(opts.CompactDir, WriteChunkFile)
+		require.NoError(t, err)
+		chunks, err := c.FindCompactibleChunk()
+		require.NoError(t, err)
+		err = c.SwapChunk(chunks)
+		require.NoError(t, err)
+		err = c.Compact()
+		require.NoError(t, err)
+		after, err := findFiles(opts.CompactDir, WriteChunkFile)
+		require.NoError(t, err)
+		require.Len(t, after, len(before)+1)
+		seen := make(map[string]bool)
+		for _, chunk := range before {
+			seen[chunk] = true
+		}
+		for _, chunk := range after {
+			if !seen[chunk] {
+				return filepath.Base(filepath.Dir(chunk))
+			}
+		}
+		return ""
+	}
+	chunk1 := compact([]storage.LogEntry{
+		{Labels: map[string]string{"app": "api", "env": "prod"}, Time: start, Data: []byte("1")},
+		{Labels: map[string]string{"app": "web", "env": "dev"}, Time: start, Data: []byte("2")},
+	})
+	chunk2 := compact([]storage.LogEntry{
+		{Labels: map[string]string{"app": "api", "env": "dev"}, Time: start.Add(time.Minute), Data: []byte("3")},
+	})
+
+	for _, p := range []*Postings{postings, func() *Postings {
+		p, err := OpenPostings(NewOptions(dataDir))
+		require.NoError(t, err)
+		return p
+	}()} {
+		for _, test := range []struct {
+			opts *storage.ReadOptions
+			want map[string]bool
+		}{
+			{
+				opts: &storage.ReadOptions{},
+				want: map[string]bool{chunk1: true, chunk2: true},
+			},
+			{
+				opts: &storage.ReadOptions{Labels: map[string]string{"app": "api", "env": "prod"}},
+				want: map[string]bool{chunk1: true},
+			},
+			{
+				opts: &storage.ReadOptions{Labels: map[string]string{"app": "api", "env": "test"}},
+				want: map[string]bool{},
+			},
+			{
+				opts: &storage.ReadOptions{Labels: map[string]string{"app": "none", "env": "dev"}},
+				want: map[string]bool{},
+			},
+			{
+				opts: &storage.ReadOptions{
+					Labels:    map[string]string{"app": "api"},
+					LabelFunc: func(labels map[string]string) bool { return labels["env"] != "prod" },
+				},
+				want: map[string]bool{chunk2: true},
+			},
+			{
+				opts: &storage.ReadOptions{Start: start.Add(30 * time.Second)},
+				want: map[string]bool{chunk2: true},
+			},
+		} {
+			got, indexed := p.Candidates(test.opts)
+			require.Equal(t, test.want, got)
+			require.True(t, indexed(chunk1))
+			require.False(t, indexed("unknown"))
+		}
+	}
+
+	var got []string
+	err = NewCompactReader(&CompactReaderOptions{Storage: opts}).Read(context.Background(), &storage.ReadOptions{
+		Labels: map[string]string{"env": "dev"},
+		ResultFunc: func(e storage.LogEntry) {
+			got = append(got, string(e.Data))
+		},
+	})
+	require.NoError(t, err)
+	require.Equal(t, []string{"2", "3"}, got)
+}
@@ -11,7 +11,6 @@ import (
 
 	"github.com/commentlens/loghouse/storage"
 	"github.com/commentlens/loghouse/storage/chunkio"
-	"github.com/commentlens/loghouse/storage/tlv"
 )
 
 func osReadDir(dir string) ([]os.DirEntry, error) {
@@ -56,103 +55,104 @@ func NewReader(chunks []string) storage.Reader {
 	return &reader{Chunks: chunks}
 }
 
+// NewCachedReader returns a reader that keeps headers, indices and compacted
+// blocks of chunks in cache.
+func NewCachedReader(chunks []string, cache *Cache) storage.Reader {
+	return &reader{Chunks: chunks, cache: cache}
+}
+
 type reader struct {
 	Chunks []string
+	cache  *Cache
 }
 
-func (r *reader) read(ctx context.Context, chunk string, opts *storage.ReadOptions) error {
-	var hdrs []*chunkio.Header
-	err := func() error {
-		f, err := os.Open(fmt.Sprintf("%s/%s", filepath.Dir(chunk), CompactHeaderFile))
-		if err != nil {
-			if errors.Is(err, os.ErrNotExist) {
-				return nil
-			}
-			return err
-		}
-		defer f.Close()
-
-		buf := chunkio.NewBuffer()
-		defer chunkio.RecycleBuffer(buf)
-		buf.Reset(f)
-		for {
-			hdr, err := chunkio.ReadHeader(buf)
-			if err != nil {
-				if errors.Is(err, io.EOF) {
-					break
-				}
-				return err
-			}
-			hdrs = append(hdrs, hdr)
-		}
-		return nil
-	}()
+// matchHeaders returns the headers of blocks in chunk that may match opts.
+func matchHeaders(chunk string, opts *storage.ReadOptions, cache *Cache) ([]*chunkio.Header, error) {
+	dir := filepath.Dir(chunk)
+	hdrs, err := cache.headers(dir)
 	if err != nil {
-		return err
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, nil
+		}
+		return nil, err
 	}
-	var indices []io.Reader
+	var indices []*chunkio.Index
 	if len(opts.Contains) > 0 {
-		f, err := os.Open(fmt.Sprintf("%s/%s", filepath.Dir(chunk), CompactIndexFile))
-		if err != nil {
-			if errors.Is(err, os.ErrNotExist) {
-				goto MATCH_HEADER
-			}
-			return err
-		}
-		defer f.Close()
-
-		tr := tlv.NewReader(f)
-		for {
-			off, n, err := tr.ReadSection()
-			if err != nil {
-				if errors.Is(err, io.EOF) {
-					break
-				}
-				return err
-			}
-			indices = append(indices, io.NewSectionReader(f, int64(off), int64(n)))
+		indices, err = cache.indices(dir)
+		if err != nil && !errors.Is(err, os.ErrNotExist) {
+			return nil, err
 		}
 	}
-MATCH_HEADER:
+	var matched []*chunkio.Header
 	for i, hdr := range hdrs {
-		if !chunkio.MatchHeader(hdr, opts) {
+		if !matchHeader(hdr, opts) {
 			continue
 		}
-		if opts.SummaryFunc != nil {
-			if !opts.SummaryFunc(storage.LogSummary{
-				Labels: hdr.Labels,
-				Start:  hdr.Start,
-				End:    hdr.End,
-				Count:  hdr.Count,
-			}) {
-				continue
-			}
-		}
 		if len(opts.Contains) > 0 && len(indices) > 0 {
 			ok, err := matchIndex(indices, i, opts)
 			if err != nil {
-				return err
+				return nil, err
 			}
 			if !ok {
 				continue
 			}
 		}
-		err := func() error {
-			f, err := os.Open(chunk)
-			if err != nil {
-				return err
-			}
-			defer f.Close()
+		matched = append(matched, hdr)
+	}
+	return matched, nil
+}
 
-			var r io.Reader = f
-			if hdr.Size > 0 {
-				r = io.NewSectionReader(f, int64(hdr.OffsetStart), int64(hdr.Size))
-			}
-			buf := chunkio.NewBuffer()
-			defer chunkio.RecycleBuffer(buf)
-			buf.Reset(r)
-			return chunkio.ReadData(ctx, hdr, buf, opts)
-		}()
+func matchHeader(hdr *chunkio.Header, opts *storage.ReadOptions) bool {
+	if !chunkio.MatchHeader(hdr, opts) {
+		return false
+	}
+	return opts.SummaryFunc == nil || opts.SummaryFunc(storage.LogSummary{
+		Labels: hdr.Labels,
+		Start:  hdr.Start,
+		End:    hdr.End,
+		Count:  hdr.Count,
+	})
+}
+
+// readBlock reads a block of chunk. Only compacted blocks are cached, as
+// incompact chunks are still appended to.
+func readBlock(ctx context.Context, chunk string, hdr *chunkio.Header, opts *storage.ReadOptions, cache *Cache) error {
+	if cache == nil || hdr.Size == 0 {
+		return readFileBlock(ctx, chunk, hdr, opts)
+	}
+	key, err := fileKey("block", chunk)
+	if err != nil {
+		return err
+	}
+	return cache.cachedBlock(ctx, fmt.Sprintf("%s#%d", key, hdr.OffsetStart), func(opts *storage.ReadOptions) error {
+		return readFileBlock(ctx, chunk, hdr, opts)
+	}, opts)
+}
+
+func readFileBlock(ctx context.Context, chunk string, hdr *chunkio.Header, opts *storage.ReadOptions) error {
+	f, err := os.Open(chunk)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	var r io.Reader = f
+	if hdr.Size > 0 {
+		r = io.NewSectionReader(f, int64(hdr.OffsetStart), int64(hdr.Size))
+	}
+	buf := chunkio.NewBuffer()
+	defer chunkio.RecycleBuffer(buf)
+	buf.Reset(r)
+	return chunkio.ReadData(ctx, hdr, buf, opts)
+}
+
+func (r *reader) read(ctx context.Context, chunk string, opts *storage.ReadOptions) error {
+	hdrs, err := matchHeaders(chunk, opts, r.cache)
+	if err != nil {
+		return err
+	}
+	for _, hdr := range hdrs {
+		err := readBlock(ctx, chunk, hdr, opts, r.cache)
 		if err != nil {
 			return err
 		}
@@ -164,20 +164,12 @@ var (
 	errCorruptedIndex = errors.New("corrupted index")
 )
 
-func matchIndex(indices []io.Reader, i int, opts *storage.ReadOptions) (bool, error) {
+func matchIndex(indices []*chunkio.Index, i int, opts *storage.ReadOptions) (bool, error) {
 	if len(indices) <= i {
 		return false, errCorruptedIndex
 	}
-	buf := chunkio.NewBuffer()
-	defer chunkio.RecycleBuffer(buf)
-	buf.Reset(indices[i])
-
-	index, err := chunkio.ReadIndex(buf)
-	if err != nil {
-		return false, err
-	}
 	for _, s := range opts.Contains {
-		if !index.Contains(s) {
+		if !indices[i].Contains(s) {
 			return false, nil
 		}
 	}
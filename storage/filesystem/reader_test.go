@@ -11,13 +11,13 @@ import (
 )
 
 func now() time.Time {
-	return time.Now().UTC().Truncate(time.Millisecond)
+	return time.Now().UTC()
 }
 
 func TestReader(t *testing.T) {
 	os.RemoveAll(WriteDir)
 
-	w := NewWriter()
+	w := NewWriter(NewOptions(DataDir))
 
 	es := []storage.LogEntry{
 		{
@@ -0,0 +1,197 @@
+package filesystem
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"github.com/commentlens/loghouse/storage"
+	"github.com/commentlens/loghouse/storage/chunkio"
+	"github.com/oklog/ulid/v2"
+	"github.com/sirupsen/logrus"
+)
+
+// Recompress rewrites compacted chunks older than RecompressAge with
+// RecompressCompression, and returns the number of chunks rewritten.
+func Recompress(opts *Options) (int, error) {
+	c := &compactor{opts: opts}
+	err := c.recoverStaging()
+	if err != nil {
+		return 0, err
+	}
+	return c.recompress()
+}
+
+func (c *compactor) recompress() (int, error) {
+	if c.opts.RecompressAge <= 0 || c.opts.RecompressCompression == "" {
+		return 0, nil
+	}
+	ds, err := osReadDir(c.opts.CompactDir)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return 0, nil
+		}
+		return 0, err
+	}
+	var n int
+	for _, d := range ds {
+		if !d.IsDir() {
+			continue
+		}
+		chunkID, err := ulid.ParseStrict(d.Name())
+		if err != nil {
+			return n, err
+		}
+		if time.Since(time.UnixMilli(int64(chunkID.Time()))) < c.opts.RecompressAge {
+			continue
+		}
+		ok, err := c.recompressChunk(d.Name())
+		if err != nil {
+			return n, err
+		}
+		if ok {
+			n++
+		}
+	}
+	return n, nil
+}
+
+// recompressChunk rewrites the blocks of a compacted chunk that are not yet
+// compressed with RecompressCompression in the staging dir, and swaps it in
+// place of the original. Blocks keep their order, so the index is copied.
+func (c *compactor) recompressChunk(name string) (bool, error) {
+	dir := fmt.Sprintf("%s/%s", c.opts.CompactDir, name)
+	hdrs, err := readHeaders(dir)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return false, nil
+		}
+		if isCorrupted(err) {
+			logrus.WithError(err).Warn(dir)
+			return false, nil
+		}
+		return false, err
+	}
+	compression := c.opts.RecompressCompression
+	var stale bool
+	for _, hdr := range hdrs {
+		if hdr.Compression != compression {
+			stale = true
+		}
+	}
+	if !stale {
+		return false, nil
+	}
+	staging := fmt.Sprintf("%s/%s", c.opts.StagingDir, name)
+	err = os.RemoveAll(staging)
+	if err != nil {
+		return false, err
+	}
+	err = os.MkdirAll(staging, 0777)
+	if err != nil {
+		return false, err
+	}
+	err = c.rewriteChunk(dir, staging, hdrs)
+	if err != nil {
+		return false, err
+	}
+	err = copyFile(fmt.Sprintf("%s/%s", dir, CompactIndexFile), fmt.Sprintf("%s/%s", staging, CompactIndexFile))
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return false, err
+	}
+	err = syncDir(staging)
+	if err != nil {
+		return false, err
+	}
+	return true, c.swapStaging(name)
+}
+
+func (c *compactor) rewriteChunk(src, dst string, hdrs []*chunkio.Header) error {
+	chunk := fmt.Sprintf("%s/%s", src, WriteChunkFile)
+	fsrc, err := os.Open(chunk)
+	if err != nil {
+		return err
+	}
+	defer fsrc.Close()
+
+	fchunk, err := os.OpenFile(fmt.Sprintf("%s/%s", dst, WriteChunkFile), os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0777)
+	if err != nil {
+		return err
+	}
+	defer fchunk.Close()
+
+	fhdr, err := os.OpenFile(fmt.Sprintf("%s/%s", dst, CompactHeaderFile), os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0777)
+	if err != nil {
+		return err
+	}
+	defer fhdr.Close()
+
+	compression := c.opts.RecompressCompression
+	var offset uint64
+	for _, hdr := range hdrs {
+		nhdr := *hdr
+		nhdr.OffsetStart = offset
+		if hdr.Compression == compression {
+			_, err := io.Copy(fchunk, io.NewSectionReader(fsrc, int64(hdr.OffsetStart), int64(hdr.Size)))
+			if err != nil {
+				return err
+			}
+		} else {
+			var es []storage.LogEntry
+			err := readBlock(context.Background(), chunk, hdr, &storage.ReadOptions{
+				ResultFunc: func(e storage.LogEntry) {
+					es = append(es, e)
+				},
+			}, nil)
+			if err != nil {
+				return err
+			}
+			buf := new(bytes.Buffer)
+			err = chunkio.WriteBlock(buf, es, compression, c.opts.RecompressCompressionLevel)
+			if err != nil {
+				return err
+			}
+			_, err = fchunk.Write(buf.Bytes())
+			if err != nil {
+				return err
+			}
+			nhdr.Size = uint64(buf.Len())
+			nhdr.Compression = compression
+			nhdr.Version = chunkio.FormatVersion
+		}
+		err = chunkio.WriteHeader(fhdr, &nhdr)
+		if err != nil {
+			return err
+		}
+		offset += nhdr.Size
+	}
+	err = fchunk.Sync()
+	if err != nil {
+		return err
+	}
+	return fhdr.Sync()
+}
+
+func copyFile(src, dst string) error {
+	fsrc, err := os.Open(src)
+	if err != nil {
+		return err
+	}
+	defer fsrc.Close()
+
+	fdst, err := os.OpenFile(dst, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0777)
+	if err != nil {
+		return err
+	}
+	defer fdst.Close()
+
+	_, err = io.Copy(fdst, fsrc)
+	if err != nil {
+		return err
+	}
+	return fdst.Sync()
+}
@@ -0,0 +1,132 @@
+package filesystem
+
+import (
+	"context"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/commentlens/loghouse/storage"
+	"github.com/commentlens/loghouse/storage/chunkio"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRecompress(t *testing.T) {
+	opts := NewOptions(t.TempDir())
+	c := compactor{opts: opts}
+	start := now().Add(-time.Hour)
+
+	// each compaction writes one chunk with the compact compression.
+	compact := func(compression string, es []storage.LogEntry) {
+		opts.CompactCompression = compression
+		err := NewWriter(opts).Write(es)
+		require.NoError(t, err)
+		err = filepath.WalkDir(opts.WriteDir, func(path string, d fs.DirEntry, err error) error {
+			if err != nil {
+				return nil
+			}
+			oldTime := now().Add(-2 * CompactChunkMaxAge)
+			return os.Chtimes(path, oldTime, oldTime)
+		})
+		require.NoError(t, err)
+		chunks, err := c.FindCompactibleChunk()
+		require.NoError(t, err)
+		err = c.SwapChunk(chunks)
+		require.NoError(t, err)
+		err = c.Compact()
+		require.NoError(t, err)
+	}
+	compact(chunkio.CompressionS2, []storage.LogEntry{
+		{Labels: map[string]string{"app": "api"}, Time: start, Data: []byte("1")},
+		{Labels: map[string]string{"app": "web"}, Time: start.Add(time.Second), Data: []byte("2")},
+	})
+	compact(chunkio.CompressionColumnarZstd, []storage.LogEntry{
+		{Labels: map[string]string{"app": "api"}, Time: start.Add(2 * time.Second), Data: []byte("3")},
+	})
+
+	compressions := func() []string {
+		var compressions []string
+		chunks, err := findFiles(opts.CompactDir, WriteChunkFile)
+		require.NoError(t, err)
+		for _, chunk := range chunks {
+			hdrs, err := readHeaders(filepath.Dir(chunk))
+			require.NoError(t, err)
+			for _, hdr := range hdrs {
+				compressions = append(compressions, hdr.Compression)
+			}
+		}
+		sort.Strings(compressions)
+		return compressions
+	}
+	read := func() []string {
+		var got []string
+		err := NewCompactReader(&CompactReaderOptions{Storage: opts}).Read(context.Background(), &storage.ReadOptions{
+			Contains: []string{"2"},
+			ResultFunc: func(e storage.LogEntry) {
+				got = append(got, string(e.Data))
+			},
+		})
+		require.NoError(t, err)
+		return got
+	}
+	require.Equal(t, []string{chunkio.CompressionColumnarZstd, chunkio.CompressionS2, chunkio.CompressionS2}, compressions())
+	require.Equal(t, []string{"2"}, read())
+
+	opts.RecompressCompression = chunkio.CompressionColumnarZstd
+	n, err := Recompress(opts)
+	require.NoError(t, err)
+	require.Equal(t, 0, n)
+
+	// blocks of legacy chunks are rewritten in the current format.
+	chunks, err := findFiles(opts.CompactDir, WriteChunkFile)
+	require.NoError(t, err)
+	for _, chunk := range chunks {
+		dir := filepath.Dir(chunk)
+		hdrs, err := readHeaders(dir)
+		require.NoError(t, err)
+		if hdrs[0].Compression != chunkio.CompressionS2 {
+			continue
+		}
+		f, err := os.Create(fmt.Sprintf("%s/%s", dir, CompactHeaderFile))
+		require.NoError(t, err)
+		for _, hdr := range hdrs {
+			hdr.Version = 1
+			err = chunkio.WriteHeader(f, hdr)
+			require.NoError(t, err)
+		}
+		require.NoError(t, f.Close())
+	}
+
+	opts.RecompressAge = time.Nanosecond
+	n, err = Recompress(opts)
+	require.NoError(t, err)
+	require.Equal(t, 1, n)
+	chunks, err = findFiles(opts.CompactDir, WriteChunkFile)
+	require.NoError(t, err)
+	for _, chunk := range chunks {
+		hdrs, err := readHeaders(filepath.Dir(chunk))
+		require.NoError(t, err)
+		for _, hdr := range hdrs {
+			require.Equal(t, uint64(chunkio.FormatVersion), hdr.Version)
+		}
+	}
+	require.Equal(t, []string{chunkio.CompressionColumnarZstd, chunkio.CompressionColumnarZstd, chunkio.CompressionColumnarZstd}, compressions())
+	require.Equal(t, []string{"2"}, read())
+
+	opts.RecompressCompression = chunkio.CompressionColumnarGzip
+	err = c.Compact()
+	require.NoError(t, err)
+	require.Equal(t, []string{chunkio.CompressionColumnarGzip, chunkio.CompressionColumnarGzip, chunkio.CompressionColumnarGzip}, compressions())
+	var got []string
+	err = NewCompactReader(&CompactReaderOptions{Storage: opts}).Read(context.Background(), &storage.ReadOptions{
+		ResultFunc: func(e storage.LogEntry) {
+			got = append(got, string(e.Data))
+		},
+	})
+	require.NoError(t, err)
+	require.Equal(t, []string{"1", "2", "3"}, got)
+}
@@ -0,0 +1,311 @@
+package filesystem
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/commentlens/loghouse/storage"
+	"github.com/commentlens/loghouse/storage/chunkio"
+	"github.com/commentlens/loghouse/storage/objstore"
+	"github.com/oklog/ulid/v2"
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	// RemoteCompactDir is the key prefix of uploaded compacted chunks.
+	RemoteCompactDir = "compact/"
+)
+
+// Remote keeps compacted chunks in a bucket, each under its name with the
+// same files as in the compact dir. The header file is uploaded last and
+// removed first, so that only complete chunks are listed. Uploaded chunks are
+// never rewritten, so their headers are kept in cache, and the listing is
+// kept until the compactor refreshes it.
+type Remote struct {
+	bucket objstore.Bucket
+	cache  *Cache
+
+	mu     sync.Mutex
+	names  map[string]bool
+	listed bool
+}
+
+// NewRemote returns the remote of bucket. cache may be nil.
+func NewRemote(bucket objstore.Bucket, cache *Cache) *Remote {
+	return &Remote{
+		bucket: bucket,
+		cache:  cache,
+		names:  make(map[string]bool),
+	}
+}
+
+func remoteKey(name, file string) string {
+	return fmt.Sprintf("%s%s/%s", RemoteCompactDir, name, file)
+}
+
+// Chunks returns the sorted names of uploaded chunks, listing the bucket only
+// on first use.
+func (r *Remote) Chunks(ctx context.Context) ([]string, error) {
+	r.mu.Lock()
+	listed := r.listed
+	r.mu.Unlock()
+	if !listed {
+		err := r.refresh(ctx)
+		if err != nil {
+			return nil, err
+		}
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	names := make([]string, 0, len(r.names))
+	for name := range r.names {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names, nil
+}
+
+// refresh lists the uploaded chunks in the bucket.
+func (r *Remote) refresh(ctx context.Context) error {
+	keys, err := r.bucket.List(ctx, RemoteCompactDir)
+	if err != nil {
+		return err
+	}
+	names := make(map[string]bool)
+	for _, key := range keys {
+		name, file, ok := strings.Cut(strings.TrimPrefix(key, RemoteCompactDir), "/")
+		if ok && file == CompactHeaderFile {
+			names[name] = true
+		}
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.names = names
+	r.listed = true
+	return nil
+}
+
+func (r *Remote) Headers(ctx context.Context, name string) ([]*chunkio.Header, error) {
+	v, err := r.cache.get(fmt.Sprintf("remote-header:%s", name), func() (interface{}, int64, error) {
+		hdrs, err := r.getHeaders(ctx, name)
+		var size int64
+		for _, hdr := range hdrs {
+			size += headerSize(hdr)
+		}
+		return hdrs, size, err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return v.([]*chunkio.Header), nil
+}
+
+func (r *Remote) getHeaders(ctx context.Context, name string) ([]*chunkio.Header, error) {
+	rc, err := r.bucket.Get(ctx, remoteKey(name, CompactHeaderFile), 0, -1)
+	if err != nil {
+		return nil, err
+	}
+	defer rc.Close()
+
+	buf := chunkio.NewBuffer()
+	defer chunkio.RecycleBuffer(buf)
+	buf.Reset(rc)
+	var hdrs []*chunkio.Header
+	for {
+		hdr, err := chunkio.ReadHeader(buf)
+		if err != nil {
+			if errors.Is(err, io.EOF) {
+				break
+			}
+			return nil, err
+		}
+		hdrs = append(hdrs, hdr)
+	}
+	return hdrs, nil
+}
+
+// readBlock reads a block of an uploaded chunk with a ranged get, or from
+// cache.
+func (r *Remote) readBlock(ctx context.Context, name string, hdr *chunkio.Header, opts *storage.ReadOptions, cache *Cache) error {
+	if cache == nil {
+		return r.getBlock(ctx, name, hdr, opts)
+	}
+	return cache.cachedBlock(ctx, fmt.Sprintf("remote:%s#%d", name, hdr.OffsetStart), func(opts *storage.ReadOptions) error {
+		return r.getBlock(ctx, name, hdr, opts)
+	}, opts)
+}
+
+func (r *Remote) getBlock(ctx context.Context, name string, hdr *chunkio.Header, opts *storage.ReadOptions) error {
+	rc, err := r.bucket.Get(ctx, remoteKey(name, WriteChunkFile), int64(hdr.OffsetStart), int64(hdr.Size))
+	if err != nil {
+		return err
+	}
+	defer rc.Close()
+
+	buf := chunkio.NewBuffer()
+	defer chunkio.RecycleBuffer(buf)
+	buf.Reset(rc)
+	return chunkio.ReadData(ctx, hdr, buf, opts)
+}
+
+// upload copies a compacted chunk dir to the bucket.
+func (r *Remote) upload(ctx context.Context, dir, name string) error {
+	for _, file := range []string{WriteChunkFile, CompactIndexFile, CompactMetaFile, CompactHeaderFile} {
+		err := func() error {
+			f, err := os.Open(fmt.Sprintf("%s/%s", dir, file))
+			if err != nil {
+				if errors.Is(err, os.ErrNotExist) && file != CompactHeaderFile {
+					return nil
+				}
+				return err
+			}
+			defer f.Close()
+
+			fi, err := f.Stat()
+			if err != nil {
+				return err
+			}
+			return r.bucket.Put(ctx, remoteKey(name, file), f, fi.Size())
+		}()
+		if err != nil {
+			return err
+		}
+	}
+	r.mu.Lock()
+	r.names[name] = true
+	r.mu.Unlock()
+	return nil
+}
+
+func (r *Remote) remove(ctx context.Context, name string) error {
+	for _, file := range []string{CompactHeaderFile, CompactMetaFile, CompactIndexFile, WriteChunkFile} {
+		err := r.bucket.Delete(ctx, remoteKey(name, file))
+		if err != nil && !errors.Is(err, os.ErrNotExist) {
+			return err
+		}
+	}
+	r.mu.Lock()
+	delete(r.names, name)
+	r.mu.Unlock()
+	return nil
+}
+
+// uploadChunks moves compacted chunks created more than RemoteAge ago to the
+// remote.
+func (c *compactor) uploadChunks() error {
+	ds, err := osReadDir(c.opts.CompactDir)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil
+		}
+		return err
+	}
+	for _, d := range ds {
+		if !d.IsDir() {
+			continue
+		}
+		chunkID, err := ulid.ParseStrict(d.Name())
+		if err != nil {
+			return err
+		}
+		if time.Since(time.UnixMilli(int64(chunkID.Time()))) < c.opts.RemoteAge {
+			continue
+		}
+		dir := fmt.Sprintf("%s/%s", c.opts.CompactDir, d.Name())
+		// rebuildIndex builds one index per compaction.
+		_, err = buildIndex(dir)
+		if err != nil {
+			return err
+		}
+		err = c.opts.Remote.upload(context.Background(), dir, d.Name())
+		if err != nil {
+			return err
+		}
+		err = os.RemoveAll(dir)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// retainRemote removes uploaded chunks once all their streams are older than
+// their retention age. Uploaded chunks are not rewritten, so partially
+// expired ones are kept whole.
+func (c *compactor) retainRemote() error {
+	ctx := context.Background()
+	err := c.opts.Remote.refresh(ctx)
+	if err != nil {
+		return err
+	}
+	names, err := c.opts.Remote.Chunks(ctx)
+	if err != nil {
+		return err
+	}
+	for _, name := range names {
+		chunkID, err := ulid.ParseStrict(name)
+		if err != nil {
+			return err
+		}
+		created := time.UnixMilli(int64(chunkID.Time()))
+		hdrs, err := c.opts.Remote.Headers(ctx, name)
+		if err != nil {
+			if isCorrupted(err) {
+				logrus.WithError(err).Warn(name)
+				continue
+			}
+			return err
+		}
+		expired := true
+		for _, hdr := range hdrs {
+			end := hdr.End
+			if end.IsZero() {
+				end = created
+			}
+			if time.Since(end) < c.retentionAge(hdr.Labels) {
+				expired = false
+				break
+			}
+		}
+		if !expired {
+			continue
+		}
+		err = c.opts.Remote.remove(ctx, name)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// remoteHeaders returns the headers of all uploaded chunks of opts.
+func remoteHeaders(opts *Options) ([]*chunkio.Header, error) {
+	if opts.Remote == nil {
+		return nil, nil
+	}
+	ctx := context.Background()
+	names, err := opts.Remote.Chunks(ctx)
+	if err != nil {
+		return nil, err
+	}
+	var all []*chunkio.Header
+	for _, name := range names {
+		hdrs, err := opts.Remote.Headers(ctx, name)
+		if err != nil {
+			if errors.Is(err, os.ErrNotExist) {
+				continue
+			}
+			return nil, err
+		}
+		all = append(all, hdrs...)
+	}
+	return all, nil
+}
@@ -0,0 +1,316 @@
+package filesystem
+
+import (
+	"context"
+	"errors"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/commentlens/loghouse/storage"
+	"github.com/commentlens/loghouse/storage/objstore"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRemote(t *testing.T) {
+	opts := NewOptions(t.TempDir())
+	bucket := objstore.NewMemBucket()
+	opts.Remote = NewRemote(bucket, nil)
+	catalog, err := OpenCatalog(opts)
+	require.NoError(t, err)
+	opts.Catalog = catalog
+	c := compactor{opts: opts}
+	start := now().Add(-time.Hour)
+
+	compact := func(es []storage.LogEntry) {
+		err := NewWriter(opts).Write(es)
+		require.NoError(t, err)
+		err = filepath.WalkDir(opts.WriteDir, func(path string, d fs.DirEntry, err error) error {
+			if err != nil {
+				return nil
+			}
+			oldTime := now().Add(-2 * CompactChunkMaxAge)
+			return os.Chtimes(path, oldTime, oldTime)
+		})
+		require.NoError(t, err)
+		chunks, err := c.FindCompactibleChunk()
+		require.NoError(t, err)
+		err = c.SwapChunk(chunks)
+		require.NoError(t, err)
+		err = c.Compact()
+		require.NoError(t, err)
+	}
+	opts.RemoteAge = time.Nanosecond
+	compact([]storage.LogEntry{
+		{Labels: map[string]string{"app": "api"}, Time: start, Data: []byte("1")},
+		{Labels: map[string]string{"app": "web"}, Time: start.Add(2 * time.Second), Data: []byte("3")},
+	})
+	opts.RemoteAge = time.Hour
+	compact([]storage.LogEntry{
+		{Labels: map[string]string{"app": "api"}, Time: start.Add(time.Second), Data: []byte("2")},
+	})
+
+	local, err := findFiles(opts.CompactDir, WriteChunkFile)
+	require.NoError(t, err)
+	require.Len(t, local, 1)
+	remote, err := opts.Remote.Chunks(context.Background())
+	require.NoError(t, err)
+	require.Len(t, remote, 1)
+	keys, err := bucket.List(context.Background(), "")
+	require.NoError(t, err)
+	require.Len(t, keys, 4)
+	_, ok := catalog.Get(hashLabels(t, map[string]string{"app": "web"}))
+	require.True(t, ok)
+
+	read := func(ropts *storage.ReadOptions) []string {
+		var got []string
+		ropts.ResultFunc = func(e storage.LogEntry) {
+			got = append(got, string(e.Data))
+		}
+		err := NewCompactReader(&CompactReaderOptions{Storage: opts}).Read(context.Background(), ropts)
+		require.NoError(t, err)
+		return got
+	}
+	require.Equal(t, []string{"1", "2", "3"}, read(&storage.ReadOptions{}))
+	require.Equal(t, []string{"1", "2"}, read(&storage.ReadOptions{Labels: map[string]string{"app": "api"}}))
+	require.Equal(t, []string{"3"}, read(&storage.ReadOptions{Start: start.Add(2 * time.Second)}))
+
+	// partially expired remote chunks are kept whole.
+	opts.Retention = []RetentionRule{{Labels: map[string]string{"app": "web"}, Age: time.Minute}}
+	opts.CompactChunkRemoveAge = 2 * time.Hour
+	err = c.Compact()
+	require.NoError(t, err)
+	require.Equal(t, []string{"1", "2", "3"}, read(&storage.ReadOptions{}))
+
+	opts.CompactChunkRemoveAge = time.Minute
+	err = c.Compact()
+	require.NoError(t, err)
+	remote, err = opts.Remote.Chunks(context.Background())
+	require.NoError(t, err)
+	require.Len(t, remote, 0)
+	keys, err = bucket.List(context.Background(), "")
+	require.NoError(t, err)
+	require.Len(t, keys, 0)
+	require.Empty(t, read(&storage.ReadOptions{}))
+	_, ok = catalog.Get(hashLabels(t, map[string]string{"app": "web"}))
+	require.False(t, ok)
+}
+
+func hashLabels(t *testing.T, labels map[string]string) string {
+	h, err := storage.HashLabels(labels)
+	require.NoError(t, err)
+	return h
+}
+
+type listCountBucket struct {
+	objstore.Bucket
+	lists int
+}
+
+func (b *listCountBucket) List(ctx context.Context, prefix string) ([]string, error) {
+	b.lists++
+	return b.Bucket.List(ctx, prefix)
+}
+
+// failBucket fails every request while down.
+type failBucket struct {
+	objstore.Bucket
+	down bool
+}
+
+var errBucketDown = errors.New("bucket down")
+
+func (b *failBucket) Put(ctx context.Context, key string, r io.Reader, size int64) error {
+	if b.down {
+		return errBucketDown
+	}
+	return b.Bucket.Put(ctx, key, r, size)
+}
+
+func (b *failBucket) Get(ctx context.Context, key string, off, n int64) (io.ReadCloser, error) {
+	if b.down {
+		return nil, errBucketDown
+	}
+	return b.Bucket.Get(ctx, key, off, n)
+}
+
+func (b *failBucket) List(ctx context.Context, prefix string) ([]string, error) {
+	if b.down {
+		return nil, errBucketDown
+	}
+	return b.Bucket.List(ctx, prefix)
+}
+
+func (b *failBucket) Delete(ctx context.Context, key string) error {
+	if b.down {
+		return errBucketDown
+	}
+	return b.Bucket.Delete(ctx, key)
+}
+
+func TestRemoteUnavailable(t *testing.T) {
+	opts := NewOptions(t.TempDir())
+	bucket := &failBucket{Bucket: objstore.NewMemBucket(), down: true}
+	opts.Remote = NewRemote(bucket, nil)
+	opts.RemoteAge = time.Nanosecond
+	catalog, err := OpenCatalog(opts)
+	require.NoError(t, err)
+	opts.Catalog = catalog
+	postings, err := OpenPostings(opts)
+	require.NoError(t, err)
+	opts.Postings = postings
+	c := compactor{opts: opts}
+
+	err = NewWriter(opts).Write([]storage.LogEntry{
+		{Labels: map[string]string{"app": "web"}, Time: now(), Data: []byte("1")},
+	})
+	require.NoError(t, err)
+	err = filepath.WalkDir(opts.WriteDir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return nil
+		}
+		oldTime := now().Add(-2 * CompactChunkMaxAge)
+		return os.Chtimes(path, oldTime, oldTime)
+	})
+	require.NoError(t, err)
+	chunks, err := c.FindCompactibleChunk()
+	require.NoError(t, err)
+	err = c.SwapChunk(chunks)
+	require.NoError(t, err)
+
+	// compaction and reads go on with local chunks.
+	err = c.Compact()
+	require.NoError(t, err)
+	read := func() []string {
+		var got []string
+		err := NewCompactReader(&CompactReaderOptions{Storage: opts}).Read(context.Background(), &storage.ReadOptions{
+			ResultFunc: func(e storage.LogEntry) {
+				got = append(got, string(e.Data))
+			},
+		})
+		require.NoError(t, err)
+		return got
+	}
+	require.Equal(t, []string{"1"}, read())
+	require.Len(t, catalog.Series(), 1)
+
+	// the next compaction uploads.
+	bucket.down = false
+	err = c.Compact()
+	require.NoError(t, err)
+	names, err := opts.Remote.Chunks(context.Background())
+	require.NoError(t, err)
+	require.Len(t, names, 1)
+	require.Equal(t, []string{"1"}, read())
+	require.Len(t, catalog.Series(), 1)
+
+	// a catalog built without the remote gets its streams by the next
+	// compaction.
+	bucket.down = true
+	err = os.Remove(catalog.file)
+	require.NoError(t, err)
+	catalog, err = OpenCatalog(&Options{
+		WriteDir:   opts.WriteDir,
+		CompactDir: opts.CompactDir,
+		Remote:     NewRemote(bucket, nil),
+	})
+	require.NoError(t, err)
+	require.True(t, catalog.noRemote)
+	opts.Catalog = catalog
+	err = c.Compact()
+	require.NoError(t, err)
+	require.True(t, catalog.noRemote)
+	bucket.down = false
+	err = c.Compact()
+	require.NoError(t, err)
+	require.False(t, catalog.noRemote)
+	series := catalog.Series()
+	require.Len(t, series, 1)
+	require.False(t, series[0].Start.IsZero())
+}
+
+func TestRemoteRead(t *testing.T) {
+	opts := NewOptions(t.TempDir())
+	bucket := &listCountBucket{Bucket: objstore.NewMemBucket()}
+	opts.Remote = NewRemote(bucket, nil)
+	postings, err := OpenPostings(opts)
+	require.NoError(t, err)
+	opts.Postings = postings
+	c := compactor{opts: opts}
+	start := now().Add(-time.Hour)
+
+	compact := func(es []storage.LogEntry) {
+		err := NewWriter(opts).Write(es)
+		require.NoError(t, err)
+		err = filepath.WalkDir(opts.WriteDir, func(path string, d fs.DirEntry, err error) error {
+			if err != nil {
+				return nil
+			}
+			oldTime := now().Add(-2 * CompactChunkMaxAge)
+			return os.Chtimes(path, oldTime, oldTime)
+		})
+		require.NoError(t, err)
+		chunks, err := c.FindCompactibleChunk()
+		require.NoError(t, err)
+		err = c.SwapChunk(chunks)
+		require.NoError(t, err)
+		err = c.Compact()
+		require.NoError(t, err)
+	}
+	opts.RemoteAge = time.Nanosecond
+	compact([]storage.LogEntry{
+		{Labels: map[string]string{"app": "web"}, Time: start, Data: []byte("1")},
+	})
+	opts.RemoteAge = time.Hour
+	compact([]storage.LogEntry{
+		{Labels: map[string]string{"app": "api"}, Time: start.Add(time.Second), Data: []byte("2")},
+	})
+
+	// remote chunks are pruned by postings.
+	names, err := opts.Remote.Chunks(context.Background())
+	require.NoError(t, err)
+	require.Len(t, names, 1)
+	candidates, indexed := postings.Candidates(&storage.ReadOptions{Labels: map[string]string{"app": "api"}})
+	require.True(t, indexed(names[0]))
+	require.False(t, candidates[names[0]])
+
+	read := func(ropts *storage.ReadOptions) []string {
+		var got []string
+		ropts.ResultFunc = func(e storage.LogEntry) {
+			got = append(got, string(e.Data))
+		}
+		err := NewCompactReader(&CompactReaderOptions{Storage: opts}).Read(context.Background(), ropts)
+		require.NoError(t, err)
+		return got
+	}
+	lists := bucket.lists
+	require.Equal(t, []string{"1", "2"}, read(&storage.ReadOptions{}))
+	require.Equal(t, []string{"1"}, read(&storage.ReadOptions{Labels: map[string]string{"app": "web"}}))
+	require.Equal(t, lists, bucket.lists)
+
+	// local chunks uploaded and removed after they were listed are read from
+	// the remote.
+	local, err := findFiles(opts.CompactDir, WriteChunkFile)
+	require.NoError(t, err)
+	require.Len(t, local, 1)
+	dir := filepath.Dir(local[0])
+	err = opts.Remote.upload(context.Background(), dir, filepath.Base(dir))
+	require.NoError(t, err)
+	err = os.RemoveAll(dir)
+	require.NoError(t, err)
+	r := NewCompactReader(&CompactReaderOptions{Storage: opts}).(*compactReader)
+	blocks, err := r.blocks(context.Background(), []block{{chunk: local[0]}}, &storage.ReadOptions{})
+	require.NoError(t, err)
+	require.Len(t, blocks, 1)
+	require.True(t, blocks[0].remote)
+	var got []string
+	for _, es := range r.load(context.Background(), blocks, &storage.ReadOptions{}) {
+		for _, e := range es {
+			got = append(got, string(e.Data))
+		}
+	}
+	require.Equal(t, []string{"2"}, got)
+}
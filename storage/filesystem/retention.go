@@ -0,0 +1,323 @@
+package filesystem
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/commentlens/loghouse/storage"
+	"github.com/commentlens/loghouse/storage/chunkio"
+	"github.com/oklog/ulid/v2"
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	StagingDir    = "data/staging"
+	stagingOldExt = ".old"
+)
+
+// RetentionRule matches streams by exact labels and, if set, by a stream
+// selector such as {app=~"web|api"}. Match is compiled from Selector by the
+// caller.
+type RetentionRule struct {
+	Labels   map[string]string                   `yaml:"labels"`
+	Selector string                              `yaml:"selector"`
+	Match    func(labels map[string]string) bool `yaml:"-"`
+	Age      time.Duration                       `yaml:"age"`
+}
+
+func (rule *RetentionRule) match(labels map[string]string) bool {
+	if !storage.MatchLabels(labels, rule.Labels) {
+		return false
+	}
+	return rule.Match == nil || rule.Match(labels)
+}
+
+// retentionAge returns the age of the first rule matching labels, or
+// CompactChunkRemoveAge if none matches.
+func (c *compactor) retentionAge(labels map[string]string) time.Duration {
+	for _, rule := range c.opts.Retention {
+		if rule.match(labels) {
+			return rule.Age
+		}
+	}
+	return c.opts.CompactChunkRemoveAge
+}
+
+func (c *compactor) applyRetention() error {
+	err := c.recoverStaging()
+	if err != nil {
+		return err
+	}
+	ds, err := osReadDir(c.opts.CompactDir)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil
+		}
+		return err
+	}
+	for _, d := range ds {
+		if !d.IsDir() {
+			continue
+		}
+		chunkID, err := ulid.ParseStrict(d.Name())
+		if err != nil {
+			return err
+		}
+		err = c.retainChunk(d.Name(), time.UnixMilli(int64(chunkID.Time())))
+		if err != nil {
+			return err
+		}
+	}
+	return c.evictChunks()
+}
+
+// retainChunk removes the streams of a compacted chunk that are older than
+// their retention age. A partially expired chunk is rewritten in the staging
+// dir and swapped in place of the original.
+func (c *compactor) retainChunk(name string, created time.Time) error {
+	dir := fmt.Sprintf("%s/%s", c.opts.CompactDir, name)
+	hdrs, err := readHeaders(dir)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil
+		}
+		if isCorrupted(err) {
+			logrus.WithError(err).Warn(dir)
+			return nil
+		}
+		return err
+	}
+	var keep []int
+	for i, hdr := range hdrs {
+		end := hdr.End
+		if end.IsZero() {
+			end = created
+		}
+		if time.Since(end) < c.retentionAge(hdr.Labels) {
+			keep = append(keep, i)
+		}
+	}
+	if len(keep) == len(hdrs) {
+		return nil
+	}
+	if len(keep) == 0 {
+		return os.RemoveAll(dir)
+	}
+	staging := fmt.Sprintf("%s/%s", c.opts.StagingDir, name)
+	err = os.RemoveAll(staging)
+	if err != nil {
+		return err
+	}
+	err = os.MkdirAll(staging, 0777)
+	if err != nil {
+		return err
+	}
+	err = copyChunk(dir, staging, hdrs, keep)
+	if err != nil {
+		return err
+	}
+	err = buildMeta(staging)
+	if err != nil {
+		return err
+	}
+	err = syncDir(staging)
+	if err != nil {
+		return err
+	}
+	return c.swapStaging(name)
+}
+
+// copyChunk writes the blocks of src at keep, with their headers and
+// indices, to dst. Indices are left to be rebuilt if src has none for every
+// block.
+func copyChunk(src, dst string, hdrs []*chunkio.Header, keep []int) error {
+	indices, _, err := readIndexFile(fmt.Sprintf("%s/%s", src, CompactIndexFile))
+	if err != nil && !isCorrupted(err) {
+		return err
+	}
+	if len(indices) != len(hdrs) {
+		indices = nil
+	}
+
+	fsrc, err := os.Open(fmt.Sprintf("%s/%s", src, WriteChunkFile))
+	if err != nil {
+		return err
+	}
+	defer fsrc.Close()
+
+	fchunk, err := os.OpenFile(fmt.Sprintf("%s/%s", dst, WriteChunkFile), os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0777)
+	if err != nil {
+		return err
+	}
+	defer fchunk.Close()
+
+	fhdr, err := os.OpenFile(fmt.Sprintf("%s/%s", dst, CompactHeaderFile), os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0777)
+	if err != nil {
+		return err
+	}
+	defer fhdr.Close()
+
+	var findex *os.File
+	if indices != nil {
+		findex, err = os.OpenFile(fmt.Sprintf("%s/%s", dst, CompactIndexFile), os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0777)
+		if err != nil {
+			return err
+		}
+		defer findex.Close()
+	}
+
+	var offset uint64
+	for _, i := range keep {
+		hdr := hdrs[i]
+		_, err := io.Copy(fchunk, io.NewSectionReader(fsrc, int64(hdr.OffsetStart), int64(hdr.Size)))
+		if err != nil {
+			return err
+		}
+		nhdr := *hdr
+		nhdr.OffsetStart = offset
+		err = chunkio.WriteHeader(fhdr, &nhdr)
+		if err != nil {
+			return err
+		}
+		offset += hdr.Size
+		if findex != nil {
+			err = chunkio.WriteIndex(findex, indices[i])
+			if err != nil {
+				return err
+			}
+		}
+	}
+	err = fchunk.Sync()
+	if err != nil {
+		return err
+	}
+	err = fhdr.Sync()
+	if err != nil {
+		return err
+	}
+	if findex == nil {
+		return nil
+	}
+	return findex.Sync()
+}
+
+// swapStaging replaces a compacted chunk with its rewritten copy in the
+// staging dir. Every step is a rename, so recoverStaging can finish or undo
+// an interrupted swap.
+func (c *compactor) swapStaging(name string) error {
+	dir := fmt.Sprintf("%s/%s", c.opts.CompactDir, name)
+	staging := fmt.Sprintf("%s/%s", c.opts.StagingDir, name)
+	old := staging + stagingOldExt
+	err := os.Rename(dir, old)
+	if err != nil {
+		return err
+	}
+	err = os.Rename(staging, dir)
+	if err != nil {
+		return err
+	}
+	err = syncDir(c.opts.CompactDir)
+	if err != nil {
+		return err
+	}
+	return os.RemoveAll(old)
+}
+
+func (c *compactor) recoverStaging() error {
+	ds, err := osReadDir(c.opts.StagingDir)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil
+		}
+		return err
+	}
+	for _, d := range ds {
+		name := strings.TrimSuffix(d.Name(), stagingOldExt)
+		if name == d.Name() {
+			continue
+		}
+		dir := fmt.Sprintf("%s/%s", c.opts.CompactDir, name)
+		_, err := os.Stat(dir)
+		if errors.Is(err, os.ErrNotExist) {
+			err = os.Rename(fmt.Sprintf("%s/%s", c.opts.StagingDir, name), dir)
+		}
+		if err != nil {
+			return err
+		}
+		err = os.RemoveAll(fmt.Sprintf("%s/%s", c.opts.StagingDir, d.Name()))
+		if err != nil {
+			return err
+		}
+	}
+	// whatever is left was never swapped in.
+	return os.RemoveAll(c.opts.StagingDir)
+}
+
+func dirSize(dir string) (int64, error) {
+	var size int64
+	err := filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			if errors.Is(err, os.ErrNotExist) {
+				return nil
+			}
+			return err
+		}
+		if d.IsDir() {
+			return nil
+		}
+		fi, err := d.Info()
+		if err != nil {
+			return err
+		}
+		size += fi.Size()
+		return nil
+	})
+	return size, err
+}
+
+// evictChunks removes the oldest compacted chunks until the data size is
+// below RetentionMaxSize.
+func (c *compactor) evictChunks() error {
+	if c.opts.RetentionMaxSize <= 0 {
+		return nil
+	}
+	size, err := dirSize(c.opts.WriteDir)
+	if err != nil {
+		return err
+	}
+	ds, err := osReadDir(c.opts.CompactDir)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil
+		}
+		return err
+	}
+	sort.Slice(ds, func(i, j int) bool { return ds[i].Name() < ds[j].Name() })
+	sizes := make([]int64, len(ds))
+	for i, d := range ds {
+		n, err := dirSize(fmt.Sprintf("%s/%s", c.opts.CompactDir, d.Name()))
+		if err != nil {
+			return err
+		}
+		sizes[i] = n
+		size += n
+	}
+	for i, d := range ds {
+		if size <= c.opts.RetentionMaxSize {
+			break
+		}
+		err := os.RemoveAll(fmt.Sprintf("%s/%s", c.opts.CompactDir, d.Name()))
+		if err != nil {
+			return err
+		}
+		size -= sizes[i]
+	}
+	return nil
+}
@@ -0,0 +1,96 @@
+package filesystem
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/commentlens/loghouse/storage"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRetention(t *testing.T) {
+	os.RemoveAll(WriteDir)
+	os.RemoveAll(CompactDir)
+
+	opts := NewOptions(DataDir)
+	w := NewWriter(opts)
+	es := []storage.LogEntry{
+		{
+			Labels: map[string]string{
+				"app":  "test",
+				"role": "test",
+			},
+			Time: now(),
+			Data: []byte(`{"test":1}`),
+		},
+		{
+			Labels: map[string]string{
+				"app":  "test",
+				"role": "test2",
+			},
+			Time: now(),
+			Data: []byte(`{"test":2}`),
+		},
+	}
+	err := w.Write(es)
+	require.NoError(t, err)
+
+	c := compactor{opts: opts}
+	err = markChunkCompactible()
+	require.NoError(t, err)
+	chunks, err := c.FindCompactibleChunk()
+	require.NoError(t, err)
+	err = c.SwapChunk(chunks)
+	require.NoError(t, err)
+	err = c.Compact()
+	require.NoError(t, err)
+
+	read := func() []storage.LogEntry {
+		var esRead []storage.LogEntry
+		err := NewCompactReader(&CompactReaderOptions{
+			Storage:     opts,
+			ReaderCount: 1,
+		}).Read(context.Background(), &storage.ReadOptions{
+			ResultFunc: func(e storage.LogEntry) {
+				esRead = append(esRead, e)
+			},
+		})
+		require.NoError(t, err)
+		return esRead
+	}
+	require.ElementsMatch(t, es, read())
+
+	opts.Retention = []RetentionRule{
+		{
+			Labels: map[string]string{"app": "test"},
+			Match: func(labels map[string]string) bool {
+				return labels["role"] != "test2"
+			},
+			Age: time.Nanosecond,
+		},
+	}
+	err = c.Compact()
+	require.NoError(t, err)
+	require.Equal(t, es[1:], read())
+
+	// the rewritten chunk has its own index and meta.
+	ds, err := os.ReadDir(opts.CompactDir)
+	require.NoError(t, err)
+	require.Len(t, ds, 1)
+	dir := fmt.Sprintf("%s/%s", opts.CompactDir, ds[0].Name())
+	indices, _, err := readIndexFile(fmt.Sprintf("%s/%s", dir, CompactIndexFile))
+	require.NoError(t, err)
+	require.Len(t, indices, 1)
+	meta, err := readMeta(dir)
+	require.NoError(t, err)
+	require.NotNil(t, meta)
+	require.Equal(t, []string{"test2"}, meta.Labels["role"])
+
+	opts.RetentionMaxSize = 1
+	err = c.Compact()
+	require.NoError(t, err)
+	require.Len(t, read(), 0)
+}
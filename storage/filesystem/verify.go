@@ -0,0 +1,269 @@
+package filesystem
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+
+	"github.com/commentlens/loghouse/storage"
+	"github.com/commentlens/loghouse/storage/chunkio"
+	"github.com/klauspost/compress/s2"
+)
+
+const (
+	QuarantineDir = "data/quarantine"
+)
+
+type VerifyOptions struct {
+	Storage *Options
+	Repair  bool
+}
+
+type VerifyResult struct {
+	Chunk    string
+	Err      error
+	Repaired bool
+}
+
+func isCorrupted(err error) bool {
+	for _, target := range []error{
+		chunkio.ErrChecksumMismatch,
+		chunkio.ErrCorruptData,
+		chunkio.ErrUnexpectedTLVType,
+		errCorruptedIndex,
+		io.ErrUnexpectedEOF,
+		os.ErrNotExist,
+		s2.ErrCorrupt,
+		s2.ErrCRC,
+		s2.ErrUnsupported,
+	} {
+		if errors.Is(err, target) {
+			return true
+		}
+	}
+	return false
+}
+
+// Verify scans compact and incompact chunks and reports damaged ones. With
+// repair, torn incompact chunks are truncated, damaged indices are rebuilt,
+// and chunks that cannot be repaired are quarantined.
+func Verify(ctx context.Context, opts *VerifyOptions) ([]*VerifyResult, error) {
+	var results []*VerifyResult
+	chunks, err := findFiles(opts.Storage.CompactDir, WriteChunkFile)
+	if err != nil {
+		return nil, err
+	}
+	for _, chunk := range chunks {
+		result, err := verifyCompactChunk(ctx, chunk, opts)
+		if err != nil {
+			return nil, err
+		}
+		if result != nil {
+			results = append(results, result)
+		}
+	}
+	for _, name := range []string{WriteChunkFile, CompactTmpFile} {
+		chunks, err := findFiles(opts.Storage.WriteDir, name)
+		if err != nil {
+			return nil, err
+		}
+		for _, chunk := range chunks {
+			result, err := verifyIncompactChunk(chunk, opts)
+			if err != nil {
+				return nil, err
+			}
+			if result != nil {
+				results = append(results, result)
+			}
+		}
+	}
+	return results, nil
+}
+
+func readHeaders(dir string) ([]*chunkio.Header, error) {
+	return readHeaderFile(fmt.Sprintf("%s/%s", dir, CompactHeaderFile))
+}
+
+func readHeaderFile(file string) ([]*chunkio.Header, error) {
+	f, err := os.Open(file)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	buf := chunkio.NewBuffer()
+	defer chunkio.RecycleBuffer(buf)
+	buf.Reset(f)
+	var hdrs []*chunkio.Header
+	for {
+		hdr, err := chunkio.ReadHeader(buf)
+		if err != nil {
+			if errors.Is(err, io.EOF) {
+				break
+			}
+			return nil, err
+		}
+		hdrs = append(hdrs, hdr)
+	}
+	return hdrs, nil
+}
+
+func verifyIndex(dir string, count int) error {
+	f, err := os.Open(fmt.Sprintf("%s/%s", dir, CompactIndexFile))
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil
+		}
+		return err
+	}
+	defer f.Close()
+
+	buf := chunkio.NewBuffer()
+	defer chunkio.RecycleBuffer(buf)
+	buf.Reset(f)
+	var n int
+	for {
+		_, err := chunkio.ReadIndex(buf)
+		if err != nil {
+			if errors.Is(err, io.EOF) {
+				break
+			}
+			return err
+		}
+		n++
+	}
+	if n > count {
+		return errCorruptedIndex
+	}
+	return nil
+}
+
+func verifyCompactChunk(ctx context.Context, chunk string, opts *VerifyOptions) (*VerifyResult, error) {
+	dir := filepath.Dir(chunk)
+	hdrs, err := readHeaders(dir)
+	if err == nil {
+		for _, hdr := range hdrs {
+			err = func() error {
+				f, err := os.Open(chunk)
+				if err != nil {
+					return err
+				}
+				defer f.Close()
+
+				buf := chunkio.NewBuffer()
+				defer chunkio.RecycleBuffer(buf)
+				buf.Reset(io.NewSectionReader(f, int64(hdr.OffsetStart), int64(hdr.Size)))
+				return chunkio.ReadData(ctx, hdr, buf, &storage.ReadOptions{
+					ResultFunc: func(storage.LogEntry) {},
+				})
+			}()
+			if err != nil {
+				break
+			}
+		}
+	}
+	if err != nil {
+		if !isCorrupted(err) {
+			return nil, err
+		}
+		result := &VerifyResult{Chunk: chunk, Err: err}
+		if opts.Repair {
+			err := quarantine(opts.Storage.CompactDir, dir, opts.Storage.QuarantineDir)
+			if err != nil {
+				return nil, err
+			}
+			result.Repaired = true
+		}
+		return result, nil
+	}
+	err = verifyIndex(dir, len(hdrs))
+	if err != nil {
+		if !isCorrupted(err) {
+			return nil, err
+		}
+		result := &VerifyResult{Chunk: chunk, Err: err}
+		if opts.Repair {
+			err := os.Remove(fmt.Sprintf("%s/%s", dir, CompactIndexFile))
+			if err != nil {
+				return nil, err
+			}
+			_, err = buildIndex(dir)
+			if err != nil {
+				return nil, err
+			}
+			result.Repaired = true
+		}
+		return result, nil
+	}
+	return nil, nil
+}
+
+func verifyIncompactChunk(chunk string, opts *VerifyOptions) (*VerifyResult, error) {
+	dir := filepath.Dir(chunk)
+	_, err := readHeaders(dir)
+	if err != nil {
+		if !isCorrupted(err) {
+			return nil, err
+		}
+		result := &VerifyResult{Chunk: chunk, Err: err}
+		if opts.Repair {
+			err := quarantine(opts.Storage.WriteDir, dir, opts.Storage.QuarantineDir)
+			if err != nil {
+				return nil, err
+			}
+			result.Repaired = true
+		}
+		return result, nil
+	}
+	fi, err := os.Stat(chunk)
+	if err != nil {
+		return nil, err
+	}
+	size, err := func() (uint64, error) {
+		f, err := os.Open(chunk)
+		if err != nil {
+			return 0, err
+		}
+		defer f.Close()
+
+		buf := chunkio.NewBuffer()
+		defer chunkio.RecycleBuffer(buf)
+		buf.Reset(f)
+		size, _, err := chunkio.VerifyData(buf)
+		return size, err
+	}()
+	if err != nil {
+		return nil, err
+	}
+	if size == uint64(fi.Size()) {
+		return nil, nil
+	}
+	result := &VerifyResult{
+		Chunk: chunk,
+		Err:   fmt.Errorf("%w: %d of %d bytes valid", chunkio.ErrChecksumMismatch, size, fi.Size()),
+	}
+	if opts.Repair {
+		err := recoverChunk(chunk, opts.Storage)
+		if err != nil {
+			return nil, err
+		}
+		result.Repaired = true
+	}
+	return result, nil
+}
+
+func quarantine(root, dir, quarantineDir string) error {
+	rel, err := filepath.Rel(filepath.Dir(root), dir)
+	if err != nil {
+		return err
+	}
+	dst := fmt.Sprintf("%s/%s", quarantineDir, rel)
+	err = os.MkdirAll(filepath.Dir(dst), 0777)
+	if err != nil {
+		return err
+	}
+	return os.Rename(dir, dst)
+}
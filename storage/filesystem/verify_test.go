@@ -0,0 +1,90 @@
+package filesystem
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/commentlens/loghouse/storage"
+	"github.com/stretchr/testify/require"
+)
+
+func TestVerify(t *testing.T) {
+	os.RemoveAll(WriteDir)
+	os.RemoveAll(CompactDir)
+	os.RemoveAll(QuarantineDir)
+
+	w := NewWriter(NewOptions(DataDir))
+	es := []storage.LogEntry{
+		{
+			Labels: map[string]string{
+				"app":  "test",
+				"role": "test",
+			},
+			Time: now(),
+			Data: []byte(`{"test":1}`),
+		},
+	}
+	err := w.Write(es)
+	require.NoError(t, err)
+
+	c := compactor{opts: NewOptions(DataDir)}
+	err = markChunkCompactible()
+	require.NoError(t, err)
+	chunks, err := c.FindCompactibleChunk()
+	require.NoError(t, err)
+	err = c.SwapChunk(chunks)
+	require.NoError(t, err)
+	err = c.Compact()
+	require.NoError(t, err)
+
+	es[0].Labels = map[string]string{
+		"app":  "test",
+		"role": "test2",
+	}
+	err = w.Write(es)
+	require.NoError(t, err)
+
+	results, err := Verify(context.Background(), &VerifyOptions{Storage: NewOptions(DataDir)})
+	require.NoError(t, err)
+	require.Len(t, results, 0)
+
+	compactChunks, err := findFiles(CompactDir, WriteChunkFile)
+	require.NoError(t, err)
+	require.Len(t, compactChunks, 1)
+	b, err := os.ReadFile(compactChunks[0])
+	require.NoError(t, err)
+	b[len(b)/2] ^= 0xff
+	err = os.WriteFile(compactChunks[0], b, 0777)
+	require.NoError(t, err)
+
+	incompactChunks, err := findFiles(WriteDir, WriteChunkFile)
+	require.NoError(t, err)
+	require.Len(t, incompactChunks, 1)
+	f, err := os.OpenFile(incompactChunks[0], os.O_WRONLY|os.O_APPEND, 0777)
+	require.NoError(t, err)
+	_, err = f.Write([]byte{0x06, 0x08, 0x00})
+	require.NoError(t, err)
+	require.NoError(t, f.Close())
+
+	results, err = Verify(context.Background(), &VerifyOptions{Storage: NewOptions(DataDir)})
+	require.NoError(t, err)
+	require.Len(t, results, 2)
+	for _, result := range results {
+		require.False(t, result.Repaired)
+	}
+
+	results, err = Verify(context.Background(), &VerifyOptions{Storage: NewOptions(DataDir), Repair: true})
+	require.NoError(t, err)
+	require.Len(t, results, 2)
+	for _, result := range results {
+		require.True(t, result.Repaired)
+	}
+	require.NoDirExists(t, filepath.Dir(compactChunks[0]))
+	require.FileExists(t, filepath.Join(QuarantineDir, "compact", filepath.Base(filepath.Dir(compactChunks[0])), WriteChunkFile))
+
+	results, err = Verify(context.Background(), &VerifyOptions{Storage: NewOptions(DataDir)})
+	require.NoError(t, err)
+	require.Len(t, results, 0)
+}
@@ -1,57 +1,142 @@
 package filesystem
 
 import (
+	"bytes"
 	"errors"
 	"fmt"
+	"io"
 	"os"
+	"path/filepath"
 
 	"github.com/commentlens/loghouse/storage"
 	"github.com/commentlens/loghouse/storage/chunkio"
+	"github.com/sirupsen/logrus"
 )
 
 const (
-	WriteDir       = "data/incompact"
-	WriteChunkFile = "chunk.loghouse"
+	WriteDir           = "data/incompact"
+	WriteChunkFile     = "chunk.loghouse"
+	WriteHeaderTmpFile = "header.loghouse.tmp"
+	WriteRepairTmpFile = "chunk.loghouse.repair"
 )
 
-func NewWriter() storage.Writer {
-	return &writer{}
+func NewWriter(opts *Options) storage.Writer {
+	return &writer{opts: opts}
 }
 
-type writer struct{}
+type writer struct {
+	opts *Options
+}
 
-func (w *writer) write(hash string, es []storage.LogEntry) error {
-	dir := fmt.Sprintf("%s/%s", WriteDir, hash)
-	err := os.MkdirAll(dir, 0777)
+func syncDir(dir string) error {
+	f, err := os.Open(dir)
 	if err != nil {
 		return err
 	}
+	defer f.Close()
+
+	return f.Sync()
+}
+
+func writeHeader(dir string, labels map[string]string) error {
+	headerFile := fmt.Sprintf("%s/%s", dir, CompactHeaderFile)
+	_, err := os.Stat(headerFile)
+	if err == nil {
+		return nil
+	}
+	if !errors.Is(err, os.ErrNotExist) {
+		return err
+	}
+	tmpFile := fmt.Sprintf("%s/%s", dir, WriteHeaderTmpFile)
 	err = func() error {
-		f, err := os.OpenFile(fmt.Sprintf("%s/%s", dir, CompactHeaderFile), os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0777)
+		f, err := os.OpenFile(tmpFile, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0777)
 		if err != nil {
 			return err
 		}
 		defer f.Close()
 
-		return chunkio.WriteHeader(f, &chunkio.Header{
-			Labels: es[0].Labels,
+		err = chunkio.WriteHeader(f, &chunkio.Header{
+			Labels: labels,
 		})
+		if err != nil {
+			return err
+		}
+		return f.Sync()
 	}()
-	if err != nil && !errors.Is(err, os.ErrExist) {
+	if err != nil {
+		return err
+	}
+	err = os.Rename(tmpFile, headerFile)
+	if err != nil {
+		return err
+	}
+	err = syncDir(dir)
+	if err != nil {
+		return err
+	}
+	return syncDir(filepath.Dir(dir))
+}
+
+func (w *writer) write(hash string, es []storage.LogEntry) error {
+	dir := fmt.Sprintf("%s/%s", w.opts.WriteDir, hash)
+	err := os.MkdirAll(dir, 0777)
+	if err != nil {
 		return err
 	}
+	err = writeHeader(dir, es[0].Labels)
+	if err != nil {
+		return err
+	}
+	buf := new(bytes.Buffer)
+	err = chunkio.WriteData(buf, es, false)
+	if err != nil {
+		return err
+	}
+	var created bool
 	err = func() error {
-		f, err := os.OpenFile(fmt.Sprintf("%s/%s", dir, WriteChunkFile), os.O_WRONLY|os.O_APPEND|os.O_CREATE, 0777)
+		chunk := fmt.Sprintf("%s/%s", dir, WriteChunkFile)
+		f, err := os.OpenFile(chunk, os.O_WRONLY|os.O_APPEND|os.O_CREATE|os.O_EXCL, 0777)
+		if err == nil {
+			created = true
+		} else if errors.Is(err, os.ErrExist) {
+			f, err = os.OpenFile(chunk, os.O_WRONLY|os.O_APPEND, 0777)
+		}
 		if err != nil {
 			return err
 		}
 		defer f.Close()
 
-		return chunkio.WriteData(f, es, false)
+		off, err := f.Seek(0, io.SeekEnd)
+		if err != nil {
+			return err
+		}
+		_, err = f.Write(buf.Bytes())
+		if err == nil {
+			err = f.Sync()
+		}
+		if err != nil {
+			// cut the failed append, so that later appends stay readable.
+			terr := f.Truncate(off)
+			if terr != nil {
+				logrus.WithError(terr).Warn(f.Name())
+			}
+			return err
+		}
+		return nil
 	}()
 	if err != nil {
 		return err
 	}
+	// a new chunk is only durable once its dir entry is.
+	if created {
+		err = syncDir(dir)
+		if err != nil {
+			return err
+		}
+	}
+	if w.opts.Catalog != nil {
+		w.opts.Catalog.Add(hash, es)
+	}
 	return nil
 }
 
@@ -72,3 +157,170 @@ func (w *writer) Write(es []storage.LogEntry) error {
 	}
 	return nil
 }
+
+// recover truncates torn tails left by interrupted appends, so that every
+// incompact chunk can be read again.
+func (w *writer) recover() error {
+	headerTmps, err := findFiles(w.opts.WriteDir, WriteHeaderTmpFile)
+	if err != nil {
+		return err
+	}
+	repairTmps, err := findFiles(w.opts.WriteDir, WriteRepairTmpFile)
+	if err != nil {
+		return err
+	}
+	for _, tmp := range append(headerTmps, repairTmps...) {
+		err := os.Remove(tmp)
+		if err != nil {
+			return err
+		}
+	}
+	for _, name := range []string{WriteChunkFile, CompactTmpFile} {
+		chunks, err := findFiles(w.opts.WriteDir, name)
+		if err != nil {
+			return err
+		}
+		for _, chunk := range chunks {
+			err := recoverChunk(chunk, w.opts)
+			if err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// recoverChunk cuts a torn tail off chunk. If it is damaged before its tail,
+// a copy is kept in the quarantine dir and only the damaged segments are
+// dropped.
+func recoverChunk(chunk string, opts *Options) error {
+	f, err := os.OpenFile(chunk, os.O_RDWR, 0777)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	fi, err := f.Stat()
+	if err != nil {
+		return err
+	}
+	size, checksummed, err := chunkio.VerifyData(f)
+	if err != nil {
+		return err
+	}
+	if checksummed && size < uint64(fi.Size()) {
+		_, err = f.Seek(0, io.SeekStart)
+		if err != nil {
+			return err
+		}
+		buf := chunkio.NewBuffer()
+		defer chunkio.RecycleBuffer(buf)
+		buf.Reset(f)
+		segs, torn, err := chunkio.VerifySegments(buf)
+		if err != nil {
+			return err
+		}
+		var valid uint64
+		for _, seg := range segs {
+			valid += seg.Size
+		}
+		if !torn || valid > size {
+			f.Close()
+			return repairChunk(chunk, segs, uint64(fi.Size())-valid, opts)
+		}
+		logrus.WithField("bytes", uint64(fi.Size())-size).Warn(fmt.Sprintf("%s: truncated torn tail", chunk))
+	}
+	if size < uint64(fi.Size()) {
+		err = f.Truncate(int64(size))
+		if err != nil {
+			return err
+		}
+	}
+	if !checksummed && size > 0 {
+		// seal chunks written before appends were checksummed.
+		b := make([]byte, size)
+		_, err = f.ReadAt(b, 0)
+		if err != nil && !errors.Is(err, io.EOF) {
+			return err
+		}
+		_, err = f.Seek(0, io.SeekEnd)
+		if err != nil {
+			return err
+		}
+		err = chunkio.WriteChecksum(f, b)
+		if err != nil {
+			return err
+		}
+	}
+	return f.Sync()
+}
+
+// repairChunk copies chunk to the quarantine dir and rewrites it with only
+// segs.
+func repairChunk(chunk string, segs []chunkio.Segment, dropped uint64, opts *Options) error {
+	rel, err := filepath.Rel(filepath.Dir(opts.WriteDir), chunk)
+	if err != nil {
+		return err
+	}
+	dst := fmt.Sprintf("%s/%s", opts.QuarantineDir, rel)
+	err = os.MkdirAll(filepath.Dir(dst), 0777)
+	if err != nil {
+		return err
+	}
+	err = copyFile(chunk, dst)
+	if err != nil {
+		return err
+	}
+	src, err := os.Open(chunk)
+	if err != nil {
+		return err
+	}
+	defer src.Close()
+
+	tmpFile := fmt.Sprintf("%s/%s", filepath.Dir(chunk), WriteRepairTmpFile)
+	err = func() error {
+		f, err := os.OpenFile(tmpFile, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0777)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+
+		for _, seg := range segs {
+			_, err := io.Copy(f, io.NewSectionReader(src, int64(seg.Offset), int64(seg.Size)))
+			if err != nil {
+				return err
+			}
+		}
+		return f.Sync()
+	}()
+	if err != nil {
+		return err
+	}
+	err = os.Rename(tmpFile, chunk)
+	if err != nil {
+		return err
+	}
+	logrus.WithFields(logrus.Fields{
+		"bytes":      dropped,
+		"quarantine": dst,
+	}).Warn(fmt.Sprintf("%s: dropped damaged data", chunk))
+	return syncDir(filepath.Dir(chunk))
+}
+
+// ListStreams returns the label hashes of streams with incompact chunks.
+func ListStreams(opts *Options) ([]string, error) {
+	ds, err := osReadDir(opts.WriteDir)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	var hashes []string
+	for _, d := range ds {
+		if d.IsDir() {
+			hashes = append(hashes, d.Name())
+		}
+	}
+	return hashes, nil
+}
@@ -1,8 +1,11 @@
 package filesystem
 
 import (
+	"bytes"
+	"context"
 	"fmt"
 	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/commentlens/loghouse/storage"
@@ -12,7 +15,7 @@ import (
 func TestWriter(t *testing.T) {
 	os.RemoveAll(WriteDir)
 
-	w := NewWriter()
+	w := NewWriter(NewOptions(DataDir))
 
 	es := []storage.LogEntry{
 		{
@@ -48,3 +51,98 @@ func TestWriter(t *testing.T) {
 	err = w.Write(es)
 	require.NoError(t, err)
 }
+
+func TestWriterRecover(t *testing.T) {
+	os.RemoveAll(WriteDir)
+
+	w := NewCompactWriter(NewOptions(DataDir))
+
+	es := []storage.LogEntry{
+		{
+			Labels: map[string]string{
+				"app":  "test",
+				"role": "test2",
+			},
+			Time: now(),
+			Data: []byte(`{"test":1}`),
+		},
+		{
+			Labels: map[string]string{
+				"app":  "test",
+				"role": "test2",
+			},
+			Time: now(),
+			Data: []byte(`plain text`),
+		},
+	}
+	err := w.Write(es)
+	require.NoError(t, err)
+
+	chunks, err := findFiles(WriteDir, WriteChunkFile)
+	require.NoError(t, err)
+	require.Len(t, chunks, 1)
+
+	f, err := os.OpenFile(chunks[0], os.O_WRONLY|os.O_APPEND, 0777)
+	require.NoError(t, err)
+	_, err = f.Write([]byte{0x01, 0x08, 0x00})
+	require.NoError(t, err)
+	require.NoError(t, f.Close())
+
+	err = w.Recover()
+	require.NoError(t, err)
+
+	err = w.Write(es)
+	require.NoError(t, err)
+
+	var esRead []storage.LogEntry
+	err = NewReader(chunks).Read(context.Background(), &storage.ReadOptions{
+		ResultFunc: func(e storage.LogEntry) {
+			esRead = append(esRead, e)
+		},
+	})
+	require.NoError(t, err)
+	require.Equal(t, append(es, es...), esRead)
+}
+
+func TestWriterRecoverDamaged(t *testing.T) {
+	opts := NewOptions(t.TempDir())
+	w := NewCompactWriter(opts)
+
+	entry := func(data string) []storage.LogEntry {
+		return []storage.LogEntry{{
+			Labels: map[string]string{"app": "test"},
+			Time:   now(),
+			Data:   []byte(data),
+		}}
+	}
+	for _, data := range []string{"first", "second", "third"} {
+		err := w.Write(entry(data))
+		require.NoError(t, err)
+	}
+	chunks, err := findFiles(opts.WriteDir, WriteChunkFile)
+	require.NoError(t, err)
+	require.Len(t, chunks, 1)
+
+	b, err := os.ReadFile(chunks[0])
+	require.NoError(t, err)
+	b[bytes.Index(b, []byte("second"))] = 'S'
+	err = os.WriteFile(chunks[0], b, 0777)
+	require.NoError(t, err)
+
+	// only the damaged append is dropped, and the original is kept.
+	err = w.Recover()
+	require.NoError(t, err)
+	var got []string
+	err = NewReader(chunks).Read(context.Background(), &storage.ReadOptions{
+		ResultFunc: func(e storage.LogEntry) {
+			got = append(got, string(e.Data))
+		},
+	})
+	require.NoError(t, err)
+	require.Equal(t, []string{"first", "third"}, got)
+	rel, err := filepath.Rel(filepath.Dir(opts.WriteDir), chunks[0])
+	require.NoError(t, err)
+	quarantined, err := os.ReadFile(filepath.Join(opts.QuarantineDir, rel))
+	require.NoError(t, err)
+	require.Equal(t, b, quarantined)
+}
@@ -0,0 +1,86 @@
+package storage
+
+import "sync"
+
+// Hub broadcasts written entries to subscribers. Publish never blocks: entries
+// for a subscriber whose buffer is full are dropped and reported by Dropped.
+type Hub struct {
+	mu   sync.RWMutex
+	subs map[*Subscription]struct{}
+}
+
+func NewHub() *Hub {
+	return &Hub{subs: make(map[*Subscription]struct{})}
+}
+
+type Subscription struct {
+	hub   *Hub
+	c     chan LogEntry
+	match func(LogEntry) bool
+
+	mu      sync.Mutex
+	dropped []LogEntry
+}
+
+// Subscribe returns a subscription buffering up to size entries for which
+// match, if not nil, returns true. Other entries are neither buffered nor
+// reported as dropped.
+func (h *Hub) Subscribe(size int, match func(LogEntry) bool) *Subscription {
+	s := &Subscription{
+		hub:   h,
+		c:     make(chan LogEntry, size),
+		match: match,
+	}
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.subs[s] = struct{}{}
+	return s
+}
+
+func (h *Hub) Publish(es []LogEntry) {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+
+	for s := range h.subs {
+		for _, e := range es {
+			if s.match != nil && !s.match(e) {
+				continue
+			}
+			select {
+			case s.c <- e:
+			default:
+				s.drop(e)
+			}
+		}
+	}
+}
+
+func (s *Subscription) drop(e LogEntry) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	// keep as many dropped entries as the buffer holds.
+	if len(s.dropped) < cap(s.c) {
+		s.dropped = append(s.dropped, e)
+	}
+}
+
+func (s *Subscription) Entries() <-chan LogEntry {
+	return s.c
+}
+
+// Dropped returns the entries dropped since the last call.
+func (s *Subscription) Dropped() []LogEntry {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	dropped := s.dropped
+	s.dropped = nil
+	return dropped
+}
+
+func (s *Subscription) Close() {
+	s.hub.mu.Lock()
+	defer s.hub.mu.Unlock()
+	delete(s.hub.subs, s)
+}
@@ -0,0 +1,36 @@
+package storage
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestHub(t *testing.T) {
+	h := NewHub()
+	s := h.Subscribe(2, nil)
+	es := []LogEntry{
+		{Labels: map[string]string{"app": "test"}, Time: now(), Data: []byte("1")},
+		{Labels: map[string]string{"app": "test"}, Time: now(), Data: []byte("2")},
+		{Labels: map[string]string{"app": "test"}, Time: now(), Data: []byte("3")},
+	}
+	h.Publish(es)
+	require.Equal(t, es[0], <-s.Entries())
+	require.Equal(t, es[1], <-s.Entries())
+	require.Equal(t, es[2:], s.Dropped())
+	require.Nil(t, s.Dropped())
+
+	s.Close()
+	h.Publish(es)
+	require.Len(t, s.Entries(), 0)
+	require.Nil(t, s.Dropped())
+
+	// unmatched entries take no room in the buffer.
+	s = h.Subscribe(1, func(e LogEntry) bool {
+		return string(e.Data) == "3"
+	})
+	defer s.Close()
+	h.Publish(es)
+	require.Equal(t, es[2], <-s.Entries())
+	require.Nil(t, s.Dropped())
+}
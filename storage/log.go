@@ -2,6 +2,7 @@ package storage
 
 import (
 	"bytes"
+	"encoding/json"
 	"sort"
 	"time"
 
@@ -23,15 +24,30 @@ type LogEntry struct {
 
 type LogEntryData []byte
 
+// IsJSON reports whether m is a structured JSON object rather than a plain text line.
+func (m LogEntryData) IsJSON() bool {
+	return bytes.HasPrefix(m, []byte{'{'}) && gjson.ValidBytes(m)
+}
+
 func (m LogEntryData) MarshalJSON() ([]byte, error) {
 	if m == nil {
 		return []byte("null"), nil
 	}
+	if !m.IsJSON() {
+		return json.Marshal(string(m))
+	}
 	return m, nil
 }
 
 func (m *LogEntryData) UnmarshalJSON(data []byte) error {
-	if !(bytes.HasPrefix(data, []byte{'{'}) && bytes.HasSuffix(data, []byte{'}'})) {
+	if bytes.HasPrefix(data, []byte{'"'}) {
+		var s string
+		err := json.Unmarshal(data, &s)
+		if err != nil {
+			return err
+		}
+		data = []byte(s)
+	} else if bytes.Equal(data, []byte("null")) {
 		data = nil
 	}
 	*m = append((*m)[0:0], data...)
@@ -39,6 +55,12 @@ func (m *LogEntryData) UnmarshalJSON(data []byte) error {
 }
 
 func (m *LogEntryData) Values() ([]string, error) {
+	if !m.IsJSON() {
+		if len(*m) == 0 {
+			return nil, nil
+		}
+		return []string{string(*m)}, nil
+	}
 	tm := make(map[string]struct{})
 	var itr func(k, v gjson.Result) bool
 	itr = func(k, v gjson.Result) bool {
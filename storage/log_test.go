@@ -1,6 +1,7 @@
 package storage
 
 import (
+	"encoding/json"
 	"testing"
 
 	"github.com/stretchr/testify/require"
@@ -40,6 +41,17 @@ func TestLogEntryDataValues(t *testing.T) {
 			in:   `{"k":"{\"comment\":\"Declined because I am out of office\",\"responseStatus\":\"declined\",\"self\":true}"}`,
 			want: []string{"Declined because I am out of office", "comment", "declined", "k", "responseStatus", "self", "true"},
 		},
+		{
+			in:   `level=info msg="plain text"`,
+			want: []string{`level=info msg="plain text"`},
+		},
+		{
+			in:   `{not json`,
+			want: []string{`{not json`},
+		},
+		{
+			in: ``,
+		},
 	} {
 		d := LogEntryData(test.in)
 		got, err := d.Values()
@@ -47,3 +59,39 @@ func TestLogEntryDataValues(t *testing.T) {
 		require.Equal(t, test.want, got)
 	}
 }
+
+func TestLogEntryDataJSON(t *testing.T) {
+	for _, test := range []struct {
+		in     string
+		want   LogEntryData
+		isJSON bool
+	}{
+		{
+			in:     `{"k":"1"}`,
+			want:   LogEntryData(`{"k":"1"}`),
+			isJSON: true,
+		},
+		{
+			in:   `"plain \"text\""`,
+			want: LogEntryData(`plain "text"`),
+		},
+		{
+			in:   `"{not json"`,
+			want: LogEntryData(`{not json`),
+		},
+		{
+			in:   `null`,
+			want: LogEntryData(nil),
+		},
+	} {
+		var got LogEntryData
+		err := json.Unmarshal([]byte(test.in), &got)
+		require.NoError(t, err)
+		require.Equal(t, test.want, got)
+		require.Equal(t, test.isJSON, got.IsJSON())
+
+		b, err := json.Marshal(got)
+		require.NoError(t, err)
+		require.Equal(t, test.in, string(b))
+	}
+}
@@ -0,0 +1,122 @@
+package objstore
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// Bucket stores objects by key, with / as separator. Missing objects are
+// reported with errors wrapping os.ErrNotExist.
+type Bucket interface {
+	Put(ctx context.Context, key string, r io.Reader, size int64) error
+	// Get returns n bytes of the object from off, or the rest of it if n < 0.
+	Get(ctx context.Context, key string, off, n int64) (io.ReadCloser, error)
+	// List returns the keys with prefix, sorted.
+	List(ctx context.Context, prefix string) ([]string, error)
+	Delete(ctx context.Context, key string) error
+}
+
+// WithPrefix returns a bucket that keeps its objects under prefix in b.
+func WithPrefix(b Bucket, prefix string) Bucket {
+	if prefix == "" {
+		return b
+	}
+	return &prefixBucket{b: b, prefix: prefix}
+}
+
+type prefixBucket struct {
+	b      Bucket
+	prefix string
+}
+
+func (b *prefixBucket) Put(ctx context.Context, key string, r io.Reader, size int64) error {
+	return b.b.Put(ctx, b.prefix+key, r, size)
+}
+
+func (b *prefixBucket) Get(ctx context.Context, key string, off, n int64) (io.ReadCloser, error) {
+	return b.b.Get(ctx, b.prefix+key, off, n)
+}
+
+func (b *prefixBucket) List(ctx context.Context, prefix string) ([]string, error) {
+	keys, err := b.b.List(ctx, b.prefix+prefix)
+	if err != nil {
+		return nil, err
+	}
+	for i, key := range keys {
+		keys[i] = strings.TrimPrefix(key, b.prefix)
+	}
+	return keys, nil
+}
+
+func (b *prefixBucket) Delete(ctx context.Context, key string) error {
+	return b.b.Delete(ctx, b.prefix+key)
+}
+
+// MemBucket is an in-memory bucket, for tests.
+type MemBucket struct {
+	mu      sync.Mutex
+	objects map[string][]byte
+}
+
+func NewMemBucket() *MemBucket {
+	return &MemBucket{objects: make(map[string][]byte)}
+}
+
+func (b *MemBucket) Put(ctx context.Context, key string, r io.Reader, size int64) error {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return err
+	}
+	if int64(len(data)) != size {
+		return io.ErrUnexpectedEOF
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.objects[key] = data
+	return nil
+}
+
+func (b *MemBucket) Get(ctx context.Context, key string, off, n int64) (io.ReadCloser, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	data, ok := b.objects[key]
+	if !ok {
+		return nil, fmt.Errorf("%w %q", os.ErrNotExist, key)
+	}
+	if off > int64(len(data)) {
+		off = int64(len(data))
+	}
+	data = data[off:]
+	if n >= 0 && n < int64(len(data)) {
+		data = data[:n]
+	}
+	return io.NopCloser(bytes.NewReader(data)), nil
+}
+
+func (b *MemBucket) List(ctx context.Context, prefix string) ([]string, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	var keys []string
+	for key := range b.objects {
+		if strings.HasPrefix(key, prefix) {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+	return keys, nil
+}
+
+func (b *MemBucket) Delete(ctx context.Context, key string) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	delete(b.objects, key)
+	return nil
+}
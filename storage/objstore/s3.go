@@ -0,0 +1,300 @@
+package objstore
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"sort"
+	"strings"
+	"time"
+)
+
+const (
+	s3Algorithm       = "AWS4-HMAC-SHA256"
+	s3UnsignedPayload = "UNSIGNED-PAYLOAD"
+	s3EmptyPayload    = "e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855"
+)
+
+type S3Options struct {
+	// Endpoint is the base url of the service, like https://s3.amazonaws.com
+	// or http://minio:9000. Buckets are addressed by path.
+	Endpoint        string
+	Region          string
+	Bucket          string
+	AccessKeyID     string
+	SecretAccessKey string
+	Client          *http.Client
+}
+
+// NewS3 returns a bucket of an S3-compatible service, signing requests with
+// signature version 4.
+func NewS3(opts *S3Options) Bucket {
+	client := opts.Client
+	if client == nil {
+		client = http.DefaultClient
+	}
+	region := opts.Region
+	if region == "" {
+		region = "us-east-1"
+	}
+	return &s3Bucket{
+		endpoint: strings.TrimSuffix(opts.Endpoint, "/"),
+		region:   region,
+		bucket:   opts.Bucket,
+		keyID:    opts.AccessKeyID,
+		secret:   opts.SecretAccessKey,
+		client:   client,
+	}
+}
+
+type s3Bucket struct {
+	endpoint string
+	region   string
+	bucket   string
+	keyID    string
+	secret   string
+	client   *http.Client
+}
+
+type s3Error struct {
+	Code    string
+	Message string
+}
+
+func (b *s3Bucket) do(ctx context.Context, method, key string, query url.Values, header http.Header, body io.Reader, size int64) (*http.Response, error) {
+	u := fmt.Sprintf("%s/%s", b.endpoint, b.bucket)
+	if key != "" {
+		u += "/" + s3EscapePath(key)
+	}
+	if len(query) > 0 {
+		u += "?" + s3CanonicalQuery(query)
+	}
+	req, err := http.NewRequestWithContext(ctx, method, u, body)
+	if err != nil {
+		return nil, err
+	}
+	for k, vs := range header {
+		req.Header[k] = vs
+	}
+	payload := s3EmptyPayload
+	if body != nil {
+		req.ContentLength = size
+		payload = s3UnsignedPayload
+	}
+	s3Sign(req, payload, b.keyID, b.secret, b.region, time.Now())
+	resp, err := b.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode/100 == 2 {
+		return resp, nil
+	}
+	defer resp.Body.Close()
+
+	var e s3Error
+	_ = xml.NewDecoder(io.LimitReader(resp.Body, 1<<16)).Decode(&e)
+	if resp.StatusCode == http.StatusNotFound {
+		return nil, fmt.Errorf("%w %q: %s", os.ErrNotExist, key, e.Code)
+	}
+	return nil, fmt.Errorf("s3 %s %q: %s %s %s", method, key, resp.Status, e.Code, e.Message)
+}
+
+func (b *s3Bucket) Put(ctx context.Context, key string, r io.Reader, size int64) error {
+	resp, err := b.do(ctx, http.MethodPut, key, nil, nil, r, size)
+	if err != nil {
+		return err
+	}
+	return resp.Body.Close()
+}
+
+func (b *s3Bucket) Get(ctx context.Context, key string, off, n int64) (io.ReadCloser, error) {
+	header := make(http.Header)
+	switch {
+	case n == 0:
+		return io.NopCloser(strings.NewReader("")), nil
+	case n > 0:
+		header.Set("Range", fmt.Sprintf("bytes=%d-%d", off, off+n-1))
+	case off > 0:
+		header.Set("Range", fmt.Sprintf("bytes=%d-", off))
+	}
+	resp, err := b.do(ctx, http.MethodGet, key, nil, header, nil, 0)
+	if err != nil {
+		return nil, err
+	}
+	if header.Get("Range") == "" || resp.StatusCode == http.StatusPartialContent {
+		return resp.Body, nil
+	}
+	// servers that ignore Range return the whole object.
+	if resp.StatusCode != http.StatusOK {
+		resp.Body.Close()
+		return nil, fmt.Errorf("s3 GET %q: unexpected %s for a range", key, resp.Status)
+	}
+	_, err = io.CopyN(io.Discard, resp.Body, off)
+	if err != nil {
+		resp.Body.Close()
+		return nil, fmt.Errorf("s3 GET %q: %w", key, err)
+	}
+	r := io.Reader(resp.Body)
+	if n > 0 {
+		r = io.LimitReader(resp.Body, n)
+	}
+	return struct {
+		io.Reader
+		io.Closer
+	}{r, resp.Body}, nil
+}
+
+type s3ListResult struct {
+	Contents []struct {
+		Key string
+	}
+	IsTruncated           bool
+	NextContinuationToken string
+}
+
+func (b *s3Bucket) List(ctx context.Context, prefix string) ([]string, error) {
+	var keys []string
+	var token string
+	for {
+		query := url.Values{"list-type": {"2"}, "prefix": {prefix}}
+		if token != "" {
+			query.Set("continuation-token", token)
+		}
+		resp, err := b.do(ctx, http.MethodGet, "", query, nil, nil, 0)
+		if err != nil {
+			return nil, err
+		}
+		var result s3ListResult
+		err = xml.NewDecoder(resp.Body).Decode(&result)
+		resp.Body.Close()
+		if err != nil {
+			return nil, err
+		}
+		for _, c := range result.Contents {
+			keys = append(keys, c.Key)
+		}
+		if !result.IsTruncated || result.NextContinuationToken == "" {
+			break
+		}
+		token = result.NextContinuationToken
+	}
+	sort.Strings(keys)
+	return keys, nil
+}
+
+func (b *s3Bucket) Delete(ctx context.Context, key string) error {
+	resp, err := b.do(ctx, http.MethodDelete, key, nil, nil, nil, 0)
+	if err != nil {
+		return err
+	}
+	return resp.Body.Close()
+}
+
+// s3Sign adds signature version 4 headers to req, signing the host, range
+// and x-amz-* headers.
+// https://docs.aws.amazon.com/AmazonS3/latest/API/sig-v4-header-based-auth.html
+func s3Sign(req *http.Request, payload, keyID, secret, region string, t time.Time) {
+	amzDate := t.UTC().Format("20060102T150405Z")
+	date := amzDate[:8]
+	req.Header.Set("X-Amz-Date", amzDate)
+	req.Header.Set("X-Amz-Content-Sha256", payload)
+
+	headers := map[string]string{"host": req.Host}
+	if req.Host == "" {
+		headers["host"] = req.URL.Host
+	}
+	for k, vs := range req.Header {
+		k = strings.ToLower(k)
+		if k == "range" || strings.HasPrefix(k, "x-amz-") {
+			headers[k] = strings.TrimSpace(strings.Join(vs, ","))
+		}
+	}
+	var names []string
+	for k := range headers {
+		names = append(names, k)
+	}
+	sort.Strings(names)
+	var canonicalHeaders strings.Builder
+	for _, k := range names {
+		fmt.Fprintf(&canonicalHeaders, "%s:%s\n", k, headers[k])
+	}
+	signedHeaders := strings.Join(names, ";")
+
+	path := req.URL.EscapedPath()
+	if path == "" {
+		path = "/"
+	}
+	canonicalRequest := strings.Join([]string{
+		req.Method,
+		path,
+		s3CanonicalQuery(req.URL.Query()),
+		canonicalHeaders.String(),
+		signedHeaders,
+		payload,
+	}, "\n")
+	scope := fmt.Sprintf("%s/%s/s3/aws4_request", date, region)
+	stringToSign := strings.Join([]string{
+		s3Algorithm,
+		amzDate,
+		scope,
+		hexSHA256(canonicalRequest),
+	}, "\n")
+	key := []byte("AWS4" + secret)
+	for _, s := range []string{date, region, "s3", "aws4_request"} {
+		key = hmacSHA256(key, s)
+	}
+	signature := hex.EncodeToString(hmacSHA256(key, stringToSign))
+	req.Header.Set("Authorization", fmt.Sprintf("%s Credential=%s/%s, SignedHeaders=%s, Signature=%s", s3Algorithm, keyID, scope, signedHeaders, signature))
+}
+
+func hmacSHA256(key []byte, s string) []byte {
+	h := hmac.New(sha256.New, key)
+	h.Write([]byte(s))
+	return h.Sum(nil)
+}
+
+func hexSHA256(s string) string {
+	sum := sha256.Sum256([]byte(s))
+	return hex.EncodeToString(sum[:])
+}
+
+// s3Escape escapes all but unreserved characters, as signature version 4
+// requires.
+func s3Escape(s string) string {
+	var b strings.Builder
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		if 'A' <= c && c <= 'Z' || 'a' <= c && c <= 'z' || '0' <= c && c <= '9' || c == '-' || c == '_' || c == '.' || c == '~' {
+			b.WriteByte(c)
+			continue
+		}
+		fmt.Fprintf(&b, "%%%02X", c)
+	}
+	return b.String()
+}
+
+func s3EscapePath(key string) string {
+	segments := strings.Split(key, "/")
+	for i, s := range segments {
+		segments[i] = s3Escape(s)
+	}
+	return strings.Join(segments, "/")
+}
+
+func s3CanonicalQuery(query url.Values) string {
+	var pairs []string
+	for k, vs := range query {
+		for _, v := range vs {
+			pairs = append(pairs, s3Escape(k)+"="+s3Escape(v))
+		}
+	}
+	sort.Strings(pairs)
+	return strings.Join(pairs, "&")
+}
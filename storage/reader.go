@@ -10,10 +10,13 @@ type Reader interface {
 }
 
 type ReadOptions struct {
-	Labels      map[string]string
-	Start       time.Time
-	End         time.Time
-	Contains    []string
+	Labels   map[string]string
+	Start    time.Time
+	End      time.Time
+	Contains []string
+	// LabelFunc, if set, reports whether a stream with labels may match.
+	// Readers use it to skip streams before reading entries.
+	LabelFunc   func(map[string]string) bool
 	SummaryFunc func(LogSummary) bool
 	FilterFunc  func(LogEntry) bool
 	ResultFunc  func(LogEntry)
@@ -0,0 +1,249 @@
+package tenant
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+
+	"github.com/commentlens/loghouse/storage"
+	"github.com/commentlens/loghouse/storage/filesystem"
+	"golang.org/x/sync/errgroup"
+)
+
+const (
+	// DefaultID is used when a request has no X-Scope-OrgID, like loki with
+	// auth disabled. Its data stays in the data dir root.
+	DefaultID = "fake"
+	Dir       = "tenants"
+
+	// MaxTenants is the default number of open tenants.
+	MaxTenants = 1000
+
+	maxIDLength = 150
+)
+
+type Options struct {
+	DataDir    string
+	Storage    func(dataDir string) *filesystem.Options
+	LabelLimit uint64
+	// MaxTenants caps the tenants created by writes, zero for unlimited.
+	// Tenants found on disk are always opened.
+	MaxTenants   int
+	Limits       Limits
+	TenantLimits map[string]Limits
+}
+
+func NewRegistry(opts *Options) *Registry {
+	return &Registry{
+		opts:    opts,
+		m:       make(map[string]*Tenant),
+		opening: make(map[string]*opening),
+		hubs:    make(map[string]*storage.Hub),
+	}
+}
+
+type Registry struct {
+	opts *Options
+
+	mu      sync.Mutex
+	m       map[string]*Tenant
+	opening map[string]*opening
+	// hubs outlive the tenants returned by Lookup, so that tails opened
+	// before the first write receive it.
+	hubs map[string]*storage.Hub
+	g    *errgroup.Group
+	ctx  context.Context
+}
+
+// opening is a tenant being opened, shared by concurrent callers.
+type opening struct {
+	done chan struct{}
+	t    *Tenant
+	err  error
+}
+
+// https://grafana.com/docs/loki/latest/operations/multi-tenancy/
+func ValidateID(id string) error {
+	if id == "" || id == "." || id == ".." || len(id) > maxIDLength {
+		return fmt.Errorf("%w %q", ErrInvalidID, id)
+	}
+	for _, c := range id {
+		switch {
+		case c >= 'a' && c <= 'z':
+		case c >= 'A' && c <= 'Z':
+		case c >= '0' && c <= '9':
+		case c == '!' || c == '-' || c == '_' || c == '.' || c == '*' || c == '\'' || c == '(' || c == ')':
+		default:
+			return fmt.Errorf("%w %q", ErrInvalidID, id)
+		}
+	}
+	return nil
+}
+
+func (r *Registry) dataDir(id string) string {
+	if id == DefaultID {
+		return r.opts.DataDir
+	}
+	return filepath.Join(r.opts.DataDir, Dir, id)
+}
+
+func (r *Registry) StorageOptions(id string) (*filesystem.Options, error) {
+	err := ValidateID(id)
+	if err != nil {
+		return nil, err
+	}
+	return r.opts.Storage(r.dataDir(id)), nil
+}
+
+// IDs returns the default tenant and every tenant with data on disk.
+func (r *Registry) IDs() ([]string, error) {
+	ids := []string{DefaultID}
+	ds, err := os.ReadDir(filepath.Join(r.opts.DataDir, Dir))
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return nil, err
+	}
+	for _, d := range ds {
+		if d.IsDir() && d.Name() != DefaultID && ValidateID(d.Name()) == nil {
+			ids = append(ids, d.Name())
+		}
+	}
+	sort.Strings(ids[1:])
+	return ids, nil
+}
+
+// Get returns the tenant, creating it on first use.
+func (r *Registry) Get(id string) (*Tenant, error) {
+	return r.open(id, true)
+}
+
+// Lookup returns the tenant for reads. Tenants that were never written are
+// returned without data, and nothing is created for them.
+func (r *Registry) Lookup(id string) (*Tenant, error) {
+	r.mu.Lock()
+	t, ok := r.m[id]
+	r.mu.Unlock()
+	if ok {
+		return t, nil
+	}
+	opts, err := r.StorageOptions(id)
+	if err != nil {
+		return nil, err
+	}
+	opts.Catalog = &filesystem.Catalog{}
+	opts.Remote = nil
+	r.mu.Lock()
+	hub := r.hub(id)
+	r.mu.Unlock()
+	return &Tenant{
+		ID:         id,
+		Storage:    opts,
+		LabelLimit: r.opts.LabelLimit,
+		Limits:     r.limits(id),
+		Hub:        hub,
+	}, nil
+}
+
+func (r *Registry) limits(id string) Limits {
+	limits, ok := r.opts.TenantLimits[id]
+	if !ok {
+		limits = r.opts.Limits
+	}
+	return limits
+}
+
+// hub returns the hub of id. r.mu must be held.
+func (r *Registry) hub(id string) *storage.Hub {
+	h, ok := r.hubs[id]
+	if !ok {
+		h = storage.NewHub()
+		r.hubs[id] = h
+	}
+	return h
+}
+
+// open returns the tenant, opening it once without holding r.mu, so that
+// recovering a new tenant does not block the others. limit enforces
+// MaxTenants.
+func (r *Registry) open(id string, limit bool) (*Tenant, error) {
+	err := ValidateID(id)
+	if err != nil {
+		return nil, err
+	}
+	r.mu.Lock()
+	if t, ok := r.m[id]; ok {
+		r.mu.Unlock()
+		return t, nil
+	}
+	if op, ok := r.opening[id]; ok {
+		r.mu.Unlock()
+		<-op.done
+		return op.t, op.err
+	}
+	if limit && r.opts.MaxTenants > 0 && len(r.m)+len(r.opening) >= r.opts.MaxTenants {
+		n := len(r.m)
+		r.mu.Unlock()
+		return nil, fmt.Errorf("%w: %d tenants", ErrTenantLimited, n)
+	}
+	op := &opening{done: make(chan struct{})}
+	r.opening[id] = op
+	hub := r.hub(id)
+	r.mu.Unlock()
+
+	op.t, op.err = func() (*Tenant, error) {
+		opts, err := r.StorageOptions(id)
+		if err != nil {
+			return nil, err
+		}
+		return newTenant(id, opts, r.opts.LabelLimit, r.limits(id), hub)
+	}()
+
+	r.mu.Lock()
+	delete(r.opening, id)
+	if op.err == nil {
+		t := op.t
+		r.m[id] = t
+		if r.g != nil {
+			r.g.Go(func() error {
+				return t.w.BackgroundCompact(r.ctx)
+			})
+		}
+	}
+	r.mu.Unlock()
+	close(op.done)
+	return op.t, op.err
+}
+
+// Open opens the tenants found on disk, recovering their incompact chunks.
+func (r *Registry) Open() error {
+	ids, err := r.IDs()
+	if err != nil {
+		return err
+	}
+	for _, id := range ids {
+		_, err := r.open(id, false)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Run compacts every open tenant in the background until ctx is done.
+func (r *Registry) Run(ctx context.Context) error {
+	g, ctx := errgroup.WithContext(ctx)
+	r.mu.Lock()
+	r.g = g
+	r.ctx = ctx
+	for _, t := range r.m {
+		t := t
+		g.Go(func() error {
+			return t.w.BackgroundCompact(ctx)
+		})
+	}
+	r.mu.Unlock()
+	return g.Wait()
+}
@@ -0,0 +1,203 @@
+package tenant
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/commentlens/loghouse/storage"
+	"github.com/commentlens/loghouse/storage/filesystem"
+	"golang.org/x/time/rate"
+)
+
+var (
+	ErrInvalidID     = errors.New("tenant: invalid id")
+	ErrRateLimited   = errors.New("tenant: ingest rate limit exceeded")
+	ErrStreamLimited = errors.New("tenant: stream limit exceeded")
+	ErrQueryLimited  = errors.New("tenant: query limit exceeded")
+	ErrTenantLimited = errors.New("tenant: tenant limit exceeded")
+	ErrTooLarge      = errors.New("tenant: push larger than ingest burst")
+)
+
+// Limits are enforced per tenant. Zero values mean unlimited.
+type Limits struct {
+	// IngestRate is the number of log bytes per second accepted by push.
+	IngestRate  float64
+	IngestBurst int
+	// MaxStreams is the number of streams that have incompact chunks.
+	MaxStreams    int
+	MaxQueryLimit uint64
+	MaxQueryRange time.Duration
+}
+
+type Tenant struct {
+	ID      string
+	Storage *filesystem.Options
+	// LabelLimit caps the values returned per label, zero for unlimited.
+	LabelLimit uint64
+	Limits     Limits
+	// Hub receives every written entry, for live tailing.
+	Hub *storage.Hub
+
+	w interface {
+		storage.Writer
+		BackgroundCompact(context.Context) error
+		Recover() error
+	}
+	limiter *rate.Limiter
+
+	mu      sync.Mutex
+	streams map[string]struct{}
+}
+
+func newTenant(id string, opts *filesystem.Options, labelLimit uint64, limits Limits, hub *storage.Hub) (*Tenant, error) {
+	t := &Tenant{
+		ID:         id,
+		Storage:    opts,
+		LabelLimit: labelLimit,
+		Limits:     limits,
+		Hub:        hub,
+		w:          filesystem.NewCompactWriter(opts),
+	}
+	if limits.IngestRate > 0 {
+		burst := limits.IngestBurst
+		if burst < int(limits.IngestRate) {
+			burst = int(limits.IngestRate)
+		}
+		t.limiter = rate.NewLimiter(rate.Limit(limits.IngestRate), burst)
+	}
+	err := t.w.Recover()
+	if err != nil {
+		return nil, err
+	}
+	opts.Catalog, err = filesystem.OpenCatalog(opts)
+	if err != nil {
+		return nil, err
+	}
+	opts.Postings, err = filesystem.OpenPostings(opts)
+	if err != nil {
+		return nil, err
+	}
+	err = t.loadStreams()
+	if err != nil {
+		return nil, err
+	}
+	return t, nil
+}
+
+func (t *Tenant) loadStreams() error {
+	hashes, err := filesystem.ListStreams(t.Storage)
+	if err != nil {
+		return err
+	}
+	t.streams = make(map[string]struct{})
+	for _, hash := range hashes {
+		t.streams[hash] = struct{}{}
+	}
+	return nil
+}
+
+func (t *Tenant) checkStreams(es []storage.LogEntry) error {
+	if t.Limits.MaxStreams <= 0 {
+		return nil
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	count := func() (int, error) {
+		m := make(map[string]struct{})
+		for _, e := range es {
+			h, err := storage.HashLabels(e.Labels)
+			if err != nil {
+				return 0, err
+			}
+			if _, ok := t.streams[h]; !ok {
+				m[h] = struct{}{}
+			}
+		}
+		return len(t.streams) + len(m), nil
+	}
+	n, err := count()
+	if err != nil {
+		return err
+	}
+	if n <= t.Limits.MaxStreams {
+		return nil
+	}
+	// streams are released when their chunks are compacted.
+	err = t.loadStreams()
+	if err != nil {
+		return err
+	}
+	n, err = count()
+	if err != nil {
+		return err
+	}
+	if n > t.Limits.MaxStreams {
+		return fmt.Errorf("%w: %d > %d", ErrStreamLimited, n, t.Limits.MaxStreams)
+	}
+	return nil
+}
+
+func (t *Tenant) Write(es []storage.LogEntry) error {
+	err := t.checkStreams(es)
+	if err != nil {
+		return err
+	}
+	if t.limiter != nil {
+		var size int
+		for _, e := range es {
+			size += len(e.Data)
+		}
+		// AllowN never allows more than the burst, however idle the tenant.
+		if size > t.limiter.Burst() {
+			return fmt.Errorf("%w: %d > %d bytes", ErrTooLarge, size, t.limiter.Burst())
+		}
+		if !t.limiter.AllowN(time.Now(), size) {
+			return fmt.Errorf("%w: %d bytes", ErrRateLimited, size)
+		}
+	}
+	err = t.w.Write(es)
+	if err != nil {
+		return err
+	}
+	t.Hub.Publish(es)
+	if t.Limits.MaxStreams > 0 {
+		t.mu.Lock()
+		defer t.mu.Unlock()
+		for _, e := range es {
+			h, err := storage.HashLabels(e.Labels)
+			if err != nil {
+				return err
+			}
+			t.streams[h] = struct{}{}
+		}
+	}
+	return nil
+}
+
+// CheckQuery returns ErrQueryLimited if a query over [start, end] returning at
+// most limit entries exceeds the tenant's limits.
+func (t *Tenant) CheckQuery(start, end time.Time, limit uint64) error {
+	if t.Limits.MaxQueryLimit > 0 && limit > t.Limits.MaxQueryLimit {
+		return fmt.Errorf("%w: limit %d > %d", ErrQueryLimited, limit, t.Limits.MaxQueryLimit)
+	}
+	if t.Limits.MaxQueryRange > 0 && end.Sub(start) > t.Limits.MaxQueryRange {
+		return fmt.Errorf("%w: range %s > %s", ErrQueryLimited, end.Sub(start), t.Limits.MaxQueryRange)
+	}
+	return nil
+}
+
+// NewReader returns a reader of the tenant's chunks. If ordered, entries are
+// returned in time order, or newest first if reverse; otherwise ResultFunc is
+// called concurrently.
+func (t *Tenant) NewReader(readerCount int, ordered, reverse bool) storage.Reader {
+	return filesystem.NewCompactReader(&filesystem.CompactReaderOptions{
+		Storage:     t.Storage,
+		ReaderCount: readerCount,
+		Ordered:     ordered,
+		Reverse:     reverse,
+	})
+}
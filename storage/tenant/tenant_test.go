@@ -0,0 +1,158 @@
+package tenant
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/commentlens/loghouse/storage"
+	"github.com/commentlens/loghouse/storage/filesystem"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidateID(t *testing.T) {
+	for _, id := range []string{"fake", "team-a", "Team_B.prod", "(x)*!'"} {
+		require.NoError(t, ValidateID(id))
+	}
+	for _, id := range []string{"", ".", "..", "a/b", "a|b", "a b"} {
+		require.ErrorIs(t, ValidateID(id), ErrInvalidID)
+	}
+}
+
+func TestRegistry(t *testing.T) {
+	dataDir := t.TempDir()
+	r := NewRegistry(&Options{
+		DataDir:    dataDir,
+		Storage:    filesystem.NewOptions,
+		LabelLimit: 10,
+		TenantLimits: map[string]Limits{
+			"limited": {
+				IngestRate:    10,
+				MaxStreams:    1,
+				MaxQueryLimit: 100,
+				MaxQueryRange: time.Hour,
+			},
+		},
+	})
+	err := r.Open()
+	require.NoError(t, err)
+
+	e := storage.LogEntry{
+		Labels: map[string]string{"app": "test"},
+		Time:   time.Now().UTC().Truncate(time.Millisecond),
+		Data:   []byte(`{"test":1}`),
+	}
+	for _, id := range []string{DefaultID, "team-a"} {
+		tenant, err := r.Get(id)
+		require.NoError(t, err)
+		e := e
+		e.Labels = map[string]string{"app": "test", "tenant": id}
+		err = tenant.Write([]storage.LogEntry{e})
+		require.NoError(t, err)
+	}
+	require.Equal(t, dataDir+"/incompact", r.m[DefaultID].Storage.WriteDir)
+	require.Equal(t, dataDir+"/tenants/team-a/incompact", r.m["team-a"].Storage.WriteDir)
+
+	ids, err := r.IDs()
+	require.NoError(t, err)
+	require.Equal(t, []string{DefaultID, "team-a"}, ids)
+
+	for _, id := range ids {
+		tenant, err := r.Get(id)
+		require.NoError(t, err)
+		require.Equal(t, uint64(10), tenant.LabelLimit)
+
+		var es []storage.LogEntry
+		err = tenant.NewReader(1, true, false).Read(context.Background(), &storage.ReadOptions{
+			ResultFunc: func(e storage.LogEntry) {
+				es = append(es, e)
+			},
+		})
+		require.NoError(t, err)
+		require.Len(t, es, 1)
+		require.Equal(t, id, es[0].Labels["tenant"])
+	}
+
+	_, err = r.Get("a/b")
+	require.ErrorIs(t, err, ErrInvalidID)
+
+	limited, err := r.Get("limited")
+	require.NoError(t, err)
+	err = limited.Write([]storage.LogEntry{e})
+	require.NoError(t, err)
+	e2 := e
+	e2.Labels = map[string]string{"app": "test2"}
+	err = limited.Write([]storage.LogEntry{e2})
+	require.ErrorIs(t, err, ErrStreamLimited)
+	err = limited.Write([]storage.LogEntry{e})
+	require.ErrorIs(t, err, ErrRateLimited)
+	e3 := e
+	e3.Data = make([]byte, 11)
+	err = limited.Write([]storage.LogEntry{e3})
+	require.ErrorIs(t, err, ErrTooLarge)
+
+	now := time.Now()
+	require.NoError(t, limited.CheckQuery(now.Add(-time.Hour), now, 100))
+	require.ErrorIs(t, limited.CheckQuery(now.Add(-time.Hour), now, 101), ErrQueryLimited)
+	require.ErrorIs(t, limited.CheckQuery(now.Add(-2*time.Hour), now, 100), ErrQueryLimited)
+}
+
+func TestRegistryLookup(t *testing.T) {
+	dataDir := t.TempDir()
+	r := NewRegistry(&Options{
+		DataDir:    dataDir,
+		Storage:    filesystem.NewOptions,
+		MaxTenants: 1,
+	})
+	err := r.Open()
+	require.NoError(t, err)
+
+	// reads of unknown tenants are empty and create nothing.
+	unknown, err := r.Lookup("team-a")
+	require.NoError(t, err)
+	var es []storage.LogEntry
+	err = unknown.NewReader(1, true, false).Read(context.Background(), &storage.ReadOptions{
+		ResultFunc: func(e storage.LogEntry) {
+			es = append(es, e)
+		},
+	})
+	require.NoError(t, err)
+	require.Empty(t, es)
+	require.Empty(t, unknown.Storage.Catalog.Series())
+	require.NoDirExists(t, filepath.Join(dataDir, Dir, "team-a"))
+	_, err = r.Lookup("a/b")
+	require.ErrorIs(t, err, ErrInvalidID)
+
+	_, err = r.Get("team-a")
+	require.ErrorIs(t, err, ErrTenantLimited)
+	def, err := r.Get(DefaultID)
+	require.NoError(t, err)
+	found, err := r.Lookup(DefaultID)
+	require.NoError(t, err)
+	require.Same(t, def, found)
+
+	// tails of unknown tenants receive their first write.
+	r = NewRegistry(&Options{
+		DataDir: t.TempDir(),
+		Storage: filesystem.NewOptions,
+	})
+	unknown, err = r.Lookup("team-b")
+	require.NoError(t, err)
+	sub := unknown.Hub.Subscribe(1, nil)
+	defer sub.Close()
+	tenants := make(chan *Tenant, 2)
+	for i := 0; i < 2; i++ {
+		go func() {
+			tn, _ := r.Get("team-b")
+			tenants <- tn
+		}()
+	}
+	tn := <-tenants
+	require.NotNil(t, tn)
+	require.Same(t, tn, <-tenants)
+	e := storage.LogEntry{Labels: map[string]string{"app": "x"}, Time: time.Now(), Data: []byte("1")}
+	err = tn.Write([]storage.LogEntry{e})
+	require.NoError(t, err)
+	require.Equal(t, e, <-sub.Entries())
+}
@@ -3,6 +3,7 @@ package tlv
 import (
 	"bufio"
 	"encoding/binary"
+	"errors"
 	"io"
 )
 
@@ -30,9 +31,12 @@ func (r *reader) Read() (uint64, io.Reader, error) {
 	}
 	l, err := r.readUint64()
 	if err != nil {
+		if errors.Is(err, io.EOF) {
+			return 0, nil, io.ErrUnexpectedEOF
+		}
 		return 0, nil, err
 	}
-	val := io.LimitReader(r.r, int64(l))
+	val := &valueReader{r: r.r, n: l}
 	return typ, val, nil
 }
 
@@ -100,3 +104,23 @@ func (r *reader) readUint64() (uint64, error) {
 		return uint64(r.b[0]), nil
 	}
 }
+
+type valueReader struct {
+	r io.Reader
+	n uint64
+}
+
+func (v *valueReader) Read(p []byte) (int, error) {
+	if v.n == 0 {
+		return 0, io.EOF
+	}
+	if uint64(len(p)) > v.n {
+		p = p[:v.n]
+	}
+	n, err := v.r.Read(p)
+	v.n -= uint64(n)
+	if errors.Is(err, io.EOF) && v.n > 0 {
+		err = io.ErrUnexpectedEOF
+	}
+	return n, err
+}
@@ -96,3 +96,12 @@ func TestReadUint64(t *testing.T) {
 		require.Equal(t, test.want, got)
 	}
 }
+
+func TestReadTruncated(t *testing.T) {
+	r := NewReader(bytes.NewReader([]byte{1, 3, 2}))
+	_, val, err := r.Read()
+	require.NoError(t, err)
+
+	_, err = io.ReadAll(val)
+	require.ErrorIs(t, err, io.ErrUnexpectedEOF)
+}